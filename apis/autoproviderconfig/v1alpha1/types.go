@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v12 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// An AutoProviderConfig manages a ProviderConfig for every kubeconfig Secret
+// matching its selector, named after the Secret.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="NAMESPACE",type="string",JSONPath=".spec.namespace"
+// +kubebuilder:printcolumn:name="DRYRUN",type="boolean",JSONPath=".spec.dryRun"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,kubernetes}
+type AutoProviderConfig struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          AutoProviderConfigSpec   `json:"spec"`
+	Status        AutoProviderConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AutoProviderConfigList contains a list of AutoProviderConfig
+type AutoProviderConfigList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []AutoProviderConfig `json:"items"`
+}
+
+// AutoProviderConfigSpec defines the desired state of AutoProviderConfig
+type AutoProviderConfigSpec struct {
+
+	// Namespace containing the kubeconfig Secrets.
+	// +kubebuilder:validation:MinLength:=1
+	Namespace string `json:"namespace"`
+
+	// Selector of the kubeconfig Secrets.
+	// +kubebuilder:default={"matchLabels": {"provider-kubernetes.crossplane.io/auto-config": "true"}}
+	// +optional
+	Selector v1.LabelSelector `json:"selector,omitempty"`
+
+	// Key of the Secrets containing the kubeconfig.
+	// +kubebuilder:default=kubeconfig
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// DryRun only logs and reports the ProviderConfigs that would be
+	// created, updated or deleted, without changing them.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// AutoProviderConfigStatus represents the observed state of an AutoProviderConfig
+type AutoProviderConfigStatus struct {
+	v12.ResourceStatus `json:",inline"`
+
+	// ProviderConfigs are the names of the ProviderConfigs managed by this
+	// AutoProviderConfig. In dry-run mode, the ProviderConfigs that would be
+	// managed.
+	// +optional
+	ProviderConfigs []string `json:"providerConfigs,omitempty"`
+
+	// MembershipLabel is the label set on each ProviderConfig managed by this
+	// AutoProviderConfig and can be used for fetching them.
+	// +optional
+	MembershipLabel map[string]string `json:"membershipLabel,omitempty"`
+}
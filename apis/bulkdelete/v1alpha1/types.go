@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v12 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// A BulkDelete deletes all Objects using a ProviderConfig, e.g. before the
+// cluster it connects to is decommissioned.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="PROVIDERCONFIG",type="string",JSONPath=".spec.providerConfigRef.name"
+// +kubebuilder:printcolumn:name="POLICY",type="string",JSONPath=".spec.deletionPolicy"
+// +kubebuilder:printcolumn:name="DELETED",type="integer",JSONPath=".status.deletedCount"
+// +kubebuilder:printcolumn:name="FAILED",type="integer",JSONPath=".status.failedCount"
+// +kubebuilder:printcolumn:name="COMPLETED",type="string",JSONPath=".status.conditions[?(@.type=='Completed')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,kubernetes}
+type BulkDelete struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          BulkDeleteSpec   `json:"spec"`
+	Status        BulkDeleteStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BulkDeleteList contains a list of BulkDelete
+type BulkDeleteList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []BulkDelete `json:"items"`
+}
+
+// BulkDeleteSpec defines the desired state of BulkDelete
+type BulkDeleteSpec struct {
+
+	// ProviderConfigReference specifies the provider config whose Objects
+	// are deleted.
+	ProviderConfigReference v12.Reference `json:"providerConfigRef"`
+
+	// DeletionPolicy set on every Object before it is deleted. Delete also
+	// deletes the resources the Objects manage on the target cluster,
+	// Orphan leaves them in place, e.g. if the cluster is already gone.
+	// +kubebuilder:validation:Enum=Orphan;Delete
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy v12.DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// BulkDeleteStatus represents the observed state of a BulkDelete
+type BulkDeleteStatus struct {
+	v12.ConditionedStatus `json:",inline"`
+
+	// DeletedCount is the number of Objects deleted so far.
+	// +optional
+	DeletedCount int64 `json:"deletedCount,omitempty"`
+
+	// FailedCount is the number of Objects that could not be deleted during
+	// the last attempt.
+	// +optional
+	FailedCount int64 `json:"failedCount,omitempty"`
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeBudgetExceeded indicates whether a cluster selected by a
+// ClusterInformerBudget watches as many GVKs as the budget allows.
+const TypeBudgetExceeded xpv1.ConditionType = "BudgetExceeded"
+
+// Reasons a ClusterInformerBudget is or is not exceeded.
+const (
+	ReasonBudgetExceeded xpv1.ConditionReason = "BudgetExceeded"
+	ReasonWithinBudget   xpv1.ConditionReason = "WithinBudget"
+)
+
+// BudgetExceeded returns a condition that indicates a cluster selected by
+// the budget watches as many GVKs as it allows.
+func BudgetExceeded(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBudgetExceeded,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonBudgetExceeded,
+		Message:            msg,
+	}
+}
+
+// WithinBudget returns a condition that indicates all clusters selected by
+// the budget may watch further GVKs.
+func WithinBudget() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBudgetExceeded,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWithinBudget,
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v12 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// A ClusterInformerBudget limits the number of GVKs the resource informers
+// of the Object controller may watch per cluster. Objects whose resources
+// cannot be watched because of it report the BudgetExceeded condition.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="MAX-GVKS",type="integer",JSONPath=".spec.maxGVKsPerCluster"
+// +kubebuilder:printcolumn:name="EXCEEDED",type="string",JSONPath=".status.conditions[?(@.type=='BudgetExceeded')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,kubernetes}
+type ClusterInformerBudget struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          ClusterInformerBudgetSpec   `json:"spec"`
+	Status        ClusterInformerBudgetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterInformerBudgetList contains a list of ClusterInformerBudget
+type ClusterInformerBudgetList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []ClusterInformerBudget `json:"items"`
+}
+
+// ClusterInformerBudgetSpec defines the desired state of ClusterInformerBudget
+type ClusterInformerBudgetSpec struct {
+	// MaxGVKsPerCluster is the number of GVKs that may be watched per
+	// cluster. Watching further GVKs is blocked until informers of others
+	// are cleaned up. If several budgets select a ProviderConfig, the lowest
+	// limit applies.
+	// +kubebuilder:validation:Minimum:=1
+	MaxGVKsPerCluster int `json:"maxGVKsPerCluster"`
+
+	// ProviderConfigSelector selects the ProviderConfigs of the clusters
+	// the budget applies to. An empty selector selects all ProviderConfigs.
+	// +optional
+	ProviderConfigSelector v1.LabelSelector `json:"providerConfigSelector,omitempty"`
+}
+
+// ClusterInformerBudgetStatus represents the observed state of a ClusterInformerBudget
+type ClusterInformerBudgetStatus struct {
+	v12.ResourceStatus `json:",inline"`
+
+	// Clusters are the clusters of the selected ProviderConfigs that watch
+	// resources, along with their usage of the budget.
+	// +optional
+	Clusters []ClusterUsage `json:"clusters,omitempty"`
+}
+
+// ClusterUsage is the usage of a budget by a cluster.
+type ClusterUsage struct {
+	// Cluster is the URL of the API server of the cluster.
+	Cluster string `json:"cluster"`
+
+	// ProviderConfigs are the selected ProviderConfigs pointing to the
+	// cluster.
+	ProviderConfigs []string `json:"providerConfigs"`
+
+	// GVKs is the number of GVKs watched in the cluster.
+	GVKs int `json:"gvks"`
+}
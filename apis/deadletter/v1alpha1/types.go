@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v12 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// A DeadLetter is created for an Object whose reconciles failed too many
+// times in a row. The Object is not reconciled again until the DeadLetter's
+// retryAfter period elapsed, or the DeadLetter is deleted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="OBJECT",type="string",JSONPath=".spec.objectRef.name"
+// +kubebuilder:printcolumn:name="FAILURES",type="integer",JSONPath=".status.failures"
+// +kubebuilder:printcolumn:name="ERROR",type="string",JSONPath=".status.lastErrorCode"
+// +kubebuilder:printcolumn:name="RETRYAFTER",type="string",JSONPath=".spec.retryAfter"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,kubernetes}
+type DeadLetter struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          DeadLetterSpec   `json:"spec"`
+	Status        DeadLetterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DeadLetterList contains a list of DeadLetter
+type DeadLetterList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []DeadLetter `json:"items"`
+}
+
+// DeadLetterSpec defines the desired state of DeadLetter
+type DeadLetterSpec struct {
+
+	// ObjectReference refers to the Object whose reconciles failed.
+	ObjectReference v12.Reference `json:"objectRef"`
+
+	// RetryAfter is how long after the creation of the DeadLetter the
+	// Object is reconciled again. The DeadLetter is deleted at that point.
+	RetryAfter v1.Duration `json:"retryAfter"`
+}
+
+// DeadLetterStatus represents the observed state of a DeadLetter
+type DeadLetterStatus struct {
+
+	// Failures is the number of consecutive reconciles of the Object that
+	// failed.
+	// +optional
+	Failures int `json:"failures,omitempty"`
+
+	// LastErrorCode is the code of the error the last reconcile of the
+	// Object failed with.
+	// +optional
+	LastErrorCode string `json:"lastErrorCode,omitempty"`
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v12 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// A DiscoveryJob imports existing resources of a namespace on the target
+// cluster by generating an Object for each of them. Generated Objects adopt
+// the resources they are generated for, which already exist. Resources that
+// are already managed by an Object or that have a controller owner
+// reference, like the Pods of a ReplicaSet, are skipped.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="NAMESPACE",type="string",JSONPath=".spec.namespace"
+// +kubebuilder:printcolumn:name="DRYRUN",type="boolean",JSONPath=".spec.dryRun"
+// +kubebuilder:printcolumn:name="DISCOVERED",type="integer",JSONPath=".status.discovered"
+// +kubebuilder:printcolumn:name="CREATED",type="integer",JSONPath=".status.created"
+// +kubebuilder:printcolumn:name="PROVIDERCONFIG",type="string",JSONPath=".spec.providerConfigRef.name"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kubernetes}
+type DiscoveryJob struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          DiscoveryJobSpec   `json:"spec"`
+	Status        DiscoveryJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DiscoveryJobList contains a list of DiscoveryJob
+type DiscoveryJobList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []DiscoveryJob `json:"items"`
+}
+
+// DiscoveryJobSpec defines the desired state of DiscoveryJob
+type DiscoveryJobSpec struct {
+
+	// Namespace on the target cluster to discover resources in.
+	// +kubebuilder:validation:MinLength:=1
+	Namespace string `json:"namespace"`
+
+	// Resources declares the kinds of resources to discover.
+	// +kubebuilder:validation:MinItems:=1
+	Resources []DiscoveryResource `json:"resources"`
+
+	// DryRun only logs and reports the Objects that would be generated,
+	// without creating them.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DeletionPolicy of the generated Objects. Imported resources are
+	// orphaned by default, deleting a generated Object leaves its resource
+	// on the target cluster.
+	// +optional
+	// +kubebuilder:validation:Enum=Orphan;Delete
+	// +kubebuilder:default=Orphan
+	DeletionPolicy v12.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// ProviderConfigReference specifies the provider config of the target
+	// cluster. Generated Objects use the same provider config.
+	// +kubebuilder:default={"name": "default"}
+	ProviderConfigReference v12.Reference `json:"providerConfigRef,omitempty"`
+}
+
+// DiscoveryResource declares a kind of resources to discover.
+type DiscoveryResource struct {
+
+	// APIVersion of the resources to discover.
+	// +kubebuilder:validation:MinLength:=1
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the resources to discover.
+	// +kubebuilder:validation:MinLength:=1
+	Kind string `json:"kind"`
+}
+
+// DiscoveryJobStatus represents the observed state of a DiscoveryJob
+type DiscoveryJobStatus struct {
+	v12.ResourceStatus `json:",inline"`
+
+	// ObservedGeneration is the generation of the DiscoveryJob that was
+	// last run to completion.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Discovered is the number of resources discovered so far.
+	// +optional
+	Discovered int `json:"discovered,omitempty"`
+
+	// Created is the number of Objects generated so far. In dry-run mode it
+	// is the number of Objects that would be generated.
+	// +optional
+	Created int `json:"created,omitempty"`
+
+	// Skipped is the number of discovered resources for which no Object was
+	// generated, because they are already managed by an existing Object or
+	// by a controller.
+	// +optional
+	Skipped int `json:"skipped,omitempty"`
+
+	// MembershipLabel is the label set on each Object generated by this
+	// DiscoveryJob and can be used for fetching them.
+	// +optional
+	MembershipLabel map[string]string `json:"membershipLabel,omitempty"`
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v12 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// A GitExport commits the manifests of selected Objects to a Git
+// repository.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.repository"
+// +kubebuilder:printcolumn:name="BRANCH",type="string",JSONPath=".spec.branch"
+// +kubebuilder:printcolumn:name="COMMIT",type="string",JSONPath=".status.lastCommit"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kubernetes}
+type GitExport struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          GitExportSpec   `json:"spec"`
+	Status        GitExportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GitExportList contains a list of GitExport
+type GitExportList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []GitExport `json:"items"`
+}
+
+// GitExportSpec defines the desired state of GitExport
+type GitExportSpec struct {
+
+	// Repository is the URL of the Git repository, e.g.
+	// https://github.com/example/fleet.git.
+	// +kubebuilder:validation:MinLength:=1
+	Repository string `json:"repository"`
+
+	// Branch to commit to. It is created if it does not exist.
+	// +optional
+	// +kubebuilder:default=main
+	Branch string `json:"branch,omitempty"`
+
+	// Selector selects the Objects whose manifests are exported. All
+	// Objects are exported if omitted.
+	// +optional
+	Selector *v1.LabelSelector `json:"selector,omitempty"`
+
+	// IncludeSecrets exports the manifests of Objects that manage Secrets.
+	// They are skipped by default, their data would be committed to the
+	// repository in plain text.
+	// +optional
+	IncludeSecrets bool `json:"includeSecrets,omitempty"`
+
+	// SecretRef refers to a Secret with the "username" and "password" keys
+	// used to authenticate against the repository. An access token can be
+	// used as password.
+	// +optional
+	SecretRef *v12.SecretReference `json:"secretRef,omitempty"`
+}
+
+// GitExportStatus represents the observed state of a GitExport
+type GitExportStatus struct {
+	v12.ConditionedStatus `json:",inline"`
+
+	// LastCommit is the commit the exported manifests were last found in.
+	// +optional
+	LastCommit string `json:"lastCommit,omitempty"`
+
+	// Files are the paths of the exported manifests in the repository,
+	// named <providerConfig>/<namespace>/<name>.yaml after the provider
+	// config and the namespace of the manifest, and the name of the Object.
+	// +optional
+	Files []string `json:"files,omitempty"`
+}
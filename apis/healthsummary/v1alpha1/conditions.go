@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeHealthy indicates whether all Objects of a summary became ready within
+// its unhealthy threshold.
+const TypeHealthy xpv1.ConditionType = "Healthy"
+
+// Reasons a summary is healthy or not.
+const (
+	ReasonObjectsHealthy   xpv1.ConditionReason = "ObjectsHealthy"
+	ReasonObjectsUnhealthy xpv1.ConditionReason = "ObjectsUnhealthy"
+)
+
+// Healthy returns a condition that indicates no Object of a summary has been
+// not ready for longer than its unhealthy threshold.
+func Healthy() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeHealthy,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonObjectsHealthy,
+	}
+}
+
+// Unhealthy returns a condition that indicates the supplied number of
+// Objects of a summary have not been ready for longer than the supplied
+// threshold.
+func Unhealthy(n int, threshold time.Duration) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeHealthy,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonObjectsUnhealthy,
+		Message:            fmt.Sprintf("%d Objects have not been ready for more than %s", n, threshold),
+	}
+}
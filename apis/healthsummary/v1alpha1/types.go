@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v12 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// A HealthSummary aggregates the health of the Objects whose resources are
+// in its namespace.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="HEALTHY",type="string",JSONPath=".status.conditions[?(@.type=='Healthy')].status"
+// +kubebuilder:printcolumn:name="READY",type="integer",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="NOT-READY",type="integer",JSONPath=".status.notReady"
+// +kubebuilder:printcolumn:name="DEGRADED",type="integer",JSONPath=".status.degraded"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,provider,kubernetes}
+type HealthSummary struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          HealthSummarySpec   `json:"spec"`
+	Status        HealthSummaryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HealthSummaryList contains a list of HealthSummary
+type HealthSummaryList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []HealthSummary `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ClusterHealthSummary aggregates the health of Objects across the
+// cluster.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="HEALTHY",type="string",JSONPath=".status.conditions[?(@.type=='Healthy')].status"
+// +kubebuilder:printcolumn:name="READY",type="integer",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="NOT-READY",type="integer",JSONPath=".status.notReady"
+// +kubebuilder:printcolumn:name="DEGRADED",type="integer",JSONPath=".status.degraded"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,kubernetes}
+type ClusterHealthSummary struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          HealthSummarySpec   `json:"spec"`
+	Status        HealthSummaryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterHealthSummaryList contains a list of ClusterHealthSummary
+type ClusterHealthSummaryList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []ClusterHealthSummary `json:"items"`
+}
+
+// HealthSummarySpec defines the desired state of a HealthSummary or
+// ClusterHealthSummary.
+type HealthSummarySpec struct {
+	// ObjectSelector selects the Objects to summarize by their labels. All
+	// Objects are summarized if it is empty.
+	// +optional
+	ObjectSelector *v1.LabelSelector `json:"objectSelector,omitempty"`
+
+	// UnhealthyThreshold is how long an Object may not be ready before the
+	// summary is unhealthy.
+	// +optional
+	// +kubebuilder:default="5m"
+	UnhealthyThreshold *v1.Duration `json:"unhealthyThreshold,omitempty"`
+}
+
+// HealthSummaryStatus represents the observed state of a HealthSummary or
+// ClusterHealthSummary.
+type HealthSummaryStatus struct {
+	v12.ResourceStatus `json:",inline"`
+
+	// Ready is the number of summarized Objects that are ready.
+	// +optional
+	Ready int64 `json:"ready,omitempty"`
+
+	// NotReady is the number of summarized Objects that are synced, but
+	// not ready.
+	// +optional
+	NotReady int64 `json:"notReady,omitempty"`
+
+	// Degraded is the number of summarized Objects that failed to sync.
+	// +optional
+	Degraded int64 `json:"degraded,omitempty"`
+
+	// UnhealthyObjects are the names of the summarized Objects that are not
+	// ready or failed to sync, sorted by name. At most 50 are listed.
+	// +optional
+	// +listType=atomic
+	UnhealthyObjects []string `json:"unhealthyObjects,omitempty"`
+}
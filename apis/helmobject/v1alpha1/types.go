@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v12 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// A HelmObject renders a Helm chart stored in an OCI registry and manages
+// every rendered manifest as an individual Object.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="CHART",type="string",JSONPath=".spec.chart.reference"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".spec.chart.version"
+// +kubebuilder:printcolumn:name="PROVIDERCONFIG",type="string",JSONPath=".spec.providerConfigRef.name"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kubernetes}
+// +kubebuilder:validation:XValidation:rule="size(self.metadata.name) < 64",message="metadata.name max length is 63"
+type HelmObject struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          HelmObjectSpec   `json:"spec"`
+	Status        HelmObjectStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HelmObjectList contains a list of HelmObject
+type HelmObjectList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []HelmObject `json:"items"`
+}
+
+// HelmObjectSpec defines the desired state of HelmObject
+type HelmObjectSpec struct {
+
+	// Chart to render.
+	Chart HelmChart `json:"chart"`
+
+	// ReleaseName used when rendering the chart.
+	// If omitted, the name of the HelmObject is used.
+	// +optional
+	ReleaseName string `json:"releaseName,omitempty"`
+
+	// Namespace used when rendering the chart. Rendered manifests
+	// without a namespace are placed into it.
+	// +kubebuilder:default=default
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Values to render the chart with.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Values runtime.RawExtension `json:"values,omitempty"`
+
+	// ProviderConfigReference specifies how the provider that will be used to
+	// create, observe, update, and delete the rendered objects should be
+	// configured.
+	// +kubebuilder:default={"name": "default"}
+	ProviderConfigReference v12.Reference `json:"providerConfigRef,omitempty"`
+}
+
+// HelmChart refers to a Helm chart stored in an OCI registry.
+type HelmChart struct {
+
+	// Reference of the chart, e.g. oci://ghcr.io/example/charts/podinfo
+	// +kubebuilder:validation:Pattern=`^oci://.+`
+	Reference string `json:"reference"`
+
+	// Version of the chart.
+	// +kubebuilder:validation:MinLength:=1
+	Version string `json:"version"`
+}
+
+// HelmObjectStatus represents the observed state of a HelmObject
+type HelmObjectStatus struct {
+	v12.ResourceStatus `json:",inline"`
+
+	// MembershipLabel is the label set on each Object rendered from this
+	// HelmObject and can be used for fetching them.
+	// +optional
+	MembershipLabel map[string]string `json:"membershipLabel,omitempty"`
+}
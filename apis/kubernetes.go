@@ -20,9 +20,24 @@ package apis
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	autoproviderconfigv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/autoproviderconfig/v1alpha1"
+	bulkdeletev1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/bulkdelete/v1alpha1"
+	clusterinformerbudgetv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/clusterinformerbudget/v1alpha1"
+	deadletterv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/deadletter/v1alpha1"
+	discoveryjobv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/discoveryjob/v1alpha1"
+	gitexportv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/gitexport/v1alpha1"
+	healthsummaryv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/healthsummary/v1alpha1"
+	helmobjectv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/helmobject/v1alpha1"
+	manifestreportv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/manifestreport/v1alpha1"
+	mutatingpolicyv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/mutatingpolicy/v1alpha1"
+	namespaceobjectquotav1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/namespaceobjectquota/v1alpha1"
+	namespacereportv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/namespacereport/v1alpha1"
 	objectv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha1"
 	objectv1alhpa2 "github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
 	observedobjectcollectionv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/observedobjectcollection/v1alpha1"
+	providerconfigmigrationv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/providerconfigmigration/v1alpha1"
+	statusexporterconfigv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/statusexporterconfig/v1alpha1"
+	syncedconfigmapv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/syncedconfigmap/v1alpha1"
 	templatev1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
 )
 
@@ -33,6 +48,21 @@ func init() {
 		objectv1alpha1.SchemeBuilder.AddToScheme,
 		objectv1alhpa2.SchemeBuilder.AddToScheme,
 		observedobjectcollectionv1alpha1.SchemeBuilder.AddToScheme,
+		helmobjectv1alpha1.SchemeBuilder.AddToScheme,
+		discoveryjobv1alpha1.SchemeBuilder.AddToScheme,
+		manifestreportv1alpha1.SchemeBuilder.AddToScheme,
+		autoproviderconfigv1alpha1.SchemeBuilder.AddToScheme,
+		bulkdeletev1alpha1.SchemeBuilder.AddToScheme,
+		deadletterv1alpha1.SchemeBuilder.AddToScheme,
+		syncedconfigmapv1alpha1.SchemeBuilder.AddToScheme,
+		gitexportv1alpha1.SchemeBuilder.AddToScheme,
+		clusterinformerbudgetv1alpha1.SchemeBuilder.AddToScheme,
+		namespaceobjectquotav1alpha1.SchemeBuilder.AddToScheme,
+		healthsummaryv1alpha1.SchemeBuilder.AddToScheme,
+		namespacereportv1alpha1.SchemeBuilder.AddToScheme,
+		mutatingpolicyv1alpha1.SchemeBuilder.AddToScheme,
+		providerconfigmigrationv1alpha1.SchemeBuilder.AddToScheme,
+		statusexporterconfigv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
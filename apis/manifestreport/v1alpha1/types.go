@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v12 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// A ManifestReport writes an inventory of all resources managed through a
+// ProviderConfig to a ConfigMap, as a CycloneDX bill of materials.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="PROVIDERCONFIG",type="string",JSONPath=".spec.providerConfigRef.name"
+// +kubebuilder:printcolumn:name="CONFIGMAP",type="string",JSONPath=".spec.configMapRef.name"
+// +kubebuilder:printcolumn:name="RESOURCES",type="integer",JSONPath=".status.resources"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kubernetes}
+type ManifestReport struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          ManifestReportSpec   `json:"spec"`
+	Status        ManifestReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ManifestReportList contains a list of ManifestReport
+type ManifestReportList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []ManifestReport `json:"items"`
+}
+
+// ManifestReportSpec defines the desired state of ManifestReport
+type ManifestReportSpec struct {
+
+	// ProviderConfigReference specifies the provider config whose managed
+	// resources are reported.
+	// +kubebuilder:default={"name": "default"}
+	ProviderConfigReference v12.Reference `json:"providerConfigRef,omitempty"`
+
+	// ConfigMapReference is the ConfigMap on the control plane the report is
+	// written to. It is created if it does not exist.
+	ConfigMapReference ConfigMapReference `json:"configMapRef"`
+}
+
+// ConfigMapReference refers to a ConfigMap.
+type ConfigMapReference struct {
+
+	// Namespace of the ConfigMap.
+	// +kubebuilder:validation:MinLength:=1
+	Namespace string `json:"namespace"`
+
+	// Name of the ConfigMap.
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// Key of the ConfigMap the report is written to.
+	// +kubebuilder:default=bom.json
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// ManifestReportStatus represents the observed state of a ManifestReport
+type ManifestReportStatus struct {
+	v12.ResourceStatus `json:",inline"`
+
+	// Resources is the number of managed resources in the last report.
+	// +optional
+	Resources int `json:"resources,omitempty"`
+}
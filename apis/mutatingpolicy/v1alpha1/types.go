@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +kubebuilder:object:root=true
+
+// A MutatingPolicy patches the manifests of the Objects it selects before
+// they are applied to their target cluster, e.g. to inject a sidecar
+// container into every Deployment.
+// +kubebuilder:printcolumn:name="TARGET-KIND",type="string",JSONPath=".spec.targetGVK.kind"
+// +kubebuilder:printcolumn:name="PRIORITY",type="integer",JSONPath=".spec.priority"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,kubernetes}
+type MutatingPolicy struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          MutatingPolicySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// MutatingPolicyList contains a list of MutatingPolicy
+type MutatingPolicyList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []MutatingPolicy `json:"items"`
+}
+
+// MutatingPolicySpec defines the desired state of a MutatingPolicy.
+type MutatingPolicySpec struct {
+	// TargetGVK is the kind of the manifests the policy patches.
+	TargetGVK GroupVersionKind `json:"targetGVK"`
+
+	// ObjectSelector selects the Objects whose manifests are patched by
+	// their labels. All Objects with a manifest of the target kind are
+	// patched if it is not set.
+	// +optional
+	ObjectSelector *v1.LabelSelector `json:"objectSelector,omitempty"`
+
+	// Patches are the JSON Patch operations applied to the manifests.
+	// +kubebuilder:validation:MinItems=1
+	Patches []JSONPatchOperation `json:"patches"`
+
+	// Priority orders the policies patching the same manifest. Policies
+	// with a lower priority are applied first, those with the same
+	// priority by name.
+	// +optional
+	// +kubebuilder:default=0
+	Priority int32 `json:"priority,omitempty"`
+}
+
+// GroupVersionKind of a manifest.
+type GroupVersionKind struct {
+	// Group of the kind, empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Version of the kind. All versions are matched if it is not set.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Kind of the manifest.
+	Kind string `json:"kind"`
+}
+
+// A JSONPatchOperation is an operation of a JSON Patch as defined by RFC
+// 6902.
+type JSONPatchOperation struct {
+	// Op is the operation to perform.
+	// +kubebuilder:validation:Enum=add;remove;replace;move;copy;test
+	Op string `json:"op"`
+	// Path is the JSON Pointer of the value the operation applies to.
+	Path string `json:"path"`
+	// From is the JSON Pointer of the value to move or copy.
+	// +optional
+	From string `json:"from,omitempty"`
+	// Value to add, replace or test.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Value *runtime.RawExtension `json:"value,omitempty"`
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v12 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// A NamespaceObjectQuota limits the number of Objects whose resources are
+// in a namespace. Objects exceeding it are rejected when they are created.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="NAMESPACE",type="string",JSONPath=".spec.namespace"
+// +kubebuilder:printcolumn:name="COUNT",type="integer",JSONPath=".status.currentCount"
+// +kubebuilder:printcolumn:name="MAX",type="integer",JSONPath=".spec.maxObjects"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,kubernetes}
+type NamespaceObjectQuota struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          NamespaceObjectQuotaSpec   `json:"spec"`
+	Status        NamespaceObjectQuotaStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NamespaceObjectQuotaList contains a list of NamespaceObjectQuota
+type NamespaceObjectQuotaList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []NamespaceObjectQuota `json:"items"`
+}
+
+// NamespaceObjectQuotaSpec defines the desired state of NamespaceObjectQuota
+type NamespaceObjectQuotaSpec struct {
+	// Namespace of the resources the limited Objects manage. Objects of
+	// cluster scoped resources are never limited.
+	// +kubebuilder:validation:MinLength:=1
+	Namespace string `json:"namespace"`
+
+	// MaxObjects is the maximum number of Objects whose resources may be in
+	// the namespace. If several quotas apply to a namespace, the lowest
+	// limit applies.
+	// +kubebuilder:validation:Minimum:=0
+	MaxObjects int64 `json:"maxObjects"`
+
+	// Grace is the percentage of MaxObjects above which a warning is
+	// emitted for every further Object, to announce the limit before it is
+	// reached.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	Grace *int32 `json:"grace,omitempty"`
+}
+
+// NamespaceObjectQuotaStatus represents the observed state of a NamespaceObjectQuota
+type NamespaceObjectQuotaStatus struct {
+	v12.ResourceStatus `json:",inline"`
+
+	// CurrentCount is the number of Objects whose resources are in the
+	// namespace, as last counted.
+	// +optional
+	CurrentCount int64 `json:"currentCount,omitempty"`
+}
+
+// GraceExceeded returns whether the supplied number of Objects exceeds the
+// grace percentage of the quota. It is always false if no grace is set.
+func (q *NamespaceObjectQuota) GraceExceeded(n int64) bool {
+	if q.Spec.Grace == nil {
+		return false
+	}
+	return n*100 > q.Spec.MaxObjects*int64(*q.Spec.Grace)
+}
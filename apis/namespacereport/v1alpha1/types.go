@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v12 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// A NamespaceReport reports which of the Objects whose resources are in its
+// namespace are in sync with their manifest, drifted from it, or failed to
+// sync.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SYNCED",type="integer",JSONPath=".status.synced"
+// +kubebuilder:printcolumn:name="DRIFTED",type="integer",JSONPath=".status.drifted"
+// +kubebuilder:printcolumn:name="ERRORED",type="integer",JSONPath=".status.errored"
+// +kubebuilder:printcolumn:name="REPORTED",type="date",JSONPath=".status.lastReportTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,provider,kubernetes}
+type NamespaceReport struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Status        NamespaceReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NamespaceReportList contains a list of NamespaceReport
+type NamespaceReportList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []NamespaceReport `json:"items"`
+}
+
+// NamespaceReportStatus represents the observed state of a NamespaceReport.
+type NamespaceReportStatus struct {
+	v12.ResourceStatus `json:",inline"`
+
+	// Synced is the number of reported Objects whose resource matches their
+	// manifest.
+	// +optional
+	Synced int64 `json:"synced,omitempty"`
+
+	// Drifted is the number of reported Objects whose resource deviates
+	// from their manifest.
+	// +optional
+	Drifted int64 `json:"drifted,omitempty"`
+
+	// Errored is the number of reported Objects that failed to sync.
+	// +optional
+	Errored int64 `json:"errored,omitempty"`
+
+	// Report is a JSON summary of the reported Objects, with their totals,
+	// their totals per kind of resource, and the names of the errored
+	// Objects. At most 50 errored Objects are listed.
+	// +optional
+	Report string `json:"report,omitempty"`
+
+	// LastReportTime is when the report was last written.
+	// +optional
+	LastReportTime *v1.Time `json:"lastReportTime,omitempty"`
+}
@@ -0,0 +1,429 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Condition types specific to Objects.
+const (
+	// TypeExpiresIn indicates whether an Object with a deleteAfter TTL is
+	// about to be deleted.
+	TypeExpiresIn xpv1.ConditionType = "ExpiresIn"
+
+	// TypeTerminationWaiting indicates whether a deleted Object is waiting
+	// for its managed resource to terminate.
+	TypeTerminationWaiting xpv1.ConditionType = "TerminationWaiting"
+
+	// TypeDeletionTimeout indicates whether a deleted Object stopped waiting
+	// for its managed resource to terminate.
+	TypeDeletionTimeout xpv1.ConditionType = "DeletionTimeout"
+
+	// TypeValidationFailed indicates whether the manifest of an Object
+	// violates the schema served by the target cluster.
+	TypeValidationFailed xpv1.ConditionType = "ValidationFailed"
+
+	// TypeSpecGenerationSynced indicates whether the latest generation of an
+	// Object's spec was successfully reconciled. Unlike Synced, it does not
+	// reflect the outcome of reconciling a previous generation.
+	TypeSpecGenerationSynced xpv1.ConditionType = "SpecGenerationSynced"
+
+	// TypeInformerLimitExceeded indicates whether the resources of an Object
+	// cannot be watched because the limit of resource informers is reached.
+	TypeInformerLimitExceeded xpv1.ConditionType = "InformerLimitExceeded"
+
+	// TypeBudgetExceeded indicates whether the resources of an Object cannot
+	// be watched because a ClusterInformerBudget of its cluster is exhausted.
+	TypeBudgetExceeded xpv1.ConditionType = "BudgetExceeded"
+
+	// TypeSlowAdmission indicates whether applying an Object's manifest was
+	// slow, e.g. because of admission webhooks of the target cluster.
+	TypeSlowAdmission xpv1.ConditionType = "SlowAdmission"
+
+	// TypeAPIVersionMismatch indicates whether the apiVersion of an Object's
+	// manifest is not served by the target cluster, while another version of
+	// its kind is.
+	TypeAPIVersionMismatch xpv1.ConditionType = "APIVersionMismatch"
+
+	// TypeOwnerReferenceConflict indicates whether the managed resource of an
+	// Object has owner references added by other controllers.
+	TypeOwnerReferenceConflict xpv1.ConditionType = "OwnerReferenceConflict"
+
+	// TypeOutsideMaintenanceWindow indicates whether changes to the managed
+	// resource of an Object are deferred until its next maintenance window.
+	TypeOutsideMaintenanceWindow xpv1.ConditionType = "OutsideMaintenanceWindow"
+
+	// TypeOrphaned indicates whether the ProviderConfig an Object references
+	// does not exist.
+	TypeOrphaned xpv1.ConditionType = "Orphaned"
+
+	// TypeReconcileTimedOut indicates whether the last reconcile of an
+	// Object exceeded its reconcile timeout.
+	TypeReconcileTimedOut xpv1.ConditionType = "ReconcileTimedOut"
+
+	// TypeNodeAffinityUnsatisfiable indicates whether no node of the target
+	// cluster can run the pods of an Object's managed workload.
+	TypeNodeAffinityUnsatisfiable xpv1.ConditionType = "NodeAffinityUnsatisfiable"
+)
+
+// Reasons an Object's specific conditions are set.
+const (
+	ReasonExpiring              xpv1.ConditionReason = "Expiring"
+	ReasonExpiryCancelled       xpv1.ConditionReason = "ExpiryCancelled"
+	ReasonTerminating           xpv1.ConditionReason = "Terminating"
+	ReasonTimedOut              xpv1.ConditionReason = "TimedOut"
+	ReasonSchemaViolation       xpv1.ConditionReason = "SchemaViolation"
+	ReasonSchemaValid           xpv1.ConditionReason = "SchemaValid"
+	ReasonGenerationPending     xpv1.ConditionReason = "GenerationPending"
+	ReasonGenerationSynced      xpv1.ConditionReason = "GenerationSynced"
+	ReasonLimitExceeded         xpv1.ConditionReason = "LimitExceeded"
+	ReasonInformerAvailable     xpv1.ConditionReason = "InformerAvailable"
+	ReasonBudgetExceeded        xpv1.ConditionReason = "BudgetExceeded"
+	ReasonWithinBudget          xpv1.ConditionReason = "WithinBudget"
+	ReasonSlowAdmission         xpv1.ConditionReason = "SlowAdmission"
+	ReasonFastAdmission         xpv1.ConditionReason = "FastAdmission"
+	ReasonVersionNotServed      xpv1.ConditionReason = "VersionNotServed"
+	ReasonVersionServed         xpv1.ConditionReason = "VersionServed"
+	ReasonConflictingOwner      xpv1.ConditionReason = "ConflictingOwner"
+	ReasonNoConflictingOwner    xpv1.ConditionReason = "NoConflictingOwner"
+	ReasonWindowClosed          xpv1.ConditionReason = "WindowClosed"
+	ReasonWindowOpen            xpv1.ConditionReason = "WindowOpen"
+	ReasonProviderConfigMissing xpv1.ConditionReason = "ProviderConfigMissing"
+	ReasonProviderConfigFound   xpv1.ConditionReason = "ProviderConfigFound"
+	ReasonDeadlineExceeded      xpv1.ConditionReason = "DeadlineExceeded"
+	ReasonCompletedInTime       xpv1.ConditionReason = "CompletedInTime"
+	ReasonNoMatchingNodes       xpv1.ConditionReason = "NoMatchingNodes"
+	ReasonMatchingNodes         xpv1.ConditionReason = "MatchingNodes"
+)
+
+// Expiring returns a condition that indicates the Object will be deleted
+// once the supplied deadline has passed.
+func Expiring(deadline time.Time) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeExpiresIn,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonExpiring,
+		Message:            fmt.Sprintf("Object will be deleted at %s", deadline.UTC().Format(time.RFC3339)),
+	}
+}
+
+// ExpiryCancelled returns a condition that indicates a previously pending
+// expiry of the Object was cancelled, e.g. because its deleteAfter TTL was
+// removed or extended.
+func ExpiryCancelled() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeExpiresIn,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonExpiryCancelled,
+	}
+}
+
+// TerminationWaiting returns a condition that indicates the Object is waiting
+// for its managed resource to terminate.
+func TerminationWaiting() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTerminationWaiting,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTerminating,
+		Message:            "Waiting for the managed resource to be deleted",
+	}
+}
+
+// DeletionTimedOut returns a condition that indicates the Object gave up
+// waiting for its managed resource to terminate after the supplied timeout.
+func DeletionTimedOut(timeout time.Duration) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionTimeout,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTimedOut,
+		Message:            fmt.Sprintf("Managed resource was not deleted within %s", timeout),
+	}
+}
+
+// ValidationFailed returns a condition that indicates the manifest of the
+// Object violates the schema served by the target cluster.
+func ValidationFailed(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeValidationFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSchemaViolation,
+		Message:            err.Error(),
+	}
+}
+
+// ValidationSucceeded returns a condition that indicates the manifest of the
+// Object conforms to the schema served by the target cluster.
+func ValidationSucceeded() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeValidationFailed,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSchemaValid,
+	}
+}
+
+// SpecGenerationPending returns a condition that indicates the supplied
+// generation of the Object's spec was not yet successfully reconciled.
+func SpecGenerationPending(generation int64) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSpecGenerationSynced,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonGenerationPending,
+		Message:            fmt.Sprintf("Generation %d was not yet reconciled", generation),
+	}
+}
+
+// SpecGenerationSynced returns a condition that indicates the latest
+// generation of the Object's spec was successfully reconciled.
+func SpecGenerationSynced() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSpecGenerationSynced,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonGenerationSynced,
+	}
+}
+
+// InformerLimitExceeded returns a condition that indicates the resources of
+// the Object cannot be watched because the limit of resource informers is
+// reached. The Object is still polled.
+func InformerLimitExceeded(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInformerLimitExceeded,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonLimitExceeded,
+		Message:            err.Error(),
+	}
+}
+
+// InformerAvailable returns a condition that indicates the resources of the
+// Object are watched.
+func InformerAvailable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInformerLimitExceeded,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInformerAvailable,
+	}
+}
+
+// BudgetExceeded returns a condition that indicates the resources of the
+// Object cannot be watched because a ClusterInformerBudget of its cluster is
+// exhausted. The Object is still polled.
+func BudgetExceeded(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBudgetExceeded,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonBudgetExceeded,
+		Message:            err.Error(),
+	}
+}
+
+// WithinBudget returns a condition that indicates the resources of the
+// Object are watched within the ClusterInformerBudgets of its cluster.
+func WithinBudget() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBudgetExceeded,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWithinBudget,
+	}
+}
+
+// SlowAdmission returns a condition that indicates applying the Object's
+// manifest took the supplied duration, more than the supplied threshold.
+func SlowAdmission(d, threshold time.Duration) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSlowAdmission,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSlowAdmission,
+		Message:            fmt.Sprintf("Applying the manifest took %s, more than the %s threshold", d.Round(time.Millisecond), threshold),
+	}
+}
+
+// FastAdmission returns a condition that indicates applying the Object's
+// manifest no longer exceeds the slow admission threshold.
+func FastAdmission() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSlowAdmission,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonFastAdmission,
+	}
+}
+
+// APIVersionMismatch returns a condition that indicates the apiVersion of the
+// Object's manifest is not served by the target cluster.
+func APIVersionMismatch(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAPIVersionMismatch,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonVersionNotServed,
+		Message:            err.Error(),
+	}
+}
+
+// APIVersionServed returns a condition that indicates the apiVersion of the
+// Object's manifest is served by the target cluster again.
+func APIVersionServed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAPIVersionMismatch,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonVersionServed,
+	}
+}
+
+// OwnerReferenceConflict returns a condition that indicates the managed
+// resource of the Object is also owned by the supplied owners, given as
+// kind/name/uid.
+func OwnerReferenceConflict(owners []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOwnerReferenceConflict,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonConflictingOwner,
+		Message:            fmt.Sprintf("The managed resource was adopted by %s", strings.Join(owners, ", ")),
+	}
+}
+
+// NoOwnerReferenceConflict returns a condition that indicates the managed
+// resource of the Object is no longer owned by other controllers.
+func NoOwnerReferenceConflict() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOwnerReferenceConflict,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoConflictingOwner,
+	}
+}
+
+// OutsideMaintenanceWindow returns a condition that indicates changes to the
+// managed resource of the Object are deferred until the maintenance window
+// starting at the supplied time.
+func OutsideMaintenanceWindow(next time.Time) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOutsideMaintenanceWindow,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWindowClosed,
+		Message:            fmt.Sprintf("Changes are deferred until the next maintenance window starts at %s", next.UTC().Format(time.RFC3339)),
+	}
+}
+
+// InsideMaintenanceWindow returns a condition that indicates the managed
+// resource of the Object may be changed again.
+func InsideMaintenanceWindow() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOutsideMaintenanceWindow,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWindowOpen,
+	}
+}
+
+// Orphaned returns a condition that indicates the ProviderConfig of the
+// supplied name, which the Object references, does not exist.
+func Orphaned(providerConfig string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOrphaned,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonProviderConfigMissing,
+		Message:            fmt.Sprintf("ProviderConfig %q does not exist", providerConfig),
+	}
+}
+
+// NotOrphaned returns a condition that indicates the ProviderConfig the
+// Object references exists again.
+func NotOrphaned() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOrphaned,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonProviderConfigFound,
+	}
+}
+
+// ReconcileTimedOut returns a condition that indicates the last reconcile of
+// the Object did not complete within the supplied timeout.
+func ReconcileTimedOut(timeout time.Duration) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeReconcileTimedOut,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeadlineExceeded,
+		Message:            fmt.Sprintf("Reconcile did not complete within %s", timeout),
+	}
+}
+
+// ReconcileCompletedInTime returns a condition that indicates the last
+// reconcile of the Object completed within its timeout again.
+func ReconcileCompletedInTime() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeReconcileTimedOut,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCompletedInTime,
+	}
+}
+
+// NodeAffinityUnsatisfiable returns a condition that indicates no node of the
+// target cluster matches the supplied node selector and tolerates the
+// tolerations of the pods of the Object's managed workload.
+func NodeAffinityUnsatisfiable(selector string) xpv1.Condition {
+	msg := "No node is tolerated by the pod template"
+	if selector != "" {
+		msg = fmt.Sprintf("No node matching node selector %q is tolerated by the pod template", selector)
+	}
+	return xpv1.Condition{
+		Type:               TypeNodeAffinityUnsatisfiable,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoMatchingNodes,
+		Message:            msg,
+	}
+}
+
+// NodeAffinitySatisfiable returns a condition that indicates nodes of the
+// target cluster can run the pods of the Object's managed workload again.
+func NodeAffinitySatisfiable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeNodeAffinityUnsatisfiable,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMatchingNodes,
+	}
+}
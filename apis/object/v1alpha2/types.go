@@ -20,6 +20,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
@@ -72,14 +73,129 @@ type Reference struct {
 	// propagate to the same path as patchesFrom.fieldPath.
 	// +optional
 	ToFieldPath *string `json:"toFieldPath,omitempty"`
+	// WatchTrigger limits which changes of the referenced resource trigger
+	// a reconcile of this Object if it is watched.
+	// +optional
+	WatchTrigger *WatchTrigger `json:"watchTrigger,omitempty"`
+}
+
+// WatchTrigger defines which changes of a watched referenced resource are
+// relevant.
+type WatchTrigger struct {
+	// AnnotationKeys only triggers a reconcile if the value of one of these
+	// annotations of the referenced resource changes, e.g. an annotation
+	// carrying a hash of its configuration. Other updates, e.g. of its
+	// status, are ignored.
+	// +optional
+	// +listType=set
+	AnnotationKeys []string `json:"annotationKeys,omitempty"`
+}
+
+// ManifestURLConfig configures how a manifest is fetched from a URL.
+type ManifestURLConfig struct {
+	// Timeout of fetching the manifest.
+	// +optional
+	// +kubebuilder:default="10s"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// BasicAuthSecretRef references a Secret whose username and password
+	// keys are used to authenticate to the server.
+	// +optional
+	BasicAuthSecretRef *xpv1.SecretReference `json:"basicAuthSecretRef,omitempty"`
+
+	// CASecretRef references a key of a Secret holding a PEM encoded CA
+	// bundle. The certificate of the server is verified against it instead
+	// of the system's CAs.
+	// +optional
+	CASecretRef *xpv1.SecretKeySelector `json:"caSecretRef,omitempty"`
 }
 
 // ObjectParameters are the configurable fields of a Object.
+// +kubebuilder:validation:XValidation:rule="!has(self.autoInstallCRD) || !self.autoInstallCRD || has(self.crdManifest)",message="crdManifest is required by autoInstallCRD"
 type ObjectParameters struct {
 	// Raw JSON representation of the kubernetes object to be created.
+	// Exactly one of Manifest, ManifestYAML or ManifestURL must be set.
+	// A manifest of kind List creates every resource of its items, which
+	// are applied in order and deleted in reverse order.
+	// +optional
+	// +kubebuilder:validation:EmbeddedResource
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Manifest runtime.RawExtension `json:"manifest,omitempty"`
+
+	// ManifestYAML is the kubernetes object to be created as a YAML
+	// document, which may contain comments documenting its fields. Comments
+	// are stripped, anchors, aliases, tags and directives are rejected.
+	// Exactly one of Manifest, ManifestYAML or ManifestURL must be set.
+	// +optional
+	ManifestYAML string `json:"manifestYAML,omitempty"`
+
+	// ManifestURL is an HTTPS URL the kubernetes object to be created is
+	// fetched from at every reconcile, as a YAML or JSON document like
+	// ManifestYAML. Responses are cached by their ETag and Last-Modified
+	// headers. Exactly one of Manifest, ManifestYAML or ManifestURL must be
+	// set.
+	// +optional
+	ManifestURL string `json:"manifestURL,omitempty"`
+
+	// ManifestURLConfig configures how the manifest is fetched from
+	// ManifestURL.
+	// +optional
+	ManifestURLConfig *ManifestURLConfig `json:"manifestURLConfig,omitempty"`
+
+	// AutoCreateNamespace creates the namespace of the manifest on the
+	// target cluster if it does not exist. Namespaces created this way are
+	// deleted together with the last Object targeting them.
+	// +optional
+	AutoCreateNamespace bool `json:"autoCreateNamespace,omitempty"`
+
+	// PreserveUnmanagedFields keeps fields of the resource that are set by
+	// others, e.g. items of lists added by other controllers, when updating
+	// it. The update is a three-way merge of the last applied manifest, the
+	// desired manifest and the live resource, like "kubectl apply". Fields
+	// removed from the manifest are still removed from the resource.
+	// +optional
+	PreserveUnmanagedFields bool `json:"preserveUnmanagedFields,omitempty"`
+
+	// AutoInstallCRD creates the CustomResourceDefinition in CRDManifest on
+	// the target cluster if the kind of the manifest is not served yet. The
+	// manifest is only applied once the CRD is established. CRDs created
+	// this way are not deleted together with the Object.
+	// +optional
+	AutoInstallCRD bool `json:"autoInstallCRD,omitempty"`
+
+	// CRDManifest is the CustomResourceDefinition of the kind of the
+	// manifest. It is only used if AutoInstallCRD is enabled.
+	// +optional
 	// +kubebuilder:validation:EmbeddedResource
 	// +kubebuilder:pruning:PreserveUnknownFields
-	Manifest runtime.RawExtension `json:"manifest"`
+	CRDManifest *runtime.RawExtension `json:"crdManifest,omitempty"`
+
+	// TemplateValues are the values of the Go templates in string fields of
+	// the manifest, e.g. "{{ .endpoint }}" for the value named endpoint.
+	// Values are either literal, or read from a key of a Secret in the
+	// cluster this Object lives in at every reconcile. Changes of the Secret
+	// are picked up right away if the resources of the Object are watched.
+	// +optional
+	TemplateValues map[string]TemplateValue `json:"templateValues,omitempty"`
+}
+
+// A TemplateValue is a value of the templates of a manifest.
+// +kubebuilder:validation:XValidation:rule="has(self.value) != has(self.secretKeyRef)",message="exactly one of value and secretKeyRef must be set"
+type TemplateValue struct {
+	// Value is a literal value.
+	// +optional
+	Value *string `json:"value,omitempty"`
+	// SecretKeyRef references the key of a Secret holding the value.
+	// +optional
+	SecretKeyRef *xpv1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// A DNSEndpointReference references an ExternalDNS DNSEndpoint.
+type DNSEndpointReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
 }
 
 // ObjectObservation are the observable fields of a Object.
@@ -88,6 +204,18 @@ type ObjectObservation struct {
 	// +kubebuilder:validation:EmbeddedResource
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Manifest runtime.RawExtension `json:"manifest,omitempty"`
+	// TerminalState is recorded once the managed resource reached the
+	// terminal state of the watch policy.
+	// +optional
+	TerminalState *TerminalState `json:"terminalState,omitempty"`
+}
+
+// TerminalState of a managed resource.
+type TerminalState struct {
+	// UID of the resource that reached its terminal state.
+	UID types.UID `json:"uid"`
+	// Time at which the terminal state was observed.
+	Time metav1.Time `json:"time"`
 }
 
 // A ObjectSpec defines the desired state of a Object.
@@ -97,6 +225,11 @@ type ObjectSpec struct {
 	ForProvider       ObjectParameters   `json:"forProvider"`
 	References        []Reference        `json:"references,omitempty"`
 	Readiness         Readiness          `json:"readiness,omitempty"`
+	Validation        Validation         `json:"validation,omitempty"`
+	DriftDetection    DriftDetection     `json:"driftDetection,omitempty"`
+	// ReconcilePolicy configures how often this Object is reconciled.
+	// +optional
+	ReconcilePolicy *ReconcilePolicy `json:"reconcilePolicy,omitempty"`
 	// Watch enables watching the referenced or managed kubernetes resources.
 	//
 	// THIS IS AN ALPHA FIELD. Do not use it in production. It is not honored
@@ -105,6 +238,229 @@ type ObjectSpec struct {
 	// +optional
 	// +kubebuilder:default=false
 	Watch bool `json:"watch,omitempty"`
+	// WatchPredicate limits which changes of the watched resources trigger
+	// a reconcile of this Object. It is only honored if Watch is enabled.
+	// +optional
+	WatchPredicate *WatchPredicate `json:"watchPredicate,omitempty"`
+	// WatchOptions configure the informer watching the kind of the managed
+	// resource. It is only honored if Watch is enabled.
+	// +optional
+	WatchOptions *WatchOptions `json:"watchOptions,omitempty"`
+	// WatchPolicy configures when to stop watching the managed resource. It
+	// is only honored if Watch is enabled.
+	// +optional
+	WatchPolicy *WatchPolicy `json:"watchPolicy,omitempty"`
+	// DeleteAfter is the time to live of this Object, e.g. "24h". Once this
+	// duration has elapsed since the Object was created, the Object is
+	// deleted automatically, honoring its deletionPolicy.
+	// +optional
+	DeleteAfter *metav1.Duration `json:"deleteAfter,omitempty"`
+	// WaitForDeletion reports a deleted Object that is still waiting for its
+	// managed resource to terminate on the target cluster through the
+	// TerminationWaiting condition, and bounds the wait by DeletionTimeout.
+	// +optional
+	WaitForDeletion bool `json:"waitForDeletion,omitempty"`
+	// DeletionTimeout is how long a deleted Object with WaitForDeletion
+	// waits for its managed resource to terminate. Once elapsed, the
+	// DeletionTimeout condition is set and the Object's finalizer removed.
+	// +optional
+	// +kubebuilder:default="10m"
+	DeletionTimeout *metav1.Duration `json:"deletionTimeout,omitempty"`
+	// SetOwnerReference adds an owner reference to this Object to the
+	// managed resource, so that the garbage collector of the target cluster
+	// deletes it together with the Object. It is only honored if the
+	// target cluster is the cluster this Object lives in, and is ignored if
+	// the deletionPolicy is Orphan. Objects whose ProviderConfig targets
+	// another cluster are rejected.
+	// +optional
+	SetOwnerReference bool `json:"setOwnerReference,omitempty"`
+	// SidecarObjects are Objects created along with this Object once its
+	// managed resource exists, e.g. a default NetworkPolicy in the namespace
+	// of a Deployment. They use the providerConfigRef and deletionPolicy of
+	// this Object and are deleted together with it.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	SidecarObjects []SidecarObject `json:"sidecarObjects,omitempty"`
+	// EventPropagation re-emits the Warning Events of the managed resource,
+	// e.g. a failing image pull of a Deployment, as Events of this Object.
+	// It is not honored unless the "watches" feature gate is enabled.
+	// +optional
+	EventPropagation *EventPropagation `json:"eventPropagation,omitempty"`
+	// EventHistory records the Normal and Warning Events of the managed
+	// resource in the status of this Object. Events are recorded whenever
+	// the Object is observed, and right away if it watches its resource.
+	// +optional
+	EventHistory *EventHistory `json:"eventHistory,omitempty"`
+	// DNSPropagate registers the load balancer of a managed Service of type
+	// LoadBalancer in DNS, by creating an ExternalDNS DNSEndpoint in the
+	// cluster this Object lives in. The DNSEndpoint is kept in sync with the
+	// ingress of the load balancer, and deleted together with this Object.
+	// Its API and namespace are configured by the dnsEndpoint of the
+	// ProviderConfig.
+	// +optional
+	DNSPropagate *DNSPropagate `json:"dnsPropagate,omitempty"`
+	// IgnoredFields are JSON Pointers, e.g. "/spec/replicas", of fields of
+	// the manifest that are managed by controllers of the target cluster,
+	// like a HorizontalPodAutoscaler. They are stripped from the manifest
+	// before it is compared to and applied to the managed resource, so they
+	// are never overwritten.
+	// +optional
+	// +listType=atomic
+	IgnoredFields []string `json:"ignoredFields,omitempty"`
+	// OwnerConflictResolution defines what happens once another controller,
+	// e.g. a StatefulSet controller, adds an owner reference to the managed
+	// resource. Such conflicts are always reported by the
+	// OwnerReferenceConflict condition. Remove also removes the conflicting
+	// owner references from the managed resource.
+	// +optional
+	// +kubebuilder:validation:Enum=None;Remove
+	// +kubebuilder:default=None
+	OwnerConflictResolution OwnerConflictResolution `json:"ownerConflictResolution,omitempty"`
+	// MaintenanceWindow restricts changes to the managed resource to
+	// recurring maintenance windows. Outside of them the managed resource is
+	// only observed, and its drift from the manifest reported.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// Immutable managed resources are never updated once they were created.
+	// Changes of the manifest are reported by ImmutableObject events
+	// instead. The provider-kubernetes.crossplane.io/force-update: "true"
+	// annotation applies them once, and is removed afterwards.
+	// +optional
+	Immutable bool `json:"immutable,omitempty"`
+	// ReconcileTimeout limits how long a reconcile of the Object may take,
+	// so that a slow target cluster does not block a reconcile worker. It
+	// is capped at 10m. Timed out reconciles are reported by the
+	// ReconcileTimedOut condition and retried with backoff.
+	// +optional
+	// +kubebuilder:default="60s"
+	ReconcileTimeout *metav1.Duration `json:"reconcileTimeout,omitempty"`
+	// NodeAffinity reports whether the pods of a managed workload, e.g. a
+	// Deployment, can be scheduled on the nodes of the target cluster.
+	// +optional
+	NodeAffinity *NodeAffinity `json:"nodeAffinity,omitempty"`
+}
+
+// NodeAffinity configures how the nodes a managed workload may be scheduled
+// on are checked.
+type NodeAffinity struct {
+	// WatchNodes checks whether any node of the target cluster matches the
+	// nodeSelector and tolerates the tolerations of the pod template of the
+	// managed resource, and reports the NodeAffinityUnsatisfiable condition
+	// if none does. Nodes are watched to check again once their labels or
+	// taints change, if the "watches" feature gate is enabled. Otherwise
+	// they are checked whenever the Object is observed. The provider config
+	// must be allowed to list and watch nodes.
+	// +optional
+	WatchNodes bool `json:"watchNodes,omitempty"`
+}
+
+// MaintenanceWindow defines recurring time windows in which the managed
+// resource of an Object may be changed.
+type MaintenanceWindow struct {
+	// Schedule is a cron expression of when the windows start, e.g.
+	// "0 2 * * 0" for Sundays at 2am. It is evaluated in UTC unless it is
+	// prefixed with a time zone, e.g. "CRON_TZ=Europe/Berlin 0 2 * * 0".
+	// +kubebuilder:validation:MinLength:=1
+	Schedule string `json:"schedule"`
+	// Duration is how long each window lasts, e.g. "2h".
+	Duration metav1.Duration `json:"duration"`
+}
+
+// OwnerConflictResolution defines how owner references added to the managed
+// resource of an Object by other controllers are resolved.
+type OwnerConflictResolution string
+
+const (
+	// OwnerConflictResolutionNone only reports conflicting owner references.
+	OwnerConflictResolutionNone OwnerConflictResolution = "None"
+	// OwnerConflictResolutionRemove removes conflicting owner references
+	// from the managed resource.
+	OwnerConflictResolutionRemove OwnerConflictResolution = "Remove"
+)
+
+// EventPropagation configures which Events of the managed resource of an
+// Object are re-emitted on the Object.
+type EventPropagation struct {
+	// Enabled re-emits the Warning Events of the managed resource on the
+	// Object, at most 5 per minute.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// EventHistory configures whether the Events of the managed resource of an
+// Object are recorded in its status.
+type EventHistory struct {
+	// Enabled records the latest 20 Events of the managed resource in
+	// status.eventHistory, evicting the oldest ones.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// DNSPropagate configures how the load balancer of the managed Service of an
+// Object is registered in DNS.
+type DNSPropagate struct {
+	// Enabled registers the load balancer of the managed Service in DNS.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Hostnames the load balancer is registered as. Defaults to the comma
+	// separated hostnames of the external-dns.alpha.kubernetes.io/hostname
+	// annotation of the managed Service.
+	// +optional
+	// +listType=atomic
+	Hostnames []string `json:"hostnames,omitempty"`
+	// RecordTTL is the TTL of the DNS records in seconds. Defaults to the
+	// external-dns.alpha.kubernetes.io/ttl annotation of the managed
+	// Service, or the default of ExternalDNS.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RecordTTL *int64 `json:"recordTTL,omitempty"`
+}
+
+// WatchOptions configure the informer watching the kind of a managed
+// resource.
+type WatchOptions struct {
+	// MaxCacheSize limits the number of resources of the kind the informer
+	// tracks, for kinds with too many resources to cache, e.g. Pods or
+	// Events. When set, the informer keeps only a hash of the resource
+	// version of each resource and fetches changed resources when they
+	// change. Changes of resources beyond the limit are not watched. The
+	// informer is shared by all Objects watching the kind on the same
+	// cluster, and uses the lowest limit any of them sets when it starts.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxCacheSize *int32 `json:"maxCacheSize,omitempty"`
+}
+
+// WatchPolicy configures when to stop watching a managed resource.
+// +kubebuilder:validation:XValidation:rule="!has(self.stopOnTerminal) || !self.stopOnTerminal || has(self.terminalExpression)",message="terminalExpression is required by stopOnTerminal"
+type WatchPolicy struct {
+	// StopOnTerminal stops watching the managed resource once it reached a
+	// terminal state, e.g. a Job that completed or failed. The Object is
+	// polled instead, and the informer of its kind is stopped once no other
+	// Object watches the kind. Watching starts again if the resource is
+	// recreated.
+	// +optional
+	StopOnTerminal bool `json:"stopOnTerminal,omitempty"`
+	// TerminalExpression is a CEL expression returning whether the managed
+	// resource, available as self, reached a terminal state, e.g.
+	// "self.status.conditions.exists(c, c.type in ['Complete', 'Failed'] && c.status == 'True')".
+	// +optional
+	TerminalExpression string `json:"terminalExpression,omitempty"`
+}
+
+// SidecarObject is an Object created and deleted along with another Object.
+type SidecarObject struct {
+	// Name of the sidecar, unique within its Object. The sidecar Object is
+	// named after its Object, suffixed with this name.
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+	// Template is a Go template that renders the YAML manifest of the
+	// sidecar. It has access to the metadata of the managed resource of the
+	// Object as .Name, .Namespace, .Labels, .Annotations, .APIVersion and
+	// .Kind, and to the name of the Object as .ObjectName.
+	// +kubebuilder:validation:MinLength:=1
+	Template string `json:"template"`
 }
 
 // ReadinessPolicy defines how the Object's readiness condition should be computed.
@@ -120,17 +476,113 @@ const (
 	// ReadinessPolicyAllTrue means that all conditions have status true on the object.
 	// There must be at least one condition.
 	ReadinessPolicyAllTrue ReadinessPolicy = "AllTrue"
+	// ReadinessPolicyWaitForEvent means the object is marked as ready once an
+	// Event matching WaitForEvent was emitted for the underlying external
+	// resource.
+	ReadinessPolicyWaitForEvent ReadinessPolicy = "WaitForEvent"
+	// ReadinessPolicyCustom means the object is marked as ready if the CEL
+	// expression CustomExpression evaluates to ready for the underlying
+	// external resource.
+	ReadinessPolicyCustom ReadinessPolicy = "Custom"
 )
 
 // Readiness defines how the object's readiness condition should be computed,
 // if not specified it will be considered ready as soon as the underlying external
 // resource is considered up-to-date.
+// +kubebuilder:validation:XValidation:rule="!has(self.policy) || self.policy != 'WaitForEvent' || has(self.waitForEvent)",message="waitForEvent is required by the WaitForEvent policy"
+// +kubebuilder:validation:XValidation:rule="!has(self.policy) || self.policy != 'Custom' || has(self.customExpression)",message="customExpression is required by the Custom policy"
 type Readiness struct {
 	// Policy defines how the Object's readiness condition should be computed.
 	// +optional
-	// +kubebuilder:validation:Enum=SuccessfulCreate;DeriveFromObject;AllTrue
+	// +kubebuilder:validation:Enum=SuccessfulCreate;DeriveFromObject;AllTrue;WaitForEvent;Custom
 	// +kubebuilder:default=SuccessfulCreate
 	Policy ReadinessPolicy `json:"policy,omitempty"`
+	// WaitForEvent defines the Event the WaitForEvent policy waits for.
+	// +optional
+	WaitForEvent *WaitForEvent `json:"waitForEvent,omitempty"`
+	// CustomExpression is the CEL expression the Custom policy evaluates.
+	// The underlying external resource is available as self, and the
+	// expression must return a map with a boolean "ready" and an optional
+	// string "message" key, e.g.
+	// {"ready": self.status.phase == "Bound", "message": self.status.phase}.
+	// +optional
+	// +kubebuilder:validation:MinLength:=1
+	CustomExpression string `json:"customExpression,omitempty"`
+}
+
+// WaitForEvent matches Events emitted for the underlying external resource.
+type WaitForEvent struct {
+	// Reason of the Event, e.g. "SuccessfulCreate".
+	// +kubebuilder:validation:MinLength:=1
+	Reason string `json:"reason"`
+	// Type of the Event.
+	// +optional
+	// +kubebuilder:validation:Enum=Normal;Warning
+	// +kubebuilder:default=Normal
+	Type string `json:"type,omitempty"`
+	// Message is a substring the message of the Event must contain.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Window ignores Events last seen longer ago than this duration, e.g.
+	// those emitted for a previous incarnation of the resource.
+	// +optional
+	// +kubebuilder:default="10m"
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// Validation defines how the manifest is validated before it is applied.
+type Validation struct {
+	// ValidateAgainstSchema validates the manifest against the OpenAPI v3
+	// schema served by the target cluster before applying it. Violations are
+	// reported through the ValidationFailed condition.
+	// +optional
+	ValidateAgainstSchema bool `json:"validateAgainstSchema,omitempty"`
+}
+
+// WatchPredicate defines which changes of watched resources are relevant.
+type WatchPredicate struct {
+	// MatchLabels only triggers a reconcile if a watched resource starts or
+	// stops matching these labels. Other updates, e.g. of its status, are
+	// ignored. Creation and deletion of watched resources always trigger a
+	// reconcile.
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+// DriftDetection defines how drift of the managed resource from its desired
+// manifest is detected.
+type DriftDetection struct {
+	// StripServerAnnotations ignores annotations that are set by the API
+	// server or controllers, e.g. "deployment.kubernetes.io/revision", when
+	// comparing the observed resource, i.e. when hashing its significant
+	// fields and recording its divergence.
+	// +optional
+	// +kubebuilder:default=true
+	StripServerAnnotations *bool `json:"stripServerAnnotations,omitempty"`
+
+	// IgnoreAnnotations are additional annotation keys that are ignored when
+	// detecting drift if StripServerAnnotations is enabled.
+	// +optional
+	IgnoreAnnotations []string `json:"ignoreAnnotations,omitempty"`
+
+	// SignificantFields are JSON Pointers, e.g. "/spec/template", of fields
+	// of the managed resource that are hashed whenever the resource is found
+	// to be up to date. The resource is only considered drifted if the hash
+	// of these fields changes, while changes of any other fields, e.g. of its
+	// status or replicas scaled by a HorizontalPodAutoscaler, are ignored.
+	// Significant fields should be set by the manifest, so that updating the
+	// resource restores them.
+	// +optional
+	// +listType=atomic
+	SignificantFields []string `json:"significantFields,omitempty"`
+}
+
+// ReconcilePolicy configures how often an Object is reconciled.
+type ReconcilePolicy struct {
+	// Period is how often this Object is checked for drift once it is
+	// ready, e.g. "5m". It overrides the provider's poll interval.
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
 }
 
 // ConnectionDetail represents an entry in the connection secret for an Object
@@ -143,6 +595,123 @@ type ConnectionDetail struct {
 type ObjectStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          ObjectObservation `json:"atProvider,omitempty"`
+
+	// ObservedGeneration is the latest generation of the Object's spec that
+	// was successfully reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// FinalizePlugins lists the plugins that clean up after the Object's
+	// resource before its finalizer is removed.
+	// +optional
+	FinalizePlugins []FinalizePluginStatus `json:"finalizePlugins,omitempty"`
+
+	// LastErrorCode is the code of the error the last reconcile of the
+	// Object failed with, e.g. E001_APIServerUnreachable. It is empty if the
+	// last reconcile succeeded. Condition messages of errors are prefixed
+	// with the short form of the code, e.g. [E001].
+	// +optional
+	LastErrorCode string `json:"lastErrorCode,omitempty"`
+
+	// SidecarObjects lists the names of the sidecar Objects created for
+	// this Object.
+	// +optional
+	SidecarObjects []string `json:"sidecarObjects,omitempty"`
+
+	// DNSEndpointRef references the ExternalDNS DNSEndpoint created for the
+	// load balancer of the managed Service, see spec.dnsPropagate.
+	// +optional
+	DNSEndpointRef *DNSEndpointReference `json:"dnsEndpointRef,omitempty"`
+
+	// Divergence of the observed resource from the desired manifest, as of
+	// the last observation of the resource.
+	// +optional
+	Divergence *Divergence `json:"divergence,omitempty"`
+
+	// EventHistory holds the latest Events of the managed resource, oldest
+	// first, see spec.eventHistory.
+	// +optional
+	// +listType=atomic
+	EventHistory []RecordedEvent `json:"eventHistory,omitempty"`
+
+	// Items are the observed states of the resources of a manifest of kind
+	// List, in the order of its items.
+	// +optional
+	// +listType=atomic
+	Items []ItemStatus `json:"items,omitempty"`
+}
+
+// ItemStatus is the observed state of a resource of a manifest of kind List.
+type ItemStatus struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// Exists is true if the resource was found on its cluster.
+	// +optional
+	Exists bool `json:"exists,omitempty"`
+
+	// UpToDate is true if the manifest last applied to the resource is the
+	// one of the item.
+	// +optional
+	UpToDate bool `json:"upToDate,omitempty"`
+
+	// Message of the error the resource was last applied with, if any.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// A RecordedEvent is an Event of the managed resource of an Object.
+type RecordedEvent struct {
+	// Type of the Event, i.e. Normal or Warning.
+	Type string `json:"type"`
+
+	// Reason of the Event, e.g. ScalingReplicaSet.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message of the Event.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Timestamp is when the Event was last emitted.
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+// Divergence of a resource from its desired manifest. Server managed fields,
+// the status and fields the desired manifest does not set, e.g. defaulted
+// ones, are not compared.
+type Divergence struct {
+	// Patch is the JSON Patch document from the observed resource to the
+	// desired manifest. It is capped at 32KB, see Truncated.
+	// +optional
+	Patch string `json:"patch,omitempty"`
+
+	// FieldCount is the number of operations of the patch, i.e. of fields
+	// that diverge, including those truncated from Patch.
+	FieldCount int `json:"fieldCount"`
+
+	// Truncated is true if operations were dropped from Patch to cap its
+	// size.
+	// +optional
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// FinalizePluginStatus is the status of a plugin that cleans up after the
+// Object's resource when it is deleted.
+type FinalizePluginStatus struct {
+	// Name the plugin was registered with.
+	Name string `json:"name"`
+
+	// Finalized is true once the plugin cleaned up after the resource.
+	// +optional
+	Finalized bool `json:"finalized,omitempty"`
+
+	// Message of the error that blocks finalization by the plugin, if any.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
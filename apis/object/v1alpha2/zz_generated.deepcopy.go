@@ -21,6 +21,8 @@ limitations under the License.
 package v1alpha2
 
 import (
+	commonv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -40,6 +42,46 @@ func (in *ConnectionDetail) DeepCopy() *ConnectionDetail {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSEndpointReference) DeepCopyInto(out *DNSEndpointReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEndpointReference.
+func (in *DNSEndpointReference) DeepCopy() *DNSEndpointReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSEndpointReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSPropagate) DeepCopyInto(out *DNSPropagate) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecordTTL != nil {
+		in, out := &in.RecordTTL, &out.RecordTTL
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPropagate.
+func (in *DNSPropagate) DeepCopy() *DNSPropagate {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSPropagate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DependsOn) DeepCopyInto(out *DependsOn) {
 	*out = *in
@@ -55,6 +97,172 @@ func (in *DependsOn) DeepCopy() *DependsOn {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Divergence) DeepCopyInto(out *Divergence) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Divergence.
+func (in *Divergence) DeepCopy() *Divergence {
+	if in == nil {
+		return nil
+	}
+	out := new(Divergence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDetection) DeepCopyInto(out *DriftDetection) {
+	*out = *in
+	if in.StripServerAnnotations != nil {
+		in, out := &in.StripServerAnnotations, &out.StripServerAnnotations
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreAnnotations != nil {
+		in, out := &in.IgnoreAnnotations, &out.IgnoreAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SignificantFields != nil {
+		in, out := &in.SignificantFields, &out.SignificantFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetection.
+func (in *DriftDetection) DeepCopy() *DriftDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventHistory) DeepCopyInto(out *EventHistory) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventHistory.
+func (in *EventHistory) DeepCopy() *EventHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(EventHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventPropagation) DeepCopyInto(out *EventPropagation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventPropagation.
+func (in *EventPropagation) DeepCopy() *EventPropagation {
+	if in == nil {
+		return nil
+	}
+	out := new(EventPropagation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FinalizePluginStatus) DeepCopyInto(out *FinalizePluginStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FinalizePluginStatus.
+func (in *FinalizePluginStatus) DeepCopy() *FinalizePluginStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FinalizePluginStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ItemStatus) DeepCopyInto(out *ItemStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ItemStatus.
+func (in *ItemStatus) DeepCopy() *ItemStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ItemStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestURLConfig) DeepCopyInto(out *ManifestURLConfig) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BasicAuthSecretRef != nil {
+		in, out := &in.BasicAuthSecretRef, &out.BasicAuthSecretRef
+		*out = new(commonv1.SecretReference)
+		**out = **in
+	}
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(commonv1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestURLConfig.
+func (in *ManifestURLConfig) DeepCopy() *ManifestURLConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestURLConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAffinity) DeepCopyInto(out *NodeAffinity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAffinity.
+func (in *NodeAffinity) DeepCopy() *NodeAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Object) DeepCopyInto(out *Object) {
 	*out = *in
@@ -118,6 +326,11 @@ func (in *ObjectList) DeepCopyObject() runtime.Object {
 func (in *ObjectObservation) DeepCopyInto(out *ObjectObservation) {
 	*out = *in
 	in.Manifest.DeepCopyInto(&out.Manifest)
+	if in.TerminalState != nil {
+		in, out := &in.TerminalState, &out.TerminalState
+		*out = new(TerminalState)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectObservation.
@@ -134,6 +347,23 @@ func (in *ObjectObservation) DeepCopy() *ObjectObservation {
 func (in *ObjectParameters) DeepCopyInto(out *ObjectParameters) {
 	*out = *in
 	in.Manifest.DeepCopyInto(&out.Manifest)
+	if in.ManifestURLConfig != nil {
+		in, out := &in.ManifestURLConfig, &out.ManifestURLConfig
+		*out = new(ManifestURLConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CRDManifest != nil {
+		in, out := &in.CRDManifest, &out.CRDManifest
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TemplateValues != nil {
+		in, out := &in.TemplateValues, &out.TemplateValues
+		*out = make(map[string]TemplateValue, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectParameters.
@@ -163,7 +393,79 @@ func (in *ObjectSpec) DeepCopyInto(out *ObjectSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.Readiness = in.Readiness
+	in.Readiness.DeepCopyInto(&out.Readiness)
+	out.Validation = in.Validation
+	in.DriftDetection.DeepCopyInto(&out.DriftDetection)
+	if in.ReconcilePolicy != nil {
+		in, out := &in.ReconcilePolicy, &out.ReconcilePolicy
+		*out = new(ReconcilePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WatchPredicate != nil {
+		in, out := &in.WatchPredicate, &out.WatchPredicate
+		*out = new(WatchPredicate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WatchOptions != nil {
+		in, out := &in.WatchOptions, &out.WatchOptions
+		*out = new(WatchOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WatchPolicy != nil {
+		in, out := &in.WatchPolicy, &out.WatchPolicy
+		*out = new(WatchPolicy)
+		**out = **in
+	}
+	if in.DeleteAfter != nil {
+		in, out := &in.DeleteAfter, &out.DeleteAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DeletionTimeout != nil {
+		in, out := &in.DeletionTimeout, &out.DeletionTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SidecarObjects != nil {
+		in, out := &in.SidecarObjects, &out.SidecarObjects
+		*out = make([]SidecarObject, len(*in))
+		copy(*out, *in)
+	}
+	if in.EventPropagation != nil {
+		in, out := &in.EventPropagation, &out.EventPropagation
+		*out = new(EventPropagation)
+		**out = **in
+	}
+	if in.EventHistory != nil {
+		in, out := &in.EventHistory, &out.EventHistory
+		*out = new(EventHistory)
+		**out = **in
+	}
+	if in.DNSPropagate != nil {
+		in, out := &in.DNSPropagate, &out.DNSPropagate
+		*out = new(DNSPropagate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IgnoredFields != nil {
+		in, out := &in.IgnoredFields, &out.IgnoredFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	if in.ReconcileTimeout != nil {
+		in, out := &in.ReconcileTimeout, &out.ReconcileTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(NodeAffinity)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSpec.
@@ -181,6 +483,38 @@ func (in *ObjectStatus) DeepCopyInto(out *ObjectStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.FinalizePlugins != nil {
+		in, out := &in.FinalizePlugins, &out.FinalizePlugins
+		*out = make([]FinalizePluginStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.SidecarObjects != nil {
+		in, out := &in.SidecarObjects, &out.SidecarObjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSEndpointRef != nil {
+		in, out := &in.DNSEndpointRef, &out.DNSEndpointRef
+		*out = new(DNSEndpointReference)
+		**out = **in
+	}
+	if in.Divergence != nil {
+		in, out := &in.Divergence, &out.Divergence
+		*out = new(Divergence)
+		**out = **in
+	}
+	if in.EventHistory != nil {
+		in, out := &in.EventHistory, &out.EventHistory
+		*out = make([]RecordedEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ItemStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStatus.
@@ -217,6 +551,11 @@ func (in *PatchesFrom) DeepCopy() *PatchesFrom {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Readiness) DeepCopyInto(out *Readiness) {
 	*out = *in
+	if in.WaitForEvent != nil {
+		in, out := &in.WaitForEvent, &out.WaitForEvent
+		*out = new(WaitForEvent)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Readiness.
@@ -229,6 +568,42 @@ func (in *Readiness) DeepCopy() *Readiness {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcilePolicy) DeepCopyInto(out *ReconcilePolicy) {
+	*out = *in
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcilePolicy.
+func (in *ReconcilePolicy) DeepCopy() *ReconcilePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcilePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordedEvent) DeepCopyInto(out *RecordedEvent) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordedEvent.
+func (in *RecordedEvent) DeepCopy() *RecordedEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordedEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Reference) DeepCopyInto(out *Reference) {
 	*out = *in
@@ -247,6 +622,11 @@ func (in *Reference) DeepCopyInto(out *Reference) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.WatchTrigger != nil {
+		in, out := &in.WatchTrigger, &out.WatchTrigger
+		*out = new(WatchTrigger)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reference.
@@ -258,3 +638,171 @@ func (in *Reference) DeepCopy() *Reference {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarObject) DeepCopyInto(out *SidecarObject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarObject.
+func (in *SidecarObject) DeepCopy() *SidecarObject {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateValue) DeepCopyInto(out *TemplateValue) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(commonv1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateValue.
+func (in *TemplateValue) DeepCopy() *TemplateValue {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerminalState) DeepCopyInto(out *TerminalState) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerminalState.
+func (in *TerminalState) DeepCopy() *TerminalState {
+	if in == nil {
+		return nil
+	}
+	out := new(TerminalState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Validation) DeepCopyInto(out *Validation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Validation.
+func (in *Validation) DeepCopy() *Validation {
+	if in == nil {
+		return nil
+	}
+	out := new(Validation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitForEvent) DeepCopyInto(out *WaitForEvent) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitForEvent.
+func (in *WaitForEvent) DeepCopy() *WaitForEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitForEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchOptions) DeepCopyInto(out *WatchOptions) {
+	*out = *in
+	if in.MaxCacheSize != nil {
+		in, out := &in.MaxCacheSize, &out.MaxCacheSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchOptions.
+func (in *WatchOptions) DeepCopy() *WatchOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchPolicy) DeepCopyInto(out *WatchPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchPolicy.
+func (in *WatchPolicy) DeepCopy() *WatchPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchPredicate) DeepCopyInto(out *WatchPredicate) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchPredicate.
+func (in *WatchPredicate) DeepCopy() *WatchPredicate {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchPredicate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchTrigger) DeepCopyInto(out *WatchTrigger) {
+	*out = *in
+	if in.AnnotationKeys != nil {
+		in, out := &in.AnnotationKeys, &out.AnnotationKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchTrigger.
+func (in *WatchTrigger) DeepCopy() *WatchTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
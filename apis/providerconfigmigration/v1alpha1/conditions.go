@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeMigrated indicates whether a ProviderConfigMigration moved all Objects
+// it selects to its target ProviderConfig.
+const TypeMigrated xpv1.ConditionType = "Migrated"
+
+// ReasonAllMigrated is the reason of a completed ProviderConfigMigration.
+const ReasonAllMigrated xpv1.ConditionReason = "AllObjectsMigrated"
+
+// Migrated returns a condition that indicates all Objects selected by a
+// ProviderConfigMigration use its target ProviderConfig.
+func Migrated() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeMigrated,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAllMigrated,
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v12 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// A ProviderConfigMigration moves the Objects using a ProviderConfig to
+// another one, without deleting and recreating the resources they manage,
+// e.g. when the cluster they are on is now reached through another
+// ProviderConfig.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SOURCE",type="string",JSONPath=".spec.sourceProviderConfig.name"
+// +kubebuilder:printcolumn:name="TARGET",type="string",JSONPath=".spec.targetProviderConfig.name"
+// +kubebuilder:printcolumn:name="MIGRATED",type="integer",JSONPath=".status.migratedCount"
+// +kubebuilder:printcolumn:name="FAILED",type="integer",JSONPath=".status.failedCount"
+// +kubebuilder:printcolumn:name="COMPLETED",type="string",JSONPath=".status.conditions[?(@.type=='Migrated')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,kubernetes}
+type ProviderConfigMigration struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          ProviderConfigMigrationSpec   `json:"spec"`
+	Status        ProviderConfigMigrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProviderConfigMigrationList contains a list of ProviderConfigMigration
+type ProviderConfigMigrationList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []ProviderConfigMigration `json:"items"`
+}
+
+// ProviderConfigMigrationSpec defines the desired state of
+// ProviderConfigMigration
+// +kubebuilder:validation:XValidation:rule="self.sourceProviderConfig.name != self.targetProviderConfig.name",message="targetProviderConfig must differ from sourceProviderConfig"
+type ProviderConfigMigrationSpec struct {
+	// SourceProviderConfig specifies the provider config whose Objects are
+	// migrated.
+	SourceProviderConfig v12.Reference `json:"sourceProviderConfig"`
+
+	// TargetProviderConfig specifies the provider config the Objects are
+	// migrated to. It must reach the resources the Objects manage.
+	TargetProviderConfig v12.Reference `json:"targetProviderConfig"`
+
+	// ObjectSelector selects the Objects to migrate by their labels. All
+	// Objects using the source provider config are migrated if it is not
+	// set.
+	// +optional
+	ObjectSelector *v1.LabelSelector `json:"objectSelector,omitempty"`
+}
+
+// ProviderConfigMigrationStatus represents the observed state of a
+// ProviderConfigMigration
+type ProviderConfigMigrationStatus struct {
+	v12.ConditionedStatus `json:",inline"`
+
+	// MigratedCount is the number of Objects migrated so far.
+	// +optional
+	MigratedCount int64 `json:"migratedCount,omitempty"`
+
+	// FailedCount is the number of Objects that could not be migrated, and
+	// were rolled back to the source provider config, during the last
+	// attempt.
+	// +optional
+	FailedCount int64 `json:"failedCount,omitempty"`
+}
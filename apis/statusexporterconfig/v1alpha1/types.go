@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// A StatusExporterConfig exports the status of selected Objects to external
+// stores whenever their conditions change, e.g. to analyze how often they
+// were ready over time.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="LAST-EXPORT",type="date",JSONPath=".status.lastExportTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,kubernetes}
+type StatusExporterConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              StatusExporterConfigSpec   `json:"spec"`
+	Status            StatusExporterConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// StatusExporterConfigList contains a list of StatusExporterConfig
+type StatusExporterConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StatusExporterConfig `json:"items"`
+}
+
+// StatusExporterConfigSpec defines the desired state of StatusExporterConfig
+type StatusExporterConfigSpec struct {
+	// Selector selects the Objects whose status is exported. The status of
+	// all Objects is exported if omitted.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// ConfigMap exports the status to a ConfigMap.
+	// +optional
+	ConfigMap *ConfigMapExporter `json:"configMap,omitempty"`
+
+	// OpenTelemetry exports the status to an OpenTelemetry metrics
+	// endpoint.
+	// +optional
+	OpenTelemetry *OpenTelemetryExporter `json:"openTelemetry,omitempty"`
+}
+
+// A ConfigMapExporter appends the status of Objects to a ConfigMap, one JSON
+// record per line of its status.jsonl key. Only the latest records are kept,
+// older ones are dropped.
+type ConfigMapExporter struct {
+	// Namespace of the ConfigMap.
+	// +kubebuilder:validation:MinLength:=1
+	Namespace string `json:"namespace"`
+
+	// Name of the ConfigMap. It is created if it does not exist.
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// MaxRecords is the number of records the ConfigMap keeps.
+	// +optional
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	MaxRecords int32 `json:"maxRecords,omitempty"`
+}
+
+// An OpenTelemetryExporter sends the status of Objects as gauges to an
+// OpenTelemetry collector, using OTLP over HTTP with JSON encoding.
+type OpenTelemetryExporter struct {
+	// Endpoint is the URL metrics are posted to, e.g.
+	// http://otel-collector.monitoring:4318/v1/metrics.
+	// +kubebuilder:validation:MinLength:=1
+	Endpoint string `json:"endpoint"`
+}
+
+// StatusExporterConfigStatus represents the observed state of a
+// StatusExporterConfig
+type StatusExporterConfigStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+
+	// LastExportTime is the time the status of an Object was last exported.
+	// +optional
+	LastExportTime *metav1.Time `json:"lastExportTime,omitempty"`
+}
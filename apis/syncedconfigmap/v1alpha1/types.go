@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v12 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// A SyncedConfigMap copies a ConfigMap of one cluster to other clusters and
+// keeps the copies in sync with it.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SOURCE",type="string",JSONPath=".spec.sourceRef.name"
+// +kubebuilder:printcolumn:name="PROVIDERCONFIG",type="string",JSONPath=".spec.sourceRef.providerConfigRef.name"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,kubernetes}
+type SyncedConfigMap struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          SyncedConfigMapSpec   `json:"spec"`
+	Status        SyncedConfigMapStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SyncedConfigMapList contains a list of SyncedConfigMap
+type SyncedConfigMapList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []SyncedConfigMap `json:"items"`
+}
+
+// SyncedConfigMapSpec defines the desired state of SyncedConfigMap
+type SyncedConfigMapSpec struct {
+
+	// SourceRef refers to the ConfigMap to copy.
+	SourceRef ConfigMapSource `json:"sourceRef"`
+
+	// TargetRefs are the clusters and namespaces to copy the ConfigMap to.
+	// +kubebuilder:validation:MinItems:=1
+	TargetRefs []ConfigMapTarget `json:"targetRefs"`
+
+	// Transform is a CEL expression that returns the data of a copy as a
+	// map of strings. The data of the source is available as "data" and the
+	// target as "target", with the keys "providerConfig", "namespace" and
+	// "name", e.g. {"endpoint": data.endpoint, "cluster": target.providerConfig}.
+	// The data of the source is copied as is if omitted.
+	// +optional
+	Transform string `json:"transform,omitempty"`
+}
+
+// ConfigMapSource refers to a ConfigMap of a cluster.
+type ConfigMapSource struct {
+
+	// ProviderConfigReference specifies the provider config of the cluster
+	// of the ConfigMap.
+	ProviderConfigReference v12.Reference `json:"providerConfigRef"`
+
+	// Namespace of the ConfigMap.
+	// +kubebuilder:validation:MinLength:=1
+	Namespace string `json:"namespace"`
+
+	// Name of the ConfigMap.
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+}
+
+// ConfigMapTarget refers to a namespace of a cluster a ConfigMap is copied
+// to.
+type ConfigMapTarget struct {
+
+	// ProviderConfigReference specifies the provider config of the cluster
+	// to copy the ConfigMap to.
+	ProviderConfigReference v12.Reference `json:"providerConfigRef"`
+
+	// Namespace to copy the ConfigMap to.
+	// +kubebuilder:validation:MinLength:=1
+	Namespace string `json:"namespace"`
+
+	// Name of the copy. The name of the source is used if omitted.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// SyncedConfigMapStatus represents the observed state of a SyncedConfigMap
+type SyncedConfigMapStatus struct {
+	v12.ResourceStatus `json:",inline"`
+
+	// Targets reports the state of every copy.
+	// +optional
+	Targets []TargetStatus `json:"targets,omitempty"`
+}
+
+// TargetStatus is the state of a copy of the ConfigMap.
+type TargetStatus struct {
+
+	// ProviderConfig of the cluster of the copy.
+	ProviderConfig string `json:"providerConfig"`
+
+	// Namespace of the copy.
+	Namespace string `json:"namespace"`
+
+	// Name of the copy.
+	Name string `json:"name"`
+
+	// Object that manages the copy.
+	Object string `json:"object"`
+
+	// Synced is true once the copy is up to date with the source.
+	// +optional
+	Synced bool `json:"synced,omitempty"`
+
+	// Message explains why the copy is not synced.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Condition types specific to ProviderConfigs.
+const (
+	// TypeCertificateExpiringSoon indicates whether the client certificate of
+	// a ProviderConfig's kubeconfig expires within 30 days.
+	TypeCertificateExpiringSoon xpv1.ConditionType = "CertificateExpiringSoon"
+
+	// TypeCertificateExpired indicates whether the client certificate of a
+	// ProviderConfig's kubeconfig has expired. Resources using the
+	// ProviderConfig are not reconciled while it is expired.
+	TypeCertificateExpired xpv1.ConditionType = "CertificateExpired"
+
+	// TypeUsageCount reports the number of Objects using a ProviderConfig
+	// in its message.
+	TypeUsageCount xpv1.ConditionType = "UsageCount"
+
+	// TypeClusterReachable indicates whether the /healthz endpoint of the
+	// cluster a ProviderConfig connects to answered the last probe.
+	TypeClusterReachable xpv1.ConditionType = "ClusterReachable"
+
+	// TypeInsufficientPermissions indicates whether the kubeconfig of a
+	// ProviderConfig lacks any of the permissions listed in its access
+	// review.
+	TypeInsufficientPermissions xpv1.ConditionType = "InsufficientPermissions"
+
+	// TypeErrorRate reports the share of Objects using a ProviderConfig
+	// that failed to sync, as last sampled.
+	TypeErrorRate xpv1.ConditionType = "ErrorRate"
+)
+
+// Reasons a ProviderConfig's specific conditions are set. The reasons of an
+// expiring certificate reflect the last expiry boundary that was crossed.
+const (
+	ReasonCertificateValid    xpv1.ConditionReason = "CertificateValid"
+	ReasonExpiresWithin30Days xpv1.ConditionReason = "ExpiresWithin30Days"
+	ReasonExpiresWithin7Days  xpv1.ConditionReason = "ExpiresWithin7Days"
+	ReasonExpiresWithin1Day   xpv1.ConditionReason = "ExpiresWithin1Day"
+	ReasonCertificateExpired  xpv1.ConditionReason = "CertificateExpired"
+
+	ReasonObjectsCounted xpv1.ConditionReason = "ObjectsCounted"
+
+	ReasonHealthy   xpv1.ConditionReason = "Healthy"
+	ReasonUnhealthy xpv1.ConditionReason = "Unhealthy"
+
+	ReasonPermissionsGranted xpv1.ConditionReason = "PermissionsGranted"
+	ReasonPermissionsDenied  xpv1.ConditionReason = "PermissionsDenied"
+
+	ReasonErrorRateHigh   xpv1.ConditionReason = "High"
+	ReasonErrorRateMedium xpv1.ConditionReason = "Medium"
+	ReasonErrorRateLow    xpv1.ConditionReason = "Low"
+	ReasonErrorRateNone   xpv1.ConditionReason = "None"
+)
+
+// CertificateExpiringSoon returns a condition that indicates the client
+// certificate expires at the supplied time, after crossing the expiry
+// boundary of the supplied reason.
+func CertificateExpiringSoon(r xpv1.ConditionReason, notAfter time.Time) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCertificateExpiringSoon,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             r,
+		Message:            fmt.Sprintf("Client certificate expires at %s", notAfter.UTC().Format(time.RFC3339)),
+	}
+}
+
+// CertificateNotExpiringSoon returns a condition that indicates the client
+// certificate does not expire within 30 days, or has already expired.
+func CertificateNotExpiringSoon(r xpv1.ConditionReason, notAfter time.Time) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCertificateExpiringSoon,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             r,
+		Message:            fmt.Sprintf("Client certificate expires at %s", notAfter.UTC().Format(time.RFC3339)),
+	}
+}
+
+// CertificateExpired returns a condition that indicates the client
+// certificate expired at the supplied time.
+func CertificateExpired(notAfter time.Time) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCertificateExpired,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCertificateExpired,
+		Message:            fmt.Sprintf("Client certificate expired at %s, reconciles using this ProviderConfig are suspended", notAfter.UTC().Format(time.RFC3339)),
+	}
+}
+
+// CertificateNotExpired returns a condition that indicates the client
+// certificate has not expired yet.
+func CertificateNotExpired(notAfter time.Time) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCertificateExpired,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCertificateValid,
+		Message:            fmt.Sprintf("Client certificate expires at %s", notAfter.UTC().Format(time.RFC3339)),
+	}
+}
+
+// UsageCount returns a condition that reports the supplied number of Objects
+// using a ProviderConfig.
+func UsageCount(n int64) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUsageCount,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonObjectsCounted,
+		Message:            strconv.FormatInt(n, 10),
+	}
+}
+
+// ObjectCount returns the number of Objects using the supplied
+// ProviderConfig, as last counted. It returns false if they were not counted
+// yet.
+func ObjectCount(pc *ProviderConfig) (int64, bool) {
+	c := pc.Status.GetCondition(TypeUsageCount)
+	if c.Status != corev1.ConditionTrue {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(c.Message, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// ClusterReachable returns a condition that indicates the cluster answered
+// its /healthz probe with the supplied status code after the supplied
+// round-trip latency.
+func ClusterReachable(code int, latency time.Duration) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeClusterReachable,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonHealthy,
+		Message:            fmt.Sprintf("GET /healthz returned %d in %s", code, latency.Round(time.Millisecond)),
+	}
+}
+
+// ClusterUnreachable returns a condition that indicates the /healthz probe
+// of the cluster failed. A status code of 0 means no response was received.
+func ClusterUnreachable(code int, latency time.Duration, err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeClusterReachable,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnhealthy,
+		Message:            fmt.Sprintf("GET /healthz returned %d in %s: %s", code, latency.Round(time.Millisecond), err),
+	}
+}
+
+// InsufficientPermissions returns a condition that indicates the supplied
+// permissions of an access review were denied.
+func InsufficientPermissions(denied []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInsufficientPermissions,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPermissionsDenied,
+		Message:            "Denied: " + strings.Join(denied, "; "),
+	}
+}
+
+// SufficientPermissions returns a condition that indicates all permissions of
+// an access review were granted.
+func SufficientPermissions() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInsufficientPermissions,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPermissionsGranted,
+	}
+}
+
+// ErrorRate returns a condition that reports the supplied number of failed
+// Objects out of all Objects using a ProviderConfig. Its status is false if
+// no Object failed.
+func ErrorRate(r xpv1.ConditionReason, failed, total int) xpv1.Condition {
+	status := corev1.ConditionTrue
+	if r == ReasonErrorRateNone {
+		status = corev1.ConditionFalse
+	}
+	return xpv1.Condition{
+		Type:               TypeErrorRate,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             r,
+		Message:            fmt.Sprintf("%d of %d Objects failed", failed, total),
+	}
+}
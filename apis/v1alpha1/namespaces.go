@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "strings"
+
+// AnnotationKeySystemNamespacesJustification is the reason a ProviderConfig
+// allows Objects to manage resources in the forbidden namespaces listed by
+// its allowedSystemNamespaces.
+const AnnotationKeySystemNamespacesJustification = "kubernetes.crossplane.io/system-namespaces-justification"
+
+// DefaultForbiddenNamespaces are the namespaces Objects must not manage
+// resources in, unless a ProviderConfig configures others.
+var DefaultForbiddenNamespaces = []string{"kube-system", "kube-public", "kube-node-lease"}
+
+// NamespaceForbidden returns whether Objects using the supplied
+// ProviderConfig must not manage resources in the supplied namespace.
+func NamespaceForbidden(pc *ProviderConfig, ns string) bool {
+	forbidden := pc.Spec.ForbiddenNamespaces
+	if forbidden == nil {
+		forbidden = DefaultForbiddenNamespaces
+	}
+	if !contains(forbidden, ns) {
+		return false
+	}
+	if strings.TrimSpace(pc.GetAnnotations()[AnnotationKeySystemNamespacesJustification]) == "" {
+		return true
+	}
+	return !contains(pc.Spec.AllowedSystemNamespaces, ns)
+}
+
+func contains(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -32,6 +32,112 @@ type ProviderConfigSpec struct {
 	// example by configuring a bearer token source such as OAuth.
 	// +optional
 	Identity *Identity `json:"identity,omitempty"`
+	// TLSConfig configures how the TLS certificate of the Kubernetes API is
+	// verified.
+	// +optional
+	TLSConfig *TLSConfig `json:"tlsConfig,omitempty"`
+	// Proxy routes the requests to the Kubernetes API through HTTP proxies
+	// instead of the ones configured by the environment of the provider.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+	// MaxObjects is the maximum number of Objects that may use this
+	// ProviderConfig. Objects exceeding it are rejected when they are
+	// created. Unlimited if not set.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxObjects *int64 `json:"maxObjects,omitempty"`
+	// AccessReview lists permissions the kubeconfig is expected to grant on
+	// the cluster. They are checked periodically and denied ones reported
+	// by the InsufficientPermissions condition.
+	// +optional
+	// +listType=atomic
+	AccessReview []AccessReviewPermission `json:"accessReview,omitempty"`
+	// CloudEventsEndpoint is the URL of an HTTP endpoint the outcome of
+	// creating, updating and deleting the resources of Objects using this
+	// ProviderConfig is published to as CloudEvents.
+	// +optional
+	CloudEventsEndpoint string `json:"cloudEventsEndpoint,omitempty"`
+	// DNSEndpoint configures the ExternalDNS DNSEndpoints created for the
+	// load balancers of Objects using this ProviderConfig, see
+	// spec.dnsPropagate of Objects.
+	// +optional
+	DNSEndpoint *DNSEndpointConfig `json:"dnsEndpoint,omitempty"`
+	// ForbiddenNamespaces are namespaces Objects using this ProviderConfig
+	// must not manage resources in. Objects of cluster scoped resources are
+	// exempt. Defaults to the system namespaces kube-system, kube-public and
+	// kube-node-lease.
+	// +kubebuilder:default={"kube-system","kube-public","kube-node-lease"}
+	// +optional
+	// +listType=set
+	ForbiddenNamespaces []string `json:"forbiddenNamespaces,omitempty"`
+	// AllowedSystemNamespaces are forbidden namespaces Objects using this
+	// ProviderConfig may manage resources in nevertheless. They are only
+	// honored if the ProviderConfig is annotated with the reason to allow
+	// them, see AnnotationKeySystemNamespacesJustification.
+	// +optional
+	// +listType=set
+	AllowedSystemNamespaces []string `json:"allowedSystemNamespaces,omitempty"`
+}
+
+// DNSEndpointConfig configures the ExternalDNS DNSEndpoints created in the
+// cluster the provider runs in.
+type DNSEndpointConfig struct {
+	// APIVersion of the DNSEndpoint CRD.
+	// +kubebuilder:default="externaldns.k8s.io/v1alpha1"
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+	// Kind of the DNSEndpoint CRD.
+	// +kubebuilder:default=DNSEndpoint
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Namespace the DNSEndpoints are created in. ExternalDNS must be
+	// configured to read DNSEndpoints from it.
+	Namespace string `json:"namespace"`
+}
+
+// An AccessReviewPermission is a permission the identity of a ProviderConfig
+// is expected to have on its cluster.
+type AccessReviewPermission struct {
+	// APIGroup of the resource. The empty string is the core API group.
+	// +optional
+	APIGroup string `json:"apiGroup,omitempty"`
+	// Resource is the plural name of the resource, e.g. deployments.
+	Resource string `json:"resource"`
+	// Verb is the API verb, e.g. get, list or create.
+	Verb string `json:"verb"`
+	// Namespace the permission is checked in. Checked cluster-wide if not
+	// set.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ProxyConfig configures the HTTP proxies requests to the Kubernetes API are
+// sent through. It takes precedence over the HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY environment variables of the provider, which are ignored once it
+// is set.
+type ProxyConfig struct {
+	// HTTPProxy is the URL of the proxy for http:// API servers.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// HTTPSProxy is the URL of the proxy for https:// API servers.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy is a comma separated list of hosts, domains, IPs and CIDRs of
+	// API servers that are connected to directly, in the format of the
+	// NO_PROXY environment variable.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// TLSConfig configures how the TLS certificate of the Kubernetes API is
+// verified.
+type TLSConfig struct {
+	// CASecretRef references a key of a Secret holding a PEM encoded CA
+	// bundle. It is trusted in addition to the CA of the kubeconfig, e.g. to
+	// rotate the cluster CA separately from the kubeconfig. Changes of the
+	// Secret are picked up without restarting the provider.
+	// +optional
+	CASecretRef *xpv1.SecretKeySelector `json:"caSecretRef,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -41,6 +147,81 @@ type ProviderCredentials struct {
 	Source xpv1.CredentialsSource `json:"source"`
 
 	xpv1.CommonCredentialSelectors `json:",inline"`
+
+	// Context of the kubeconfig to use, e.g. of a kubeconfig shared by a
+	// fleet of clusters. Defaults to the kubeconfig's current context.
+	// +optional
+	Context string `json:"context,omitempty"`
+
+	// MTLS configures a client certificate to authenticate to the
+	// Kubernetes API with. It takes precedence over a client certificate of
+	// the kubeconfig.
+	// +optional
+	MTLS *MTLSConfig `json:"mtls,omitempty"`
+
+	// ExternalSecretRef references a Secret managed by the External Secrets
+	// Operator to read the kubeconfig from if the source is Secret. It takes
+	// precedence over secretRef. Clients are rebuilt whenever the operator
+	// rotates the Secret.
+	// +optional
+	ExternalSecretRef *ExternalSecretReference `json:"externalSecretRef,omitempty"`
+
+	// ServiceRef references a Service of the cluster the provider runs in
+	// to connect to the Kubernetes API through. Its ClusterIP replaces the
+	// host of the API server URL, e.g. of a kubeconfig that points to a
+	// virtual cluster running in the same cluster. Clients are rebuilt
+	// whenever the ClusterIP changes.
+	// +optional
+	ServiceRef *ServiceReference `json:"serviceRef,omitempty"`
+}
+
+// A ServiceReference references a port of a Service.
+type ServiceReference struct {
+	// Namespace of the Service.
+	// +kubebuilder:validation:MinLength:=1
+	Namespace string `json:"namespace"`
+
+	// Name of the Service.
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// Port of the Service the Kubernetes API is served on. Defaults to the
+	// first port of the Service.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+}
+
+// An ExternalSecretReference references a Secret managed by the External
+// Secrets Operator.
+type ExternalSecretReference struct {
+	// Name of the Secret.
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// Namespace of the Secret.
+	// +kubebuilder:validation:MinLength:=1
+	Namespace string `json:"namespace"`
+
+	// Key of the Secret holding the kubeconfig. Defaults to kubeconfig for
+	// Secrets labeled external-secrets.io/external-secret=true, and is
+	// required for other Secrets.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// MTLSConfig references the client certificate and key used for TLS mutual
+// authentication. Changes of the Secrets are picked up without restarting
+// the provider.
+type MTLSConfig struct {
+	// ClientCertSecretRef references a key of a Secret holding a PEM
+	// encoded client certificate.
+	ClientCertSecretRef xpv1.SecretKeySelector `json:"clientCertSecretRef"`
+
+	// ClientKeySecretRef references a key of a Secret holding the PEM
+	// encoded private key of the client certificate.
+	ClientKeySecretRef xpv1.SecretKeySelector `json:"clientKeySecretRef"`
 }
 
 // IdentityType used to authenticate to the Kubernetes API.
@@ -18,27 +18,42 @@ package main
 
 import (
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"time"
 
+	uberzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 
 	"github.com/crossplane-contrib/provider-kubernetes/apis"
+	mutatingpolicyv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/mutatingpolicy/v1alpha1"
 	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/audit"
 	object "github.com/crossplane-contrib/provider-kubernetes/internal/controller"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/config"
+	objectcontroller "github.com/crossplane-contrib/provider-kubernetes/internal/controller/object"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/orphan"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/drain"
 	"github.com/crossplane-contrib/provider-kubernetes/internal/features"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/manifest"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 )
@@ -47,13 +62,20 @@ const (
 	webhookTLSCertDirEnvVar = "WEBHOOK_TLS_CERT_DIR"
 	tlsServerCertDirEnvVar  = "TLS_SERVER_CERTS_DIR"
 	tlsServerCertDir        = "/tls/server"
+
+	auditLogNone   = "none"
+	auditLogStdout = "stdout"
+	auditLogEvent  = "event"
+
+	shutdownGracePeriod = 10 * time.Second
 )
 
 func main() {
 	var (
 		app                  = kingpin.New(filepath.Base(os.Args[0]), "Template support for Crossplane.").DefaultEnvars()
 		debug                = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
-		syncInterval         = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
+		syncInterval         = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m. Deprecated: use --sync-period.").Short('s').Default("1h").Duration()
+		syncPeriod           = app.Flag("sync-period", "How often the controller manager resyncs all Objects, such as 300ms, 1.5h, or 2h45m. Overrides --sync. The poll interval of single Objects is set by their spec.reconcilePolicy.period.").Envar("SYNC_PERIOD").Duration()
 		pollInterval         = app.Flag("poll", "Poll interval controls how often an individual resource should be checked for drift.").Default("10m").Duration()
 		pollJitterPercentage = app.Flag("poll-jitter-percentage", "Percentage of jitter to apply to poll interval. It cannot be negative, and must be less than 100.").Default("10").Uint()
 		leaderElection       = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").Envar("LEADER_ELECTION").Bool()
@@ -62,10 +84,61 @@ func main() {
 
 		enableManagementPolicies = app.Flag("enable-management-policies", "Enable support for Management Policies.").Default("true").Envar("ENABLE_MANAGEMENT_POLICIES").Bool()
 		enableWatches            = app.Flag("enable-watches", "Enable support for watching resources.").Default("false").Envar("ENABLE_WATCHES").Bool()
+		maxInformers             = app.Flag("max-informers", "The maximum number of resource informers that may run at one time when watching resources.").Default("500").Envar("MAX_INFORMERS").Int()
+		goroutinesBudget         = app.Flag("informer-goroutines-budget", "The maximum number of goroutines resource informers may run at one time when watching resources. Starting further informers is deferred.").Default("1000").Envar("INFORMER_GOROUTINES_BUDGET").Int()
+		cleanupBatchSize         = app.Flag("informer-cleanup-batch-size", "The number of resource informers checked at a time when garbage collecting those no Object references anymore. Zero checks all at once.").Default("50").Envar("INFORMER_CLEANUP_BATCH_SIZE").Int()
+		cleanupBatchInterval     = app.Flag("informer-cleanup-batch-interval", "How long to wait between batches of resource informers when garbage collecting them.").Default("100ms").Envar("INFORMER_CLEANUP_BATCH_INTERVAL").Duration()
+		sharedInformerFactory    = app.Flag("informer-shared-factory", "Create the resource informers of a cluster from one shared informer factory, discovering the cluster once, instead of a cache per GVK.").Default("false").Envar("INFORMER_SHARED_FACTORY").Bool()
+		lazyInformerStart        = app.Flag("informer-lazy-start", "Defer starting the informers of resources referenced by Objects until a managed resource referencing them changes.").Default("false").Envar("INFORMER_LAZY_START").Bool()
+		configCM                 = app.Flag("config-cm", "Name of a ConfigMap in the provider's namespace whose logLevel and maxInformers keys are applied at runtime.").Envar("CONFIG_CM").String()
+		configCMNamespace        = app.Flag("config-cm-namespace", "Namespace of the ConfigMap set by --config-cm.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
+		slowAdmission            = app.Flag("slow-admission-threshold", "Applies of managed resources taking longer are reported through the SlowAdmission condition of their Object. Zero disables reporting.").Default("5s").Envar("SLOW_ADMISSION_THRESHOLD").Duration()
+		liveValidation           = app.Flag("live-validation", "Validate Objects against the state of their resource on the target cluster at admission time, rejecting those whose resource is controlled by something else.").Default("false").Envar("LIVE_VALIDATION").Bool()
+		enablePreview            = app.Flag("enable-preview", "Serve dry-run previews of Objects at /preview of the webhook server. Callers must be allowed to update the Object they preview.").Default("false").Envar("ENABLE_PREVIEW").Bool()
+		allowHTTPManifestURLs    = app.Flag("allow-http-manifest-urls", "Accept and fetch plain HTTP manifestURLs of Objects, e.g. for development. Only HTTPS URLs are accepted by default.").Default("false").Envar("ALLOW_HTTP_MANIFEST_URLS").Bool()
+		auditLog                 = app.Flag("audit-log", "Where to record the creates, updates and deletes of managed resources: none, stdout as JSON, or event as events of their Objects.").Default(auditLogNone).Envar("AUDIT_LOG").Enum(auditLogNone, auditLogStdout, auditLogEvent)
+
+		transientBaseDelay = app.Flag("transient-error-base-delay", "Delay before retrying the reconcile of an Object that failed with a transient error, e.g. a timeout or rate limit. Doubled with every failure.").Default("500ms").Envar("TRANSIENT_ERROR_BASE_DELAY").Duration()
+		transientMaxDelay  = app.Flag("transient-error-max-delay", "Maximum delay between retries of an Object that failed with a transient error.").Default("30s").Envar("TRANSIENT_ERROR_MAX_DELAY").Duration()
+		permanentBaseDelay = app.Flag("permanent-error-base-delay", "Delay before retrying the reconcile of an Object that failed with a permanent error, e.g. forbidden or an invalid manifest. Doubled with every failure.").Default("30s").Envar("PERMANENT_ERROR_BASE_DELAY").Duration()
+		permanentMaxDelay  = app.Flag("permanent-error-max-delay", "Maximum delay between retries of an Object that failed with a permanent error.").Default("1h").Envar("PERMANENT_ERROR_MAX_DELAY").Duration()
+		timeoutBaseDelay   = app.Flag("timeout-error-base-delay", "Delay before retrying the reconcile of an Object that exceeded its reconcile timeout. Doubled with every failure.").Default("10s").Envar("TIMEOUT_ERROR_BASE_DELAY").Duration()
+		timeoutMaxDelay    = app.Flag("timeout-error-max-delay", "Maximum delay between retries of an Object that exceeded its reconcile timeout.").Default("5m").Envar("TIMEOUT_ERROR_MAX_DELAY").Duration()
+
+		deadLetterThreshold  = app.Flag("dead-letter-threshold", "The number of consecutive failed reconciles after which an Object is moved to a DeadLetter and no longer retried. Zero disables dead letters.").Default("20").Envar("DEAD_LETTER_THRESHOLD").Int()
+		deadLetterRetryAfter = app.Flag("dead-letter-retry-after", "How long an Object stays in its DeadLetter before it is reconciled again.").Default("1h").Envar("DEAD_LETTER_RETRY_AFTER").Duration()
+
+		autoscaleMinWorkers         = app.Flag("autoscale-min-workers", "The number of Objects reconciled at the same time while the reconcile queue is short, if autoscaling is enabled.").Default("10").Envar("AUTOSCALE_MIN_WORKERS").Int()
+		autoscaleMaxWorkers         = app.Flag("autoscale-max-workers", "The number of Objects reconciled at the same time at most if autoscaling is enabled. Zero disables autoscaling, reconciling up to --max-reconcile-rate Objects at the same time.").Default("0").Envar("AUTOSCALE_MAX_WORKERS").Int()
+		autoscaleScaleUpThreshold   = app.Flag("autoscale-scale-up-threshold", "The depth of the reconcile queue above which the number of Objects reconciled at the same time is doubled.").Default("100").Envar("AUTOSCALE_SCALE_UP_THRESHOLD").Int()
+		autoscaleScaleDownThreshold = app.Flag("autoscale-scale-down-threshold", "The depth of the reconcile queue below which the number of Objects reconciled at the same time is halved.").Default("10").Envar("AUTOSCALE_SCALE_DOWN_THRESHOLD").Int()
+		autoscaleScaleDownCooldown  = app.Flag("autoscale-scale-down-cooldown", "How long the reconcile queue needs to stay below --autoscale-scale-down-threshold before scaling down.").Default("5m").Envar("AUTOSCALE_SCALE_DOWN_COOLDOWN").Duration()
+		enableObjectHistory         = app.Flag("enable-object-history", "Record a snapshot of the spec of every generation of Objects as a ConfigMap, and serve them at /history of the webhook server. Callers must be allowed to get the Object.").Default("false").Envar("ENABLE_OBJECT_HISTORY").Bool()
+		objectHistorySize           = app.Flag("object-history-size", "The number of spec snapshots kept per Object if the history is enabled.").Default("10").Envar("OBJECT_HISTORY_SIZE").Int()
+		objectHistoryNamespace      = app.Flag("object-history-namespace", "Namespace the spec snapshots of Objects are stored in.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
+		enableObjectRollback        = app.Flag("enable-object-rollback", "Serve rollbacks of Objects to the manifest of a previous generation at /rollback of the webhook server. Requires --enable-object-history. Callers must be allowed to update the Object.").Default("false").Envar("ENABLE_OBJECT_ROLLBACK").Bool()
+
+		drainTimeout = app.Flag("drain-timeout", "How long to wait for in-flight reconciles of Objects to complete when shutting down. Should be shorter than the termination grace period of the provider's pod. Zero disables draining.").Default("30s").Envar("DRAIN_TIMEOUT").Duration()
+		podName      = app.Flag("pod-name", "Name of the provider's pod, annotated while draining. Defaults to the hostname.").Envar("POD_NAME").String()
+		podNamespace = app.Flag("pod-namespace", "Namespace of the provider's pod.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
+
+		orphanDetectionInterval = app.Flag("orphan-detection-interval", "How often Objects are checked for a ProviderConfig that does not exist. Orphaned Objects get the Orphaned condition. Zero disables the check.").Default("5m").Envar("ORPHAN_DETECTION_INTERVAL").Duration()
+
+		_               = app.Command("start", "Start the provider.").Default()
+		migrateCmd      = app.Command("migrate", "Migrate Objects stored in an older API version in place.")
+		migrateFrom     = migrateCmd.Flag("from", "API version to migrate Objects from.").Default("v1alpha1").String()
+		migrateTo       = migrateCmd.Flag("to", "API version to migrate Objects to.").Default("v1alpha2").String()
+		migrateDryRun   = migrateCmd.Flag("dry-run", "Print the changes of every Object instead of applying them.").Bool()
+		migrateNS       = migrateCmd.Flag("namespace", "Only migrate Objects whose manifest is in this namespace.").String()
+		migrateSelector = migrateCmd.Flag("label-selector", "Only migrate Objects matching this label selector.").String()
 	)
-	kingpin.MustParse(app.Parse(os.Args[1:]))
+	cmd := kingpin.MustParse(app.Parse(os.Args[1:]))
 
-	zl := zap.New(zap.UseDevMode(*debug), UseISO8601())
+	logLevel := uberzap.NewAtomicLevelAt(zapcore.InfoLevel)
+	if *debug {
+		logLevel.SetLevel(zapcore.DebugLevel)
+	}
+	zl := zap.New(zap.UseDevMode(*debug), zap.Level(logLevel), UseISO8601())
 	log := logging.NewLogrLogger(zl.WithName("provider-kubernetes"))
 	// explicitly  provide a no-op logger by default, otherwise controller-runtime gives a warning
 	ctrl.SetLogger(zap.New(zap.WriteTo(io.Discard)))
@@ -80,6 +153,9 @@ func main() {
 		kingpin.Fatalf("invalid --poll-jitter-percentage %v must be less than 100", *pollJitterPercentage)
 	}
 	pollJitter := time.Duration(float64(*pollInterval) * (float64(*pollJitterPercentage) / 100.0))
+	if *syncPeriod > 0 {
+		syncInterval = syncPeriod
+	}
 	log.Debug("Starting",
 		"sync-interval", syncInterval.String(),
 		"poll-interval", pollInterval.String(),
@@ -89,6 +165,11 @@ func main() {
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
+	if cmd == migrateCmd.FullCommand() {
+		kingpin.FatalIfError(migrate(cfg, *migrateFrom, *migrateTo, *migrateDryRun, *migrateNS, *migrateSelector), "Cannot migrate Objects")
+		return
+	}
+
 	// Get the TLS certs directory from the environment variable if set
 	// In older XP versions we used WEBHOOK_TLS_CERT_DIR, in newer versions
 	// we use TLS_SERVER_CERTS_DIR. If neither are set, use the default.
@@ -101,7 +182,16 @@ func main() {
 		}
 	}
 
+	// The state of the resource informers is served next to the metrics,
+	// and like them without authentication.
+	informersHandler := &objectcontroller.InformersHandler{}
+
 	mgr, err := ctrl.NewManager(ratelimiter.LimitRESTConfig(cfg, *maxReconcileRate), ctrl.Options{
+		Metrics: metricsserver.Options{
+			ExtraHandlers: map[string]http.Handler{
+				"/debug/informers": informersHandler,
+			},
+		},
 		Cache: cache.Options{
 			SyncPeriod: syncInterval,
 		},
@@ -121,6 +211,10 @@ func main() {
 		WebhookServer: webhook.NewServer(webhook.Options{
 			CertDir: certDir,
 		}),
+
+		// Leave the controllers time to stop once in-flight reconciles
+		// were drained.
+		GracefulShutdownTimeout: func() *time.Duration { d := *drainTimeout + shutdownGracePeriod; return &d }(),
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
@@ -148,11 +242,105 @@ func main() {
 	// which version we use here. Leaving it as v1alpha1 as it will be easy to
 	// notice and remove when we drop support for v1alpha1.
 	kingpin.FatalIfError(ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.Object{}).Complete(), "Cannot create Object webhook")
+	objectValidators := objectcontroller.Validators{objectcontroller.NewManifestValidator(*allowHTTPManifestURLs), objectcontroller.NewQuotaValidator(mgr.GetClient()), objectcontroller.NewNamespaceQuotaValidator(mgr.GetClient()), objectcontroller.NewForbiddenNamespaceValidator(mgr.GetClient()), objectcontroller.NewOwnerReferenceValidator(mgr.GetClient(), mgr.GetConfig())}
+	if *liveValidation {
+		objectValidators = append(objectValidators, objectcontroller.NewLiveValidator(mgr.GetClient()))
+	}
+	kingpin.FatalIfError(ctrl.NewWebhookManagedBy(mgr).For(&v1alpha2.Object{}).WithValidator(objectValidators).Complete(), "Cannot create Object validation webhook")
+	// The endpoints served next to the webhooks act with the credentials of
+	// the provider, their callers are authorized by a SubjectAccessReview.
+	authorizer := objectcontroller.NewAuthorizer(mgr.GetClient())
+	if *enablePreview {
+		mgr.GetWebhookServer().Register("/preview", objectcontroller.NewPreviewHandler(mgr.GetClient(), authorizer, log))
+		log.Info("Serving previews of Objects", "path", "/preview")
+	}
+	history := objectcontroller.HistoryOptions{Namespace: *objectHistoryNamespace}
+	if *enableObjectHistory {
+		history.Size = *objectHistorySize
+		mgr.GetWebhookServer().Register("/history", objectcontroller.NewHistoryHandler(mgr.GetAPIReader(), history, authorizer, log))
+		log.Info("Serving the spec history of Objects", "path", "/history")
+	}
+	if *enableObjectRollback {
+		if !history.Enabled() {
+			kingpin.Fatalf("--enable-object-rollback requires --enable-object-history")
+		}
+		mgr.GetWebhookServer().Register("/rollback", objectcontroller.NewRollbackHandler(mgr.GetClient(), mgr.GetAPIReader(), history, authorizer, log))
+		log.Info("Serving rollbacks of Objects", "path", "/rollback")
+	}
+	kingpin.FatalIfError(ctrl.NewWebhookManagedBy(mgr).For(&apisv1alpha1.ProviderConfig{}).WithValidator(config.NewProviderConfigValidator(mgr.GetClient())).Complete(), "Cannot create ProviderConfig validation webhook")
+	kingpin.FatalIfError(ctrl.NewWebhookManagedBy(mgr).For(&mutatingpolicyv1alpha1.MutatingPolicy{}).WithValidator(objectcontroller.NewMutatingPolicyValidator()).Complete(), "Cannot create MutatingPolicy validation webhook")
+
+	// Plugins with custom cleanup logic for deleted Objects are registered
+	// here, e.g. plugins.Register("dns", dnsPlugin).
+	plugins := objectcontroller.NewFinalizePlugins()
+
+	backoff := objectcontroller.BackoffOptions{
+		Transient: objectcontroller.Backoff{BaseDelay: *transientBaseDelay, MaxDelay: *transientMaxDelay, Jitter: 0.1},
+		Permanent: objectcontroller.Backoff{BaseDelay: *permanentBaseDelay, MaxDelay: *permanentMaxDelay},
+		Timeout:   objectcontroller.Backoff{BaseDelay: *timeoutBaseDelay, MaxDelay: *timeoutMaxDelay, Jitter: 0.1},
+	}
+
+	informerLimit := &atomic.Int64{}
+	informerLimit.Store(int64(*maxInformers))
+
+	var drainer *drain.Drainer
+	if *drainTimeout > 0 {
+		if *podName == "" {
+			*podName, _ = os.Hostname()
+		}
+		drainer = drain.New(mgr.GetClient(), types.NamespacedName{Namespace: *podNamespace, Name: *podName}, *drainTimeout, log)
+		kingpin.FatalIfError(mgr.Add(drainer), "Cannot add drainer")
+	}
+
+	if *orphanDetectionInterval > 0 {
+		orphans := orphan.NewOrphanDetector(mgr.GetClient(), event.NewAPIRecorder(mgr.GetEventRecorderFor("orphandetector")), *podNamespace, *orphanDetectionInterval, log)
+		kingpin.FatalIfError(mgr.Add(orphans), "Cannot add orphan detector")
+	}
+
+	if *configCM != "" {
+		kingpin.FatalIfError(mgr.Add(newReloader(cfg, *configCMNamespace, *configCM, log, logLevel, informerLimit)), "Cannot add configuration reloader")
+	}
 
-	kingpin.FatalIfError(object.Setup(mgr, o, *sanitizeSecrets, pollJitter), "Cannot setup controller")
+	kingpin.FatalIfError(object.Setup(mgr, o, objectcontroller.SetupOptions{
+		SanitizeSecrets:          *sanitizeSecrets,
+		PollJitter:               pollJitter,
+		SyncPeriod:               *syncInterval,
+		SlowAdmissionThreshold:   *slowAdmission,
+		MaxInformers:             informerLimit,
+		GoroutinesBudget:         *goroutinesBudget,
+		UseSharedInformerFactory: *sharedInformerFactory,
+		LazyInformerStart:        *lazyInformerStart,
+		InformerCleanup:          objectcontroller.InformerCleanupOptions{BatchSize: *cleanupBatchSize, BatchInterval: *cleanupBatchInterval},
+		InformersHandler:         informersHandler,
+		FinalizePlugins:          plugins,
+		Backoff:                  backoff,
+		DeadLetters:              objectcontroller.DeadLetterOptions{Threshold: *deadLetterThreshold, RetryAfter: *deadLetterRetryAfter},
+		Autoscaling: objectcontroller.AutoscalerOptions{
+			MinWorkers:         *autoscaleMinWorkers,
+			MaxWorkers:         *autoscaleMaxWorkers,
+			ScaleUpThreshold:   *autoscaleScaleUpThreshold,
+			ScaleDownThreshold: *autoscaleScaleDownThreshold,
+			ScaleDownCooldown:  *autoscaleScaleDownCooldown,
+		},
+		History:   history,
+		Auditor:   auditLogger(mgr, *auditLog),
+		Drainer:   drainer,
+		Manifests: manifest.NewFetcher(mgr.GetClient(), *allowHTTPManifestURLs),
+	}), "Cannot setup controller")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
 
+// auditLogger returns the audit logger selected by the --audit-log flag.
+func auditLogger(mgr ctrl.Manager, to string) audit.AuditLogger {
+	switch to {
+	case auditLogStdout:
+		return audit.NewJSONLogger(os.Stdout)
+	case auditLogEvent:
+		return audit.NewEventLogger(event.NewAPIRecorder(mgr.GetEventRecorderFor("provider-kubernetes-audit")))
+	}
+	return audit.NopLogger{}
+}
+
 // UseISO8601 sets the logger to use ISO8601 timestamp format
 func UseISO8601() zap.Opts {
 	return func(o *zap.Options) {
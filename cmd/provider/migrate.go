@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/migration"
+)
+
+// migrate migrates the Objects of the cluster behind cfg and reports how
+// many were migrated.
+func migrate(cfg *rest.Config, from, to string, dryRun bool, namespace, selector string) error {
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		return errors.Wrap(err, "cannot add APIs to scheme")
+	}
+	c, err := client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		return errors.Wrap(err, "cannot create client")
+	}
+
+	o := migration.Options{From: from, To: to, DryRun: dryRun, Namespace: namespace}
+	if selector != "" {
+		if o.LabelSelector, err = labels.Parse(selector); err != nil {
+			return errors.Wrap(err, "cannot parse label selector")
+		}
+	}
+
+	res, err := migration.Migrate(context.Background(), c, os.Stdout, o)
+	if err != nil {
+		return err
+	}
+	verb := "Migrated"
+	if dryRun {
+		verb = "Would migrate"
+	}
+	fmt.Printf("%s %d Objects, %d failed, %d skipped\n", verb, res.Migrated, res.Failed, res.Skipped)
+	if res.Failed > 0 {
+		return errors.Errorf("failed to migrate %d Objects", res.Failed)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strconv"
+	"sync/atomic"
+
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/client-go/rest"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/internal/reload"
+)
+
+// newReloader returns a Reloader that applies the configuration keys of the
+// supplied ConfigMap that can be changed at runtime. All other flags are
+// only applied when the provider restarts.
+func newReloader(cfg *rest.Config, namespace, name string, log logging.Logger, level uberzap.AtomicLevel, maxInformers *atomic.Int64) *reload.Reloader {
+	r := reload.New(cfg, namespace, name, log)
+
+	r.Handle("logLevel", func(v string) error {
+		l, err := zapcore.ParseLevel(v)
+		if err != nil {
+			return err
+		}
+		level.SetLevel(l)
+		return nil
+	})
+	r.Handle("maxInformers", func(v string) error {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		maxInformers.Store(n)
+		return nil
+	})
+
+	// The cache of the controller manager is only configured when it starts.
+	r.RequiresRestart("syncPeriod")
+
+	return r
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the actions taken on the resources managed by
+// Objects.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+)
+
+// An Action taken on a managed resource.
+type Action string
+
+// Actions taken on managed resources.
+const (
+	ActionCreate Action = "Create"
+	ActionUpdate Action = "Update"
+	ActionDelete Action = "Delete"
+)
+
+// The Outcome of an action.
+type Outcome string
+
+// Outcomes of actions.
+const (
+	OutcomeSuccess Outcome = "Success"
+	OutcomeFailure Outcome = "Failure"
+)
+
+// An AuditEntry records an action taken on a managed resource.
+type AuditEntry struct {
+	// Timestamp of the action.
+	Timestamp time.Time `json:"timestamp"`
+	// Object is the name of the Object managing the resource.
+	Object string `json:"object"`
+	// GVK of the managed resource.
+	GVK string `json:"gvk"`
+	// Resource is the namespace and name of the managed resource.
+	Resource string `json:"resource"`
+	// Action taken on the managed resource.
+	Action Action `json:"action"`
+	// Diff is the JSON Patch from the previously to the newly applied
+	// manifest, if any.
+	Diff json.RawMessage `json:"diff,omitempty"`
+	// Actor is the identity the action was taken as on the target cluster,
+	// if known.
+	Actor string `json:"actor,omitempty"`
+	// Outcome of the action.
+	Outcome Outcome `json:"outcome"`
+	// Error the action failed with, if any.
+	Error string `json:"error,omitempty"`
+
+	// Subject is the Object managing the resource.
+	Subject client.Object `json:"-"`
+}
+
+// An AuditLogger records AuditEntries.
+type AuditLogger interface {
+	Log(ctx context.Context, entry AuditEntry)
+}
+
+// A NopLogger does nothing.
+type NopLogger struct{}
+
+// Log does nothing.
+func (NopLogger) Log(_ context.Context, _ AuditEntry) {}
+
+// A JSONLogger writes every AuditEntry as a line of JSON.
+type JSONLogger struct {
+	lock sync.Mutex
+	out  io.Writer
+}
+
+// NewJSONLogger returns an AuditLogger that writes to the supplied writer.
+func NewJSONLogger(out io.Writer) *JSONLogger {
+	return &JSONLogger{out: out}
+}
+
+// Log writes the supplied AuditEntry.
+func (l *JSONLogger) Log(_ context.Context, entry AuditEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	_, _ = l.out.Write(append(data, '\n'))
+}
+
+// An EventLogger records every AuditEntry as an event of its Subject.
+type EventLogger struct {
+	record event.Recorder
+}
+
+// NewEventLogger returns an AuditLogger that records events using the
+// supplied recorder.
+func NewEventLogger(r event.Recorder) *EventLogger {
+	return &EventLogger{record: r}
+}
+
+// Log records the supplied AuditEntry as an event. Failed actions are
+// recorded as warnings.
+func (l *EventLogger) Log(_ context.Context, entry AuditEntry) {
+	if entry.Subject == nil {
+		return
+	}
+	reason := event.Reason("Audit" + string(entry.Action))
+	msg := fmt.Sprintf("%s of %s %s", entry.Action, entry.GVK, entry.Resource)
+	if entry.Actor != "" {
+		msg += " as " + entry.Actor
+	}
+	if len(entry.Diff) > 0 {
+		msg += ": " + string(entry.Diff)
+	}
+
+	e := event.Normal(reason, msg)
+	if entry.Outcome == OutcomeFailure {
+		e = event.Warning(reason, fmt.Errorf("%s failed: %s", msg, entry.Error))
+	}
+	l.record.Event(entry.Subject, e)
+}
+
+// Diff returns the JSON Patch from one JSON document to another, sorted by
+// path. An empty document is treated as an empty object.
+func Diff(from, to []byte) (json.RawMessage, error) {
+	if len(from) == 0 {
+		from = []byte("{}")
+	}
+	if len(to) == 0 {
+		to = []byte("{}")
+	}
+	ops, err := jsonpatch.CreatePatch(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	// Operations are created from maps, sort them for a stable diff.
+	sort.Stable(jsonpatch.ByPath(ops))
+	return json.Marshal(ops)
+}
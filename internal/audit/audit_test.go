@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+)
+
+func TestDiff(t *testing.T) {
+	type args struct {
+		from string
+		to   string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   string
+	}{
+		"Created": {
+			reason: "We should diff against an empty object if there is no previous document.",
+			args: args{
+				to: `{"a":1}`,
+			},
+			want: `[{"op":"add","path":"/a","value":1}]`,
+		},
+		"Changed": {
+			reason: "We should return the operations that turn one document into the other.",
+			args: args{
+				from: `{"a":1,"b":2}`,
+				to:   `{"a":2}`,
+			},
+			want: `[{"op":"replace","path":"/a","value":2},{"op":"remove","path":"/b"}]`,
+		},
+		"Unchanged": {
+			reason: "We should return no diff for equal documents.",
+			args: args{
+				from: `{"a":1}`,
+				to:   `{"a":1}`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Diff([]byte(tc.args.from), []byte(tc.args.to))
+			if err != nil {
+				t.Fatalf("\n%s\nDiff(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("\n%s\nDiff(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestJSONLogger(t *testing.T) {
+	entry := AuditEntry{
+		Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Object:    "obj",
+		GVK:       "/v1, Kind=ConfigMap",
+		Resource:  "default/cm",
+		Action:    ActionCreate,
+		Diff:      json.RawMessage(`[{"op":"add","path":"/a","value":1}]`),
+		Outcome:   OutcomeSuccess,
+		Subject:   &corev1.ConfigMap{},
+	}
+	out := &bytes.Buffer{}
+	NewJSONLogger(out).Log(context.Background(), entry)
+
+	want := `{"timestamp":"2024-01-01T00:00:00Z","object":"obj","gvk":"/v1, Kind=ConfigMap","resource":"default/cm","action":"Create","diff":[{"op":"add","path":"/a","value":1}],"outcome":"Success"}` + "\n"
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("Log(...): -want, +got:\n%s", diff)
+	}
+}
+
+type recordingRecorder struct {
+	events []event.Event
+}
+
+func (r *recordingRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *recordingRecorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestEventLogger(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		entry  AuditEntry
+		want   []event.Event
+	}{
+		"NoSubject": {
+			reason: "We should not record an event without a subject.",
+			entry:  AuditEntry{Action: ActionDelete},
+		},
+		"Success": {
+			reason: "We should record a normal event for successful actions.",
+			entry: AuditEntry{
+				GVK:      "/v1, Kind=ConfigMap",
+				Resource: "default/cm",
+				Action:   ActionUpdate,
+				Actor:    "system:serviceaccount:crossplane-system:provider-kubernetes",
+				Diff:     json.RawMessage(`[]`),
+				Outcome:  OutcomeSuccess,
+				Subject:  &corev1.ConfigMap{},
+			},
+			want: []event.Event{event.Normal("AuditUpdate", "Update of /v1, Kind=ConfigMap default/cm as system:serviceaccount:crossplane-system:provider-kubernetes: []")},
+		},
+		"Failure": {
+			reason: "We should record a warning event for failed actions.",
+			entry: AuditEntry{
+				GVK:      "/v1, Kind=ConfigMap",
+				Resource: "default/cm",
+				Action:   ActionDelete,
+				Outcome:  OutcomeFailure,
+				Error:    "boom",
+				Subject:  &corev1.ConfigMap{},
+			},
+			want: []event.Event{event.Warning("AuditDelete", errors.New("Delete of /v1, Kind=ConfigMap default/cm failed: boom"))},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &recordingRecorder{}
+			NewEventLogger(r).Log(context.Background(), tc.entry)
+			if diff := cmp.Diff(tc.want, r.events); diff != "" {
+				t.Errorf("\n%s\nLog(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"k8s.io/client-go/rest"
+)
+
+// ClusterIdentity returns a canonical identity of the API server behind the
+// supplied config, made of its normalized URL and the fingerprint of the CA
+// its certificate is verified against. Configs of different ProviderConfigs,
+// e.g. with different credentials, that point to the same API server have the
+// same identity.
+func ClusterIdentity(rc *rest.Config) string {
+	if rc == nil {
+		return ""
+	}
+	return normalizeHost(rc.Host) + "#" + caFingerprint(rc.TLSClientConfig)
+}
+
+// normalizeHost returns the supplied API server URL with the scheme and the
+// host in lower case, an explicit port, and without a trailing slash.
+func normalizeHost(host string) string {
+	h := host
+	if !strings.Contains(h, "://") {
+		h = "https://" + h
+	}
+	u, err := url.Parse(h)
+	if err != nil {
+		return host
+	}
+	scheme := strings.ToLower(u.Scheme)
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if scheme == "http" {
+			port = "80"
+		}
+	}
+	return fmt.Sprintf("%s://%s:%s%s", scheme, strings.ToLower(u.Hostname()), port, strings.TrimSuffix(u.Path, "/"))
+}
+
+func caFingerprint(tc rest.TLSClientConfig) string {
+	switch {
+	case tc.Insecure:
+		return "insecure"
+	case len(tc.CAData) > 0:
+		return fmt.Sprintf("%x", sha256.Sum256(tc.CAData))
+	case tc.CAFile != "":
+		return "file:" + tc.CAFile
+	default:
+		return "system"
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestClusterIdentity(t *testing.T) {
+	ca := rest.TLSClientConfig{CAData: []byte("ca")}
+
+	cases := map[string]struct {
+		reason string
+		a, b   *rest.Config
+		same   bool
+	}{
+		"SameServer": {
+			reason: "Configs of the same server and CA with different credentials should have the same identity.",
+			a:      &rest.Config{Host: "https://Example.org:443/", TLSClientConfig: ca, BearerToken: "a"},
+			b:      &rest.Config{Host: "example.org", TLSClientConfig: ca, BearerToken: "b"},
+			same:   true,
+		},
+		"OtherPort": {
+			reason: "Configs of different ports should have different identities.",
+			a:      &rest.Config{Host: "https://example.org:6443", TLSClientConfig: ca},
+			b:      &rest.Config{Host: "https://example.org", TLSClientConfig: ca},
+		},
+		"OtherCA": {
+			reason: "Configs verifying the server against different CAs should have different identities.",
+			a:      &rest.Config{Host: "https://example.org", TLSClientConfig: ca},
+			b:      &rest.Config{Host: "https://example.org", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("other")}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a, b := ClusterIdentity(tc.a), ClusterIdentity(tc.b)
+			if (a == b) != tc.same {
+				t.Errorf("\n%s\nClusterIdentity(...): want same identity %t, got %q and %q", tc.reason, tc.same, a, b)
+			}
+		})
+	}
+}
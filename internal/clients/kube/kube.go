@@ -15,8 +15,18 @@ package kube
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
+	"golang.org/x/net/http/httpproxy"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -33,18 +43,40 @@ import (
 
 const (
 	errGetPC                    = "cannot get ProviderConfig"
+	errGetCASecret              = "cannot get CA bundle Secret"
+	errReadCAFile               = "cannot read CA file"
+	errInvalidCABundle          = "CA bundle does not contain any PEM encoded certificate"
+	errGetClientCertSecret      = "cannot get client certificate"
+	errGetClientKeySecret       = "cannot get client key"
+	errInvalidClientCert        = "invalid client certificate or key"
+	errCertificateExpired       = "client certificate of ProviderConfig expired, reconciles are suspended until it is rotated"
 	errGetCreds                 = "cannot get credentials"
 	errCreateRestConfig         = "cannot create new REST config using provider secret"
 	errExtractGoogleCredentials = "cannot extract Google Application Credentials"
 	errInjectGoogleCredentials  = "cannot wrap REST client with Google Application Credentials"
 	errExtractAzureCredentials  = "failed to extract Azure Application Credentials"
 	errInjectAzureCredentials   = "failed to wrap REST client with Azure Application Credentials"
+	errGetExternalSecret        = "cannot get external Secret"
+	errNoExternalSecretKey      = "Secret %s/%s is not managed by the External Secrets Operator, externalSecretRef.key is required"
+	errGetService               = "cannot get API server Service"
+	errNoClusterIP              = "Service %s/%s has no ClusterIP"
+	errNoServicePort            = "Service %s/%s has no port"
+	errParseHost                = "cannot parse API server URL"
+	errCreateTransport          = "cannot create transport for the API server"
+
+	// LabelKeyExternalSecret labels the Secrets managed by the External
+	// Secrets Operator.
+	LabelKeyExternalSecret = "external-secrets.io/external-secret"
+
+	// defaultKubeconfigKey is the key of Secrets managed by the External
+	// Secrets Operator holding the kubeconfig, unless another one is set.
+	defaultKubeconfigKey = "kubeconfig"
 )
 
 // ClientForProvider returns the client and *rest.config for the given provider
 // config.
 func ClientForProvider(ctx context.Context, inclusterClient client.Client, providerConfigName string) (client.Client, *rest.Config, error) { //nolint:gocyclo
-	rc, err := configForProvider(ctx, inclusterClient, providerConfigName)
+	rc, err := ConfigForProvider(ctx, inclusterClient, providerConfigName)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "cannot get REST config for provider %q", providerConfigName)
 	}
@@ -56,12 +88,18 @@ func ClientForProvider(ctx context.Context, inclusterClient client.Client, provi
 }
 
 // ConfigForProvider returns the *rest.config for the given provider config.
-func configForProvider(ctx context.Context, local client.Client, providerConfigName string) (*rest.Config, error) { // nolint:gocyclo
+func ConfigForProvider(ctx context.Context, local client.Client, providerConfigName string) (*rest.Config, error) { // nolint:gocyclo
 	pc := &v1alpha1.ProviderConfig{}
 	if err := local.Get(ctx, types.NamespacedName{Name: providerConfigName}, pc); err != nil {
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
+	// Suspend reconciles of resources using the ProviderConfig until its
+	// expired client certificate is rotated.
+	if c := pc.Status.GetCondition(v1alpha1.TypeCertificateExpired); c.Status == corev1.ConditionTrue {
+		return nil, errors.New(errCertificateExpired)
+	}
+
 	var rc *rest.Config
 	var err error
 
@@ -72,7 +110,7 @@ func configForProvider(ctx context.Context, local client.Client, providerConfigN
 			return nil, errors.Wrap(err, errCreateRestConfig)
 		}
 	default:
-		kc, err := resource.CommonCredentialExtractor(ctx, cd.Source, local, cd.CommonCredentialSelectors)
+		kc, err := Kubeconfig(ctx, local, cd)
 		if err != nil {
 			return nil, errors.Wrap(err, errGetCreds)
 		}
@@ -82,11 +120,17 @@ func configForProvider(ctx context.Context, local client.Client, providerConfigN
 			return nil, errors.Wrap(err, "failed to load kubeconfig")
 		}
 
-		if rc, err = fromAPIConfig(ac); err != nil {
+		if rc, err = fromAPIConfig(ac, cd.Context); err != nil {
 			return nil, errors.Wrap(err, errCreateRestConfig)
 		}
 	}
 
+	if ref := pc.Spec.Credentials.ServiceRef; ref != nil {
+		if err := setServiceEndpoint(ctx, local, rc, ref); err != nil {
+			return nil, err
+		}
+	}
+
 	if id := pc.Spec.Identity; id != nil {
 		switch id.Type {
 		case v1alpha1.IdentityTypeGoogleApplicationCredentials:
@@ -125,17 +169,192 @@ func configForProvider(ctx context.Context, local client.Client, providerConfigN
 		}
 	}
 
+	if tc := pc.Spec.TLSConfig; tc != nil && tc.CASecretRef != nil {
+		if err := mergeCABundle(ctx, local, rc, tc.CASecretRef); err != nil {
+			return nil, err
+		}
+	}
+
+	if m := pc.Spec.Credentials.MTLS; m != nil {
+		if err := setClientCertificate(ctx, local, rc, m); err != nil {
+			return nil, err
+		}
+	}
+
+	if p := pc.Spec.Proxy; p != nil {
+		setProxy(rc, p)
+	}
+
+	if err := meterConfig(rc, providerConfigName, pc.GetResourceVersion()); err != nil {
+		return nil, errors.Wrap(err, errCreateTransport)
+	}
+
 	return rc, nil
 }
 
-func fromAPIConfig(c *api.Config) (*rest.Config, error) {
-	if c.CurrentContext == "" {
-		return nil, errors.New("currentContext not set in kubeconfig")
+// Kubeconfig returns the kubeconfig of the supplied credentials. It is read
+// from the external Secret, if the credentials reference one.
+func Kubeconfig(ctx context.Context, local client.Client, cd v1alpha1.ProviderCredentials) ([]byte, error) {
+	if ref := cd.ExternalSecretRef; ref != nil && cd.Source == xpv1.CredentialsSourceSecret {
+		return externalSecretKubeconfig(ctx, local, ref)
+	}
+	return resource.CommonCredentialExtractor(ctx, cd.Source, local, cd.CommonCredentialSelectors)
+}
+
+// externalSecretKubeconfig returns the kubeconfig of the referenced Secret.
+// Unless a key is referenced, Secrets managed by the External Secrets
+// Operator hold it in the default kubeconfig key.
+func externalSecretKubeconfig(ctx context.Context, local client.Client, ref *v1alpha1.ExternalSecretReference) ([]byte, error) {
+	s := &corev1.Secret{}
+	if err := local.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetExternalSecret)
+	}
+	key := ref.Key
+	if key == "" {
+		if s.GetLabels()[LabelKeyExternalSecret] != "true" {
+			return nil, errors.Errorf(errNoExternalSecretKey, ref.Namespace, ref.Name)
+		}
+		key = defaultKubeconfigKey
+	}
+	v, ok := s.Data[key]
+	if !ok {
+		return nil, errors.Errorf("key %q not found in Secret %s/%s", key, ref.Namespace, ref.Name)
+	}
+	return v, nil
+}
+
+// setServiceEndpoint points the supplied config to the ClusterIP of the
+// referenced Service. The scheme of the original URL is kept and, unless it
+// is already set, its host remains the name the TLS certificate of the API
+// server is verified against.
+func setServiceEndpoint(ctx context.Context, local client.Client, rc *rest.Config, ref *v1alpha1.ServiceReference) error {
+	s := &corev1.Service{}
+	if err := local.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return errors.Wrap(err, errGetService)
+	}
+	ip := s.Spec.ClusterIP
+	if ip == "" || ip == corev1.ClusterIPNone {
+		return errors.Errorf(errNoClusterIP, ref.Namespace, ref.Name)
+	}
+	var port int32
+	switch {
+	case ref.Port != nil:
+		port = *ref.Port
+	case len(s.Spec.Ports) > 0:
+		port = s.Spec.Ports[0].Port
+	default:
+		return errors.Errorf(errNoServicePort, ref.Namespace, ref.Name)
+	}
+
+	host := rc.Host
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return errors.Wrap(err, errParseHost)
+	}
+	if rc.TLSClientConfig.ServerName == "" && !rc.TLSClientConfig.Insecure && u.Hostname() != "" {
+		rc.TLSClientConfig.ServerName = u.Hostname()
+	}
+	u.Host = net.JoinHostPort(ip, strconv.Itoa(int(port)))
+	rc.Host = u.String()
+	return nil
+}
+
+// setProxy makes the supplied config send its requests through the
+// configured proxies, ignoring the proxy environment variables.
+func setProxy(rc *rest.Config, p *v1alpha1.ProxyConfig) {
+	pf := (&httpproxy.Config{HTTPProxy: p.HTTPProxy, HTTPSProxy: p.HTTPSProxy, NoProxy: p.NoProxy}).ProxyFunc()
+	rc.Proxy = func(r *http.Request) (*url.URL, error) {
+		return pf(r.URL)
+	}
+}
+
+// setClientCertificate makes the supplied config authenticate with the
+// client certificate and key of the referenced Secrets.
+func setClientCertificate(ctx context.Context, local client.Client, rc *rest.Config, m *v1alpha1.MTLSConfig) error {
+	cert, err := secretKey(ctx, local, m.ClientCertSecretRef)
+	if err != nil {
+		return errors.Wrap(err, errGetClientCertSecret)
+	}
+	key, err := secretKey(ctx, local, m.ClientKeySecretRef)
+	if err != nil {
+		return errors.Wrap(err, errGetClientKeySecret)
+	}
+	if _, err := tls.X509KeyPair(cert, key); err != nil {
+		return errors.Wrap(err, errInvalidClientCert)
+	}
+
+	// CertData and KeyData take precedence over CertFile and KeyFile, clear
+	// them anyway not to mix up the certificate of the kubeconfig with ours.
+	rc.TLSClientConfig.CertData = cert
+	rc.TLSClientConfig.KeyData = key
+	rc.TLSClientConfig.CertFile = ""
+	rc.TLSClientConfig.KeyFile = ""
+	return nil
+}
+
+func secretKey(ctx context.Context, local client.Client, ref xpv1.SecretKeySelector) ([]byte, error) {
+	s := &corev1.Secret{}
+	if err := local.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, err
+	}
+	v, ok := s.Data[ref.Key]
+	if !ok {
+		return nil, errors.Errorf("key %q not found in Secret %s/%s", ref.Key, ref.Namespace, ref.Name)
+	}
+	return v, nil
+}
+
+// mergeCABundle adds the CA bundle of the referenced Secret to the CAs the
+// supplied config trusts.
+func mergeCABundle(ctx context.Context, local client.Client, rc *rest.Config, ref *xpv1.SecretKeySelector) error {
+	s := &corev1.Secret{}
+	if err := local.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return errors.Wrap(err, errGetCASecret)
+	}
+	bundle := s.Data[ref.Key]
+	if !x509.NewCertPool().AppendCertsFromPEM(bundle) {
+		return errors.Errorf("%s: %s/%s[%s]", errInvalidCABundle, ref.Namespace, ref.Name, ref.Key)
+	}
+
+	// CAData takes precedence over CAFile, so don't lose the CA of the
+	// latter, e.g. of an injected identity.
+	ca := rc.TLSClientConfig.CAData
+	if len(ca) == 0 && rc.TLSClientConfig.CAFile != "" {
+		b, err := os.ReadFile(rc.TLSClientConfig.CAFile)
+		if err != nil {
+			return errors.Wrap(err, errReadCAFile)
+		}
+		ca = b
+	}
+	merged := make([]byte, 0, len(ca)+len(bundle)+1)
+	merged = append(merged, ca...)
+	if len(merged) > 0 && merged[len(merged)-1] != '\n' {
+		merged = append(merged, '\n')
+	}
+	rc.TLSClientConfig.CAData = append(merged, bundle...)
+	rc.TLSClientConfig.CAFile = ""
+	return nil
+}
+
+// fromAPIConfig returns the config of the named context of the supplied
+// kubeconfig, or of its current context if no name is supplied.
+func fromAPIConfig(c *api.Config, name string) (*rest.Config, error) {
+	if name == "" {
+		if c.CurrentContext == "" {
+			return nil, errors.New("currentContext not set in kubeconfig")
+		}
+		name = c.CurrentContext
+	}
+	ctx := c.Contexts[name]
+	if ctx == nil {
+		return nil, errors.Errorf("context %q not found in kubeconfig", name)
 	}
-	ctx := c.Contexts[c.CurrentContext]
 	cluster := c.Clusters[ctx.Cluster]
 	if cluster == nil {
-		return nil, errors.Errorf("cluster for currentContext (%s) not found", c.CurrentContext)
+		return nil, errors.Errorf("cluster for context (%s) not found", name)
 	}
 	user := c.AuthInfos[ctx.AuthInfo]
 	if user == nil {
@@ -0,0 +1,617 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+// newCertificate returns a PEM encoded certificate and key signed by the
+// supplied parent, or self-signed if no parent is supplied.
+func newCertificate(t *testing.T, tmpl *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) ([]byte, []byte, *x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate key: %v", err)
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("cannot create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("cannot parse certificate: %v", err)
+	}
+	kb, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("cannot marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: kb}), cert, key
+}
+
+func TestConfigForProviderMTLS(t *testing.T) {
+	now := time.Now()
+	_, _, ca, caKey := newCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "client-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+	clientCert, clientKey, _, _ := newCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "provider-kubernetes"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+	_, otherKey, _, _ := newCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+	}, nil, nil)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool, MinVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	defer srv.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: remote
+clusters:
+- name: remote
+  cluster:
+    server: %s
+    certificate-authority-data: %s
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: remote
+users:
+- name: remote
+  user: {}
+`, srv.URL, base64.StdEncoding.EncodeToString(serverCA))
+
+	type args struct {
+		mtls bool
+		key  []byte
+	}
+	type want struct {
+		configErr  bool
+		requestErr bool
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ClientCertificate": {
+			reason: "We should authenticate with the client certificate of the referenced Secrets.",
+			args: args{
+				mtls: true,
+				key:  clientKey,
+			},
+		},
+		"NoClientCertificate": {
+			reason: "A server requiring client certificates should reject a config without one.",
+			want: want{
+				requestErr: true,
+			},
+		},
+		"MismatchedKey": {
+			reason: "We should return an error if the key does not belong to the certificate.",
+			args: args{
+				mtls: true,
+				key:  otherKey,
+			},
+			want: want{
+				configErr: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			local := &test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					switch o := obj.(type) {
+					case *v1alpha1.ProviderConfig:
+						o.Spec.Credentials = v1alpha1.ProviderCredentials{
+							Source: xpv1.CredentialsSourceSecret,
+							CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "kubeconfig"},
+								Key:             "kubeconfig",
+							}},
+						}
+						if tc.args.mtls {
+							o.Spec.Credentials.MTLS = &v1alpha1.MTLSConfig{
+								ClientCertSecretRef: xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "client-cert"}, Key: "tls.crt"},
+								ClientKeySecretRef:  xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "client-key"}, Key: "tls.key"},
+							}
+						}
+					case *corev1.Secret:
+						switch key.Name {
+						case "kubeconfig":
+							o.Data = map[string][]byte{"kubeconfig": []byte(kubeconfig)}
+						case "client-cert":
+							o.Data = map[string][]byte{"tls.crt": clientCert}
+						case "client-key":
+							o.Data = map[string][]byte{"tls.key": tc.args.key}
+						}
+					}
+					return nil
+				},
+			}
+
+			rc, err := ConfigForProvider(context.Background(), local, "remote")
+			if (err != nil) != tc.want.configErr {
+				t.Fatalf("\n%s\nConfigForProvider(...): want error: %t, got error: %v", tc.reason, tc.want.configErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			hc, err := rest.HTTPClientFor(rc)
+			if err != nil {
+				t.Fatalf("cannot create HTTP client: %v", err)
+			}
+			resp, err := hc.Get(srv.URL)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+			if (err != nil) != tc.want.requestErr {
+				t.Errorf("\n%s\nGET %s: want error: %t, got error: %v", tc.reason, srv.URL, tc.want.requestErr, err)
+			}
+		})
+	}
+}
+
+func TestKubeconfig(t *testing.T) {
+	ref := &v1alpha1.ExternalSecretReference{Namespace: "crossplane-system", Name: "remote"}
+
+	type args struct {
+		labels map[string]string
+		key    string
+	}
+	type want struct {
+		kubeconfig []byte
+		err        bool
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ExternalSecretDefaultKey": {
+			reason: "We should read the default kubeconfig key of Secrets managed by the External Secrets Operator.",
+			args: args{
+				labels: map[string]string{LabelKeyExternalSecret: "true"},
+			},
+			want: want{
+				kubeconfig: []byte("default"),
+			},
+		},
+		"ExternalSecretExplicitKey": {
+			reason: "We should read the referenced key if one is supplied.",
+			args: args{
+				key: "other",
+			},
+			want: want{
+				kubeconfig: []byte("other"),
+			},
+		},
+		"NotAnExternalSecret": {
+			reason: "We should return an error if no key is referenced and the Secret is not managed by the External Secrets Operator.",
+			want: want{
+				err: true,
+			},
+		},
+		"MissingKey": {
+			reason: "We should return an error if the referenced key does not exist.",
+			args: args{
+				key: "missing",
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			local := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					s := obj.(*corev1.Secret)
+					s.SetLabels(tc.args.labels)
+					s.Data = map[string][]byte{"kubeconfig": []byte("default"), "other": []byte("other")}
+					return nil
+				},
+			}
+			r := *ref
+			r.Key = tc.args.key
+			got, err := Kubeconfig(context.Background(), local, v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret, ExternalSecretRef: &r})
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nKubeconfig(...): want error: %t, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if string(got) != string(tc.want.kubeconfig) {
+				t.Errorf("\n%s\nKubeconfig(...): want %q, got %q", tc.reason, tc.want.kubeconfig, got)
+			}
+		})
+	}
+}
+
+func TestSetServiceEndpoint(t *testing.T) {
+	port := int32(8443)
+
+	type args struct {
+		host     string
+		insecure bool
+		ref      v1alpha1.ServiceReference
+		svc      corev1.ServiceSpec
+	}
+	type want struct {
+		host       string
+		serverName string
+		err        bool
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"FirstPort": {
+			reason: "We should connect to the ClusterIP and first port of the Service, verifying the certificate against the original host.",
+			args: args{
+				host: "https://vcluster.example.org:6443",
+				svc:  corev1.ServiceSpec{ClusterIP: "10.0.0.10", Ports: []corev1.ServicePort{{Port: 443}, {Port: 8443}}},
+			},
+			want: want{
+				host:       "https://10.0.0.10:443",
+				serverName: "vcluster.example.org",
+			},
+		},
+		"ExplicitPort": {
+			reason: "We should connect to the referenced port of the Service.",
+			args: args{
+				host: "vcluster.example.org",
+				ref:  v1alpha1.ServiceReference{Port: &port},
+				svc:  corev1.ServiceSpec{ClusterIP: "fd00::10", Ports: []corev1.ServicePort{{Port: 443}}},
+			},
+			want: want{
+				host:       "https://[fd00::10]:8443",
+				serverName: "vcluster.example.org",
+			},
+		},
+		"Insecure": {
+			reason: "We should not set a server name if the certificate is not verified.",
+			args: args{
+				host:     "http://vcluster:80/prefix",
+				insecure: true,
+				svc:      corev1.ServiceSpec{ClusterIP: "10.0.0.10", Ports: []corev1.ServicePort{{Port: 80}}},
+			},
+			want: want{
+				host: "http://10.0.0.10:80/prefix",
+			},
+		},
+		"Headless": {
+			reason: "We should return an error if the Service has no ClusterIP.",
+			args: args{
+				svc: corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone, Ports: []corev1.ServicePort{{Port: 443}}},
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"NoPort": {
+			reason: "We should return an error if no port is referenced and the Service has none.",
+			args: args{
+				svc: corev1.ServiceSpec{ClusterIP: "10.0.0.10"},
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			local := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					obj.(*corev1.Service).Spec = tc.args.svc
+					return nil
+				},
+			}
+			rc := &rest.Config{Host: tc.args.host, TLSClientConfig: rest.TLSClientConfig{Insecure: tc.args.insecure}}
+			err := setServiceEndpoint(context.Background(), local, rc, &tc.args.ref)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nsetServiceEndpoint(...): want error: %t, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if rc.Host != tc.want.host {
+				t.Errorf("\n%s\nsetServiceEndpoint(...): want host %q, got %q", tc.reason, tc.want.host, rc.Host)
+			}
+			if rc.TLSClientConfig.ServerName != tc.want.serverName {
+				t.Errorf("\n%s\nsetServiceEndpoint(...): want server name %q, got %q", tc.reason, tc.want.serverName, rc.TLSClientConfig.ServerName)
+			}
+		})
+	}
+}
+
+func TestSetProxy(t *testing.T) {
+	type args struct {
+		host  string
+		proxy v1alpha1.ProxyConfig
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   string
+	}{
+		"HTTP": {
+			reason: "We should send requests to http:// API servers to the HTTP proxy.",
+			args: args{
+				host:  "http://cluster.example.org",
+				proxy: v1alpha1.ProxyConfig{HTTPProxy: "PROXY"},
+			},
+			want: "GET http://cluster.example.org/version",
+		},
+		"HTTPS": {
+			reason: "We should tunnel requests to https:// API servers through the HTTPS proxy.",
+			args: args{
+				host:  "https://cluster.example.org:6443",
+				proxy: v1alpha1.ProxyConfig{HTTPSProxy: "PROXY"},
+			},
+			want: "CONNECT cluster.example.org:6443",
+		},
+		"NoProxy": {
+			reason: "We should not send requests to API servers matching noProxy to the proxy.",
+			args: args{
+				host:  "https://cluster.invalid:6443",
+				proxy: v1alpha1.ProxyConfig{HTTPSProxy: "PROXY", NoProxy: "other.example.org,.invalid"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// The configured proxies take precedence over the environment.
+			t.Setenv("HTTP_PROXY", "http://environment.invalid")
+			t.Setenv("HTTPS_PROXY", "http://environment.invalid")
+
+			proxied := make(chan string, 1)
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				target := r.Host
+				if r.Method != http.MethodConnect {
+					target = r.URL.String()
+				}
+				proxied <- r.Method + " " + target
+				w.WriteHeader(http.StatusForbidden)
+			}))
+			defer proxy.Close()
+
+			p := tc.args.proxy
+			for _, v := range []*string{&p.HTTPProxy, &p.HTTPSProxy} {
+				if *v == "PROXY" {
+					*v = proxy.URL
+				}
+			}
+			rc := &rest.Config{Host: tc.args.host, Timeout: 5 * time.Second}
+			setProxy(rc, &p)
+			if err := meterConfig(rc, "proxy-"+name, "1"); err != nil {
+				t.Fatalf("meterConfig(...): unexpected error: %v", err)
+			}
+			if rc.Proxy != nil {
+				t.Errorf("\n%s\nmeterConfig(...): want the proxy of the shared transport only, got a config with a proxy", tc.reason)
+			}
+
+			hc, err := rest.HTTPClientFor(rc)
+			if err != nil {
+				t.Fatalf("rest.HTTPClientFor(...): unexpected error: %v", err)
+			}
+			if rsp, err := hc.Get(rc.Host + "/version"); err == nil {
+				rsp.Body.Close()
+			}
+
+			var got string
+			select {
+			case got = <-proxied:
+			default:
+			}
+			if got != tc.want {
+				t.Errorf("\n%s\nsetProxy(...): want proxied request %q, got %q", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMeterConfig(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	const pc = "metered"
+	rc := &rest.Config{Host: srv.URL}
+	if err := meterConfig(rc, pc, "1"); err != nil {
+		t.Fatalf("meterConfig(...): %v", err)
+	}
+	hc, err := rest.HTTPClientFor(rc)
+	if err != nil {
+		t.Fatalf("rest.HTTPClientFor(...): %v", err)
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodGet, http.MethodDelete} {
+		req, _ := http.NewRequestWithContext(context.Background(), method, srv.URL, nil)
+		resp, err := hc.Do(req)
+		if err != nil {
+			t.Fatalf("hc.Do(...): %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if got := testutil.ToFloat64(requestsTotal.WithLabelValues(pc, http.MethodGet, "200")); got != 2 {
+		t.Errorf("requestsTotal GET 200: want 2, got %v", got)
+	}
+	if got := testutil.ToFloat64(requestsTotal.WithLabelValues(pc, http.MethodDelete, "404")); got != 1 {
+		t.Errorf("requestsTotal DELETE 404: want 1, got %v", got)
+	}
+	if got := testutil.ToFloat64(connectionsActive.WithLabelValues(pc)); got != 1 {
+		t.Errorf("connectionsActive: want 1 kept alive connection, got %v", got)
+	}
+	utilnet.CloseIdleConnectionsFor(hc.Transport)
+	if got := testutil.ToFloat64(connectionsActive.WithLabelValues(pc)); got != 0 {
+		t.Errorf("connectionsActive: want 0 after closing idle connections, got %v", got)
+	}
+}
+
+func TestConfigForProviderSharesTransport(t *testing.T) {
+	kubeconfig := func(server string) []byte {
+		return []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: remote
+clusters:
+- name: remote
+  cluster:
+    server: %s
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: remote
+users:
+- name: remote
+  user:
+    token: secret
+`, server))
+	}
+	type config struct {
+		revision string
+		server   string
+		proxy    *v1alpha1.ProxyConfig
+	}
+	type want struct {
+		shared bool
+	}
+	cases := map[string]struct {
+		reason string
+		first  config
+		second config
+		want   want
+	}{
+		"SameRevision": {
+			reason: "Two configs of the same ProviderConfig should share one transport.",
+			first:  config{revision: "1", server: "https://10.0.0.1"},
+			second: config{revision: "1", server: "https://10.0.0.1"},
+			want:   want{shared: true},
+		},
+		"Proxy": {
+			reason: "Two configs of a ProviderConfig with a proxy should share one transport.",
+			first:  config{revision: "1", server: "https://10.0.0.1", proxy: &v1alpha1.ProxyConfig{HTTPSProxy: "http://proxy.example.org"}},
+			second: config{revision: "1", server: "https://10.0.0.1", proxy: &v1alpha1.ProxyConfig{HTTPSProxy: "http://proxy.example.org"}},
+			want:   want{shared: true},
+		},
+		"NewRevision": {
+			reason: "A new revision of a ProviderConfig should get a new transport.",
+			first:  config{revision: "1", server: "https://10.0.0.1"},
+			second: config{revision: "2", server: "https://10.0.0.1"},
+		},
+		"RotatedKubeconfig": {
+			reason: "A config for another API server should get a new transport.",
+			first:  config{revision: "1", server: "https://10.0.0.1"},
+			second: config{revision: "1", server: "https://10.0.0.2"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			configFor := func(c config) *rest.Config {
+				local := &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						switch o := obj.(type) {
+						case *v1alpha1.ProviderConfig:
+							o.SetResourceVersion(c.revision)
+							o.Spec.Proxy = c.proxy
+							o.Spec.Credentials = v1alpha1.ProviderCredentials{
+								Source: xpv1.CredentialsSourceSecret,
+								CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+									SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "kubeconfig"},
+									Key:             "kubeconfig",
+								}},
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{"kubeconfig": kubeconfig(c.server)}
+						}
+						return nil
+					},
+				}
+				rc, err := ConfigForProvider(context.Background(), local, "shared-"+name)
+				if err != nil {
+					t.Fatalf("ConfigForProvider(...): %v", err)
+				}
+				return rc
+			}
+
+			rc1, rc2 := configFor(tc.first), configFor(tc.second)
+			shared := rc1.WrapTransport(nil) == rc2.WrapTransport(nil)
+			if shared != tc.want.shared {
+				t.Errorf("\n%s\nConfigForProvider(...): want shared transport: %t, got %t", tc.reason, tc.want.shared, shared)
+			}
+			if rc1.Proxy != nil || rc2.Proxy != nil {
+				t.Errorf("\n%s\nConfigForProvider(...): want configs without a proxy client-go does not cache transports for", tc.reason)
+			}
+		})
+	}
+}
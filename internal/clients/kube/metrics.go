@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// connectionsActive is the number of open connections to the API server of
+// the cluster of a ProviderConfig.
+var connectionsActive = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "provider_kubernetes_cluster_connections_active",
+	Help: "The number of open connections to the API server of a cluster, by provider config.",
+}, []string{"providerConfig"})
+
+// requestsTotal counts the requests sent to the API server of the cluster
+// of a ProviderConfig.
+var requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "provider_kubernetes_cluster_requests_total",
+	Help: "The number of requests sent to the API server of a cluster, by provider config, method and response code.",
+}, []string{"providerConfig", "method", "code"})
+
+func init() {
+	metrics.Registry.MustRegister(connectionsActive, requestsTotal)
+}
+
+// codeError is the code of requests that did not receive a response, like
+// the one of the rest_client_requests_total metric of client-go.
+const codeError = "<error>"
+
+// idleConnsPerHost is the number of idle connections client-go keeps per
+// host, see k8s.io/client-go/transport.
+const idleConnsPerHost = 25
+
+// transports holds the shared transport of each ProviderConfig.
+var transports = &sharedTransports{byProviderConfig: map[string]*sharedTransport{}}
+
+// A sharedTransport is the transport of a ProviderConfig, built for the
+// config with the supplied key.
+type sharedTransport struct {
+	key  string
+	base *http.Transport
+	rt   http.RoundTripper
+}
+
+// sharedTransports caches a transport per ProviderConfig. client-go caches
+// transports by the TLS options and the dial function of a config, which
+// does not help for configs that are built anew for each reconcile.
+type sharedTransports struct {
+	mu               sync.Mutex
+	byProviderConfig map[string]*sharedTransport
+}
+
+// Get returns the transport of the supplied ProviderConfig, building it for
+// the supplied config unless one with the supplied key exists. The idle
+// connections of a replaced transport are closed.
+func (s *sharedTransports) Get(rc *rest.Config, providerConfig, key string) (http.RoundTripper, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.byProviderConfig[providerConfig]
+	if ok && st.key == key {
+		return st.rt, nil
+	}
+	base, err := newTransport(rc, providerConfig)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		st.base.CloseIdleConnections()
+	}
+	rt := &meteredTransport{base: base, providerConfig: providerConfig}
+	s.byProviderConfig[providerConfig] = &sharedTransport{key: key, base: base, rt: rt}
+	return rt, nil
+}
+
+// newTransport returns a transport for the supplied config like the one
+// client-go would build, that reports the connections it opens on behalf of
+// the supplied ProviderConfig.
+func newTransport(rc *rest.Config, providerConfig string) (*http.Transport, error) {
+	tlsConfig, err := rest.TLSConfigFor(rc)
+	if err != nil {
+		return nil, err
+	}
+	dial := rc.Dial
+	if dial == nil {
+		// The dialer client-go uses unless the config has one.
+		dial = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+	}
+	proxy := rc.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+	active := connectionsActive.WithLabelValues(providerConfig)
+	return utilnet.SetTransportDefaults(&http.Transport{
+		Proxy:               proxy,
+		TLSHandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConnsPerHost: idleConnsPerHost,
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			conn, err := dial(ctx, network, address)
+			if err != nil {
+				return nil, err
+			}
+			active.Inc()
+			return &meteredConn{Conn: conn, closed: active.Dec}, nil
+		},
+	}), nil
+}
+
+// transportKey identifies the transport of the supplied config. Configs of
+// the supplied revision of a ProviderConfig may still differ in the
+// credentials they are built from, e.g. a rotated kubeconfig Secret.
+func transportKey(rc *rest.Config, revision string) (string, error) {
+	h := sha256.New()
+	tc := rc.TLSClientConfig
+	for _, f := range [][]byte{
+		[]byte(rc.Host), []byte(rc.APIPath),
+		tc.CAData, []byte(tc.CAFile),
+		tc.CertData, []byte(tc.CertFile),
+		tc.KeyData, []byte(tc.KeyFile),
+		[]byte(tc.ServerName), []byte(strconv.FormatBool(tc.Insecure)),
+		[]byte(strings.Join(tc.NextProtos, ",")),
+	} {
+		_, _ = h.Write(f)
+		_, _ = h.Write([]byte{0})
+	}
+	if rc.Proxy != nil {
+		req, err := http.NewRequest(http.MethodGet, rc.Host, nil)
+		if err != nil {
+			return "", err
+		}
+		u, err := rc.Proxy(req)
+		if err != nil {
+			return "", err
+		}
+		if u != nil {
+			_, _ = h.Write([]byte(u.String()))
+		}
+	}
+	return revision + "/" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// meterConfig makes the supplied config send its requests through the
+// shared transport of the supplied ProviderConfig, which reports the
+// connections it opens and the requests it sends. The transport is rebuilt
+// when the supplied revision of the ProviderConfig or the TLS options of
+// the config change.
+func meterConfig(rc *rest.Config, providerConfig, revision string) error {
+	key, err := transportKey(rc, revision)
+	if err != nil {
+		return err
+	}
+	rt, err := transports.Get(rc, providerConfig, key)
+	if err != nil {
+		return err
+	}
+	// The shared transport replaces the one client-go builds, before the
+	// wrappers of the config, e.g. the ones of identities, are applied.
+	rc.WrapTransport = transport.Wrappers(func(http.RoundTripper) http.RoundTripper {
+		return rt
+	}, rc.WrapTransport)
+	// The shared transport sends requests to the proxy of the config already.
+	// client-go builds a new transport for each client of a config with a
+	// proxy, instead of one it caches.
+	rc.Proxy = nil
+	return nil
+}
+
+// A meteredConn reports being closed once.
+type meteredConn struct {
+	net.Conn
+	once   sync.Once
+	closed func()
+}
+
+func (c *meteredConn) Close() error {
+	c.once.Do(c.closed)
+	return c.Conn.Close()
+}
+
+// A meteredTransport counts the requests it sends.
+type meteredTransport struct {
+	base           http.RoundTripper
+	providerConfig string
+}
+
+func (t *meteredTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	code := codeError
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	requestsTotal.WithLabelValues(t.providerConfig, req.Method, code).Inc()
+	return resp, err
+}
+
+// WrappedRoundTripper returns the transport the requests are sent with.
+func (t *meteredTransport) WrappedRoundTripper() http.RoundTripper {
+	return t.base
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoproviderconfig
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/autoproviderconfig/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+const (
+	errStatusUpdate    = "cannot update status"
+	fieldOwner         = client.FieldOwner("kubernetes.crossplane.io/auto-provider-config-controller")
+	membershipLabelKey = "kubernetes.crossplane.io/owned-by-auto-provider-config"
+	defaultKey         = "kubeconfig"
+)
+
+// Reconciler watches for AutoProviderConfig resources and manages a
+// ProviderConfig for every matching kubeconfig Secret.
+type Reconciler struct {
+	client       client.Client
+	log          logging.Logger
+	pollInterval func() time.Duration
+}
+
+// Setup adds a controller that reconciles AutoProviderConfig resources.
+func Setup(mgr ctrl.Manager, o controller.Options, pollJitter time.Duration) error {
+	name := managed.ControllerName(v1alpha1.AutoProviderConfigGroupKind)
+
+	r := &Reconciler{
+		client: mgr.GetClient(),
+		log:    o.Logger,
+		pollInterval: func() time.Duration {
+			return o.PollInterval + time.Duration((rand.Float64()-0.5)*2*float64(pollJitter)) //nolint
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.AutoProviderConfig{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{}),
+		)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.autoProviderConfigsForSecret)).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// autoProviderConfigsForSecret enqueues the AutoProviderConfigs that are
+// interested in the namespace of the supplied Secret.
+func (r *Reconciler) autoProviderConfigsForSecret(ctx context.Context, s client.Object) []reconcile.Request {
+	l := &v1alpha1.AutoProviderConfigList{}
+	if err := r.client.List(ctx, l); err != nil {
+		r.log.Debug("cannot list auto provider configs", "error", err)
+		return nil
+	}
+	var reqs []reconcile.Request
+	for _, apc := range l.Items {
+		if apc.Spec.Namespace == s.GetNamespace() {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: apc.Name}})
+		}
+	}
+	return reqs
+}
+
+// Reconcile applies a ProviderConfig for each Secret matching an
+// AutoProviderConfig's selector, and deletes the ProviderConfigs whose Secret
+// is gone.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, error error) { //nolint:gocyclo
+	log := r.log.WithValues("request", req)
+
+	defer func() {
+		if error == nil {
+			log.Info("Reconciled")
+		} else {
+			log.Info("Retry", "err", error)
+		}
+	}()
+
+	apc := &v1alpha1.AutoProviderConfig{}
+	err := r.client.Get(ctx, req.NamespacedName, apc)
+
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	// Managed ProviderConfigs are owned by the AutoProviderConfig and garbage
+	// collected once it is gone.
+	if meta.WasDeleted(apc) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsPaused(apc) {
+		apc.Status.SetConditions(xpv1.ReconcilePaused())
+		return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, apc), errStatusUpdate)
+	}
+
+	log.Info("Reconciling")
+
+	selector, err := metav1.LabelSelectorAsSelector(&apc.Spec.Selector)
+	if err != nil {
+		werr := errors.Wrap(err, "error creating selector")
+		apc.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, apc)
+		return ctrl.Result{}, werr
+	}
+
+	sl := &corev1.SecretList{}
+	if err := r.client.List(ctx, sl, client.InNamespace(apc.Spec.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		werr := errors.Wrapf(err, "cannot list secrets in namespace %s", apc.Spec.Namespace)
+		apc.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, apc)
+		return ctrl.Result{}, werr
+	}
+
+	// Fetch any existing ProviderConfigs managed by this AutoProviderConfig.
+	ml := map[string]string{membershipLabelKey: apc.Name}
+	pcl := &apisv1alpha1.ProviderConfigList{}
+	if err := r.client.List(ctx, pcl, client.MatchingLabels(ml)); err != nil {
+		werr := errors.Wrapf(err, "cannot list provider configs matching labels %v", ml)
+		apc.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, apc)
+		return ctrl.Result{}, werr
+	}
+	owned := sets.New[string]()
+	for _, pc := range pcl.Items {
+		owned.Insert(pc.Name)
+	}
+
+	names := sets.New[string]()
+	for _, s := range sl.Items {
+		if !owned.Has(s.Name) {
+			// Never take over a ProviderConfig that is managed otherwise.
+			existing := &apisv1alpha1.ProviderConfig{}
+			err := r.client.Get(ctx, types.NamespacedName{Name: s.Name}, existing)
+			if err == nil {
+				log.Info("Skipping secret, a provider config with its name already exists", "secret", s.Name)
+				continue
+			}
+			if !kerrors.IsNotFound(err) {
+				werr := errors.Wrapf(err, "cannot get provider config %s", s.Name)
+				apc.Status.SetConditions(xpv1.ReconcileError(werr))
+				_ = r.client.Status().Update(ctx, apc)
+				return ctrl.Result{}, werr
+			}
+		}
+
+		names.Insert(s.Name)
+		if apc.Spec.DryRun {
+			log.Info("Would apply provider config for secret", "providerConfig", s.Name)
+			continue
+		}
+		if err := r.client.Patch(ctx, providerConfig(apc, s.Name), client.Apply, fieldOwner, client.ForceOwnership); err != nil {
+			werr := errors.Wrapf(err, "cannot apply provider config %s", s.Name)
+			apc.Status.SetConditions(xpv1.ReconcileError(werr))
+			_ = r.client.Status().Update(ctx, apc)
+			return ctrl.Result{}, werr
+		}
+		log.Debug("Applied provider config for secret", "providerConfig", s.Name)
+	}
+
+	// Remove ProviderConfigs whose Secret is gone or no longer matches.
+	for i := range pcl.Items {
+		pc := pcl.Items[i]
+		if names.Has(pc.Name) {
+			continue
+		}
+		if apc.Spec.DryRun {
+			log.Info("Would delete provider config", "providerConfig", pc.Name)
+			continue
+		}
+		log.Debug("Removing", "providerConfig", pc.Name)
+		if err := r.client.Delete(ctx, &pcl.Items[i]); resource.IgnoreNotFound(err) != nil {
+			werr := errors.Wrapf(err, "cannot delete provider config %s", pc.Name)
+			apc.Status.SetConditions(xpv1.ReconcileError(werr))
+			_ = r.client.Status().Update(ctx, apc)
+			return ctrl.Result{}, werr
+		}
+	}
+
+	apc.Status.ProviderConfigs = sets.List(names)
+	apc.Status.MembershipLabel = ml
+	apc.Status.SetConditions(xpv1.ReconcileSuccess(), xpv1.Available())
+
+	return ctrl.Result{RequeueAfter: r.pollInterval()}, r.client.Status().Update(ctx, apc)
+}
+
+// providerConfig returns the ProviderConfig for the named kubeconfig Secret.
+func providerConfig(apc *v1alpha1.AutoProviderConfig, secret string) *apisv1alpha1.ProviderConfig {
+	key := apc.Spec.Key
+	if key == "" {
+		key = defaultKey
+	}
+	return &apisv1alpha1.ProviderConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apisv1alpha1.SchemeGroupVersion.String(),
+			Kind:       apisv1alpha1.ProviderConfigKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: secret,
+			Labels: map[string]string{
+				membershipLabelKey: apc.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				meta.AsOwner(meta.TypedReferenceTo(apc, v1alpha1.AutoProviderConfigGroupVersionKind)),
+			},
+		},
+		Spec: apisv1alpha1.ProviderConfigSpec{
+			Credentials: apisv1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Namespace: apc.Spec.Namespace, Name: secret},
+						Key:             key,
+					},
+				},
+			},
+		},
+	}
+}
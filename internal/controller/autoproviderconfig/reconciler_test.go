@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoproviderconfig
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/autoproviderconfig/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+func TestReconciler(t *testing.T) {
+	apcName := types.NamespacedName{Name: "fleet"}
+	errBoom := fmt.Errorf("error reading")
+
+	getAPC := func(dryRun bool, existing ...string) test.MockGetFn {
+		return func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha1.AutoProviderConfig:
+				o.Name = key.Name
+				o.Spec.Namespace = "fleet"
+				o.Spec.DryRun = dryRun
+				return nil
+			case *apisv1alpha1.ProviderConfig:
+				for _, e := range existing {
+					if e == key.Name {
+						return nil
+					}
+				}
+			}
+			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+	}
+	list := func(ctx context.Context, l client.ObjectList, opts ...client.ListOption) error {
+		switch l := l.(type) {
+		case *corev1.SecretList:
+			for _, n := range []string{"cluster-a", "cluster-b", "taken"} {
+				l.Items = append(l.Items, corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "fleet", Name: n}})
+			}
+		case *apisv1alpha1.ProviderConfigList:
+			for _, n := range []string{"cluster-a", "gone"} {
+				l.Items = append(l.Items, apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: n}})
+			}
+		}
+		return nil
+	}
+	wantProviderConfigs := func(want ...string) test.MockSubResourceUpdateFn {
+		return func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			if diff := cmp.Diff(want, obj.(*v1alpha1.AutoProviderConfig).Status.ProviderConfigs); diff != "" {
+				t.Errorf("-want provider configs, +got:\n%s", diff)
+			}
+			return nil
+		}
+	}
+
+	type want struct {
+		err     error
+		applied []string
+		deleted []string
+	}
+	cases := map[string]struct {
+		reason string
+		client *test.MockClient
+		want   want
+	}{
+		"ErrorGetAutoProviderConfig": {
+			reason: "We should return error.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(errBoom),
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"AutoProviderConfigNotFound": {
+			reason: "We should not return an error if the AutoProviderConfig was not found.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+			},
+		},
+		"ErrorListSecrets": {
+			reason: "We should return an error if the Secrets cannot be listed.",
+			client: &test.MockClient{
+				MockGet:          getAPC(false),
+				MockList:         test.NewMockListFn(errBoom),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"ManageProviderConfigs": {
+			reason: "We should apply a ProviderConfig per Secret, skip names that are taken and delete ProviderConfigs of removed Secrets.",
+			client: &test.MockClient{
+				MockGet:          getAPC(false, "taken"),
+				MockList:         list,
+				MockStatusUpdate: wantProviderConfigs("cluster-a", "cluster-b"),
+			},
+			want: want{
+				applied: []string{"cluster-a", "cluster-b"},
+				deleted: []string{"gone"},
+			},
+		},
+		"DryRun": {
+			reason: "We should not change any ProviderConfig in dry-run mode.",
+			client: &test.MockClient{
+				MockGet:          getAPC(true, "taken"),
+				MockList:         list,
+				MockStatusUpdate: wantProviderConfigs("cluster-a", "cluster-b"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var applied, deleted []string
+			tc.client.MockPatch = func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				pc := obj.(*apisv1alpha1.ProviderConfig)
+				if ref := pc.Spec.Credentials.SecretRef; ref == nil || ref.Name != pc.Name || ref.Key != defaultKey {
+					t.Errorf("unexpected secret reference %v", ref)
+				}
+				applied = append(applied, pc.Name)
+				return nil
+			}
+			tc.client.MockDelete = func(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+				deleted = append(deleted, obj.GetName())
+				return nil
+			}
+			r := &Reconciler{
+				client: tc.client,
+				log:    logging.NewNopLogger(),
+				pollInterval: func() time.Duration {
+					return time.Minute
+				},
+			}
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: apcName})
+			if !errors.Is(err, tc.want.err) {
+				t.Errorf("\n%s\nr.Reconcile(...): want error: %v, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.applied, applied); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want applied, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want deleted, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bulkdelete
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/bulkdelete/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/config"
+)
+
+const (
+	errListObjects       = "cannot list Objects using the ProviderConfig"
+	errSetDeletionPolicy = "cannot set deletion policy of Object"
+	errDeleteObject      = "cannot delete Object"
+	errStatusUpdate      = "cannot update status"
+
+	// deleteConcurrency is the maximum number of Objects deleted in
+	// parallel.
+	deleteConcurrency = 10
+
+	// waitInterval is how often a BulkDelete checks whether the Objects it
+	// deleted are gone.
+	waitInterval = 10 * time.Second
+)
+
+// Reconciler watches for BulkDelete resources and deletes all Objects using
+// their ProviderConfig.
+type Reconciler struct {
+	client client.Client
+	log    logging.Logger
+}
+
+// Setup adds a controller that reconciles BulkDelete resources. It requires
+// the Objects to be indexed by their ProviderConfig, see config.Setup.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.BulkDeleteGroupKind)
+
+	r := &Reconciler{
+		client: mgr.GetClient(),
+		log:    o.Logger,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.BulkDelete{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// Reconcile deletes the Objects using the ProviderConfig of a BulkDelete, at
+// most deleteConcurrency at a time, and reports its progress in the
+// BulkDelete's status. It completes once all Objects are gone, after which
+// the BulkDelete may be deleted.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) { //nolint:gocyclo
+	log := r.log.WithValues("request", req)
+
+	defer func() {
+		if retErr == nil {
+			log.Info("Reconciled")
+		} else {
+			log.Info("Retry", "err", retErr)
+		}
+	}()
+
+	bd := &v1alpha1.BulkDelete{}
+	err := r.client.Get(ctx, req.NamespacedName, bd)
+
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if meta.WasDeleted(bd) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsPaused(bd) {
+		bd.Status.SetConditions(xpv1.ReconcilePaused())
+		return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, bd), errStatusUpdate)
+	}
+
+	if bd.Status.GetCondition(v1alpha1.TypeCompleted).Status == v1.ConditionTrue {
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("Reconciling")
+
+	ol := &v1alpha2.ObjectList{}
+	if err := r.client.List(ctx, ol, client.MatchingFields{config.ObjectProviderConfigIndex: bd.Spec.ProviderConfigReference.Name}); err != nil {
+		werr := errors.Wrap(err, errListObjects)
+		bd.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, bd)
+		return ctrl.Result{}, werr
+	}
+
+	var deleted, failed, pending atomic.Int64
+	g := &errgroup.Group{}
+	g.SetLimit(deleteConcurrency)
+	for i := range ol.Items {
+		o := &ol.Items[i]
+		if meta.WasDeleted(o) {
+			// Already deleted, waiting for its finalizer to be removed.
+			pending.Add(1)
+			continue
+		}
+		g.Go(func() error {
+			if err := r.delete(ctx, o, bd.Spec.DeletionPolicy); err != nil {
+				failed.Add(1)
+				return err
+			}
+			log.Debug("Deleted Object", "name", o.GetName())
+			deleted.Add(1)
+			return nil
+		})
+	}
+	err = g.Wait()
+
+	bd.Status.DeletedCount += deleted.Load()
+	bd.Status.FailedCount = failed.Load()
+	if err != nil {
+		bd.Status.SetConditions(xpv1.ReconcileError(err))
+		_ = r.client.Status().Update(ctx, bd)
+		return ctrl.Result{}, err
+	}
+
+	bd.Status.SetConditions(xpv1.ReconcileSuccess())
+	if remaining := deleted.Load() + pending.Load(); remaining > 0 {
+		// Deleted Objects are only gone once their finalizers are removed.
+		log.Debug("Waiting for deleted Objects to be gone", "remaining", remaining)
+		return ctrl.Result{RequeueAfter: waitInterval}, errors.Wrap(r.client.Status().Update(ctx, bd), errStatusUpdate)
+	}
+
+	bd.Status.SetConditions(v1alpha1.Completed(), xpv1.Available())
+	return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, bd), errStatusUpdate)
+}
+
+// delete sets the supplied deletion policy on the supplied Object and
+// deletes it.
+func (r *Reconciler) delete(ctx context.Context, o *v1alpha2.Object, policy xpv1.DeletionPolicy) error {
+	if policy != "" && o.Spec.DeletionPolicy != policy {
+		orig := o.DeepCopy()
+		o.Spec.DeletionPolicy = policy
+		if err := r.client.Patch(ctx, o, client.MergeFrom(orig)); err != nil {
+			return errors.Wrapf(resource.IgnoreNotFound(err), "%s %s", errSetDeletionPolicy, o.GetName())
+		}
+	}
+	return errors.Wrapf(resource.IgnoreNotFound(r.client.Delete(ctx, o)), "%s %s", errDeleteObject, o.GetName())
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bulkdelete
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/bulkdelete/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/config"
+)
+
+func TestReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := metav1.Now()
+
+	object := func(name string, deleting bool) v1alpha2.Object {
+		o := v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if deleting {
+			o.SetDeletionTimestamp(&now)
+		}
+		return o
+	}
+
+	type args struct {
+		getErr    error
+		completed bool
+		policy    xpv1.DeletionPolicy
+		listErr   error
+		objects   []v1alpha2.Object
+		deleteErr error
+	}
+	type want struct {
+		result    ctrl.Result
+		err       error
+		deleted   []string
+		orphaned  []string
+		status    *v1alpha1.BulkDeleteStatus
+		completed corev1.ConditionStatus
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotFound": {
+			reason: "We should not return an error if the BulkDelete was not found.",
+			args: args{
+				getErr: kerrors.NewNotFound(schema.GroupResource{}, ""),
+			},
+		},
+		"AlreadyCompleted": {
+			reason: "We should not list or delete any Objects once a BulkDelete completed.",
+			args: args{
+				completed: true,
+				listErr:   errBoom,
+			},
+		},
+		"ListError": {
+			reason: "We should return an error if the Objects cannot be listed.",
+			args: args{
+				listErr: errBoom,
+			},
+			want: want{
+				err:    errors.Wrap(errBoom, errListObjects),
+				status: &v1alpha1.BulkDeleteStatus{},
+			},
+		},
+		"DeleteError": {
+			reason: "We should count Objects that cannot be deleted and return an error to retry.",
+			args: args{
+				objects:   []v1alpha2.Object{object("a", false)},
+				deleteErr: errBoom,
+			},
+			want: want{
+				err:    errors.Wrapf(errBoom, "%s %s", errDeleteObject, "a"),
+				status: &v1alpha1.BulkDeleteStatus{FailedCount: 1},
+			},
+		},
+		"DeleteObjects": {
+			reason: "We should delete all Objects and wait for them to be gone, skipping those that are already deleting.",
+			args: args{
+				objects: []v1alpha2.Object{object("a", false), object("b", false), object("c", true)},
+			},
+			want: want{
+				result:    ctrl.Result{RequeueAfter: waitInterval},
+				deleted:   []string{"a", "b"},
+				status:    &v1alpha1.BulkDeleteStatus{DeletedCount: 2},
+				completed: corev1.ConditionUnknown,
+			},
+		},
+		"OrphanObjects": {
+			reason: "We should set the deletion policy of every Object before deleting it.",
+			args: args{
+				policy:  xpv1.DeletionOrphan,
+				objects: []v1alpha2.Object{object("a", false)},
+			},
+			want: want{
+				result:    ctrl.Result{RequeueAfter: waitInterval},
+				deleted:   []string{"a"},
+				orphaned:  []string{"a"},
+				status:    &v1alpha1.BulkDeleteStatus{DeletedCount: 1},
+				completed: corev1.ConditionUnknown,
+			},
+		},
+		"Completed": {
+			reason: "We should complete once no Objects use the ProviderConfig anymore.",
+			want: want{
+				status:    &v1alpha1.BulkDeleteStatus{},
+				completed: corev1.ConditionTrue,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var lock sync.Mutex
+			var deleted, orphaned []string
+			var status *v1alpha1.BulkDeleteStatus
+			var completed corev1.ConditionStatus
+
+			r := &Reconciler{
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						if tc.args.getErr != nil {
+							return tc.args.getErr
+						}
+						bd := obj.(*v1alpha1.BulkDelete)
+						bd.Name = key.Name
+						bd.Spec.ProviderConfigReference = xpv1.Reference{Name: "decommissioned"}
+						bd.Spec.DeletionPolicy = tc.args.policy
+						if tc.args.completed {
+							bd.Status.SetConditions(v1alpha1.Completed())
+						}
+						return nil
+					},
+					MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if got := lo.FieldSelector.String(); got != config.ObjectProviderConfigIndex+"=decommissioned" {
+							t.Errorf("unexpected field selector %q", got)
+						}
+						list.(*v1alpha2.ObjectList).Items = tc.args.objects
+						return tc.args.listErr
+					},
+					MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						lock.Lock()
+						defer lock.Unlock()
+						if p := obj.(*v1alpha2.Object).Spec.DeletionPolicy; p != xpv1.DeletionOrphan {
+							t.Errorf("unexpected deletion policy %q", p)
+						}
+						orphaned = append(orphaned, obj.GetName())
+						return nil
+					},
+					MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+						lock.Lock()
+						defer lock.Unlock()
+						if tc.args.deleteErr != nil {
+							return tc.args.deleteErr
+						}
+						deleted = append(deleted, obj.GetName())
+						return nil
+					},
+					MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+						bd := obj.(*v1alpha1.BulkDelete)
+						status = &v1alpha1.BulkDeleteStatus{DeletedCount: bd.Status.DeletedCount, FailedCount: bd.Status.FailedCount}
+						completed = bd.Status.GetCondition(v1alpha1.TypeCompleted).Status
+						return nil
+					},
+				},
+				log: logging.NewNopLogger(),
+			}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "bd"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got result:\n%s", tc.reason, diff)
+			}
+			sortStrings := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+			if diff := cmp.Diff(tc.want.deleted, deleted, sortStrings); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.orphaned, orphaned, sortStrings); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want orphaned, +got orphaned:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+			if tc.want.completed != "" && completed != tc.want.completed {
+				t.Errorf("\n%s\nr.Reconcile(...): want Completed condition %q, got %q", tc.reason, tc.want.completed, completed)
+			}
+		})
+	}
+}
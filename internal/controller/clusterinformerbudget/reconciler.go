@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterinformerbudget
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/clusterinformerbudget/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/object"
+)
+
+const (
+	errStatusUpdate        = "cannot update status"
+	errInvalidSelector     = "cannot parse provider config selector"
+	errListProviderConfigs = "cannot list provider configs"
+)
+
+// budgetRemaining is the number of GVKs each cluster may still watch
+// within a budget.
+var budgetRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "provider_kubernetes_informer_budget_remaining",
+	Help: "The number of GVKs a cluster may still watch within a ClusterInformerBudget.",
+}, []string{"budget", "cluster"})
+
+func init() {
+	metrics.Registry.MustRegister(budgetRemaining)
+}
+
+// Budgets limits and reports the GVKs watched per cluster.
+type Budgets interface {
+	SetBudget(name string, max int, providerConfigs []string)
+	RemoveBudget(name string)
+	Usage(providerConfig string) (object.BudgetUsage, bool)
+}
+
+// Reconciler watches for ClusterInformerBudget resources and limits the
+// GVKs watched in the clusters of the ProviderConfigs they select.
+type Reconciler struct {
+	client       client.Client
+	log          logging.Logger
+	budgets      Budgets
+	pollInterval func() time.Duration
+}
+
+// Setup adds a controller that reconciles ClusterInformerBudget resources.
+func Setup(mgr ctrl.Manager, o controller.Options, pollJitter time.Duration, budgets Budgets) error {
+	name := managed.ControllerName(v1alpha1.ClusterInformerBudgetGroupKind)
+
+	r := &Reconciler{
+		client:  mgr.GetClient(),
+		log:     o.Logger,
+		budgets: budgets,
+		pollInterval: func() time.Duration {
+			return o.PollInterval + time.Duration((rand.Float64()-0.5)*2*float64(pollJitter)) //nolint
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.ClusterInformerBudget{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{}),
+		)).
+		Watches(&apisv1alpha1.ProviderConfig{}, handler.EnqueueRequestsFromMapFunc(r.allBudgets), builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// allBudgets enqueues all ClusterInformerBudgets, as any of them may select
+// the supplied ProviderConfig.
+func (r *Reconciler) allBudgets(ctx context.Context, _ client.Object) []reconcile.Request {
+	l := &v1alpha1.ClusterInformerBudgetList{}
+	if err := r.client.List(ctx, l); err != nil {
+		r.log.Debug("cannot list cluster informer budgets", "error", err)
+		return nil
+	}
+	reqs := make([]reconcile.Request, 0, len(l.Items))
+	for _, b := range l.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: b.Name}})
+	}
+	return reqs
+}
+
+// Reconcile limits the clusters of the ProviderConfigs selected by a
+// ClusterInformerBudget and reports their usage of it.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, error error) {
+	log := r.log.WithValues("request", req)
+
+	defer func() {
+		if error == nil {
+			log.Info("Reconciled")
+		} else {
+			log.Info("Retry", "err", error)
+		}
+	}()
+
+	b := &v1alpha1.ClusterInformerBudget{}
+	err := r.client.Get(ctx, req.NamespacedName, b)
+
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			r.removeBudget(req.Name)
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if meta.WasDeleted(b) {
+		r.removeBudget(b.Name)
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsPaused(b) {
+		b.Status.SetConditions(xpv1.ReconcilePaused())
+		return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, b), errStatusUpdate)
+	}
+
+	log.Info("Reconciling")
+
+	sel, err := metav1.LabelSelectorAsSelector(&b.Spec.ProviderConfigSelector)
+	if err != nil {
+		werr := errors.Wrap(err, errInvalidSelector)
+		b.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, b)
+		return ctrl.Result{}, werr
+	}
+	pcl := &apisv1alpha1.ProviderConfigList{}
+	if err := r.client.List(ctx, pcl, client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		werr := errors.Wrap(err, errListProviderConfigs)
+		b.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, b)
+		return ctrl.Result{}, werr
+	}
+
+	pcs := make([]string, 0, len(pcl.Items))
+	for _, pc := range pcl.Items {
+		pcs = append(pcs, pc.Name)
+	}
+	sort.Strings(pcs)
+	r.budgets.SetBudget(b.Name, b.Spec.MaxGVKsPerCluster, pcs)
+
+	b.Status.Clusters = clusterUsages(r.budgets, pcs)
+	budgetRemaining.DeletePartialMatch(prometheus.Labels{"budget": b.Name})
+	var exhausted []string
+	for _, c := range b.Status.Clusters {
+		remaining := b.Spec.MaxGVKsPerCluster - c.GVKs
+		budgetRemaining.WithLabelValues(b.Name, c.Cluster).Set(float64(remaining))
+		if remaining <= 0 {
+			exhausted = append(exhausted, fmt.Sprintf("%s watches %d GVKs", c.Cluster, c.GVKs))
+		}
+	}
+
+	b.Status.SetConditions(xpv1.ReconcileSuccess())
+	if len(exhausted) > 0 {
+		b.Status.SetConditions(v1alpha1.BudgetExceeded(fmt.Sprintf("budget of %d GVKs per cluster exhausted: %s", b.Spec.MaxGVKsPerCluster, strings.Join(exhausted, ", "))))
+	} else {
+		b.Status.SetConditions(v1alpha1.WithinBudget())
+	}
+
+	// The usage changes as resources are watched and informers cleaned up,
+	// without any events for the budget.
+	return ctrl.Result{RequeueAfter: r.pollInterval()}, errors.Wrap(r.client.Status().Update(ctx, b), errStatusUpdate)
+}
+
+func (r *Reconciler) removeBudget(name string) {
+	r.budgets.RemoveBudget(name)
+	budgetRemaining.DeletePartialMatch(prometheus.Labels{"budget": name})
+}
+
+// clusterUsages groups the supplied provider configs by the cluster they
+// point to, skipping those that did not watch any resources yet.
+func clusterUsages(budgets Budgets, providerConfigs []string) []v1alpha1.ClusterUsage {
+	byIdentity := map[string]*v1alpha1.ClusterUsage{}
+	var order []string
+	for _, pc := range providerConfigs {
+		u, ok := budgets.Usage(pc)
+		if !ok {
+			continue
+		}
+		c, ok := byIdentity[u.Identity]
+		if !ok {
+			c = &v1alpha1.ClusterUsage{Cluster: u.Cluster, GVKs: u.GVKs}
+			byIdentity[u.Identity] = c
+			order = append(order, u.Identity)
+		}
+		c.ProviderConfigs = append(c.ProviderConfigs, pc)
+	}
+	out := make([]v1alpha1.ClusterUsage, 0, len(order))
+	for _, id := range order {
+		out = append(out, *byIdentity[id])
+	}
+	return out
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterinformerbudget
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/clusterinformerbudget/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/object"
+)
+
+type fakeBudgets struct {
+	// limits of provider configs by budget.
+	limits map[string]map[string]int
+	usage  map[string]object.BudgetUsage
+}
+
+func (f *fakeBudgets) SetBudget(name string, max int, providerConfigs []string) {
+	f.limits[name] = map[string]int{}
+	for _, pc := range providerConfigs {
+		f.limits[name][pc] = max
+	}
+}
+
+func (f *fakeBudgets) RemoveBudget(name string) {
+	delete(f.limits, name)
+}
+
+func (f *fakeBudgets) Usage(providerConfig string) (object.BudgetUsage, bool) {
+	u, ok := f.usage[providerConfig]
+	return u, ok
+}
+
+func TestReconciler(t *testing.T) {
+	budgetName := types.NamespacedName{Name: "budget"}
+	errBoom := fmt.Errorf("error reading")
+	pollInterval := 10 * time.Second
+
+	getBudget := func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		b := obj.(*v1alpha1.ClusterInformerBudget)
+		b.Name = key.Name
+		b.Spec.MaxGVKsPerCluster = 2
+		return nil
+	}
+	listProviderConfigs := func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		l := list.(*apisv1alpha1.ProviderConfigList)
+		for _, n := range []string{"a", "b", "idle"} {
+			l.Items = append(l.Items, apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: n}})
+		}
+		return nil
+	}
+
+	type args struct {
+		client *test.MockClient
+		usage  map[string]object.BudgetUsage
+	}
+	type want struct {
+		r        reconcile.Result
+		err      error
+		limits   map[string]map[string]int
+		clusters []v1alpha1.ClusterUsage
+		exceeded corev1.ConditionStatus
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ErrorGetBudget": {
+			reason: "We should return error.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			want: want{
+				err:    errBoom,
+				limits: map[string]map[string]int{"budget": {"a": 1}},
+			},
+		},
+		"BudgetNotFound": {
+			reason: "We should lift the limits of a budget that was deleted.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				},
+			},
+			want: want{
+				limits: map[string]map[string]int{},
+			},
+		},
+		"ErrorListProviderConfigs": {
+			reason: "We should return an error if the provider configs cannot be listed.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          getBudget,
+					MockList:         test.NewMockListFn(errBoom),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+			},
+			want: want{
+				err:    errors.Wrap(errBoom, errListProviderConfigs),
+				limits: map[string]map[string]int{"budget": {"a": 1}},
+			},
+		},
+		"BudgetExceeded": {
+			reason: "We should limit the selected provider configs and report the clusters that exhausted the budget.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          getBudget,
+					MockList:         listProviderConfigs,
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				usage: map[string]object.BudgetUsage{
+					"a": {Cluster: "https://one", Identity: "one", GVKs: 2},
+					"b": {Cluster: "https://one", Identity: "one", GVKs: 2},
+				},
+			},
+			want: want{
+				r:      reconcile.Result{RequeueAfter: pollInterval},
+				limits: map[string]map[string]int{"budget": {"a": 2, "b": 2, "idle": 2}},
+				clusters: []v1alpha1.ClusterUsage{
+					{Cluster: "https://one", ProviderConfigs: []string{"a", "b"}, GVKs: 2},
+				},
+				exceeded: corev1.ConditionTrue,
+			},
+		},
+		"WithinBudget": {
+			reason: "We should report clusters that may watch further GVKs.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          getBudget,
+					MockList:         listProviderConfigs,
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				usage: map[string]object.BudgetUsage{
+					"a": {Cluster: "https://one", Identity: "one", GVKs: 1},
+					"b": {Cluster: "https://two", Identity: "two", GVKs: 0},
+				},
+			},
+			want: want{
+				r:      reconcile.Result{RequeueAfter: pollInterval},
+				limits: map[string]map[string]int{"budget": {"a": 2, "b": 2, "idle": 2}},
+				clusters: []v1alpha1.ClusterUsage{
+					{Cluster: "https://one", ProviderConfigs: []string{"a"}, GVKs: 1},
+					{Cluster: "https://two", ProviderConfigs: []string{"b"}},
+				},
+				exceeded: corev1.ConditionFalse,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var status *v1alpha1.ClusterInformerBudget
+			if tc.args.client.MockStatusUpdate != nil {
+				mock := tc.args.client.MockStatusUpdate
+				tc.args.client.MockStatusUpdate = func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					status = obj.(*v1alpha1.ClusterInformerBudget).DeepCopy()
+					return mock(ctx, obj, opts...)
+				}
+			}
+			budgets := &fakeBudgets{limits: map[string]map[string]int{"budget": {"a": 1}}, usage: tc.args.usage}
+			r := &Reconciler{
+				client:  tc.args.client,
+				log:     logging.NewNopLogger(),
+				budgets: budgets,
+				pollInterval: func() time.Duration {
+					return pollInterval
+				},
+			}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: budgetName})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.limits, budgets.limits); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want limits, +got limits:\n%s", tc.reason, diff)
+			}
+			if tc.want.exceeded == "" {
+				return
+			}
+			if diff := cmp.Diff(tc.want.clusters, status.Status.Clusters); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want clusters, +got clusters:\n%s", tc.reason, diff)
+			}
+			if c := status.Status.GetCondition(v1alpha1.TypeBudgetExceeded); c.Status != tc.want.exceeded {
+				t.Errorf("\n%s\nr.Reconcile(...): want BudgetExceeded %s, got %v", tc.reason, tc.want.exceeded, c)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+)
+
+const (
+	errAccessClient = "cannot create client for cluster"
+	errReviewAccess = "cannot review access"
+)
+
+// An AccessReviewReconciler checks whether the kubeconfig of a ProviderConfig
+// grants the permissions listed in its access review.
+type AccessReviewReconciler struct {
+	client       client.Client
+	log          logging.Logger
+	pollInterval time.Duration
+
+	clientFor func(ctx context.Context, local client.Client, providerConfigName string) (client.Client, *rest.Config, error)
+}
+
+func setupAccessReviewReconciler(mgr ctrl.Manager, o controller.Options) error {
+	name := "accessreview/" + strings.ToLower(v1alpha1.ProviderConfigGroupKind)
+
+	r := &AccessReviewReconciler{
+		client:       mgr.GetClient(),
+		log:          o.Logger.WithValues("controller", name),
+		pollInterval: o.PollInterval,
+		clientFor:    kube.ClientForProvider,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(providerConfigsForExternalSecret(r.client, r.log))).
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(providerConfigsForService(r.client, r.log)), builder.WithPredicates(serviceEndpointChanged)).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// Reconcile reviews every permission listed in the access review of a
+// ProviderConfig with a SelfSubjectAccessReview on its cluster, and records
+// the denied ones in its InsufficientPermissions condition. Permissions are
+// reviewed again after the poll interval, since RBAC may change at any time.
+func (r *AccessReviewReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("request", req)
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.client.Get(ctx, req.NamespacedName, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Keep the condition of a ProviderConfig whose access review was removed
+	// up to date, but don't add it to those that never had one.
+	if len(pc.Spec.AccessReview) == 0 && pc.Status.GetCondition(v1alpha1.TypeInsufficientPermissions).Reason == "" {
+		return ctrl.Result{}, nil
+	}
+
+	kc, _, err := r.clientFor(ctx, r.client, pc.GetName())
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, errAccessClient)
+	}
+
+	var denied []string
+	for _, p := range pc.Spec.AccessReview {
+		allowed, err := reviewAccess(ctx, kc, p)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "%s %s", errReviewAccess, permissionString(p))
+		}
+		if !allowed {
+			denied = append(denied, permissionString(p))
+		}
+	}
+
+	orig := pc.DeepCopy()
+	c := v1alpha1.SufficientPermissions()
+	if len(denied) > 0 {
+		log.Info("Kubeconfig lacks permissions", "denied", denied)
+		c = v1alpha1.InsufficientPermissions(denied)
+	}
+	pc.Status.SetConditions(c)
+	return ctrl.Result{RequeueAfter: r.pollInterval}, errors.Wrap(r.client.Status().Patch(ctx, pc, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{})), errStatusPatch)
+}
+
+// reviewAccess returns whether the identity of the supplied client has the
+// supplied permission.
+func reviewAccess(ctx context.Context, kc client.Client, p v1alpha1.AccessReviewPermission) (bool, error) {
+	sar := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:     p.APIGroup,
+				Resource:  p.Resource,
+				Verb:      p.Verb,
+				Namespace: p.Namespace,
+			},
+		},
+	}
+	if err := kc.Create(ctx, sar); err != nil {
+		return false, err
+	}
+	return sar.Status.Allowed, nil
+}
+
+// permissionString returns a human readable form of a permission, e.g.
+// "create deployments.apps in namespace default".
+func permissionString(p v1alpha1.AccessReviewPermission) string {
+	r := p.Resource
+	if p.APIGroup != "" {
+		r += "." + p.APIGroup
+	}
+	if p.Namespace == "" {
+		return p.Verb + " " + r + " cluster-wide"
+	}
+	return p.Verb + " " + r + " in namespace " + p.Namespace
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+func TestAccessReviewReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	pollInterval := time.Minute
+
+	deployments := v1alpha1.AccessReviewPermission{APIGroup: "apps", Resource: "deployments", Verb: "create", Namespace: "default"}
+	namespaces := v1alpha1.AccessReviewPermission{Resource: "namespaces", Verb: "list"}
+
+	type args struct {
+		review    []v1alpha1.AccessReviewPermission
+		condition bool
+		allowed   map[string]bool
+		reviewErr error
+	}
+	type want struct {
+		result  ctrl.Result
+		err     error
+		patched bool
+		status  corev1.ConditionStatus
+		message string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoAccessReview": {
+			reason: "We should not review anything if the ProviderConfig has no access review.",
+		},
+		"AccessReviewRemoved": {
+			reason: "We should clear a previously reported condition once the access review was removed.",
+			args: args{
+				condition: true,
+			},
+			want: want{
+				result:  ctrl.Result{RequeueAfter: pollInterval},
+				patched: true,
+				status:  corev1.ConditionFalse,
+			},
+		},
+		"ReviewError": {
+			reason: "We should return an error if a permission cannot be reviewed.",
+			args: args{
+				review:    []v1alpha1.AccessReviewPermission{deployments},
+				reviewErr: errBoom,
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errReviewAccess+" create deployments.apps in namespace default"),
+			},
+		},
+		"Granted": {
+			reason: "We should report sufficient permissions if every permission is granted.",
+			args: args{
+				review:  []v1alpha1.AccessReviewPermission{deployments, namespaces},
+				allowed: map[string]bool{"deployments": true, "namespaces": true},
+			},
+			want: want{
+				result:  ctrl.Result{RequeueAfter: pollInterval},
+				patched: true,
+				status:  corev1.ConditionFalse,
+			},
+		},
+		"Denied": {
+			reason: "We should list every denied permission in the InsufficientPermissions condition.",
+			args: args{
+				review:  []v1alpha1.AccessReviewPermission{deployments, namespaces},
+				allowed: map[string]bool{"deployments": true},
+			},
+			want: want{
+				result:  ctrl.Result{RequeueAfter: pollInterval},
+				patched: true,
+				status:  corev1.ConditionTrue,
+				message: "Denied: list namespaces cluster-wide",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var patched bool
+			var status corev1.ConditionStatus
+			var message string
+			kc := &test.MockClient{
+				MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					sar := obj.(*authorizationv1.SelfSubjectAccessReview)
+					sar.Status.Allowed = tc.args.allowed[sar.Spec.ResourceAttributes.Resource]
+					return tc.args.reviewErr
+				},
+			}
+			r := &AccessReviewReconciler{
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						pc := obj.(*v1alpha1.ProviderConfig)
+						pc.SetName(key.Name)
+						pc.Spec.AccessReview = tc.args.review
+						if tc.args.condition {
+							pc.Status.SetConditions(v1alpha1.InsufficientPermissions([]string{"get secrets cluster-wide"}))
+						}
+						return nil
+					},
+					MockStatusPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+						c := obj.(*v1alpha1.ProviderConfig).Status.GetCondition(v1alpha1.TypeInsufficientPermissions)
+						patched, status, message = true, c.Status, c.Message
+						return nil
+					},
+				},
+				log:          logging.NewNopLogger(),
+				pollInterval: pollInterval,
+				clientFor: func(_ context.Context, _ client.Client, _ string) (client.Client, *rest.Config, error) {
+					return kc, &rest.Config{}, nil
+				},
+			}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "pc"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got result:\n%s", tc.reason, diff)
+			}
+			if patched != tc.want.patched || status != tc.want.status || message != tc.want.message {
+				t.Errorf("\n%s\nr.Reconcile(...): want patched %t with InsufficientPermissions %q %q, got %t with %q %q", tc.reason, tc.want.patched, tc.want.status, tc.want.message, patched, status, message)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+)
+
+const (
+	errGetCreds           = "cannot get credentials"
+	errLoadKubeconfig     = "cannot load kubeconfig"
+	errParseCertificate   = "cannot parse client certificate"
+	errNoCurrentContext   = "currentContext not set in kubeconfig"
+	errCertificateExpires = "client certificate of the kubeconfig expires at"
+	errStatusUpdate       = "cannot update status"
+)
+
+// expiryBoundaries at which a ProviderConfig's client certificate is reported
+// to be expiring, from the closest to the farthest one.
+var expiryBoundaries = []struct {
+	within time.Duration
+	reason xpv1.ConditionReason
+}{
+	{within: 0, reason: v1alpha1.ReasonCertificateExpired},
+	{within: 24 * time.Hour, reason: v1alpha1.ReasonExpiresWithin1Day},
+	{within: 7 * 24 * time.Hour, reason: v1alpha1.ReasonExpiresWithin7Days},
+	{within: 30 * 24 * time.Hour, reason: v1alpha1.ReasonExpiresWithin30Days},
+}
+
+// A CertificateReconciler checks when the client certificate of a
+// ProviderConfig's kubeconfig expires.
+type CertificateReconciler struct {
+	client       client.Client
+	log          logging.Logger
+	record       event.Recorder
+	pollInterval time.Duration
+	now          func() time.Time
+}
+
+func setupCertificateReconciler(mgr ctrl.Manager, o controller.Options) error {
+	name := "certificate/" + strings.ToLower(v1alpha1.ProviderConfigGroupKind)
+
+	r := &CertificateReconciler{
+		client:       mgr.GetClient(),
+		log:          o.Logger.WithValues("controller", name),
+		record:       event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+		pollInterval: o.PollInterval,
+		now:          time.Now,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(providerConfigsForExternalSecret(r.client, r.log))).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// Reconcile sets the certificate expiry conditions of a ProviderConfig and
+// emits an event whenever another expiry boundary is crossed. The
+// ProviderConfig is checked again once the next boundary is reached, or after
+// the poll interval to notice rotated credentials.
+func (r *CertificateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("request", req)
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.client.Get(ctx, req.NamespacedName, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	cd := pc.Spec.Credentials
+	if cd.Source == xpv1.CredentialsSourceInjectedIdentity || cd.Source == xpv1.CredentialsSourceNone {
+		return ctrl.Result{}, nil
+	}
+	kc, err := kube.Kubeconfig(ctx, r.client, cd)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, errGetCreds)
+	}
+	notAfter, ok, err := clientCertificateExpiry(kc, cd.Context)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !ok {
+		// The kubeconfig authenticates without a client certificate, e.g.
+		// using a token.
+		return ctrl.Result{RequeueAfter: r.pollInterval}, nil
+	}
+
+	previous := expiryReason(pc)
+	remaining := notAfter.Sub(r.now())
+	reason := v1alpha1.ReasonCertificateValid
+	for _, b := range expiryBoundaries {
+		if remaining <= b.within {
+			reason = b.reason
+			break
+		}
+	}
+
+	switch reason { //nolint:exhaustive
+	case v1alpha1.ReasonCertificateValid:
+		pc.Status.SetConditions(v1alpha1.CertificateNotExpiringSoon(reason, notAfter), v1alpha1.CertificateNotExpired(notAfter))
+	case v1alpha1.ReasonCertificateExpired:
+		pc.Status.SetConditions(v1alpha1.CertificateNotExpiringSoon(reason, notAfter), v1alpha1.CertificateExpired(notAfter))
+	default:
+		pc.Status.SetConditions(v1alpha1.CertificateExpiringSoon(reason, notAfter), v1alpha1.CertificateNotExpired(notAfter))
+	}
+
+	if reason != previous && reason != v1alpha1.ReasonCertificateValid {
+		log.Info("Client certificate is expiring", "reason", reason, "notAfter", notAfter)
+		r.record.Event(pc, event.Warning(event.Reason(reason), errors.Errorf("%s %s", errCertificateExpires, notAfter.UTC().Format(time.RFC3339))))
+	}
+
+	return ctrl.Result{RequeueAfter: r.requeueAfter(remaining)}, errors.Wrap(r.client.Status().Update(ctx, pc), errStatusUpdate)
+}
+
+// requeueAfter returns when the next expiry boundary is crossed, at most
+// after the poll interval.
+func (r *CertificateReconciler) requeueAfter(remaining time.Duration) time.Duration {
+	after := r.pollInterval
+	for _, b := range expiryBoundaries {
+		if d := remaining - b.within; d > 0 && d < after {
+			after = d
+		}
+	}
+	return after
+}
+
+// expiryReason returns the reason of the last expiry boundary crossed by
+// the client certificate of the supplied ProviderConfig, as recorded in its
+// CertificateExpiringSoon condition.
+func expiryReason(pc *v1alpha1.ProviderConfig) xpv1.ConditionReason {
+	if c := pc.Status.GetCondition(v1alpha1.TypeCertificateExpiringSoon); c.Reason != "" {
+		return c.Reason
+	}
+	return v1alpha1.ReasonCertificateValid
+}
+
+// clientCertificateExpiry returns when the client certificate of the named
+// context, or the current context if no name is supplied, of the supplied
+// kubeconfig expires. It returns false if the kubeconfig does not embed a
+// client certificate.
+func clientCertificateExpiry(kubeconfig []byte, name string) (time.Time, bool, error) {
+	ac, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return time.Time{}, false, errors.Wrap(err, errLoadKubeconfig)
+	}
+	if name == "" {
+		if ac.CurrentContext == "" {
+			return time.Time{}, false, errors.New(errNoCurrentContext)
+		}
+		name = ac.CurrentContext
+	}
+	ctx, ok := ac.Contexts[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	user, ok := ac.AuthInfos[ctx.AuthInfo]
+	if !ok || len(user.ClientCertificateData) == 0 {
+		return time.Time{}, false, nil
+	}
+	b, _ := pem.Decode(user.ClientCertificateData)
+	if b == nil {
+		return time.Time{}, false, errors.New(errParseCertificate)
+	}
+	cert, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		return time.Time{}, false, errors.Wrap(err, errParseCertificate)
+	}
+	return cert.NotAfter, true, nil
+}
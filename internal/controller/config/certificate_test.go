@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+type recorder struct {
+	reasons []event.Reason
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+func (r *recorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
+func kubeconfigWithCertificate(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "provider-kubernetes"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("cannot create certificate: %v", err)
+	}
+	kc, err := clientcmd.Write(api.Config{
+		CurrentContext: "default",
+		Contexts:       map[string]*api.Context{"default": {Cluster: "default", AuthInfo: "default"}},
+		Clusters:       map[string]*api.Cluster{"default": {Server: "https://example.org"}},
+		AuthInfos: map[string]*api.AuthInfo{"default": {
+			ClientCertificateData: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("cannot write kubeconfig: %v", err)
+	}
+	return kc
+}
+
+func TestCertificateReconciler(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	pollInterval := 10 * time.Minute
+
+	type args struct {
+		notAfter time.Time
+		previous []xpv1.Condition
+	}
+	type want struct {
+		r       ctrl.Result
+		soon    xpv1.ConditionReason
+		expired corev1.ConditionStatus
+		events  []event.Reason
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Valid": {
+			reason: "We should not emit an event for a certificate that does not expire soon.",
+			args: args{
+				notAfter: now.Add(90 * 24 * time.Hour),
+			},
+			want: want{
+				r:       ctrl.Result{RequeueAfter: pollInterval},
+				soon:    v1alpha1.ReasonCertificateValid,
+				expired: corev1.ConditionFalse,
+			},
+		},
+		"CrossedBoundary": {
+			reason: "We should emit an event once a certificate crosses an expiry boundary.",
+			args: args{
+				notAfter: now.Add(6 * 24 * time.Hour),
+				previous: []xpv1.Condition{v1alpha1.CertificateExpiringSoon(v1alpha1.ReasonExpiresWithin30Days, now.Add(6*24*time.Hour))},
+			},
+			want: want{
+				r:       ctrl.Result{RequeueAfter: pollInterval},
+				soon:    v1alpha1.ReasonExpiresWithin7Days,
+				expired: corev1.ConditionFalse,
+				events:  []event.Reason{event.Reason(v1alpha1.ReasonExpiresWithin7Days)},
+			},
+		},
+		"BoundaryAlreadyReported": {
+			reason: "We should not emit another event for a boundary that was already reported.",
+			args: args{
+				notAfter: now.Add(20 * 24 * time.Hour),
+				previous: []xpv1.Condition{v1alpha1.CertificateExpiringSoon(v1alpha1.ReasonExpiresWithin30Days, now.Add(20*24*time.Hour))},
+			},
+			want: want{
+				r:       ctrl.Result{RequeueAfter: pollInterval},
+				soon:    v1alpha1.ReasonExpiresWithin30Days,
+				expired: corev1.ConditionFalse,
+			},
+		},
+		"RequeueAtNextBoundary": {
+			reason: "We should check the certificate again when it crosses the next boundary.",
+			args: args{
+				notAfter: now.Add(24*time.Hour + time.Minute),
+			},
+			want: want{
+				r:       ctrl.Result{RequeueAfter: time.Minute},
+				soon:    v1alpha1.ReasonExpiresWithin7Days,
+				expired: corev1.ConditionFalse,
+				events:  []event.Reason{event.Reason(v1alpha1.ReasonExpiresWithin7Days)},
+			},
+		},
+		"Expired": {
+			reason: "We should set the CertificateExpired condition once a certificate expired.",
+			args: args{
+				notAfter: now.Add(-time.Hour),
+			},
+			want: want{
+				r:       ctrl.Result{RequeueAfter: pollInterval},
+				soon:    v1alpha1.ReasonCertificateExpired,
+				expired: corev1.ConditionTrue,
+				events:  []event.Reason{event.Reason(v1alpha1.ReasonCertificateExpired)},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kc := kubeconfigWithCertificate(t, tc.args.notAfter)
+			var status v1alpha1.ProviderConfigStatus
+			rec := &recorder{}
+			r := &CertificateReconciler{
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						switch o := obj.(type) {
+						case *v1alpha1.ProviderConfig:
+							o.Name = key.Name
+							o.Spec.Credentials.Source = xpv1.CredentialsSourceSecret
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "kubeconfig"},
+								Key:             "kubeconfig",
+							}
+							o.Status.SetConditions(tc.args.previous...)
+						case *corev1.Secret:
+							o.Data = map[string][]byte{"kubeconfig": kc}
+						}
+						return nil
+					},
+					MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+						status = obj.(*v1alpha1.ProviderConfig).Status
+						return nil
+					},
+				},
+				log:          logging.NewNopLogger(),
+				record:       rec,
+				pollInterval: pollInterval,
+				now:          func() time.Time { return now },
+			}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+			if err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got:\n%s", tc.reason, diff)
+			}
+			if got := status.GetCondition(v1alpha1.TypeCertificateExpiringSoon).Reason; got != tc.want.soon {
+				t.Errorf("\n%s\nr.Reconcile(...): want %s reason %q, got %q", tc.reason, v1alpha1.TypeCertificateExpiringSoon, tc.want.soon, got)
+			}
+			if got := status.GetCondition(v1alpha1.TypeCertificateExpired).Status; got != tc.want.expired {
+				t.Errorf("\n%s\nr.Reconcile(...): want %s status %q, got %q", tc.reason, v1alpha1.TypeCertificateExpired, tc.want.expired, got)
+			}
+			if diff := cmp.Diff(tc.want.events, rec.reasons); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want events, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
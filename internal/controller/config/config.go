@@ -29,7 +29,10 @@ import (
 )
 
 // Setup adds a controller that reconciles ProviderConfigs by accounting for
-// their current usage.
+// their current usage, one that checks when their client certificates
+// expire, one that counts the Objects using them, one that probes whether
+// their clusters are reachable, and one that reviews whether their
+// kubeconfigs grant the expected permissions.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := providerconfig.ControllerName(v1alpha1.ProviderConfigGroupKind)
 
@@ -42,10 +45,26 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		providerconfig.WithLogger(o.Logger.WithValues("controller", name)),
 		providerconfig.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
 
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.ProviderConfig{}).
 		Watches(&v1alpha1.ProviderConfigUsage{}, &resource.EnqueueRequestForProviderConfig{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter)); err != nil {
+		return err
+	}
+
+	if err := setupCertificateReconciler(mgr, o); err != nil {
+		return err
+	}
+	if err := setupUsageCountReconciler(mgr, o); err != nil {
+		return err
+	}
+	if err := setupErrorRateReconciler(mgr, o); err != nil {
+		return err
+	}
+	if err := setupProbeReconciler(mgr, o); err != nil {
+		return err
+	}
+	return setupAccessReviewReconciler(mgr, o)
 }
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+const (
+	errErrorRateHigh = "more than 10% of the Objects using the ProviderConfig failed to sync"
+
+	// errorRateInterval is how often the error rate of the Objects using a
+	// ProviderConfig is sampled.
+	errorRateInterval = time.Minute
+)
+
+// An ErrorRateReconciler reports the share of Objects using a ProviderConfig
+// that failed to sync through its ErrorRate condition.
+type ErrorRateReconciler struct {
+	client client.Client
+	log    logging.Logger
+	record event.Recorder
+}
+
+// setupErrorRateReconciler relies on the ObjectProviderConfigIndex registered
+// by setupUsageCountReconciler.
+func setupErrorRateReconciler(mgr ctrl.Manager, o controller.Options) error {
+	name := "errorrate/" + strings.ToLower(v1alpha1.ProviderConfigGroupKind)
+
+	r := &ErrorRateReconciler{
+		client: mgr.GetClient(),
+		log:    o.Logger.WithValues("controller", name),
+		record: event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// Reconcile samples the share of Objects using a ProviderConfig whose Synced
+// condition is false, and samples it again after the error rate interval. A
+// warning is emitted whenever the error rate becomes high.
+func (r *ErrorRateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("request", req)
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.client.Get(ctx, req.NamespacedName, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	l := &v1alpha2.ObjectList{}
+	if err := r.client.List(ctx, l, client.MatchingFields{ObjectProviderConfigIndex: pc.GetName()}); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, errListObjects)
+	}
+
+	failed := 0
+	for i := range l.Items {
+		if l.Items[i].Status.GetCondition(xpv1.TypeSynced).Status == corev1.ConditionFalse {
+			failed++
+		}
+	}
+	total := len(l.Items)
+
+	want := v1alpha1.ErrorRate(errorRateReason(failed, total), failed, total)
+	prev := pc.Status.GetCondition(v1alpha1.TypeErrorRate)
+	if prev.Equal(want) {
+		return ctrl.Result{RequeueAfter: errorRateInterval}, nil
+	}
+
+	if want.Reason == v1alpha1.ReasonErrorRateHigh && prev.Reason != v1alpha1.ReasonErrorRateHigh {
+		log.Info("Error rate of Objects using the ProviderConfig is high", "failed", failed, "total", total)
+		r.record.Event(pc, event.Warning(event.Reason(v1alpha1.TypeErrorRate), errors.Errorf("%s: %s", errErrorRateHigh, want.Message)))
+	}
+
+	orig := pc.DeepCopy()
+	pc.Status.SetConditions(want)
+	log.Debug("Sampled error rate of Objects using the ProviderConfig", "failed", failed, "total", total)
+	return ctrl.Result{RequeueAfter: errorRateInterval}, errors.Wrap(r.client.Status().Patch(ctx, pc, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{})), errStatusPatch)
+}
+
+// errorRateReason returns the reason of the ErrorRate condition for the
+// supplied number of failed Objects out of all Objects: high above 10%,
+// medium from 1% to 10%, and low below 1%.
+func errorRateReason(failed, total int) xpv1.ConditionReason {
+	switch {
+	case failed == 0 || total == 0:
+		return v1alpha1.ReasonErrorRateNone
+	case failed*10 > total:
+		return v1alpha1.ReasonErrorRateHigh
+	case failed*100 >= total:
+		return v1alpha1.ReasonErrorRateMedium
+	default:
+		return v1alpha1.ReasonErrorRateLow
+	}
+}
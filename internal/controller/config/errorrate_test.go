@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+func TestErrorRateReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		previous xpv1.ConditionReason
+		objects  int
+		failed   int
+		listErr  error
+		getErr   error
+	}
+	type want struct {
+		result ctrl.Result
+		err    error
+		reason xpv1.ConditionReason
+		events []event.Reason
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotFound": {
+			reason: "We should not return an error if the ProviderConfig was deleted.",
+			args: args{
+				getErr: kerrors.NewNotFound(schema.GroupResource{}, "pc"),
+			},
+		},
+		"ListError": {
+			reason: "We should return an error if the Objects cannot be listed.",
+			args: args{
+				listErr: errBoom,
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListObjects),
+			},
+		},
+		"None": {
+			reason: "We should report no error rate if no Object failed.",
+			args: args{
+				objects: 3,
+			},
+			want: want{
+				result: ctrl.Result{RequeueAfter: errorRateInterval},
+				reason: v1alpha1.ReasonErrorRateNone,
+			},
+		},
+		"Low": {
+			reason: "We should report a low error rate if less than 1% of Objects failed.",
+			args: args{
+				objects: 200,
+				failed:  1,
+			},
+			want: want{
+				result: ctrl.Result{RequeueAfter: errorRateInterval},
+				reason: v1alpha1.ReasonErrorRateLow,
+			},
+		},
+		"Medium": {
+			reason: "We should report a medium error rate if 1% to 10% of Objects failed.",
+			args: args{
+				objects: 10,
+				failed:  1,
+			},
+			want: want{
+				result: ctrl.Result{RequeueAfter: errorRateInterval},
+				reason: v1alpha1.ReasonErrorRateMedium,
+			},
+		},
+		"BecameHigh": {
+			reason: "We should report a high error rate and emit an event if more than 10% of Objects failed.",
+			args: args{
+				previous: v1alpha1.ReasonErrorRateLow,
+				objects:  4,
+				failed:   2,
+			},
+			want: want{
+				result: ctrl.Result{RequeueAfter: errorRateInterval},
+				reason: v1alpha1.ReasonErrorRateHigh,
+				events: []event.Reason{event.Reason(v1alpha1.TypeErrorRate)},
+			},
+		},
+		"StillHigh": {
+			reason: "We should not emit another event while the error rate stays high.",
+			args: args{
+				previous: v1alpha1.ReasonErrorRateHigh,
+				objects:  4,
+				failed:   3,
+			},
+			want: want{
+				result: ctrl.Result{RequeueAfter: errorRateInterval},
+				reason: v1alpha1.ReasonErrorRateHigh,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var reason xpv1.ConditionReason
+			rec := &recorder{}
+			r := &ErrorRateReconciler{
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						if tc.args.getErr != nil {
+							return tc.args.getErr
+						}
+						pc := obj.(*v1alpha1.ProviderConfig)
+						pc.Name = key.Name
+						if tc.args.previous != "" {
+							pc.Status.SetConditions(v1alpha1.ErrorRate(tc.args.previous, 0, 0))
+						}
+						return nil
+					},
+					MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if got := lo.FieldSelector.String(); got != ObjectProviderConfigIndex+"=pc" {
+							t.Errorf("unexpected field selector %q", got)
+						}
+						l := list.(*v1alpha2.ObjectList)
+						l.Items = make([]v1alpha2.Object, tc.args.objects)
+						for i := 0; i < tc.args.failed; i++ {
+							l.Items[i].Status.SetConditions(xpv1.ReconcileError(errBoom))
+						}
+						return tc.args.listErr
+					},
+					MockStatusPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+						reason = obj.(*v1alpha1.ProviderConfig).Status.GetCondition(v1alpha1.TypeErrorRate).Reason
+						return nil
+					},
+				},
+				log:    logging.NewNopLogger(),
+				record: rec,
+			}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "pc"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got result:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, reason); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want reason, +got reason:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, rec.reasons); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
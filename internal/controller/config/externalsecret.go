@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+// providerConfigsForExternalSecret returns a function that maps a Secret to
+// the ProviderConfigs reading their kubeconfig from it, so that they are
+// reconciled again once the External Secrets Operator rotated it.
+func providerConfigsForExternalSecret(c client.Reader, log logging.Logger) handler.MapFunc {
+	return func(ctx context.Context, s client.Object) []reconcile.Request {
+		l := &v1alpha1.ProviderConfigList{}
+		if err := c.List(ctx, l); err != nil {
+			log.Debug("cannot list provider configs", "error", err)
+			return nil
+		}
+		var reqs []reconcile.Request
+		for _, pc := range l.Items {
+			if ref := pc.Spec.Credentials.ExternalSecretRef; ref != nil && ref.Namespace == s.GetNamespace() && ref.Name == s.GetName() {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: pc.Name}})
+			}
+		}
+		return reqs
+	}
+}
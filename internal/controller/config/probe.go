@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+)
+
+const (
+	errNewDiscoveryClient = "cannot create discovery client"
+	errUnexpectedStatus   = "unexpected status code"
+	errProbeCluster       = "cluster is unreachable"
+
+	healthzPath = "/healthz"
+
+	// probeTimeout bounds a single /healthz probe.
+	probeTimeout = 10 * time.Second
+
+	// Failed probes are retried with an exponential backoff between these
+	// delays.
+	probeBaseDelay = time.Second
+	probeMaxDelay  = 5 * time.Minute
+)
+
+// A ProbeReconciler checks whether the cluster a ProviderConfig connects to
+// is reachable.
+type ProbeReconciler struct {
+	client       client.Client
+	log          logging.Logger
+	pollInterval time.Duration
+	now          func() time.Time
+
+	configFor func(ctx context.Context, local client.Client, providerConfigName string) (*rest.Config, error)
+	probe     func(ctx context.Context, rc *rest.Config) (int, error)
+}
+
+func setupProbeReconciler(mgr ctrl.Manager, o controller.Options) error {
+	name := "probe/" + strings.ToLower(v1alpha1.ProviderConfigGroupKind)
+
+	r := &ProbeReconciler{
+		client:       mgr.GetClient(),
+		log:          o.Logger.WithValues("controller", name),
+		pollInterval: o.PollInterval,
+		now:          time.Now,
+		configFor:    kube.ConfigForProvider,
+		probe:        probeHealthz,
+	}
+
+	// Probes run on their own workqueue, which retries unreachable clusters
+	// with an exponential backoff.
+	co := o.ForControllerRuntime()
+	co.RateLimiter = workqueue.NewItemExponentialFailureRateLimiter(probeBaseDelay, probeMaxDelay)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(co).
+		For(&v1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(providerConfigsForExternalSecret(r.client, r.log))).
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(providerConfigsForService(r.client, r.log)), builder.WithPredicates(serviceEndpointChanged)).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// Reconcile probes the /healthz endpoint of the cluster a ProviderConfig
+// connects to and records the outcome in its ClusterReachable and Ready
+// conditions. Reachable clusters are probed again after the poll interval,
+// unreachable ones are retried with an exponential backoff.
+func (r *ProbeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("request", req)
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.client.Get(ctx, req.NamespacedName, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	var code int
+	start := r.now()
+	rc, err := r.configFor(ctx, r.client, pc.GetName())
+	if err == nil {
+		code, err = r.probe(ctx, rc)
+	}
+	latency := r.now().Sub(start)
+
+	orig := pc.DeepCopy()
+	if err != nil {
+		log.Info("Cannot reach cluster", "error", err, "statusCode", code, "latency", latency)
+		pc.Status.SetConditions(v1alpha1.ClusterUnreachable(code, latency, err), xpv1.Unavailable().WithMessage(errProbeCluster+": "+err.Error()))
+		if perr := r.client.Status().Patch(ctx, pc, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{})); perr != nil {
+			return ctrl.Result{}, errors.Wrap(perr, errStatusPatch)
+		}
+		return ctrl.Result{}, errors.Wrap(err, errProbeCluster)
+	}
+
+	log.Debug("Probed cluster", "statusCode", code, "latency", latency)
+	pc.Status.SetConditions(v1alpha1.ClusterReachable(code, latency), xpv1.Available())
+	return ctrl.Result{RequeueAfter: r.pollInterval}, errors.Wrap(r.client.Status().Patch(ctx, pc, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{})), errStatusPatch)
+}
+
+// probeHealthz returns the status code the /healthz endpoint of the cluster
+// behind rc answers with. It returns an error unless the cluster answers
+// with 200 OK.
+func probeHealthz(ctx context.Context, rc *rest.Config) (int, error) {
+	rc = rest.CopyConfig(rc)
+	rc.Timeout = probeTimeout
+	dc, err := discovery.NewDiscoveryClientForConfig(rc)
+	if err != nil {
+		return 0, errors.Wrap(err, errNewDiscoveryClient)
+	}
+	var code int
+	if err := dc.RESTClient().Get().AbsPath(healthzPath).Do(ctx).StatusCode(&code).Error(); err != nil {
+		return code, err
+	}
+	if code != http.StatusOK {
+		return code, errors.Errorf("%s %d", errUnexpectedStatus, code)
+	}
+	return code, nil
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+func TestProbeReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	pollInterval := time.Minute
+
+	type args struct {
+		getErr    error
+		configErr error
+		code      int
+		probeErr  error
+	}
+	type want struct {
+		result    ctrl.Result
+		err       error
+		reachable corev1.ConditionStatus
+		ready     corev1.ConditionStatus
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotFound": {
+			reason: "We should not return an error if the ProviderConfig was deleted.",
+			args: args{
+				getErr: kerrors.NewNotFound(schema.GroupResource{}, "pc"),
+			},
+		},
+		"ConfigError": {
+			reason: "We should report the cluster as unreachable if no REST config can be built for it.",
+			args: args{
+				configErr: errBoom,
+			},
+			want: want{
+				err:       errors.Wrap(errBoom, errProbeCluster),
+				reachable: corev1.ConditionFalse,
+				ready:     corev1.ConditionFalse,
+			},
+		},
+		"Unreachable": {
+			reason: "We should return an error to back off if the probe fails, and set Ready to false.",
+			args: args{
+				code:     http.StatusInternalServerError,
+				probeErr: errBoom,
+			},
+			want: want{
+				err:       errors.Wrap(errBoom, errProbeCluster),
+				reachable: corev1.ConditionFalse,
+				ready:     corev1.ConditionFalse,
+			},
+		},
+		"Reachable": {
+			reason: "We should report a reachable cluster and probe it again after the poll interval.",
+			args: args{
+				code: http.StatusOK,
+			},
+			want: want{
+				result:    ctrl.Result{RequeueAfter: pollInterval},
+				reachable: corev1.ConditionTrue,
+				ready:     corev1.ConditionTrue,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var reachable, ready corev1.ConditionStatus
+			r := &ProbeReconciler{
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						obj.SetName(key.Name)
+						return tc.args.getErr
+					},
+					MockStatusPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+						pc := obj.(*v1alpha1.ProviderConfig)
+						reachable = pc.Status.GetCondition(v1alpha1.TypeClusterReachable).Status
+						ready = pc.Status.GetCondition(xpv1.TypeReady).Status
+						return nil
+					},
+				},
+				log:          logging.NewNopLogger(),
+				pollInterval: pollInterval,
+				now:          time.Now,
+				configFor: func(_ context.Context, _ client.Client, name string) (*rest.Config, error) {
+					if name != "pc" {
+						t.Errorf("unexpected ProviderConfig %q", name)
+					}
+					return &rest.Config{}, tc.args.configErr
+				},
+				probe: func(_ context.Context, _ *rest.Config) (int, error) {
+					return tc.args.code, tc.args.probeErr
+				},
+			}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "pc"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got result:\n%s", tc.reason, diff)
+			}
+			if reachable != tc.want.reachable || ready != tc.want.ready {
+				t.Errorf("\n%s\nr.Reconcile(...): want ClusterReachable %q and Ready %q, got %q and %q", tc.reason, tc.want.reachable, tc.want.ready, reachable, ready)
+			}
+		})
+	}
+}
+
+func TestProbeHealthz(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		status  int
+		wantErr bool
+	}{
+		"Healthy": {
+			reason: "We should not return an error if /healthz answers with 200 OK.",
+			status: http.StatusOK,
+		},
+		"Unhealthy": {
+			reason:  "We should return an error if /healthz answers with any other status code.",
+			status:  http.StatusServiceUnavailable,
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != healthzPath {
+					t.Errorf("unexpected request to %q", r.URL.Path)
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			code, err := probeHealthz(context.Background(), &rest.Config{Host: srv.URL})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("\n%s\nprobeHealthz(...): want error: %t, got error: %v", tc.reason, tc.wantErr, err)
+			}
+			if code != tc.status {
+				t.Errorf("\n%s\nprobeHealthz(...): want status code %d, got %d", tc.reason, tc.status, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+const (
+	errListObjects  = "cannot list Objects using the ProviderConfig"
+	errStatusPatch  = "cannot patch status"
+	errIndexObjects = "cannot add index for the provider config of Objects"
+
+	// usageCountInterval is how often the Objects using a ProviderConfig
+	// are counted.
+	usageCountInterval = 30 * time.Second
+)
+
+// ObjectProviderConfigIndex indexes Objects by the name of the ProviderConfig
+// they use. It is registered by Setup.
+const ObjectProviderConfigIndex = "spec.providerConfigRef.name"
+
+// A UsageCountReconciler reports the number of Objects using a
+// ProviderConfig through its UsageCount condition.
+type UsageCountReconciler struct {
+	client client.Client
+	log    logging.Logger
+}
+
+func setupUsageCountReconciler(mgr ctrl.Manager, o controller.Options) error {
+	name := "usagecount/" + strings.ToLower(v1alpha1.ProviderConfigGroupKind)
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha2.Object{}, ObjectProviderConfigIndex, indexByProviderConfig); err != nil {
+		return errors.Wrap(err, errIndexObjects)
+	}
+
+	r := &UsageCountReconciler{
+		client: mgr.GetClient(),
+		log:    o.Logger.WithValues("controller", name),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// indexByProviderConfig returns the name of the ProviderConfig an Object
+// uses.
+func indexByProviderConfig(o client.Object) []string {
+	obj, ok := o.(*v1alpha2.Object)
+	if !ok || obj.GetProviderConfigReference() == nil {
+		return nil
+	}
+	return []string{obj.GetProviderConfigReference().Name}
+}
+
+// Reconcile counts the Objects using a ProviderConfig, and counts them again
+// after the usage count interval.
+func (r *UsageCountReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("request", req)
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.client.Get(ctx, req.NamespacedName, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	l := &v1alpha2.ObjectList{}
+	if err := r.client.List(ctx, l, client.MatchingFields{ObjectProviderConfigIndex: pc.GetName()}); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, errListObjects)
+	}
+
+	n := int64(len(l.Items))
+	if prev, ok := v1alpha1.ObjectCount(pc); ok && prev == n {
+		return ctrl.Result{RequeueAfter: usageCountInterval}, nil
+	}
+
+	orig := pc.DeepCopy()
+	pc.Status.SetConditions(v1alpha1.UsageCount(n))
+	log.Debug("Counted Objects using the ProviderConfig", "count", n)
+	return ctrl.Result{RequeueAfter: usageCountInterval}, errors.Wrap(r.client.Status().Patch(ctx, pc, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{})), errStatusPatch)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+func TestUsageCountReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		previous *int64
+		objects  int
+		listErr  error
+		getErr   error
+	}
+	type want struct {
+		result  ctrl.Result
+		err     error
+		patched bool
+		count   int64
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotFound": {
+			reason: "We should not return an error if the ProviderConfig was deleted.",
+			args: args{
+				getErr: kerrors.NewNotFound(schema.GroupResource{}, "pc"),
+			},
+		},
+		"ListError": {
+			reason: "We should return an error if the Objects cannot be listed.",
+			args: args{
+				listErr: errBoom,
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListObjects),
+			},
+		},
+		"Counted": {
+			reason: "We should report the number of Objects using the ProviderConfig.",
+			args: args{
+				objects: 3,
+			},
+			want: want{
+				result:  ctrl.Result{RequeueAfter: usageCountInterval},
+				patched: true,
+				count:   3,
+			},
+		},
+		"Unchanged": {
+			reason: "We should not patch the status if the count did not change.",
+			args: args{
+				previous: ptr.To[int64](2),
+				objects:  2,
+			},
+			want: want{
+				result: ctrl.Result{RequeueAfter: usageCountInterval},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			patched := false
+			var count int64
+			r := &UsageCountReconciler{
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						if tc.args.getErr != nil {
+							return tc.args.getErr
+						}
+						pc := obj.(*v1alpha1.ProviderConfig)
+						pc.Name = key.Name
+						if tc.args.previous != nil {
+							pc.Status.SetConditions(v1alpha1.UsageCount(*tc.args.previous))
+						}
+						return nil
+					},
+					MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if got := lo.FieldSelector.String(); got != ObjectProviderConfigIndex+"=pc" {
+							t.Errorf("unexpected field selector %q", got)
+						}
+						l := list.(*v1alpha2.ObjectList)
+						l.Items = make([]v1alpha2.Object, tc.args.objects)
+						return tc.args.listErr
+					},
+					MockStatusPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+						patched = true
+						count, _ = v1alpha1.ObjectCount(obj.(*v1alpha1.ProviderConfig))
+						return nil
+					},
+				},
+				log: logging.NewNopLogger(),
+			}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "pc"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want result, +got result:\n%s", tc.reason, diff)
+			}
+			if patched != tc.want.patched || count != tc.want.count {
+				t.Errorf("\n%s\nr.Reconcile(...): want patched %t with count %d, got patched %t with count %d", tc.reason, tc.want.patched, tc.want.count, patched, count)
+			}
+		})
+	}
+}
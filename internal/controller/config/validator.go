@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+)
+
+const (
+	errNotProviderConfig = "managed resource is not a ProviderConfig"
+	errContextNotFound   = "context %q not found in the kubeconfig of the credentials"
+	errNoJustification   = "allowedSystemNamespaces require the annotation " + v1alpha1.AnnotationKeySystemNamespacesJustification + " explaining why"
+)
+
+// A ProviderConfigValidator rejects ProviderConfigs using a kubeconfig
+// context that does not exist. ProviderConfigs whose kubeconfig cannot be
+// read yet, e.g. because its Secret is not created yet, are admitted with a
+// warning. ProviderConfigs allowing system namespaces without a
+// justification are rejected as well.
+type ProviderConfigValidator struct {
+	client client.Client
+}
+
+var _ admission.CustomValidator = &ProviderConfigValidator{}
+
+// NewProviderConfigValidator returns a ProviderConfigValidator that reads
+// credentials using the supplied client.
+func NewProviderConfigValidator(c client.Client) *ProviderConfigValidator {
+	return &ProviderConfigValidator{client: c}
+}
+
+// ValidateCreate validates the kubeconfig context of a ProviderConfig.
+func (v *ProviderConfigValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pc, ok := obj.(*v1alpha1.ProviderConfig)
+	if !ok {
+		return nil, errors.New(errNotProviderConfig)
+	}
+	if err := validateJustification(pc); err != nil {
+		return nil, err
+	}
+	return v.validateContext(ctx, pc)
+}
+
+// ValidateUpdate validates the kubeconfig context of a ProviderConfig.
+func (v *ProviderConfigValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	pc, ok := newObj.(*v1alpha1.ProviderConfig)
+	if !ok {
+		return nil, errors.New(errNotProviderConfig)
+	}
+	if err := validateJustification(pc); err != nil {
+		return nil, err
+	}
+	return v.validateContext(ctx, pc)
+}
+
+// ValidateDelete never rejects a ProviderConfig.
+func (v *ProviderConfigValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ProviderConfigValidator) validateContext(ctx context.Context, pc *v1alpha1.ProviderConfig) (admission.Warnings, error) {
+	cd := pc.Spec.Credentials
+	if cd.Context == "" || cd.Source == xpv1.CredentialsSourceInjectedIdentity || cd.Source == xpv1.CredentialsSourceNone {
+		return nil, nil
+	}
+	kc, err := kube.Kubeconfig(ctx, v.client, cd)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("cannot validate context %q: %s: %s", cd.Context, errGetCreds, err)}, nil
+	}
+	ac, err := clientcmd.Load(kc)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("cannot validate context %q: %s: %s", cd.Context, errLoadKubeconfig, err)}, nil
+	}
+	if _, ok := ac.Contexts[cd.Context]; !ok {
+		return nil, errors.Errorf(errContextNotFound, cd.Context)
+	}
+	return nil, nil
+}
+
+// validateJustification rejects ProviderConfigs allowing system namespaces
+// without saying why.
+func validateJustification(pc *v1alpha1.ProviderConfig) error {
+	if len(pc.Spec.AllowedSystemNamespaces) == 0 {
+		return nil
+	}
+	if strings.TrimSpace(pc.GetAnnotations()[v1alpha1.AnnotationKeySystemNamespacesJustification]) == "" {
+		return errors.New(errNoJustification)
+	}
+	return nil
+}
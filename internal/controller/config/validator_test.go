@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+func TestProviderConfigValidator(t *testing.T) {
+	kc := kubeconfigWithCertificate(t, time.Now().Add(time.Hour))
+
+	providerConfig := func(source xpv1.CredentialsSource, context string) *v1alpha1.ProviderConfig {
+		pc := &v1alpha1.ProviderConfig{}
+		pc.Spec.Credentials.Source = source
+		pc.Spec.Credentials.Context = context
+		pc.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "kubeconfig"},
+			Key:             "kubeconfig",
+		}
+		return pc
+	}
+	getSecret := func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		obj.(*corev1.Secret).Data = map[string][]byte{"kubeconfig": kc}
+		return nil
+	}
+
+	type args struct {
+		get test.MockGetFn
+		pc  *v1alpha1.ProviderConfig
+	}
+	type want struct {
+		warnings bool
+		err      error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoContext": {
+			reason: "We should admit ProviderConfigs using the current context.",
+			args: args{
+				pc: providerConfig(xpv1.CredentialsSourceSecret, ""),
+			},
+		},
+		"InjectedIdentity": {
+			reason: "We should admit ProviderConfigs without a kubeconfig.",
+			args: args{
+				pc: providerConfig(xpv1.CredentialsSourceInjectedIdentity, "other"),
+			},
+		},
+		"ContextExists": {
+			reason: "We should admit ProviderConfigs using a context of their kubeconfig.",
+			args: args{
+				get: getSecret,
+				pc:  providerConfig(xpv1.CredentialsSourceSecret, "default"),
+			},
+		},
+		"ContextNotFound": {
+			reason: "We should reject ProviderConfigs using a context their kubeconfig does not have.",
+			args: args{
+				get: getSecret,
+				pc:  providerConfig(xpv1.CredentialsSourceSecret, "other"),
+			},
+			want: want{
+				err: errors.Errorf(errContextNotFound, "other"),
+			},
+		},
+		"AllowedWithoutJustification": {
+			reason: "We should reject ProviderConfigs allowing system namespaces without a justification.",
+			args: args{
+				pc: func() *v1alpha1.ProviderConfig {
+					pc := providerConfig(xpv1.CredentialsSourceInjectedIdentity, "")
+					pc.Spec.AllowedSystemNamespaces = []string{"kube-system"}
+					return pc
+				}(),
+			},
+			want: want{
+				err: errors.New(errNoJustification),
+			},
+		},
+		"AllowedWithJustification": {
+			reason: "We should admit ProviderConfigs allowing system namespaces with a justification.",
+			args: args{
+				pc: func() *v1alpha1.ProviderConfig {
+					pc := providerConfig(xpv1.CredentialsSourceInjectedIdentity, "")
+					pc.Spec.AllowedSystemNamespaces = []string{"kube-system"}
+					pc.SetAnnotations(map[string]string{v1alpha1.AnnotationKeySystemNamespacesJustification: "Manages the cluster autoscaler."})
+					return pc
+				}(),
+			},
+		},
+		"SecretNotFound": {
+			reason: "We should admit ProviderConfigs with a warning if their kubeconfig cannot be read.",
+			args: args{
+				get: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "kubeconfig")),
+				pc:  providerConfig(xpv1.CredentialsSourceSecret, "other"),
+			},
+			want: want{
+				warnings: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := NewProviderConfigValidator(&test.MockClient{MockGet: tc.args.get})
+			warnings, err := v.ValidateCreate(context.Background(), tc.args.pc)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if got := len(warnings) > 0; got != tc.want.warnings {
+				t.Errorf("\n%s\nValidateCreate(...): want warnings %t, got %v", tc.reason, tc.want.warnings, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discoveryjob
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/discoveryjob/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/manifest"
+)
+
+const (
+	errNewKubernetesClient = "cannot create new Kubernetes client"
+	errStatusUpdate        = "cannot update status"
+	membershipLabelKey     = "kubernetes.crossplane.io/discovered-by"
+
+	// maxObjectNameLength is the maximum length of an Object's name.
+	maxObjectNameLength = 63
+)
+
+// Reconciler watches for DiscoveryJob resources and generates an Object for
+// every discovered resource that is not managed by an Object yet.
+type Reconciler struct {
+	client            client.Client
+	log               logging.Logger
+	clientForProvider func(ctx context.Context, inclusterClient client.Client, providerConfigName string) (client.Client, *rest.Config, error)
+}
+
+// Setup adds a controller that reconciles DiscoveryJob resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.DiscoveryJobGroupKind)
+
+	r := &Reconciler{
+		client:            mgr.GetClient(),
+		log:               o.Logger,
+		clientForProvider: kube.ClientForProvider,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.DiscoveryJob{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// Reconcile runs a DiscoveryJob once per generation. It lists the resources
+// of the requested kinds in the requested namespace and generates an Object
+// for each of them, reporting its progress in the DiscoveryJob's status.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, error error) { //nolint:gocyclo
+	log := r.log.WithValues("request", req)
+
+	defer func() {
+		if error == nil {
+			log.Info("Reconciled")
+		} else {
+			log.Info("Retry", "err", error)
+		}
+	}()
+
+	j := &v1alpha1.DiscoveryJob{}
+	err := r.client.Get(ctx, req.NamespacedName, j)
+
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if meta.WasDeleted(j) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsPaused(j) {
+		j.Status.SetConditions(xpv1.ReconcilePaused())
+		return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, j), errStatusUpdate)
+	}
+
+	// The job already ran for this generation.
+	if j.Status.ObservedGeneration == j.Generation && j.Status.GetCondition(xpv1.TypeReady).Status == v1.ConditionTrue {
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("Reconciling")
+
+	clusterClient, _, err := r.clientForProvider(ctx, r.client, j.Spec.ProviderConfigReference.Name)
+	if err != nil {
+		werr := errors.Wrap(err, errNewKubernetesClient)
+		j.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, j)
+		return ctrl.Result{}, werr
+	}
+
+	// Resources that are already managed by an Object must not be imported
+	// again, regardless of the name of that Object.
+	ol := &v1alpha2.ObjectList{}
+	if err := r.client.List(ctx, ol); err != nil {
+		werr := errors.Wrap(err, "cannot list objects")
+		j.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, j)
+		return ctrl.Result{}, werr
+	}
+	managedResources := sets.New[string]()
+	for i := range ol.Items {
+		if k, ok := managedResourceKey(&ol.Items[i]); ok {
+			managedResources.Insert(k)
+		}
+	}
+
+	j.Status.Discovered, j.Status.Created, j.Status.Skipped = 0, 0, 0
+	for _, res := range j.Spec.Resources {
+		l := &unstructured.UnstructuredList{}
+		l.SetAPIVersion(res.APIVersion)
+		l.SetKind(res.Kind)
+		if err := clusterClient.List(ctx, l, client.InNamespace(j.Spec.Namespace)); err != nil {
+			werr := errors.Wrapf(err, "cannot list %s %s in namespace %s", res.APIVersion, res.Kind, j.Spec.Namespace)
+			j.Status.SetConditions(xpv1.ReconcileError(werr))
+			_ = r.client.Status().Update(ctx, j)
+			return ctrl.Result{}, werr
+		}
+
+		for i := range l.Items {
+			u := &l.Items[i]
+			j.Status.Discovered++
+
+			if managedResources.Has(resourceKey(j.Spec.ProviderConfigReference.Name, u)) {
+				log.Debug("Skipping resource that is already managed by an Object", "gvk", u.GroupVersionKind(), "name", u.GetName())
+				j.Status.Skipped++
+				continue
+			}
+
+			// Resources of a controller, like the Pods of a ReplicaSet, are
+			// managed through that controller.
+			if ref := metav1.GetControllerOf(u); ref != nil {
+				log.Debug("Skipping resource that is controlled by another resource", "gvk", u.GroupVersionKind(), "name", u.GetName(), "controller", ref.Kind+"/"+ref.Name)
+				j.Status.Skipped++
+				continue
+			}
+
+			o, err := discoveredObject(j, u)
+			if err != nil {
+				werr := errors.Wrapf(err, "cannot generate object for %s %s", u.GetKind(), u.GetName())
+				j.Status.SetConditions(xpv1.ReconcileError(werr))
+				_ = r.client.Status().Update(ctx, j)
+				return ctrl.Result{}, werr
+			}
+
+			if j.Spec.DryRun {
+				log.Info("Would create object for discovered resource", "object", o.GetName(), "gvk", u.GroupVersionKind(), "name", u.GetName())
+				j.Status.Created++
+				continue
+			}
+
+			err = r.client.Create(ctx, o)
+			if kerrors.IsAlreadyExists(err) {
+				// An Object with the generated name exists, but manages a
+				// different resource.
+				log.Info("Skipping resource because an object with the generated name already exists", "object", o.GetName(), "gvk", u.GroupVersionKind(), "name", u.GetName())
+				j.Status.Skipped++
+				continue
+			}
+			if err != nil {
+				werr := errors.Wrapf(err, "cannot create object %s", o.GetName())
+				j.Status.SetConditions(xpv1.ReconcileError(werr))
+				_ = r.client.Status().Update(ctx, j)
+				return ctrl.Result{}, werr
+			}
+			log.Debug("Created object for discovered resource", "object", o.GetName())
+			j.Status.Created++
+		}
+
+		// Report progress after every kind.
+		if err := r.client.Status().Update(ctx, j); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, errStatusUpdate)
+		}
+	}
+
+	j.Status.ObservedGeneration = j.Generation
+	j.Status.MembershipLabel = map[string]string{membershipLabelKey: j.Name}
+	j.Status.SetConditions(xpv1.ReconcileSuccess(), xpv1.Available())
+
+	return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, j), errStatusUpdate)
+}
+
+// resourceKey identifies a resource on the cluster of the supplied provider
+// config.
+func resourceKey(providerConfig string, u *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", providerConfig, u.GetAPIVersion(), u.GetKind(), u.GetNamespace(), u.GetName())
+}
+
+// managedResourceKey returns the key of the resource managed by an Object.
+func managedResourceKey(o *v1alpha2.Object) (string, bool) {
+	raw, err := manifest.ForObject(o)
+	if err != nil {
+		return "", false
+	}
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(raw, u); err != nil {
+		return "", false
+	}
+	if u.GetName() == "" {
+		u.SetName(o.GetName())
+	}
+	pc := ""
+	if o.Spec.ProviderConfigReference != nil {
+		pc = o.Spec.ProviderConfigReference.Name
+	}
+	return resourceKey(pc, u), true
+}
+
+// discoveredObjectName returns a stable name for the Object generated for
+// the supplied resource.
+func discoveredObjectName(u *unstructured.Unstructured) string {
+	k := fmt.Sprintf("%v/%s/%s", u.GroupVersionKind(), u.GetNamespace(), u.GetName())
+	h := fmt.Sprintf("%x", sha256.Sum256([]byte(k)))[0:7]
+	prefix := strings.ToLower(fmt.Sprintf("%s-%s", u.GetKind(), u.GetName()))
+	if n := maxObjectNameLength - len(h) - 1; len(prefix) > n {
+		prefix = strings.TrimRight(prefix[:n], "-.")
+	}
+	return fmt.Sprintf("%s-%s", prefix, h)
+}
+
+// discoveredObject returns an Object that manages the supplied resource as
+// it currently is. Objects adopt resources that already exist on the target
+// cluster, generated ones orphan them by default.
+func discoveredObject(j *v1alpha1.DiscoveryJob, u *unstructured.Unstructured) (*v1alpha2.Object, error) {
+	m := u.DeepCopy()
+	unstructured.RemoveNestedField(m.Object, "status")
+	for _, f := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "selfLink", "ownerReferences"} {
+		unstructured.RemoveNestedField(m.Object, "metadata", f)
+	}
+	if a := m.GetAnnotations(); a != nil {
+		delete(a, v1.LastAppliedConfigAnnotation)
+		m.SetAnnotations(a)
+	}
+
+	raw, err := m.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal discovered resource")
+	}
+	deletionPolicy := j.Spec.DeletionPolicy
+	if deletionPolicy == "" {
+		deletionPolicy = xpv1.DeletionOrphan
+	}
+	return &v1alpha2.Object{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: discoveredObjectName(u),
+			Labels: map[string]string{
+				membershipLabelKey: j.Name,
+			},
+		},
+		Spec: v1alpha2.ObjectSpec{
+			ResourceSpec: xpv1.ResourceSpec{
+				ProviderConfigReference: &xpv1.Reference{Name: j.Spec.ProviderConfigReference.Name},
+				DeletionPolicy:          deletionPolicy,
+			},
+			ForProvider: v1alpha2.ObjectParameters{
+				Manifest: runtime.RawExtension{Raw: raw},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discoveryjob
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/discoveryjob/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestReconciler(t *testing.T) {
+	jobName := types.NamespacedName{Name: "import"}
+	errBoom := fmt.Errorf("error reading")
+
+	configMap := func(name string) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace("default")
+		u.SetName(name)
+		u.SetResourceVersion("42")
+		return u
+	}
+	getJob := func(dryRun bool) test.MockGetFn {
+		return func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+			j := obj.(*v1alpha1.DiscoveryJob)
+			j.Name = key.Name
+			j.Generation = 1
+			j.Spec = v1alpha1.DiscoveryJobSpec{
+				Namespace:               "default",
+				Resources:               []v1alpha1.DiscoveryResource{{APIVersion: "v1", Kind: "ConfigMap"}},
+				DryRun:                  dryRun,
+				ProviderConfigReference: xpv1.Reference{Name: "default"},
+			}
+			return nil
+		}
+	}
+	listConfigMaps := func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+		l := list.(*unstructured.UnstructuredList)
+		l.Items = append(l.Items, configMap("managed"), configMap("unmanaged"))
+		return nil
+	}
+	listObjects := func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+		l := list.(*v1alpha2.ObjectList)
+		l.Items = append(l.Items, v1alpha2.Object{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing"},
+			Spec: v1alpha2.ObjectSpec{
+				ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+				ForProvider: v1alpha2.ObjectParameters{
+					Manifest: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"default","name":"managed"}}`)},
+				},
+			},
+		})
+		return nil
+	}
+	wantStatus := func(created, skipped int) func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+		return func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			s := obj.(*v1alpha1.DiscoveryJob).Status
+			if s.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+				// Progress update.
+				return nil
+			}
+			if diff := cmp.Diff([]int{2, created, skipped}, []int{s.Discovered, s.Created, s.Skipped}); diff != "" {
+				t.Errorf("-want discovered, created, skipped, +got:\n%s", diff)
+			}
+			return nil
+		}
+	}
+
+	type args struct {
+		client        *test.MockClient
+		clusterClient *test.MockClient
+	}
+	type want struct {
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ErrorGetDiscoveryJob": {
+			reason: "We should return error.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"DiscoveryJobNotFound": {
+			reason: "We should not return an error if the DiscoveryJob was not found.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				},
+			},
+		},
+		"AlreadyRun": {
+			reason: "We should not run a DiscoveryJob again for the same generation.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						j := obj.(*v1alpha1.DiscoveryJob)
+						j.Generation = 1
+						j.Status.ObservedGeneration = 1
+						j.Status.SetConditions(xpv1.Available())
+						return nil
+					},
+				},
+			},
+		},
+		"ErrorListResources": {
+			reason: "We should return an error if the resources cannot be listed.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          getJob(false),
+					MockList:         listObjects,
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				clusterClient: &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"CreateObjects": {
+			reason: "We should create an Object for every resource that is not managed yet.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  getJob(false),
+					MockList: listObjects,
+					MockCreate: func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+						o := obj.(*v1alpha2.Object)
+						if !strings.HasPrefix(o.Name, "configmap-unmanaged-") {
+							t.Errorf("unexpected creation of %q", o.Name)
+						}
+						if l := o.GetLabels()[membershipLabelKey]; l != jobName.Name {
+							t.Errorf("expected membership label %q, got %q", jobName.Name, l)
+						}
+						if o.Spec.DeletionPolicy != xpv1.DeletionOrphan {
+							t.Errorf("expected deletion policy %q, got %q", xpv1.DeletionOrphan, o.Spec.DeletionPolicy)
+						}
+						if strings.Contains(string(o.Spec.ForProvider.Manifest.Raw), "resourceVersion") {
+							t.Errorf("expected server fields to be removed from manifest, got %s", o.Spec.ForProvider.Manifest.Raw)
+						}
+						return nil
+					},
+					MockStatusUpdate: wantStatus(1, 1),
+				},
+				clusterClient: &test.MockClient{
+					MockList: listConfigMaps,
+				},
+			},
+		},
+		"ManagedByManifestURL": {
+			reason: "We should skip resources managed by Objects with a manifest URL, by their observed manifest.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: getJob(false),
+					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						l := list.(*v1alpha2.ObjectList)
+						l.Items = append(l.Items, v1alpha2.Object{
+							ObjectMeta: metav1.ObjectMeta{Name: "existing"},
+							Spec: v1alpha2.ObjectSpec{
+								ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+								ForProvider:  v1alpha2.ObjectParameters{ManifestURL: "https://example.org/managed.yaml"},
+							},
+							Status: v1alpha2.ObjectStatus{AtProvider: v1alpha2.ObjectObservation{
+								Manifest: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"default","name":"managed"}}`)},
+							}},
+						})
+						return nil
+					},
+					MockCreate: func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+						if o := obj.(*v1alpha2.Object); !strings.HasPrefix(o.Name, "configmap-unmanaged-") {
+							t.Errorf("unexpected creation of %q", o.Name)
+						}
+						return nil
+					},
+					MockStatusUpdate: wantStatus(1, 1),
+				},
+				clusterClient: &test.MockClient{
+					MockList: listConfigMaps,
+				},
+			},
+		},
+		"ControlledResource": {
+			reason: "We should skip resources that have a controller owner reference.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  getJob(false),
+					MockList: listObjects,
+					MockCreate: func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+						t.Errorf("unexpected creation of %q for a controlled resource", obj.GetName())
+						return nil
+					},
+					MockStatusUpdate: wantStatus(0, 2),
+				},
+				clusterClient: &test.MockClient{
+					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						controlled := configMap("unmanaged")
+						controlled.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs", Controller: ptr.To(true)}})
+						l := list.(*unstructured.UnstructuredList)
+						l.Items = append(l.Items, configMap("managed"), controlled)
+						return nil
+					},
+				},
+			},
+		},
+		"NameCollision": {
+			reason: "We should skip resources if an Object with the generated name already exists.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          getJob(false),
+					MockList:         listObjects,
+					MockCreate:       test.NewMockCreateFn(kerrors.NewAlreadyExists(schema.GroupResource{}, "")),
+					MockStatusUpdate: wantStatus(0, 2),
+				},
+				clusterClient: &test.MockClient{
+					MockList: listConfigMaps,
+				},
+			},
+		},
+		"DryRun": {
+			reason: "We should not create any Object in dry-run mode.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  getJob(true),
+					MockList: listObjects,
+					MockCreate: func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+						t.Errorf("unexpected creation of %q in dry-run mode", obj.GetName())
+						return nil
+					},
+					MockStatusUpdate: wantStatus(1, 1),
+				},
+				clusterClient: &test.MockClient{
+					MockList: listConfigMaps,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{
+				client: tc.args.client,
+				log:    logging.NewNopLogger(),
+				clientForProvider: func(ctx context.Context, inclusterClient client.Client, providerConfigName string) (client.Client, *rest.Config, error) {
+					return tc.args.clusterClient, nil, nil
+				},
+			}
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: jobName})
+			if !errors.Is(err, tc.want.err) {
+				t.Errorf("\n%s\nr.Reconcile(...): want error: %v, got error: %v", tc.reason, tc.want.err, err)
+			}
+		})
+	}
+}
+
+func TestDiscoveredObjectName(t *testing.T) {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetNamespace("default")
+	u.SetName(strings.Repeat("a", 100))
+
+	got := discoveredObjectName(u)
+	if len(got) > maxObjectNameLength {
+		t.Errorf("discoveredObjectName(...): want at most %d characters, got %d", maxObjectNameLength, len(got))
+	}
+	if got != discoveredObjectName(u.DeepCopy()) {
+		t.Errorf("discoveredObjectName(...): want a stable name")
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitexport
+
+import (
+	"context"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/pkg/errors"
+)
+
+const (
+	errInitRepository = "cannot initialize repository"
+	errFetch          = "cannot fetch branch"
+	errCheckout       = "cannot check out branch"
+	errWriteFile      = "cannot write manifest"
+	errRemoveFile     = "cannot remove manifest"
+	errStage          = "cannot stage changes"
+	errCommit         = "cannot commit changes"
+	errPush           = "cannot push changes"
+
+	remoteName  = "origin"
+	authorName  = "provider-kubernetes"
+	authorEmail = "provider-kubernetes@crossplane.io"
+
+	// maxPushAttempts bounds how often a commit is rebuilt on top of a
+	// branch that others pushed to in the meantime.
+	maxPushAttempts = 5
+)
+
+// A commitRequest describes the state of the exported manifests in a
+// repository.
+type commitRequest struct {
+	url    string
+	branch string
+	auth   transport.AuthMethod
+	// files are the contents of the exported manifests by path.
+	files map[string][]byte
+	// stale are the paths of previously exported manifests to remove.
+	stale   []string
+	message string
+}
+
+// commitFiles commits the files of the supplied request to the tip of its
+// branch and returns the resulting commit. No commit is made if the tip
+// already contains the files.
+//
+// Other exports, e.g. of other providers, may push to the same branch
+// concurrently. A rejected push is retried on top of the new tip, which is
+// safe because every export only writes its own files.
+func commitFiles(ctx context.Context, req commitRequest) (string, error) {
+	var err error
+	for i := 0; i < maxPushAttempts; i++ {
+		var sha string
+		sha, err = commitOnce(ctx, req)
+		if !pushRejected(err) {
+			return sha, err
+		}
+	}
+	return "", err
+}
+
+func commitOnce(ctx context.Context, req commitRequest) (string, error) { //nolint:gocyclo // a linear sequence of Git operations.
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		return "", errors.Wrap(err, errInitRepository)
+	}
+	if _, err := r.CreateRemote(&config.RemoteConfig{Name: remoteName, URLs: []string{req.url}}); err != nil {
+		return "", errors.Wrap(err, errInitRepository)
+	}
+
+	branch := plumbing.NewBranchReferenceName(req.branch)
+	remoteBranch := plumbing.NewRemoteReferenceName(remoteName, req.branch)
+	err = r.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{config.RefSpec("+" + branch + ":" + remoteBranch)},
+		Auth:       req.auth,
+		Depth:      1,
+	})
+	var noMatch git.NoMatchingRefSpecError
+	switch {
+	case err == nil, errors.Is(err, git.NoErrAlreadyUpToDate):
+		tip, err := r.Reference(remoteBranch, true)
+		if err != nil {
+			return "", errors.Wrap(err, errCheckout)
+		}
+		wt, err := r.Worktree()
+		if err != nil {
+			return "", errors.Wrap(err, errCheckout)
+		}
+		if err := wt.Checkout(&git.CheckoutOptions{Branch: branch, Hash: tip.Hash(), Create: true}); err != nil {
+			return "", errors.Wrap(err, errCheckout)
+		}
+	case errors.Is(err, transport.ErrEmptyRemoteRepository), errors.As(err, &noMatch):
+		// Start the branch with the first commit.
+		if err := r.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, branch)); err != nil {
+			return "", errors.Wrap(err, errCheckout)
+		}
+	default:
+		return "", errors.Wrap(err, errFetch)
+	}
+
+	wt, err := r.Worktree()
+	if err != nil {
+		return "", errors.Wrap(err, errCheckout)
+	}
+	if err := writeFiles(wt.Filesystem, req.files, req.stale); err != nil {
+		return "", err
+	}
+	if err := wt.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return "", errors.Wrap(err, errStage)
+	}
+	st, err := wt.Status()
+	if err != nil {
+		return "", errors.Wrap(err, errStage)
+	}
+	if st.IsClean() {
+		head, err := r.Head()
+		if err != nil {
+			return "", errors.Wrap(err, errCommit)
+		}
+		return head.Hash().String(), nil
+	}
+
+	sig := &object.Signature{Name: authorName, Email: authorEmail, When: time.Now()}
+	sha, err := wt.Commit(req.message, &git.CommitOptions{Author: sig, Committer: sig})
+	if err != nil {
+		return "", errors.Wrap(err, errCommit)
+	}
+
+	err = r.PushContext(ctx, &git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{config.RefSpec(branch + ":" + branch)},
+		Auth:       req.auth,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, errPush)
+	}
+	return sha.String(), nil
+}
+
+// rejectedPushStatuses are the statuses a server reports for the branch of
+// a rejected push when the branch moved on, e.g. because another export
+// pushed to it after we fetched it.
+var rejectedPushStatuses = []string{"non-fast-forward", "fetch first", "cannot lock ref", "failed to update ref"}
+
+// pushRejected returns true if the supplied error is a push that was
+// rejected because the branch moved on, either found before pushing or
+// reported by the server.
+func pushRejected(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, git.ErrForceNeeded) {
+		return true
+	}
+	for _, s := range rejectedPushStatuses {
+		if strings.Contains(err.Error(), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeFiles writes the supplied files and removes the stale ones.
+func writeFiles(fs billy.Filesystem, files map[string][]byte, stale []string) error {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if err := fs.MkdirAll(path.Dir(p), 0o755); err != nil {
+			return errors.Wrapf(err, "%s %s", errWriteFile, p)
+		}
+		if err := util.WriteFile(fs, p, files[p], 0o644); err != nil {
+			return errors.Wrapf(err, "%s %s", errWriteFile, p)
+		}
+	}
+	for _, p := range stale {
+		if _, ok := files[p]; ok {
+			continue
+		}
+		if err := fs.Remove(p); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "%s %s", errRemoveFile, p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitexport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func TestCommitFiles(t *testing.T) {
+	remote := t.TempDir()
+	if _, err := git.PlainInit(remote, true); err != nil {
+		t.Fatalf("cannot initialize remote: %v", err)
+	}
+	commit := func(files map[string][]byte, stale ...string) string {
+		t.Helper()
+		sha, err := commitFiles(context.Background(), commitRequest{url: remote, branch: "main", files: files, stale: stale, message: "test"})
+		if err != nil {
+			t.Fatalf("commitFiles(...): unexpected error: %v", err)
+		}
+		return sha
+	}
+	tree := func() []string {
+		t.Helper()
+		r, err := git.PlainOpen(remote)
+		if err != nil {
+			t.Fatalf("cannot open remote: %v", err)
+		}
+		ref, err := r.Reference(plumbing.NewBranchReferenceName("main"), true)
+		if err != nil {
+			t.Fatalf("cannot get branch: %v", err)
+		}
+		c, err := r.CommitObject(ref.Hash())
+		if err != nil {
+			t.Fatalf("cannot get commit: %v", err)
+		}
+		files, err := c.Files()
+		if err != nil {
+			t.Fatalf("cannot get files: %v", err)
+		}
+		var paths []string
+		_ = files.ForEach(func(f *object.File) error {
+			paths = append(paths, f.Name)
+			return nil
+		})
+		return paths
+	}
+
+	// The first commit starts the branch of an empty repository.
+	first := commit(map[string][]byte{"default/apps/a.yaml": []byte("a"), "default/apps/b.yaml": []byte("b")})
+
+	// Another export of the same repository keeps the files of the first.
+	commit(map[string][]byte{"other/_cluster/c.yaml": []byte("c")})
+	if diff := cmp.Diff([]string{"default/apps/a.yaml", "default/apps/b.yaml", "other/_cluster/c.yaml"}, tree()); diff != "" {
+		t.Errorf("files after second export: -want, +got:\n%s", diff)
+	}
+
+	// Stale files are removed.
+	third := commit(map[string][]byte{"default/apps/a.yaml": []byte("a")}, "default/apps/a.yaml", "default/apps/b.yaml")
+	if diff := cmp.Diff([]string{"default/apps/a.yaml", "other/_cluster/c.yaml"}, tree()); diff != "" {
+		t.Errorf("files after removing stale files: -want, +got:\n%s", diff)
+	}
+	if third == first {
+		t.Errorf("expected a new commit after removing stale files")
+	}
+
+	// Nothing is committed if the files are unchanged.
+	if got := commit(map[string][]byte{"default/apps/a.yaml": []byte("a")}, "default/apps/a.yaml"); got != third {
+		t.Errorf("commitFiles(...): want unchanged commit %s, got %s", third, got)
+	}
+}
+
+func TestPushRejected(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   bool
+	}{
+		"NoError": {
+			reason: "A successful push was not rejected.",
+		},
+		"ForceNeeded": {
+			reason: "A push go-git found to be no fast-forward before pushing was rejected.",
+			err:    errors.Wrap(git.ErrForceNeeded, errPush),
+			want:   true,
+		},
+		"NonFastForward": {
+			reason: "A push the server reported to be no fast-forward was rejected.",
+			err:    errors.Wrap(errors.New("command error on refs/heads/main: non-fast-forward"), errPush),
+			want:   true,
+		},
+		"LockedRef": {
+			reason: "A push the server could not update the branch for, because it was updated concurrently, was rejected.",
+			err:    errors.Wrap(errors.New("command error on refs/heads/main: cannot lock ref 'refs/heads/main'"), errPush),
+			want:   true,
+		},
+		"Unauthorized": {
+			reason: "A push that failed for other reasons was not rejected.",
+			err:    errors.Wrap(errors.New("authentication required"), errPush),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := pushRejected(tc.err); got != tc.want {
+				t.Errorf("\n%s\npushRejected(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
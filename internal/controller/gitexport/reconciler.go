@@ -0,0 +1,333 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitexport
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/gitexport/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/manifest"
+)
+
+const (
+	errStatusUpdate   = "cannot update status"
+	errParseSelector  = "cannot parse selector"
+	errListObjects    = "cannot list Objects"
+	errRenderManifest = "cannot render manifest of Object"
+	errGetSecret      = "cannot get credentials secret"
+
+	secretKeyUsername = "username"
+	secretKeyPassword = "password"
+
+	// clusterScoped is the directory of manifests without a namespace.
+	clusterScoped = "_cluster"
+)
+
+// secretKind is the kind of the manifests that are only exported on request.
+var secretKind = corev1.SchemeGroupVersion.WithKind("Secret").GroupKind()
+
+// Reconciler watches for GitExport resources and commits the manifests of
+// the Objects they select to a Git repository.
+type Reconciler struct {
+	client       client.Client
+	log          logging.Logger
+	pollInterval func() time.Duration
+	commit       func(ctx context.Context, req commitRequest) (string, error)
+}
+
+// Setup adds a controller that reconciles GitExport resources.
+func Setup(mgr ctrl.Manager, o controller.Options, pollJitter time.Duration) error {
+	name := managed.ControllerName(v1alpha1.GitExportGroupKind)
+
+	r := &Reconciler{
+		client: mgr.GetClient(),
+		log:    o.Logger,
+		pollInterval: func() time.Duration {
+			return o.PollInterval + time.Duration((rand.Float64()-0.5)*2*float64(pollJitter)) //nolint
+		},
+		commit: commitFiles,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.GitExport{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{}),
+		)).
+		Watches(&v1alpha2.Object{}, handler.EnqueueRequestsFromMapFunc(r.gitExportsForObject), builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{}),
+		)).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// gitExportsForObject enqueues the GitExports that select the supplied
+// Object.
+func (r *Reconciler) gitExportsForObject(ctx context.Context, o client.Object) []reconcile.Request {
+	l := &v1alpha1.GitExportList{}
+	if err := r.client.List(ctx, l); err != nil {
+		r.log.Debug("cannot list git exports", "error", err)
+		return nil
+	}
+	var reqs []reconcile.Request
+	for _, ge := range l.Items {
+		s, err := selectorOf(&ge)
+		if err != nil {
+			continue
+		}
+		// Objects that no longer match are enqueued as well if their
+		// manifest was exported, so it is removed.
+		if s.Matches(labels.Set(o.GetLabels())) || hasFileOf(&ge, o.GetName()) {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: ge.Name}})
+		}
+	}
+	return reqs
+}
+
+// Reconcile renders the manifests of the Objects a GitExport selects and
+// commits them to its repository, removing the manifests of Objects that
+// are no longer selected.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, error error) { //nolint:gocyclo
+	log := r.log.WithValues("request", req)
+
+	defer func() {
+		if error == nil {
+			log.Info("Reconciled")
+		} else {
+			log.Info("Retry", "err", error)
+		}
+	}()
+
+	ge := &v1alpha1.GitExport{}
+	err := r.client.Get(ctx, req.NamespacedName, ge)
+
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	// Exported manifests are kept in the repository once the GitExport is
+	// gone.
+	if meta.WasDeleted(ge) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsPaused(ge) {
+		ge.Status.SetConditions(xpv1.ReconcilePaused())
+		return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, ge), errStatusUpdate)
+	}
+
+	log.Info("Reconciling")
+
+	files, err := r.render(ctx, ge)
+	if err != nil {
+		ge.Status.SetConditions(xpv1.ReconcileError(err))
+		_ = r.client.Status().Update(ctx, ge)
+		return ctrl.Result{}, err
+	}
+
+	auth, err := r.auth(ctx, ge)
+	if err != nil {
+		ge.Status.SetConditions(xpv1.ReconcileError(err))
+		_ = r.client.Status().Update(ctx, ge)
+		return ctrl.Result{}, err
+	}
+
+	sha, err := r.commit(ctx, commitRequest{
+		url:     ge.Spec.Repository,
+		branch:  branchOf(ge),
+		auth:    auth,
+		files:   files,
+		stale:   ge.Status.Files,
+		message: fmt.Sprintf("Export %d manifests of GitExport %s", len(files), ge.Name),
+	})
+	if err != nil {
+		ge.Status.SetConditions(xpv1.ReconcileError(err))
+		_ = r.client.Status().Update(ctx, ge)
+		return ctrl.Result{}, err
+	}
+	log.Debug("Exported manifests", "commit", sha, "count", len(files))
+
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	ge.Status.LastCommit = sha
+	ge.Status.Files = paths
+	ge.Status.SetConditions(xpv1.ReconcileSuccess(), xpv1.Available())
+
+	return ctrl.Result{RequeueAfter: r.pollInterval()}, r.client.Status().Update(ctx, ge)
+}
+
+// render returns the manifests of the Objects selected by the supplied
+// GitExport by their path in the repository.
+func (r *Reconciler) render(ctx context.Context, ge *v1alpha1.GitExport) (map[string][]byte, error) {
+	s, err := selectorOf(ge)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseSelector)
+	}
+	ol := &v1alpha2.ObjectList{}
+	if err := r.client.List(ctx, ol, client.MatchingLabelsSelector{Selector: s}); err != nil {
+		return nil, errors.Wrap(err, errListObjects)
+	}
+
+	files := make(map[string][]byte, len(ol.Items))
+	for i := range ol.Items {
+		o := &ol.Items[i]
+		if meta.WasDeleted(o) {
+			continue
+		}
+		p, data, ok, err := manifestFile(o, ge.Spec.IncludeSecrets)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s %s", errRenderManifest, o.Name)
+		}
+		if !ok {
+			continue
+		}
+		files[p] = data
+	}
+	return files, nil
+}
+
+// auth returns the credentials of the repository of the supplied GitExport,
+// or nil if it does not reference any.
+func (r *Reconciler) auth(ctx context.Context, ge *v1alpha1.GitExport) (transport.AuthMethod, error) {
+	ref := ge.Spec.SecretRef
+	if ref == nil {
+		return nil, nil
+	}
+	s := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetSecret)
+	}
+	return &http.BasicAuth{
+		Username: string(s.Data[secretKeyUsername]),
+		Password: string(s.Data[secretKeyPassword]),
+	}, nil
+}
+
+// manifestFile returns the path and content of the exported manifest of the
+// supplied Object. Manifests of Secrets are only exported if includeSecrets
+// is true, false is returned for skipped manifests.
+func manifestFile(o *v1alpha2.Object, includeSecrets bool) (string, []byte, bool, error) {
+	raw, err := manifest.ForObject(o)
+	if err != nil {
+		return "", nil, false, err
+	}
+	u := &unstructured.Unstructured{}
+	if err := u.UnmarshalJSON(raw); err != nil {
+		return "", nil, false, err
+	}
+	if !includeSecrets && u.GroupVersionKind().GroupKind() == secretKind {
+		return "", nil, false, nil
+	}
+	if o.Spec.ForProvider.ManifestURL != "" {
+		// The manifest of a manifestURL is the observed resource, without
+		// the fields the API server sets it is the one we applied.
+		withoutServerFields(u)
+		if raw, err = u.MarshalJSON(); err != nil {
+			return "", nil, false, err
+		}
+	}
+	data, err := yaml.JSONToYAML(raw)
+	if err != nil {
+		return "", nil, false, err
+	}
+	return manifestPath(o, u.GetNamespace()), data, true, nil
+}
+
+// withoutServerFields removes the status and the metadata the API server
+// sets from the supplied resource.
+func withoutServerFields(u *unstructured.Unstructured) {
+	unstructured.RemoveNestedField(u.Object, "status")
+	for _, f := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "selfLink"} {
+		unstructured.RemoveNestedField(u.Object, "metadata", f)
+	}
+}
+
+func manifestPath(o *v1alpha2.Object, namespace string) string {
+	pc := "default"
+	if ref := o.Spec.ProviderConfigReference; ref != nil {
+		pc = ref.Name
+	}
+	if namespace == "" {
+		namespace = clusterScoped
+	}
+	return path.Join(pc, namespace, o.Name+".yaml")
+}
+
+func selectorOf(ge *v1alpha1.GitExport) (labels.Selector, error) {
+	if ge.Spec.Selector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(ge.Spec.Selector)
+}
+
+// hasFileOf returns true if the supplied GitExport exported the manifest of
+// the named Object.
+func hasFileOf(ge *v1alpha1.GitExport, name string) bool {
+	for _, f := range ge.Status.Files {
+		if path.Base(f) == name+".yaml" {
+			return true
+		}
+	}
+	return false
+}
+
+func branchOf(ge *v1alpha1.GitExport) string {
+	if ge.Spec.Branch != "" {
+		return ge.Spec.Branch
+	}
+	return "main"
+}
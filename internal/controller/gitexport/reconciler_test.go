@@ -0,0 +1,278 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitexport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/gitexport/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestReconciler(t *testing.T) {
+	gitExportName := types.NamespacedName{Name: "fleet"}
+	errBoom := fmt.Errorf("error reading")
+	pollInterval := 10 * time.Second
+
+	getGitExport := func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		ge := obj.(*v1alpha1.GitExport)
+		ge.Name = key.Name
+		ge.Spec.Repository = "https://example.org/fleet.git"
+		ge.Status.Files = []string{"default/_cluster/stale.yaml"}
+		return nil
+	}
+	listObjects := func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+		l := list.(*v1alpha2.ObjectList)
+		l.Items = []v1alpha2.Object{{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings"},
+			Spec: v1alpha2.ObjectSpec{
+				ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "spoke"}},
+				ForProvider: v1alpha2.ObjectParameters{
+					Manifest: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"apps","name":"settings"}}`)},
+				},
+			},
+		}}
+		return nil
+	}
+	secretObject := v1alpha2.Object{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials"},
+		Spec: v1alpha2.ObjectSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "spoke"}},
+			ForProvider: v1alpha2.ObjectParameters{
+				Manifest: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"namespace":"apps","name":"credentials"},"stringData":{"password":"secret"}}`)},
+			},
+		},
+	}
+
+	type args struct {
+		client *test.MockClient
+		commit func(ctx context.Context, req commitRequest) (string, error)
+	}
+	type want struct {
+		r   reconcile.Result
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ErrorGetGitExport": {
+			reason: "We should return error.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"GitExportNotFound": {
+			reason: "We should not return an error if the GitExport was not found.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				},
+			},
+		},
+		"ErrorCommit": {
+			reason: "We should return an error and set the Synced condition if the manifests cannot be committed.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  getGitExport,
+					MockList: listObjects,
+					MockStatusUpdate: func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						if c := obj.(*v1alpha1.GitExport).Status.GetCondition(xpv1.TypeSynced); c.Status != corev1.ConditionFalse {
+							t.Errorf("expected Synced condition to be false, got %v", c)
+						}
+						return nil
+					},
+				},
+				commit: func(ctx context.Context, req commitRequest) (string, error) {
+					return "", errBoom
+				},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"ExportManifests": {
+			reason: "We should commit the manifests of the selected Objects and remove the stale ones.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  getGitExport,
+					MockList: listObjects,
+					MockStatusUpdate: func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						ge := obj.(*v1alpha1.GitExport)
+						if c := ge.Status.GetCondition(xpv1.TypeReady); c.Status != corev1.ConditionTrue {
+							t.Errorf("expected Ready condition to be true, got %v", c)
+						}
+						if diff := cmp.Diff([]string{"spoke/apps/settings.yaml"}, ge.Status.Files); diff != "" {
+							t.Errorf("status.files: -want, +got:\n%s", diff)
+						}
+						if ge.Status.LastCommit != "abc" {
+							t.Errorf("expected last commit %q, got %q", "abc", ge.Status.LastCommit)
+						}
+						return nil
+					},
+				},
+				commit: func(ctx context.Context, req commitRequest) (string, error) {
+					want := commitRequest{
+						url:     "https://example.org/fleet.git",
+						branch:  "main",
+						files:   map[string][]byte{"spoke/apps/settings.yaml": []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n  namespace: apps\n")},
+						stale:   []string{"default/_cluster/stale.yaml"},
+						message: "Export 1 manifests of GitExport fleet",
+					}
+					if diff := cmp.Diff(want, req, cmp.AllowUnexported(commitRequest{})); diff != "" {
+						t.Errorf("commit(...): -want, +got:\n%s", diff)
+					}
+					return "abc", nil
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: pollInterval},
+			},
+		},
+		"SkipSecrets": {
+			reason: "We should not commit the manifests of Secrets unless the GitExport includes them.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: getGitExport,
+					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						if err := listObjects(ctx, list, opts...); err != nil {
+							return err
+						}
+						l := list.(*v1alpha2.ObjectList)
+						l.Items = append(l.Items, secretObject)
+						return nil
+					},
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				commit: func(ctx context.Context, req commitRequest) (string, error) {
+					if _, ok := req.files["spoke/apps/credentials.yaml"]; ok {
+						t.Errorf("commit(...): unexpected manifest of a Secret")
+					}
+					return "abc", nil
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: pollInterval},
+			},
+		},
+		"IncludeSecrets": {
+			reason: "We should commit the manifests of Secrets if the GitExport includes them.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						if err := getGitExport(ctx, key, obj); err != nil {
+							return err
+						}
+						obj.(*v1alpha1.GitExport).Spec.IncludeSecrets = true
+						return nil
+					},
+					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						list.(*v1alpha2.ObjectList).Items = []v1alpha2.Object{secretObject}
+						return nil
+					},
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				commit: func(ctx context.Context, req commitRequest) (string, error) {
+					if _, ok := req.files["spoke/apps/credentials.yaml"]; !ok {
+						t.Errorf("commit(...): want manifest of a Secret, got %v", req.files)
+					}
+					return "abc", nil
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: pollInterval},
+			},
+		},
+		"ExportManifestURL": {
+			reason: "We should commit the observed manifest of Objects with a manifest URL, without the fields set by the API server.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: getGitExport,
+					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						l := list.(*v1alpha2.ObjectList)
+						l.Items = []v1alpha2.Object{{
+							ObjectMeta: metav1.ObjectMeta{Name: "settings"},
+							Spec: v1alpha2.ObjectSpec{
+								ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "spoke"}},
+								ForProvider:  v1alpha2.ObjectParameters{ManifestURL: "https://example.org/settings.yaml"},
+							},
+							Status: v1alpha2.ObjectStatus{AtProvider: v1alpha2.ObjectObservation{
+								Manifest: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"apps","name":"settings","uid":"42","resourceVersion":"7"},"status":{}}`)},
+							}},
+						}}
+						return nil
+					},
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				commit: func(ctx context.Context, req commitRequest) (string, error) {
+					want := map[string][]byte{"spoke/apps/settings.yaml": []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n  namespace: apps\n")}
+					if diff := cmp.Diff(want, req.files); diff != "" {
+						t.Errorf("commit(...): -want files, +got:\n%s", diff)
+					}
+					return "abc", nil
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: pollInterval},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{
+				client: tc.args.client,
+				log:    logging.NewNopLogger(),
+				commit: tc.args.commit,
+				pollInterval: func() time.Duration {
+					return pollInterval
+				},
+			}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: gitExportName})
+			if !errors.Is(err, tc.want.err) {
+				t.Errorf("\n%s\nr.Reconcile(...): want error: %v, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
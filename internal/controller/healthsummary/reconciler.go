@@ -0,0 +1,249 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthsummary
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/healthsummary/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/object"
+)
+
+const (
+	errStatusUpdate  = "cannot update status"
+	errParseSelector = "cannot parse object selector"
+	errListObjects   = "cannot list Objects"
+
+	// summaryInterval is how often the summarized Objects are counted.
+	summaryInterval = time.Minute
+
+	// maxUnhealthyObjects is the number of unhealthy Objects listed in the
+	// status of a summary at most.
+	maxUnhealthyObjects = 50
+
+	defaultUnhealthyThreshold = 5 * time.Minute
+)
+
+// A Reconciler summarizes the health of the Objects whose resources are in
+// the namespace of a HealthSummary.
+type Reconciler struct {
+	summarizer
+	log logging.Logger
+}
+
+// A ClusterReconciler summarizes the health of the Objects selected by a
+// ClusterHealthSummary.
+type ClusterReconciler struct {
+	summarizer
+	log logging.Logger
+}
+
+// Setup adds the controllers that reconcile HealthSummary and
+// ClusterHealthSummary resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	s := summarizer{client: mgr.GetClient(), now: time.Now}
+
+	name := managed.ControllerName(v1alpha1.HealthSummaryGroupKind)
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.HealthSummary{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{}),
+		)).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(&Reconciler{summarizer: s, log: o.Logger}), o.GlobalRateLimiter)); err != nil {
+		return err
+	}
+
+	name = managed.ControllerName(v1alpha1.ClusterHealthSummaryGroupKind)
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.ClusterHealthSummary{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{}),
+		)).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(&ClusterReconciler{summarizer: s, log: o.Logger}), o.GlobalRateLimiter))
+}
+
+// Reconcile summarizes the health of the Objects of a HealthSummary, and
+// summarizes it again after the summary interval.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, error error) {
+	log := r.log.WithValues("request", req)
+
+	defer func() {
+		if error == nil {
+			log.Debug("Reconciled")
+		} else {
+			log.Info("Retry", "err", error)
+		}
+	}()
+
+	hs := &v1alpha1.HealthSummary{}
+	if err := r.client.Get(ctx, req.NamespacedName, hs); err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if meta.WasDeleted(hs) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsPaused(hs) {
+		hs.Status.SetConditions(xpv1.ReconcilePaused())
+		return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, hs), errStatusUpdate)
+	}
+
+	if err := r.summarize(ctx, hs.Spec, hs.GetNamespace(), &hs.Status); err != nil {
+		hs.Status.SetConditions(xpv1.ReconcileError(err))
+		_ = r.client.Status().Update(ctx, hs)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: summaryInterval}, errors.Wrap(r.client.Status().Update(ctx, hs), errStatusUpdate)
+}
+
+// Reconcile summarizes the health of the Objects of a ClusterHealthSummary,
+// and summarizes it again after the summary interval.
+func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, error error) {
+	log := r.log.WithValues("request", req)
+
+	defer func() {
+		if error == nil {
+			log.Debug("Reconciled")
+		} else {
+			log.Info("Retry", "err", error)
+		}
+	}()
+
+	hs := &v1alpha1.ClusterHealthSummary{}
+	if err := r.client.Get(ctx, req.NamespacedName, hs); err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if meta.WasDeleted(hs) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsPaused(hs) {
+		hs.Status.SetConditions(xpv1.ReconcilePaused())
+		return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, hs), errStatusUpdate)
+	}
+
+	if err := r.summarize(ctx, hs.Spec, "", &hs.Status); err != nil {
+		hs.Status.SetConditions(xpv1.ReconcileError(err))
+		_ = r.client.Status().Update(ctx, hs)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: summaryInterval}, errors.Wrap(r.client.Status().Update(ctx, hs), errStatusUpdate)
+}
+
+// A summarizer summarizes the health of Objects.
+type summarizer struct {
+	client client.Client
+	now    func() time.Time
+}
+
+// summarize counts the Objects selected by the supplied spec into the
+// supplied status. If a namespace is supplied, only Objects whose resources
+// are in it are counted. Objects that failed to sync are degraded, others
+// are ready or not ready by their Ready condition.
+func (s summarizer) summarize(ctx context.Context, spec v1alpha1.HealthSummarySpec, namespace string, status *v1alpha1.HealthSummaryStatus) error {
+	sel := labels.Everything()
+	if spec.ObjectSelector != nil {
+		var err error
+		if sel, err = metav1.LabelSelectorAsSelector(spec.ObjectSelector); err != nil {
+			return errors.Wrap(err, errParseSelector)
+		}
+	}
+	l := &v1alpha2.ObjectList{}
+	if err := s.client.List(ctx, l, client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return errors.Wrap(err, errListObjects)
+	}
+
+	threshold := defaultUnhealthyThreshold
+	if spec.UnhealthyThreshold != nil {
+		threshold = spec.UnhealthyThreshold.Duration
+	}
+	now := s.now()
+
+	var ready, notReady, degraded int64
+	var unhealthy []string
+	overdue := 0
+	for i := range l.Items {
+		o := &l.Items[i]
+		if namespace != "" {
+			if ns := object.IndexByManifestNamespace(o); len(ns) == 0 || ns[0] != namespace {
+				continue
+			}
+		}
+
+		rc := o.GetCondition(xpv1.TypeReady)
+		switch {
+		case o.GetCondition(xpv1.TypeSynced).Status == corev1.ConditionFalse:
+			degraded++
+		case rc.Status == corev1.ConditionTrue:
+			ready++
+			continue
+		default:
+			notReady++
+		}
+		unhealthy = append(unhealthy, o.GetName())
+		if rc.Status == corev1.ConditionFalse && now.Sub(rc.LastTransitionTime.Time) > threshold {
+			overdue++
+		}
+	}
+
+	sort.Strings(unhealthy)
+	if len(unhealthy) > maxUnhealthyObjects {
+		unhealthy = unhealthy[:maxUnhealthyObjects]
+	}
+
+	status.Ready, status.NotReady, status.Degraded = ready, notReady, degraded
+	status.UnhealthyObjects = unhealthy
+	healthy := v1alpha1.Healthy()
+	if overdue > 0 {
+		healthy = v1alpha1.Unhealthy(overdue, threshold)
+	}
+	status.SetConditions(xpv1.ReconcileSuccess(), healthy)
+	return nil
+}
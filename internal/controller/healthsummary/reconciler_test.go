@@ -0,0 +1,234 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthsummary
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/healthsummary/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestSummarize(t *testing.T) {
+	now := time.Now()
+	errBoom := errors.New("boom")
+
+	obj := func(name, namespace string, conditions ...xpv1.Condition) v1alpha2.Object {
+		m, _ := json.Marshal(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]string{"name": name, "namespace": namespace},
+		})
+		o := v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		o.Spec.ForProvider.Manifest = runtime.RawExtension{Raw: m}
+		o.SetConditions(conditions...)
+		return o
+	}
+	notReadySince := func(d time.Duration) xpv1.Condition {
+		c := xpv1.Unavailable()
+		c.LastTransitionTime = metav1.NewTime(now.Add(-d))
+		return c
+	}
+
+	type args struct {
+		spec      v1alpha1.HealthSummarySpec
+		namespace string
+		objects   []v1alpha2.Object
+		listErr   error
+	}
+	type want struct {
+		status  v1alpha1.HealthSummaryStatus
+		healthy corev1.ConditionStatus
+		err     error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ListError": {
+			reason: "We should return an error if the Objects cannot be listed.",
+			args: args{
+				listErr: errBoom,
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListObjects),
+			},
+		},
+		"Healthy": {
+			reason: "We should count ready, not ready and degraded Objects, and be healthy while no Object is not ready for longer than the threshold.",
+			args: args{
+				objects: []v1alpha2.Object{
+					obj("a", "default", xpv1.Available(), xpv1.ReconcileSuccess()),
+					obj("b", "default", notReadySince(time.Minute), xpv1.ReconcileSuccess()),
+					obj("c", "default", xpv1.ReconcileError(errBoom)),
+				},
+			},
+			want: want{
+				status: v1alpha1.HealthSummaryStatus{
+					Ready:            1,
+					NotReady:         1,
+					Degraded:         1,
+					UnhealthyObjects: []string{"b", "c"},
+				},
+				healthy: corev1.ConditionTrue,
+			},
+		},
+		"Unhealthy": {
+			reason: "We should be unhealthy if an Object is not ready for longer than the threshold.",
+			args: args{
+				spec: v1alpha1.HealthSummarySpec{UnhealthyThreshold: &metav1.Duration{Duration: time.Minute}},
+				objects: []v1alpha2.Object{
+					obj("a", "default", notReadySince(time.Hour), xpv1.ReconcileSuccess()),
+				},
+			},
+			want: want{
+				status: v1alpha1.HealthSummaryStatus{
+					NotReady:         1,
+					UnhealthyObjects: []string{"a"},
+				},
+				healthy: corev1.ConditionFalse,
+			},
+		},
+		"Namespace": {
+			reason: "We should only count Objects whose resources are in the namespace of the summary.",
+			args: args{
+				namespace: "team-a",
+				objects: []v1alpha2.Object{
+					obj("a", "team-a", xpv1.Available()),
+					obj("b", "team-b", notReadySince(time.Hour)),
+				},
+			},
+			want: want{
+				status: v1alpha1.HealthSummaryStatus{
+					Ready: 1,
+				},
+				healthy: corev1.ConditionTrue,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := summarizer{
+				client: &test.MockClient{
+					MockList: func(_ context.Context, l client.ObjectList, _ ...client.ListOption) error {
+						l.(*v1alpha2.ObjectList).Items = tc.args.objects
+						return tc.args.listErr
+					},
+				},
+				now: func() time.Time { return now },
+			}
+			got := v1alpha1.HealthSummaryStatus{}
+			err := s.summarize(context.Background(), tc.args.spec, tc.args.namespace, &got)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.summarize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.status, got, cmpopts.IgnoreFields(v1alpha1.HealthSummaryStatus{}, "ResourceStatus")); diff != "" {
+				t.Errorf("\n%s\ns.summarize(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+			if c := got.GetCondition(v1alpha1.TypeHealthy); c.Status != tc.want.healthy {
+				t.Errorf("\n%s\ns.summarize(...): want Healthy condition %s, got %v", tc.reason, tc.want.healthy, c)
+			}
+		})
+	}
+}
+
+func TestReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		r   reconcile.Result
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		client *test.MockClient
+		want   want
+	}{
+		"NotFound": {
+			reason: "We should not return an error if the HealthSummary was not found.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+			},
+		},
+		"ListError": {
+			reason: "We should return an error and set the Synced condition if the Objects cannot be listed.",
+			client: &test.MockClient{
+				MockGet:  test.NewMockGetFn(nil),
+				MockList: test.NewMockListFn(errBoom),
+				MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+					if c := obj.(*v1alpha1.HealthSummary).Status.GetCondition(xpv1.TypeSynced); c.Status != corev1.ConditionFalse {
+						t.Errorf("expected Synced condition to be false, got %v", c)
+					}
+					return nil
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListObjects),
+			},
+		},
+		"Summarized": {
+			reason: "We should summarize the Objects again after the summary interval.",
+			client: &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil),
+				MockList:         test.NewMockListFn(nil),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: summaryInterval},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{
+				summarizer: summarizer{client: tc.client, now: time.Now},
+				log:        logging.NewNopLogger(),
+			}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "summary"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
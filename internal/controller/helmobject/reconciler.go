@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helmobject
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/helmobject/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errStatusUpdate    = "cannot update status"
+	fieldOwner         = client.FieldOwner("kubernetes.crossplane.io/helm-object-controller")
+	membershipLabelKey = "kubernetes.crossplane.io/owned-by-helm-object"
+)
+
+// Reconciler watches for HelmObject resources, renders their charts and
+// creates an Object for every rendered manifest.
+type Reconciler struct {
+	client       client.Client
+	log          logging.Logger
+	pollInterval func() time.Duration
+	render       func(ctx context.Context, ho *v1alpha1.HelmObject) ([]*unstructured.Unstructured, error)
+}
+
+// Setup adds a controller that reconciles HelmObject resources.
+func Setup(mgr ctrl.Manager, o controller.Options, pollJitter time.Duration) error {
+	name := managed.ControllerName(v1alpha1.HelmObjectGroupKind)
+
+	r := &Reconciler{
+		client: mgr.GetClient(),
+		log:    o.Logger,
+		pollInterval: func() time.Duration {
+			return o.PollInterval + time.Duration((rand.Float64()-0.5)*2*float64(pollJitter)) //nolint
+		},
+		render: renderChart,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.HelmObject{}).
+		WithEventFilter(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{}),
+		).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// Reconcile renders the chart of a HelmObject and applies an Object for each
+// rendered manifest, removing Objects which are no longer rendered.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, error error) { //nolint:gocyclo
+	log := r.log.WithValues("request", req)
+
+	defer func() {
+		if error == nil {
+			log.Info("Reconciled")
+		} else {
+			log.Info("Retry", "err", error)
+		}
+	}()
+
+	ho := &v1alpha1.HelmObject{}
+	err := r.client.Get(ctx, req.NamespacedName, ho)
+
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	// Rendered Objects are owned by the HelmObject and garbage collected
+	// once it is gone.
+	if meta.WasDeleted(ho) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsPaused(ho) {
+		ho.Status.SetConditions(xpv1.ReconcilePaused())
+		return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, ho), errStatusUpdate)
+	}
+
+	log.Info("Reconciling")
+
+	manifests, err := r.render(ctx, ho)
+	if err != nil {
+		ho.Status.SetConditions(xpv1.ReconcileError(err))
+		_ = r.client.Status().Update(ctx, ho)
+		return ctrl.Result{}, err
+	}
+
+	// Fetch any existing Objects rendered from this HelmObject by label.
+	ml := map[string]string{membershipLabelKey: ho.Name}
+	ol := &v1alpha2.ObjectList{}
+	if err := r.client.List(ctx, ol, client.MatchingLabels(ml)); err != nil {
+		werr := errors.Wrapf(err, "cannot list members matching labels %v", ml)
+		ho.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, ho)
+		return ctrl.Result{}, werr
+	}
+
+	// Apply an Object for every rendered manifest. Server-side apply only
+	// changes Objects whose rendered manifest changed.
+	names := sets.New[string]()
+	for _, m := range manifests {
+		po, err := renderedObjectPatch(ho, m)
+		if err != nil {
+			werr := errors.Wrapf(err, "error generating patch for rendered manifest %s/%s", m.GetKind(), m.GetName())
+			ho.Status.SetConditions(xpv1.ReconcileError(werr))
+			_ = r.client.Status().Update(ctx, ho)
+			return ctrl.Result{}, werr
+		}
+		if err := r.client.Patch(ctx, po, client.Apply, fieldOwner, client.ForceOwnership); err != nil {
+			werr := errors.Wrap(err, "cannot apply rendered object")
+			ho.Status.SetConditions(xpv1.ReconcileError(werr))
+			_ = r.client.Status().Update(ctx, ho)
+			return ctrl.Result{}, werr
+		}
+		log.Debug("applied rendered object", "name", po.GetName())
+		names.Insert(po.GetName())
+	}
+
+	// Remove Objects that are no longer rendered by the chart.
+	for i := range ol.Items {
+		o := ol.Items[i]
+		if names.Has(o.Name) {
+			continue
+		}
+		log.Debug("Removing", "name", o.Name)
+		if err := r.client.Delete(ctx, &ol.Items[i]); resource.IgnoreNotFound(err) != nil {
+			werr := errors.Wrapf(err, "cannot delete rendered object %s", o.Name)
+			ho.Status.SetConditions(xpv1.ReconcileError(werr))
+			_ = r.client.Status().Update(ctx, ho)
+			return ctrl.Result{}, werr
+		}
+	}
+	ho.Status.SetConditions(xpv1.ReconcileSuccess(), xpv1.Available())
+
+	ho.Status.MembershipLabel = ml
+
+	return ctrl.Result{RequeueAfter: r.pollInterval()}, r.client.Status().Update(ctx, ho)
+}
+
+// renderedObjectName returns a stable name for the Object that manages the
+// supplied rendered manifest.
+func renderedObjectName(ho *v1alpha1.HelmObject, m *unstructured.Unstructured) string {
+	k := fmt.Sprintf("%v/%s/%s", m.GroupVersionKind().GroupKind(), m.GetNamespace(), m.GetName())
+	h := sha256.Sum256([]byte(k))
+	return fmt.Sprintf("%s-%s", ho.GetName(), fmt.Sprintf("%x", h)[0:7])
+}
+
+func renderedObjectPatch(ho *v1alpha1.HelmObject, m *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	raw, err := m.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal rendered manifest")
+	}
+	o := &v1alpha2.Object{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: renderedObjectName(ho, m),
+			Labels: map[string]string{
+				membershipLabelKey: ho.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: v1alpha1.SchemeGroupVersion.String(),
+					Kind:       v1alpha1.HelmObjectKind,
+					Name:       ho.Name,
+					UID:        ho.UID,
+				},
+			},
+		},
+		Spec: v1alpha2.ObjectSpec{
+			ResourceSpec: xpv1.ResourceSpec{
+				ProviderConfigReference: &ho.Spec.ProviderConfigReference,
+			},
+			ForProvider: v1alpha2.ObjectParameters{
+				Manifest: runtime.RawExtension{Raw: raw},
+			},
+		},
+	}
+	v, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot convert to unstructured")
+	}
+	u := &unstructured.Unstructured{Object: v}
+	u.SetGroupVersionKind(v1alpha2.ObjectGroupVersionKind)
+	return u, nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helmobject
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/helmobject/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestReconciler(t *testing.T) {
+	helmObjectName := types.NamespacedName{Name: "podinfo"}
+	errBoom := fmt.Errorf("error reading")
+	pollInterval := 10 * time.Second
+
+	configMap := func(name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace("default")
+		u.SetName(name)
+		return u
+	}
+	getHelmObject := func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		ho := obj.(*v1alpha1.HelmObject)
+		ho.Name = key.Name
+		ho.Spec.Chart = v1alpha1.HelmChart{Reference: "oci://ghcr.io/example/charts/podinfo", Version: "1.0.0"}
+		return nil
+	}
+
+	type args struct {
+		client *test.MockClient
+		render func(ctx context.Context, ho *v1alpha1.HelmObject) ([]*unstructured.Unstructured, error)
+	}
+	type want struct {
+		r   reconcile.Result
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ErrorGetHelmObject": {
+			reason: "We should return error.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"HelmObjectNotFound": {
+			reason: "We should not return an error if the HelmObject was not found.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				},
+			},
+		},
+		"ErrorRender": {
+			reason: "We should return an error and set the Synced condition if the chart cannot be rendered.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: getHelmObject,
+					MockStatusUpdate: func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						if c := obj.(*v1alpha1.HelmObject).Status.GetCondition(xpv1.TypeSynced); c.Status != corev1.ConditionFalse {
+							t.Errorf("expected Synced condition to be false, got %v", c)
+						}
+						return nil
+					},
+				},
+				render: func(ctx context.Context, ho *v1alpha1.HelmObject) ([]*unstructured.Unstructured, error) {
+					return nil, errBoom
+				},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"ApplyRenderedObjects": {
+			reason: "We should apply an Object for every rendered manifest and remove stale ones.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: getHelmObject,
+					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						l := list.(*v1alpha2.ObjectList)
+						l.Items = append(l.Items, v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{Name: "podinfo-stale"}})
+						return nil
+					},
+					MockPatch: func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if l := obj.GetLabels()[membershipLabelKey]; l != helmObjectName.Name {
+							t.Errorf("expected membership label %q, got %q", helmObjectName.Name, l)
+						}
+						return nil
+					},
+					MockDelete: func(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+						if obj.GetName() != "podinfo-stale" {
+							t.Errorf("unexpected deletion of %q", obj.GetName())
+						}
+						return nil
+					},
+					MockStatusUpdate: func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						if c := obj.(*v1alpha1.HelmObject).Status.GetCondition(xpv1.TypeReady); c.Status != corev1.ConditionTrue {
+							t.Errorf("expected Ready condition to be true, got %v", c)
+						}
+						return nil
+					},
+				},
+				render: func(ctx context.Context, ho *v1alpha1.HelmObject) ([]*unstructured.Unstructured, error) {
+					return []*unstructured.Unstructured{configMap("a"), configMap("b")}, nil
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: pollInterval},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{
+				client: tc.args.client,
+				log:    logging.NewNopLogger(),
+				render: tc.args.render,
+				pollInterval: func() time.Duration {
+					return pollInterval
+				},
+			}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: helmObjectName})
+			if !errors.Is(err, tc.want.err) {
+				t.Errorf("\n%s\nr.Reconcile(...): want error: %v, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParseManifests(t *testing.T) {
+	manifest := `---
+# Source: podinfo/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: podinfo
+---
+# Source: podinfo/templates/disabled.yaml
+---
+# Source: podinfo/templates/clusterrole.yaml
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: podinfo
+  namespace: other
+`
+	got, err := parseManifests(manifest, "default")
+	if err != nil {
+		t.Fatalf("parseManifests(...): unexpected error: %v", err)
+	}
+
+	want := []string{"Service/default/podinfo", "ClusterRole/other/podinfo"}
+	gotKeys := make([]string, 0, len(got))
+	for _, u := range got {
+		gotKeys = append(gotKeys, fmt.Sprintf("%s/%s/%s", u.GetKind(), u.GetNamespace(), u.GetName()))
+	}
+	if diff := cmp.Diff(want, gotKeys); diff != "" {
+		t.Errorf("parseManifests(...): -want, +got:\n%s", diff)
+	}
+}
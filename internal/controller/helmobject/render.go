@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helmobject
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/helmobject/v1alpha1"
+)
+
+const (
+	errNewRegistryClient = "cannot create OCI registry client"
+	errPullChart         = "cannot pull chart"
+	errLoadChart         = "cannot load chart"
+	errUnmarshalValues   = "cannot unmarshal values"
+	errRenderChart       = "cannot render chart"
+	errParseManifest     = "cannot parse rendered manifest"
+)
+
+// renderChart pulls the chart of the supplied HelmObject from its OCI
+// registry and renders it the same way "helm template" would.
+func renderChart(ctx context.Context, ho *v1alpha1.HelmObject) ([]*unstructured.Unstructured, error) {
+	rc, err := registry.NewClient()
+	if err != nil {
+		return nil, errors.Wrap(err, errNewRegistryClient)
+	}
+
+	ref := strings.TrimPrefix(ho.Spec.Chart.Reference, "oci://") + ":" + ho.Spec.Chart.Version
+	pulled, err := rc.Pull(ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %q", errPullChart, ref)
+	}
+
+	ch, err := loader.LoadArchive(bytes.NewReader(pulled.Chart.Data))
+	if err != nil {
+		return nil, errors.Wrap(err, errLoadChart)
+	}
+
+	vals := map[string]interface{}{}
+	if len(ho.Spec.Values.Raw) > 0 {
+		if err := json.Unmarshal(ho.Spec.Values.Raw, &vals); err != nil {
+			return nil, errors.Wrap(err, errUnmarshalValues)
+		}
+	}
+
+	install := action.NewInstall(&action.Configuration{Log: func(string, ...interface{}) {}})
+	install.DryRun = true
+	install.ClientOnly = true
+	install.IncludeCRDs = true
+	install.Replace = true
+	install.ReleaseName = releaseName(ho)
+	install.Namespace = ho.Spec.Namespace
+
+	rel, err := install.RunWithContext(ctx, ch, vals)
+	if err != nil {
+		return nil, errors.Wrap(err, errRenderChart)
+	}
+
+	return parseManifests(rel.Manifest, ho.Spec.Namespace)
+}
+
+// parseManifests splits a rendered multi-document manifest into objects,
+// defaulting the namespace of those that do not set one. The API server
+// ignores the namespace of cluster scoped objects.
+func parseManifests(manifest, namespace string) ([]*unstructured.Unstructured, error) {
+	docs := releaseutil.SplitManifests(manifest)
+
+	// SplitManifests returns a map, sort it to keep a stable order.
+	keys := make([]string, 0, len(docs))
+	for k := range docs {
+		keys = append(keys, k)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+
+	objs := make([]*unstructured.Unstructured, 0, len(docs))
+	for _, k := range keys {
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(docs[k]), &u.Object); err != nil {
+			return nil, errors.Wrap(err, errParseManifest)
+		}
+		if len(u.Object) == 0 {
+			// Templates that render to nothing, e.g. disabled by values.
+			continue
+		}
+		if u.GetNamespace() == "" && namespace != "" {
+			u.SetNamespace(namespace)
+		}
+		objs = append(objs, u)
+	}
+	return objs, nil
+}
+
+func releaseName(ho *v1alpha1.HelmObject) string {
+	if ho.Spec.ReleaseName != "" {
+		return ho.Spec.ReleaseName
+	}
+	return ho.Name
+}
@@ -17,28 +17,86 @@ limitations under the License.
 package controller
 
 import (
-	"time"
-
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/autoproviderconfig"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/bulkdelete"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/clusterinformerbudget"
 	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/config"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/discoveryjob"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/gitexport"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/healthsummary"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/helmobject"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/manifestreport"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/namespaceobjectquota"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/namespacereport"
 	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/object"
 	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/observedobjectcollection"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/providerconfigmigration"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/statusexporterconfig"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/syncedconfigmap"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/ttl"
 )
 
 // Setup creates all Template controllers with the supplied logger and adds them to
 // the supplied manager.
-func Setup(mgr ctrl.Manager, o controller.Options, sanitizeSecrets bool, pollJitter time.Duration) error {
+func Setup(mgr ctrl.Manager, o controller.Options, so object.SetupOptions) error {
 	if err := config.Setup(mgr, o); err != nil {
 		return err
 	}
-	if err := object.Setup(mgr, o, sanitizeSecrets, pollJitter); err != nil {
+	pollJitter := so.PollJitter
+	// Budgets set by ClusterInformerBudgets limit the informers of Objects.
+	budgets := object.NewInformerBudgets()
+	so.InformerBudgets = budgets
+	if err := object.Setup(mgr, o, so); err != nil {
 		return err
 	}
 	if err := observedobjectcollection.Setup(mgr, o, pollJitter); err != nil {
 		return err
 	}
+	if err := helmobject.Setup(mgr, o, pollJitter); err != nil {
+		return err
+	}
+	if err := ttl.Setup(mgr, o); err != nil {
+		return err
+	}
+	if err := discoveryjob.Setup(mgr, o); err != nil {
+		return err
+	}
+	if err := manifestreport.Setup(mgr, o, pollJitter); err != nil {
+		return err
+	}
+	if err := autoproviderconfig.Setup(mgr, o, pollJitter); err != nil {
+		return err
+	}
+	if err := bulkdelete.Setup(mgr, o); err != nil {
+		return err
+	}
+	if err := providerconfigmigration.Setup(mgr, o); err != nil {
+		return err
+	}
+	if err := syncedconfigmap.Setup(mgr, o, pollJitter); err != nil {
+		return err
+	}
+	if err := gitexport.Setup(mgr, o, pollJitter); err != nil {
+		return err
+	}
+	if err := clusterinformerbudget.Setup(mgr, o, pollJitter, budgets); err != nil {
+		return err
+	}
+	if err := namespaceobjectquota.Setup(mgr, o, pollJitter); err != nil {
+		return err
+	}
+	if err := healthsummary.Setup(mgr, o); err != nil {
+		return err
+	}
+	if err := namespacereport.Setup(mgr, o); err != nil {
+		return err
+	}
+	if err := statusexporterconfig.Setup(mgr, o); err != nil {
+		return err
+	}
 	return nil
 }
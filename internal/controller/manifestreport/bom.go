@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifestreport
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// CycloneDX BOM format constants, see https://cyclonedx.org/specification/overview/
+const (
+	bomFormat        = "CycloneDX"
+	bomSpecVersion   = "1.5"
+	bomComponentType = "data"
+
+	propertyAPIVersion      = "kubernetes.crossplane.io/apiVersion"
+	propertyKind            = "kubernetes.crossplane.io/kind"
+	propertyNamespace       = "kubernetes.crossplane.io/namespace"
+	propertyResourceVersion = "kubernetes.crossplane.io/resourceVersion"
+	propertyObject          = "kubernetes.crossplane.io/object"
+)
+
+// bom is the subset of a CycloneDX bill of materials the report uses.
+type bom struct {
+	BOMFormat    string      `json:"bomFormat"`
+	SpecVersion  string      `json:"specVersion"`
+	SerialNumber string      `json:"serialNumber"`
+	Version      int         `json:"version"`
+	Metadata     bomMetadata `json:"metadata"`
+	Components   []component `json:"components"`
+}
+
+type bomMetadata struct {
+	Timestamp string    `json:"timestamp"`
+	Component component `json:"component"`
+}
+
+type component struct {
+	Type       string     `json:"type"`
+	BOMRef     string     `json:"bom-ref,omitempty"`
+	Group      string     `json:"group,omitempty"`
+	Name       string     `json:"name"`
+	Version    string     `json:"version,omitempty"`
+	Properties []property `json:"properties,omitempty"`
+}
+
+type property struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// resourceComponent returns the component of the supplied managed resource
+// of the named Object.
+func resourceComponent(objectName string, u *unstructured.Unstructured) component {
+	gvk := u.GroupVersionKind()
+	props := []property{
+		{Name: propertyAPIVersion, Value: u.GetAPIVersion()},
+		{Name: propertyKind, Value: gvk.Kind},
+	}
+	if ns := u.GetNamespace(); ns != "" {
+		props = append(props, property{Name: propertyNamespace, Value: ns})
+	}
+	props = append(props,
+		property{Name: propertyResourceVersion, Value: u.GetResourceVersion()},
+		property{Name: propertyObject, Value: objectName},
+	)
+	return component{
+		Type:       bomComponentType,
+		BOMRef:     string(u.GetUID()),
+		Group:      gvk.Group,
+		Name:       u.GetName(),
+		Version:    u.GetResourceVersion(),
+		Properties: props,
+	}
+}
+
+func newBOM(serial string, now time.Time, providerConfig string, components []component) bom {
+	if components == nil {
+		components = []component{}
+	}
+	return bom{
+		BOMFormat:    bomFormat,
+		SpecVersion:  bomSpecVersion,
+		SerialNumber: "urn:uuid:" + serial,
+		Version:      1,
+		Metadata: bomMetadata{
+			Timestamp: now.UTC().Format(time.RFC3339),
+			Component: component{
+				Type: bomComponentType,
+				Name: providerConfig,
+			},
+		},
+		Components: components,
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifestreport
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/manifestreport/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/manifest"
+)
+
+const (
+	errNewKubernetesClient = "cannot create new Kubernetes client"
+	errStatusUpdate        = "cannot update status"
+	fieldOwner             = client.FieldOwner("kubernetes.crossplane.io/manifest-report-controller")
+	defaultKey             = "bom.json"
+)
+
+// Reconciler watches for ManifestReport resources and writes the inventory
+// of resources managed through their provider config to a ConfigMap.
+type Reconciler struct {
+	client            client.Client
+	log               logging.Logger
+	pollInterval      func() time.Duration
+	clientForProvider func(ctx context.Context, inclusterClient client.Client, providerConfigName string) (client.Client, *rest.Config, error)
+	now               func() time.Time
+}
+
+// Setup adds a controller that reconciles ManifestReport resources.
+func Setup(mgr ctrl.Manager, o controller.Options, pollJitter time.Duration) error {
+	name := managed.ControllerName(v1alpha1.ManifestReportGroupKind)
+
+	r := &Reconciler{
+		client: mgr.GetClient(),
+		log:    o.Logger,
+		pollInterval: func() time.Duration {
+			return o.PollInterval + time.Duration((rand.Float64()-0.5)*2*float64(pollJitter)) //nolint
+		},
+		clientForProvider: kube.ClientForProvider,
+		now:               time.Now,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.ManifestReport{}).
+		WithEventFilter(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{}),
+		).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// Reconcile fetches the live state of every resource managed by an Object of
+// the report's provider config and writes it to the report's ConfigMap.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, error error) { //nolint:gocyclo
+	log := r.log.WithValues("request", req)
+
+	defer func() {
+		if error == nil {
+			log.Info("Reconciled")
+		} else {
+			log.Info("Retry", "err", error)
+		}
+	}()
+
+	mr := &v1alpha1.ManifestReport{}
+	err := r.client.Get(ctx, req.NamespacedName, mr)
+
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if meta.WasDeleted(mr) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsPaused(mr) {
+		mr.Status.SetConditions(xpv1.ReconcilePaused())
+		return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, mr), errStatusUpdate)
+	}
+
+	log.Info("Reconciling")
+
+	pc := mr.Spec.ProviderConfigReference.Name
+	clusterClient, _, err := r.clientForProvider(ctx, r.client, pc)
+	if err != nil {
+		werr := errors.Wrap(err, errNewKubernetesClient)
+		mr.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, mr)
+		return ctrl.Result{}, werr
+	}
+
+	ol := &v1alpha2.ObjectList{}
+	if err := r.client.List(ctx, ol); err != nil {
+		werr := errors.Wrap(err, "cannot list objects")
+		mr.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, mr)
+		return ctrl.Result{}, werr
+	}
+
+	var components []component
+	for i := range ol.Items {
+		o := &ol.Items[i]
+		if o.Spec.ProviderConfigReference == nil || o.Spec.ProviderConfigReference.Name != pc {
+			continue
+		}
+		u, err := managedResource(o)
+		if err != nil {
+			log.Debug("Skipping object with an invalid manifest", "object", o.Name, "error", err)
+			continue
+		}
+		err = clusterClient.Get(ctx, types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}, u)
+		if kerrors.IsNotFound(err) {
+			// Not created yet, or already deleted.
+			continue
+		}
+		if err != nil {
+			werr := errors.Wrapf(err, "cannot get resource managed by object %s", o.Name)
+			mr.Status.SetConditions(xpv1.ReconcileError(werr))
+			_ = r.client.Status().Update(ctx, mr)
+			return ctrl.Result{}, werr
+		}
+		components = append(components, resourceComponent(o.Name, u))
+	}
+
+	report, err := json.Marshal(newBOM(uuid.NewString(), r.now(), pc, components))
+	if err != nil {
+		werr := errors.Wrap(err, "cannot marshal report")
+		mr.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, mr)
+		return ctrl.Result{}, werr
+	}
+
+	if err := r.client.Patch(ctx, reportConfigMap(mr, report), client.Apply, fieldOwner, client.ForceOwnership); err != nil {
+		werr := errors.Wrap(err, "cannot apply report configmap")
+		mr.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, mr)
+		return ctrl.Result{}, werr
+	}
+
+	mr.Status.Resources = len(components)
+	mr.Status.SetConditions(xpv1.ReconcileSuccess(), xpv1.Available())
+
+	return ctrl.Result{RequeueAfter: r.pollInterval()}, r.client.Status().Update(ctx, mr)
+}
+
+// managedResource returns an empty resource of the kind, namespace and name
+// managed by the supplied Object.
+func managedResource(o *v1alpha2.Object) (*unstructured.Unstructured, error) {
+	raw, err := manifest.ForObject(o)
+	if err != nil {
+		return nil, err
+	}
+	m := &unstructured.Unstructured{}
+	if err := json.Unmarshal(raw, m); err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(m.GroupVersionKind())
+	u.SetNamespace(m.GetNamespace())
+	u.SetName(m.GetName())
+	if u.GetName() == "" {
+		u.SetName(o.Name)
+	}
+	return u, nil
+}
+
+func reportConfigMap(mr *v1alpha1.ManifestReport, report []byte) *corev1.ConfigMap {
+	key := mr.Spec.ConfigMapReference.Key
+	if key == "" {
+		key = defaultKey
+	}
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: mr.Spec.ConfigMapReference.Namespace,
+			Name:      mr.Spec.ConfigMapReference.Name,
+			OwnerReferences: []metav1.OwnerReference{
+				meta.AsOwner(meta.TypedReferenceTo(mr, v1alpha1.ManifestReportGroupVersionKind)),
+			},
+		},
+		Data: map[string]string{key: string(report)},
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifestreport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/manifestreport/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestReconciler(t *testing.T) {
+	reportName := types.NamespacedName{Name: "inventory"}
+	errBoom := fmt.Errorf("error reading")
+	pollInterval := 10 * time.Second
+
+	getReport := func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		mr := obj.(*v1alpha1.ManifestReport)
+		mr.Name = key.Name
+		mr.Spec.ProviderConfigReference = xpv1.Reference{Name: "cluster-a"}
+		mr.Spec.ConfigMapReference = v1alpha1.ConfigMapReference{Namespace: "crossplane-system", Name: "inventory"}
+		return nil
+	}
+	object := func(name, pc, manifest string) v1alpha2.Object {
+		return v1alpha2.Object{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha2.ObjectSpec{
+				ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: pc}},
+				ForProvider:  v1alpha2.ObjectParameters{Manifest: runtime.RawExtension{Raw: []byte(manifest)}},
+			},
+		}
+	}
+	listObjects := func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+		list.(*v1alpha2.ObjectList).Items = []v1alpha2.Object{
+			object("cm", "cluster-a", `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"default","name":"cm"}}`),
+			object("ns", "cluster-a", `{"apiVersion":"v1","kind":"Namespace"}`),
+			object("missing", "cluster-a", `{"apiVersion":"v1","kind":"Secret","metadata":{"namespace":"default","name":"missing"}}`),
+			object("other", "cluster-b", `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"default","name":"other"}}`),
+		}
+		return nil
+	}
+	getLive := func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		if key.Name == "missing" {
+			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+		if key.Name == "other" {
+			t.Errorf("unexpected get of resource managed through another provider config")
+		}
+		obj.SetResourceVersion("42")
+		return nil
+	}
+
+	type args struct {
+		client        *test.MockClient
+		clusterClient *test.MockClient
+	}
+	type want struct {
+		r          reconcile.Result
+		err        error
+		components []string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ErrorGetManifestReport": {
+			reason: "We should return error.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"ManifestReportNotFound": {
+			reason: "We should not return an error if the ManifestReport was not found.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				},
+			},
+		},
+		"ErrorGetManagedResource": {
+			reason: "We should return an error if a managed resource cannot be fetched.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          getReport,
+					MockList:         listObjects,
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				clusterClient: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"WriteReport": {
+			reason: "We should write every existing resource managed through the provider config to the ConfigMap.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          getReport,
+					MockList:         listObjects,
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				clusterClient: &test.MockClient{
+					MockGet: getLive,
+				},
+			},
+			want: want{
+				r:          reconcile.Result{RequeueAfter: pollInterval},
+				components: []string{"cm", "ns"},
+			},
+		},
+		"ReportManifestURL": {
+			reason: "We should report the resources of Objects with a manifest URL by their observed manifest.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: getReport,
+					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						o := v1alpha2.Object{
+							ObjectMeta: metav1.ObjectMeta{Name: "fetched"},
+							Spec: v1alpha2.ObjectSpec{
+								ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "cluster-a"}},
+								ForProvider:  v1alpha2.ObjectParameters{ManifestURL: "https://example.org/fetched.yaml"},
+							},
+							Status: v1alpha2.ObjectStatus{AtProvider: v1alpha2.ObjectObservation{
+								Manifest: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"default","name":"fetched"}}`)},
+							}},
+						}
+						list.(*v1alpha2.ObjectList).Items = []v1alpha2.Object{o}
+						return nil
+					},
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				clusterClient: &test.MockClient{
+					MockGet: getLive,
+				},
+			},
+			want: want{
+				r:          reconcile.Result{RequeueAfter: pollInterval},
+				components: []string{"fetched"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			tc.args.client.MockPatch = func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				cm := obj.(*corev1.ConfigMap)
+				b := bom{}
+				if err := json.Unmarshal([]byte(cm.Data[defaultKey]), &b); err != nil {
+					t.Fatalf("cannot parse report: %v", err)
+				}
+				if b.BOMFormat != bomFormat {
+					t.Errorf("want bomFormat %q, got %q", bomFormat, b.BOMFormat)
+				}
+				for _, c := range b.Components {
+					got = append(got, c.Name)
+				}
+				return nil
+			}
+			r := &Reconciler{
+				client: tc.args.client,
+				log:    logging.NewNopLogger(),
+				pollInterval: func() time.Duration {
+					return pollInterval
+				},
+				clientForProvider: func(ctx context.Context, inclusterClient client.Client, providerConfigName string) (client.Client, *rest.Config, error) {
+					return tc.args.clusterClient, nil, nil
+				},
+				now: time.Now,
+			}
+			gotR, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: reportName})
+			if !errors.Is(err, tc.want.err) {
+				t.Errorf("\n%s\nr.Reconcile(...): want error: %v, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.r, gotR); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.components, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want components, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestResourceComponent(t *testing.T) {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("apps/v1")
+	u.SetKind("Deployment")
+	u.SetNamespace("default")
+	u.SetName("web")
+	u.SetResourceVersion("7")
+
+	want := component{
+		Type:    bomComponentType,
+		Group:   "apps",
+		Name:    "web",
+		Version: "7",
+		Properties: []property{
+			{Name: propertyAPIVersion, Value: "apps/v1"},
+			{Name: propertyKind, Value: "Deployment"},
+			{Name: propertyNamespace, Value: "default"},
+			{Name: propertyResourceVersion, Value: "7"},
+			{Name: propertyObject, Value: "web-object"},
+		},
+	}
+	if diff := cmp.Diff(want, resourceComponent("web-object", u)); diff != "" {
+		t.Errorf("resourceComponent(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespaceobjectquota
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/namespaceobjectquota/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/object"
+)
+
+const (
+	errStatusUpdate = "cannot update status"
+	errCountObjects = "cannot count Objects in namespace"
+
+	reasonGraceExceeded event.Reason = "GraceExceeded"
+)
+
+// Reconciler watches for NamespaceObjectQuota resources and counts the
+// Objects managing resources in their namespaces.
+type Reconciler struct {
+	client       client.Client
+	log          logging.Logger
+	record       event.Recorder
+	pollInterval func() time.Duration
+}
+
+// Setup adds a controller that reconciles NamespaceObjectQuota resources. It
+// counts Objects by the ManifestNamespaceIndex, which is added by the Object
+// controller.
+func Setup(mgr ctrl.Manager, o controller.Options, pollJitter time.Duration) error {
+	name := managed.ControllerName(v1alpha1.NamespaceObjectQuotaGroupKind)
+
+	r := &Reconciler{
+		client: mgr.GetClient(),
+		log:    o.Logger,
+		record: event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+		pollInterval: func() time.Duration {
+			return o.PollInterval + time.Duration((rand.Float64()-0.5)*2*float64(pollJitter)) //nolint
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.NamespaceObjectQuota{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{}),
+		)).
+		Watches(&v1alpha2.Object{}, handler.EnqueueRequestsFromMapFunc(r.quotasForObject), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// quotasForObject enqueues the NamespaceObjectQuotas of the namespace of the
+// resource managed by the supplied Object.
+func (r *Reconciler) quotasForObject(ctx context.Context, o client.Object) []reconcile.Request {
+	ns := object.IndexByManifestNamespace(o)
+	if len(ns) == 0 {
+		return nil
+	}
+	l := &v1alpha1.NamespaceObjectQuotaList{}
+	if err := r.client.List(ctx, l); err != nil {
+		r.log.Debug("cannot list namespace object quotas", "error", err)
+		return nil
+	}
+	var reqs []reconcile.Request
+	for _, q := range l.Items {
+		if q.Spec.Namespace == ns[0] {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: q.Name}})
+		}
+	}
+	return reqs
+}
+
+// Reconcile counts the Objects managing resources in the namespace of a
+// NamespaceObjectQuota, and warns once their number exceeds its grace.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, error error) {
+	log := r.log.WithValues("request", req)
+
+	defer func() {
+		if error == nil {
+			log.Info("Reconciled")
+		} else {
+			log.Info("Retry", "err", error)
+		}
+	}()
+
+	q := &v1alpha1.NamespaceObjectQuota{}
+	err := r.client.Get(ctx, req.NamespacedName, q)
+
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if meta.WasDeleted(q) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsPaused(q) {
+		q.Status.SetConditions(xpv1.ReconcilePaused())
+		return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, q), errStatusUpdate)
+	}
+
+	log.Info("Reconciling")
+
+	ol := &v1alpha2.ObjectList{}
+	if err := r.client.List(ctx, ol, client.MatchingFields{object.ManifestNamespaceIndex: q.Spec.Namespace}); err != nil {
+		werr := errors.Wrapf(err, "%s %q", errCountObjects, q.Spec.Namespace)
+		q.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, q)
+		return ctrl.Result{}, werr
+	}
+	n := int64(len(ol.Items))
+
+	if q.GraceExceeded(n) {
+		r.record.Event(q, event.Warning(reasonGraceExceeded, errors.Errorf("%d of %d Objects allowed in namespace %q exist", n, q.Spec.MaxObjects, q.Spec.Namespace)))
+	}
+
+	q.Status.CurrentCount = n
+	q.Status.SetConditions(xpv1.ReconcileSuccess(), xpv1.Available())
+	return ctrl.Result{RequeueAfter: r.pollInterval()}, errors.Wrap(r.client.Status().Update(ctx, q), errStatusUpdate)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespaceobjectquota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/namespaceobjectquota/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+type recorder struct {
+	events []event.Event
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) { r.events = append(r.events, e) }
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func TestReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+	pollInterval := 10 * time.Second
+
+	getQuota := func(grace *int32) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			q := obj.(*v1alpha1.NamespaceObjectQuota)
+			q.Name = key.Name
+			q.Spec = v1alpha1.NamespaceObjectQuotaSpec{Namespace: "team-a", MaxObjects: 10, Grace: grace}
+			return nil
+		}
+	}
+	listObjects := func(n int) test.MockListFn {
+		return func(_ context.Context, l client.ObjectList, _ ...client.ListOption) error {
+			l.(*v1alpha2.ObjectList).Items = make([]v1alpha2.Object, n)
+			return nil
+		}
+	}
+
+	type args struct {
+		get  test.MockGetFn
+		list test.MockListFn
+	}
+	type want struct {
+		r      reconcile.Result
+		err    error
+		count  int64
+		events int
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotFound": {
+			reason: "We should not return an error if the NamespaceObjectQuota was not found.",
+			args: args{
+				get: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+			},
+		},
+		"ListError": {
+			reason: "We should return an error if the Objects cannot be counted.",
+			args: args{
+				get:  getQuota(nil),
+				list: test.NewMockListFn(errBoom),
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, "%s %q", errCountObjects, "team-a"),
+			},
+		},
+		"WithinGrace": {
+			reason: "We should record the count without a warning while it is within the grace.",
+			args: args{
+				get:  getQuota(ptr.To[int32](80)),
+				list: listObjects(8),
+			},
+			want: want{
+				r:     reconcile.Result{RequeueAfter: pollInterval},
+				count: 8,
+			},
+		},
+		"GraceExceeded": {
+			reason: "We should emit a warning once the count exceeds the grace.",
+			args: args{
+				get:  getQuota(ptr.To[int32](80)),
+				list: listObjects(9),
+			},
+			want: want{
+				r:      reconcile.Result{RequeueAfter: pollInterval},
+				count:  9,
+				events: 1,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var count int64
+			rec := &recorder{}
+			r := &Reconciler{
+				client: &test.MockClient{
+					MockGet:  tc.args.get,
+					MockList: tc.args.list,
+					MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+						count = obj.(*v1alpha1.NamespaceObjectQuota).Status.CurrentCount
+						return nil
+					},
+				},
+				log:    logging.NewNopLogger(),
+				record: rec,
+				pollInterval: func() time.Duration {
+					return pollInterval
+				},
+			}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "quota"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if count != tc.want.count {
+				t.Errorf("\n%s\nr.Reconcile(...): want count %d, got %d", tc.reason, tc.want.count, count)
+			}
+			if len(rec.events) != tc.want.events {
+				t.Errorf("\n%s\nr.Reconcile(...): want %d events, got %d", tc.reason, tc.want.events, len(rec.events))
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacereport
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/namespacereport/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/object"
+)
+
+const (
+	errStatusUpdate  = "cannot update status"
+	errListObjects   = "cannot list Objects"
+	errMarshalReport = "cannot marshal report"
+	unknownKind      = "unknown"
+
+	// reportInterval is how often the reported Objects are counted.
+	reportInterval = 10 * time.Minute
+
+	// maxErroredObjects is the number of errored Objects listed in a report
+	// at most.
+	maxErroredObjects = 50
+)
+
+// A Reconciler reports the state of the Objects whose resources are in the
+// namespace of a NamespaceReport.
+type Reconciler struct {
+	client client.Client
+	log    logging.Logger
+	now    func() time.Time
+}
+
+// Setup adds a controller that reconciles NamespaceReport resources. It
+// lists Objects by the ManifestNamespaceIndex, which is added by the Object
+// controller.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.NamespaceReportGroupKind)
+
+	r := &Reconciler{client: mgr.GetClient(), log: o.Logger, now: time.Now}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.NamespaceReport{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{}),
+		)).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// Reconcile reports the state of the Objects of a NamespaceReport, and
+// reports it again after the report interval.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, error error) {
+	log := r.log.WithValues("request", req)
+
+	defer func() {
+		if error == nil {
+			log.Debug("Reconciled")
+		} else {
+			log.Info("Retry", "err", error)
+		}
+	}()
+
+	nr := &v1alpha1.NamespaceReport{}
+	if err := r.client.Get(ctx, req.NamespacedName, nr); err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if meta.WasDeleted(nr) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsPaused(nr) {
+		nr.Status.SetConditions(xpv1.ReconcilePaused())
+		return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, nr), errStatusUpdate)
+	}
+
+	if err := r.report(ctx, nr.GetNamespace(), &nr.Status); err != nil {
+		nr.Status.SetConditions(xpv1.ReconcileError(err))
+		_ = r.client.Status().Update(ctx, nr)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: reportInterval}, errors.Wrap(r.client.Status().Update(ctx, nr), errStatusUpdate)
+}
+
+// counts of Objects by state.
+type counts struct {
+	Synced  int64 `json:"synced"`
+	Drifted int64 `json:"drifted"`
+	Errored int64 `json:"errored"`
+}
+
+// A report is written to the status of a NamespaceReport as JSON.
+type report struct {
+	Totals counts `json:"totals"`
+	// Kinds are the counts per apiVersion and kind of resource, e.g.
+	// "apps/v1/Deployment".
+	Kinds   map[string]*counts `json:"kinds,omitempty"`
+	Errored []string           `json:"errored,omitempty"`
+}
+
+// report counts the Objects whose resources are in the supplied namespace
+// into the supplied status. Objects that failed to sync are errored, those
+// whose resource diverges from their manifest are drifted, and all others
+// are synced.
+func (r *Reconciler) report(ctx context.Context, namespace string, status *v1alpha1.NamespaceReportStatus) error {
+	l := &v1alpha2.ObjectList{}
+	if err := r.client.List(ctx, l, client.MatchingFields{object.ManifestNamespaceIndex: namespace}); err != nil {
+		return errors.Wrap(err, errListObjects)
+	}
+
+	rep := report{Kinds: map[string]*counts{}}
+	for i := range l.Items {
+		o := &l.Items[i]
+		k := kindOf(o)
+		if rep.Kinds[k] == nil {
+			rep.Kinds[k] = &counts{}
+		}
+		switch {
+		case o.GetCondition(xpv1.TypeSynced).Status == corev1.ConditionFalse:
+			rep.Totals.Errored++
+			rep.Kinds[k].Errored++
+			rep.Errored = append(rep.Errored, o.GetName())
+		case o.Status.Divergence != nil && o.Status.Divergence.FieldCount > 0:
+			rep.Totals.Drifted++
+			rep.Kinds[k].Drifted++
+		default:
+			rep.Totals.Synced++
+			rep.Kinds[k].Synced++
+		}
+	}
+
+	sort.Strings(rep.Errored)
+	if len(rep.Errored) > maxErroredObjects {
+		rep.Errored = rep.Errored[:maxErroredObjects]
+	}
+
+	b, err := json.Marshal(rep)
+	if err != nil {
+		return errors.Wrap(err, errMarshalReport)
+	}
+
+	status.Synced, status.Drifted, status.Errored = rep.Totals.Synced, rep.Totals.Drifted, rep.Totals.Errored
+	status.Report = string(b)
+	now := metav1.NewTime(r.now())
+	status.LastReportTime = &now
+	status.SetConditions(xpv1.ReconcileSuccess())
+	return nil
+}
+
+// kindOf returns the apiVersion and kind of the resource of the supplied
+// Object, as last observed or otherwise as in its manifest.
+func kindOf(o *v1alpha2.Object) string {
+	for _, raw := range [][]byte{o.Status.AtProvider.Manifest.Raw, o.Spec.ForProvider.Manifest.Raw} {
+		tm := metav1.TypeMeta{}
+		if len(raw) == 0 || json.Unmarshal(raw, &tm) != nil || tm.Kind == "" {
+			continue
+		}
+		return tm.APIVersion + "/" + tm.Kind
+	}
+	return unknownKind
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacereport
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/namespacereport/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/object"
+)
+
+func TestReport(t *testing.T) {
+	now := time.Now()
+	errBoom := errors.New("boom")
+
+	obj := func(name, apiVersion, kind string, divergence *v1alpha2.Divergence, conditions ...xpv1.Condition) v1alpha2.Object {
+		m, _ := json.Marshal(map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata":   map[string]string{"name": name, "namespace": "team-a"},
+		})
+		o := v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		o.Spec.ForProvider.Manifest = runtime.RawExtension{Raw: m}
+		o.Status.Divergence = divergence
+		o.SetConditions(conditions...)
+		return o
+	}
+
+	type args struct {
+		objects []v1alpha2.Object
+		listErr error
+	}
+	type want struct {
+		status v1alpha1.NamespaceReportStatus
+		report report
+		err    error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ListError": {
+			reason: "We should return an error if the Objects cannot be listed.",
+			args: args{
+				listErr: errBoom,
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListObjects),
+			},
+		},
+		"Empty": {
+			reason: "We should report no Objects if there are none in the namespace.",
+			want: want{
+				report: report{},
+			},
+		},
+		"Report": {
+			reason: "We should count synced, drifted and errored Objects in total and per kind, and list the errored ones.",
+			args: args{
+				objects: []v1alpha2.Object{
+					obj("a", "v1", "ConfigMap", &v1alpha2.Divergence{}, xpv1.ReconcileSuccess()),
+					obj("b", "v1", "ConfigMap", &v1alpha2.Divergence{FieldCount: 2}, xpv1.ReconcileSuccess()),
+					obj("d", "apps/v1", "Deployment", nil, xpv1.ReconcileError(errBoom)),
+					obj("c", "apps/v1", "Deployment", &v1alpha2.Divergence{FieldCount: 1}, xpv1.ReconcileError(errBoom)),
+				},
+			},
+			want: want{
+				status: v1alpha1.NamespaceReportStatus{Synced: 1, Drifted: 1, Errored: 2},
+				report: report{
+					Totals: counts{Synced: 1, Drifted: 1, Errored: 2},
+					Kinds: map[string]*counts{
+						"v1/ConfigMap":       {Synced: 1, Drifted: 1},
+						"apps/v1/Deployment": {Errored: 2},
+					},
+					Errored: []string{"c", "d"},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{
+				client: &test.MockClient{
+					MockList: func(_ context.Context, l client.ObjectList, opts ...client.ListOption) error {
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if sel := lo.FieldSelector.String(); sel != object.ManifestNamespaceIndex+"=team-a" {
+							t.Errorf("expected Objects to be listed by the namespace of their resource, got field selector %q", sel)
+						}
+						l.(*v1alpha2.ObjectList).Items = tc.args.objects
+						return tc.args.listErr
+					},
+				},
+				now: func() time.Time { return now },
+			}
+			got := v1alpha1.NamespaceReportStatus{}
+			err := r.report(context.Background(), "team-a", &got)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.report(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.status, got, cmpopts.IgnoreFields(v1alpha1.NamespaceReportStatus{}, "ResourceStatus", "Report", "LastReportTime")); diff != "" {
+				t.Errorf("\n%s\nr.report(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+			rep := report{}
+			if err := json.Unmarshal([]byte(got.Report), &rep); err != nil {
+				t.Fatalf("cannot parse report: %v", err)
+			}
+			if diff := cmp.Diff(tc.want.report, rep, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nr.report(...): -want report, +got report:\n%s", tc.reason, diff)
+			}
+			if got.LastReportTime == nil || !got.LastReportTime.Time.Equal(metav1.NewTime(now).Time) {
+				t.Errorf("\n%s\nr.report(...): want report time %v, got %v", tc.reason, now, got.LastReportTime)
+			}
+		})
+	}
+}
+
+func TestReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		r   reconcile.Result
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		client *test.MockClient
+		want   want
+	}{
+		"NotFound": {
+			reason: "We should not return an error if the NamespaceReport was not found.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+			},
+		},
+		"ListError": {
+			reason: "We should return an error and set the Synced condition if the Objects cannot be listed.",
+			client: &test.MockClient{
+				MockGet:  test.NewMockGetFn(nil),
+				MockList: test.NewMockListFn(errBoom),
+				MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+					if c := obj.(*v1alpha1.NamespaceReport).Status.GetCondition(xpv1.TypeSynced); c.Status != corev1.ConditionFalse {
+						t.Errorf("expected Synced condition to be false, got %v", c)
+					}
+					return nil
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListObjects),
+			},
+		},
+		"Reported": {
+			reason: "We should report the Objects again after the report interval.",
+			client: &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil),
+				MockList:         test.NewMockListFn(nil),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: reportInterval},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{client: tc.client, log: logging.NewNopLogger(), now: time.Now}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "report"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
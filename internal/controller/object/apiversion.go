@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errDiscoverGroup      = "cannot discover the served versions of group"
+	errAPIVersionMismatch = "apiVersion %s of kind %s is not served by the target cluster, update forProvider.manifest.apiVersion to its preferred version %s"
+)
+
+// checkAPIVersion is called when the kind of the supplied Object's manifest
+// is not known to the target cluster. If the cluster serves the kind in
+// another version of its group, it returns an error suggesting to update the
+// Object to the preferred version, and sets the APIVersionMismatch condition.
+// It returns nil otherwise, e.g. if the group is not served at all, so that
+// the original error is surfaced.
+func (c *external) checkAPIVersion(cr *v1alpha2.Object, gvk schema.GroupVersionKind) error {
+	dc, err := discovery.NewDiscoveryClientForConfig(c.rest)
+	if err != nil {
+		c.logger.Debug("Cannot create discovery client to check apiVersion", "error", err)
+		return nil
+	}
+	preferred, err := preferredVersion(dc, gvk)
+	if err != nil {
+		c.logger.Debug("Cannot check apiVersion against the target cluster", "error", err, "gvk", gvk.String())
+		return nil
+	}
+	if preferred == "" || preferred == gvk.Version {
+		return nil
+	}
+	suggested := schema.GroupVersion{Group: gvk.Group, Version: preferred}.String()
+	c.logger.Info("The apiVersion of the manifest is not served by the target cluster, consider updating it", "name", cr.GetName(), "apiVersion", gvk.GroupVersion().String(), "kind", gvk.Kind, "preferredAPIVersion", suggested)
+
+	merr := errors.Errorf(errAPIVersionMismatch, gvk.GroupVersion(), gvk.Kind, suggested)
+	cr.SetConditions(v1alpha2.APIVersionMismatch(merr))
+	return merr
+}
+
+// clearAPIVersionMismatch resets the APIVersionMismatch condition of the
+// supplied Object once its resource was observed.
+func clearAPIVersionMismatch(cr *v1alpha2.Object) {
+	if cr.GetCondition(v1alpha2.TypeAPIVersionMismatch).Status == corev1.ConditionTrue {
+		cr.SetConditions(v1alpha2.APIVersionServed())
+	}
+}
+
+// preferredVersion returns the preferred version of the group of the supplied
+// kind, if it serves the kind. It returns an empty version if the group is
+// not served, or does not serve the kind in its preferred version.
+func preferredVersion(dc discovery.DiscoveryInterface, gvk schema.GroupVersionKind) (string, error) {
+	groups, err := dc.ServerGroups()
+	if err != nil {
+		return "", errors.Wrap(err, errDiscoverGroup)
+	}
+	for _, g := range groups.Groups {
+		if g.Name != gvk.Group {
+			continue
+		}
+		v := g.PreferredVersion.Version
+		served, err := kindServed(dc, gvk.GroupKind().WithVersion(v))
+		if err != nil || !served {
+			return "", err
+		}
+		return v, nil
+	}
+	return "", nil
+}
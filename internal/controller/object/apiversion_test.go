@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestPreferredVersion(t *testing.T) {
+	ingress := schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1beta1", Kind: "Ingress"}
+	networking := &metav1.APIResourceList{
+		GroupVersion: "networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{{Name: "ingresses", Kind: "Ingress"}},
+	}
+
+	cases := map[string]struct {
+		reason    string
+		gvk       schema.GroupVersionKind
+		resources []*metav1.APIResourceList
+		want      string
+	}{
+		"GroupNotServed": {
+			reason: "We should not suggest a version if the group is not served at all.",
+			gvk:    ingress,
+		},
+		"KindNotServed": {
+			reason:    "We should not suggest a version that does not serve the kind.",
+			gvk:       schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1beta1", Kind: "Gateway"},
+			resources: []*metav1.APIResourceList{networking},
+		},
+		"PreferredVersion": {
+			reason:    "We should return the preferred version of the group serving the kind.",
+			gvk:       ingress,
+			resources: []*metav1.APIResourceList{networking},
+			want:      "v1",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: tc.resources}}
+			got, err := preferredVersion(dc, tc.gvk)
+			if err != nil {
+				t.Fatalf("\n%s\npreferredVersion(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\npreferredVersion(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/audit"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/manifest"
+)
+
+// logAudit records an action taken on the managed resource of the supplied
+// Object. from is the previously applied manifest. The diff of Secrets is
+// never recorded, not to leak their data.
+func (c *external) logAudit(ctx context.Context, cr *v1alpha2.Object, obj *unstructured.Unstructured, action audit.Action, from []byte, err error) {
+	if c.auditor == nil {
+		return
+	}
+	entry := audit.AuditEntry{
+		Timestamp: time.Now(),
+		Object:    cr.GetName(),
+		GVK:       obj.GroupVersionKind().String(),
+		Resource:  obj.GetName(),
+		Action:    action,
+		Actor:     actorFor(c.rest),
+		Outcome:   audit.OutcomeSuccess,
+		Subject:   cr,
+	}
+	if ns := obj.GetNamespace(); ns != "" {
+		entry.Resource = ns + "/" + entry.Resource
+	}
+	if err != nil {
+		entry.Outcome = audit.OutcomeFailure
+		entry.Error = err.Error()
+	}
+	if action != audit.ActionDelete && !isSecret(obj) {
+		// The diff is best effort, the action is recorded regardless.
+		raw, _ := manifest.Raw(cr.Spec.ForProvider)
+		entry.Diff, _ = audit.Diff(from, raw)
+	}
+	c.auditor.Log(ctx, entry)
+}
+
+// lastAppliedManifest returns the manifest last applied to the observed
+// managed resource of the supplied Object, if any.
+func lastAppliedManifest(cr *v1alpha2.Object) []byte {
+	if len(cr.Status.AtProvider.Manifest.Raw) == 0 {
+		return nil
+	}
+	observed := &unstructured.Unstructured{}
+	if err := json.Unmarshal(cr.Status.AtProvider.Manifest.Raw, &observed.Object); err != nil {
+		return nil
+	}
+	return []byte(observed.GetAnnotations()[v1.LastAppliedConfigAnnotation])
+}
+
+// actorFor returns the identity the supplied config authenticates as, if it
+// is known without asking the API server. The subject of bearer tokens, e.g.
+// of service accounts, is read without verifying them.
+func actorFor(rc *rest.Config) string {
+	switch {
+	case rc == nil:
+		return ""
+	case rc.Impersonate.UserName != "":
+		return rc.Impersonate.UserName
+	case rc.Username != "":
+		return rc.Username
+	case rc.BearerToken != "":
+		return tokenSubject(rc.BearerToken)
+	}
+	return ""
+}
+
+func tokenSubject(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	claims := struct {
+		Subject string `json:"sub"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.Subject
+}
+
+func isSecret(obj *unstructured.Unstructured) bool {
+	return obj.GetKind() == "Secret" && obj.GetAPIVersion() == "v1"
+}
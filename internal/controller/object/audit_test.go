@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+
+	"github.com/crossplane-contrib/provider-kubernetes/internal/audit"
+)
+
+type recordingAuditLogger struct {
+	entries []audit.AuditEntry
+}
+
+func (l *recordingAuditLogger) Log(_ context.Context, entry audit.AuditEntry) {
+	l.entries = append(l.entries, entry)
+}
+
+func Test_external_logAudit(t *testing.T) {
+	errBoom := errors.New("boom")
+	token := "header." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"system:serviceaccount:crossplane-system:provider-kubernetes"}`)) + ".signature"
+
+	type args struct {
+		rest   *rest.Config
+		obj    *unstructured.Unstructured
+		action audit.Action
+		from   []byte
+		err    error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   audit.AuditEntry
+	}{
+		"Update": {
+			reason: "We should record the diff from the last applied manifest and the subject of the bearer token.",
+			args: args{
+				rest:   &rest.Config{BearerToken: token},
+				obj:    externalResource(),
+				action: audit.ActionUpdate,
+				from:   []byte(`{}`),
+			},
+			want: audit.AuditEntry{
+				Object:   testObjectName,
+				GVK:      "/v1, Kind=Namespace",
+				Resource: externalResourceName,
+				Action:   audit.ActionUpdate,
+				Diff:     []byte(`[{"op":"add","path":"/apiVersion","value":"v1"},{"op":"add","path":"/kind","value":"Namespace"},{"op":"add","path":"/metadata","value":{"name":"crossplane-system"}}]`),
+				Actor:    "system:serviceaccount:crossplane-system:provider-kubernetes",
+				Outcome:  audit.OutcomeSuccess,
+			},
+		},
+		"FailedDelete": {
+			reason: "We should record the error of failed actions, and no diff for deletes.",
+			args: args{
+				rest:   &rest.Config{Impersonate: rest.ImpersonationConfig{UserName: "admin"}},
+				obj:    externalResource(),
+				action: audit.ActionDelete,
+				err:    errBoom,
+			},
+			want: audit.AuditEntry{
+				Object:   testObjectName,
+				GVK:      "/v1, Kind=Namespace",
+				Resource: externalResourceName,
+				Action:   audit.ActionDelete,
+				Actor:    "admin",
+				Outcome:  audit.OutcomeFailure,
+				Error:    errBoom.Error(),
+			},
+		},
+		"Secret": {
+			reason: "We should not record the diff of Secrets.",
+			args: args{
+				obj: func() *unstructured.Unstructured {
+					u := &unstructured.Unstructured{}
+					u.SetAPIVersion("v1")
+					u.SetKind("Secret")
+					u.SetNamespace(testNamespace)
+					u.SetName(testSecretName)
+					return u
+				}(),
+				action: audit.ActionCreate,
+			},
+			want: audit.AuditEntry{
+				Object:   testObjectName,
+				GVK:      "/v1, Kind=Secret",
+				Resource: testNamespace + "/" + testSecretName,
+				Action:   audit.ActionCreate,
+				Outcome:  audit.OutcomeSuccess,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l := &recordingAuditLogger{}
+			e := &external{rest: tc.args.rest, auditor: l}
+			e.logAudit(context.Background(), kubernetesObject(), tc.args.obj, tc.args.action, tc.args.from, tc.args.err)
+
+			if diff := cmp.Diff([]audit.AuditEntry{tc.want}, l.entries, cmpopts.IgnoreFields(audit.AuditEntry{}, "Timestamp", "Subject")); diff != "" {
+				t.Errorf("\n%s\ne.logAudit(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errNoAuthorizer    = "requests cannot be authorized"
+	errNoBearerToken   = "a bearer token is required"
+	errReviewToken     = "cannot review bearer token"
+	errUnauthenticated = "bearer token is not authenticated"
+	errReviewAccess    = "cannot review access"
+	errForbidden       = "forbidden"
+
+	resourceObjects = "objects"
+)
+
+// An Authorizer authorizes the requests of the HTTP endpoints served next to
+// the webhooks, e.g. /rollback. These act with the credentials of the
+// provider, so callers must authenticate with a bearer token, which is
+// verified by a TokenReview, and be allowed the same access to the Object
+// they address by a SubjectAccessReview.
+type Authorizer struct {
+	client client.Client
+}
+
+// NewAuthorizer returns an Authorizer that reviews tokens and access using
+// the supplied client.
+func NewAuthorizer(c client.Client) *Authorizer {
+	return &Authorizer{client: c}
+}
+
+// Authorize returns an error and the HTTP status code to respond with unless
+// the caller of the supplied request may perform the supplied verb on the
+// named Object.
+func (a *Authorizer) Authorize(ctx context.Context, r *http.Request, verb, name string) (int, error) {
+	if a == nil || a.client == nil {
+		return http.StatusInternalServerError, errors.New(errNoAuthorizer)
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return http.StatusUnauthorized, errors.New(errNoBearerToken)
+	}
+
+	tr := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := a.client.Create(ctx, tr); err != nil {
+		return http.StatusInternalServerError, errors.Wrap(err, errReviewToken)
+	}
+	if !tr.Status.Authenticated {
+		return http.StatusUnauthorized, errors.New(errUnauthenticated)
+	}
+
+	u := tr.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(u.Extra))
+	for k, v := range u.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	sar := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		User:   u.Username,
+		UID:    u.UID,
+		Groups: u.Groups,
+		Extra:  extra,
+		ResourceAttributes: &authorizationv1.ResourceAttributes{
+			Group:    v1alpha2.Group,
+			Version:  v1alpha2.Version,
+			Resource: resourceObjects,
+			Verb:     verb,
+			Name:     name,
+		},
+	}}
+	if err := a.client.Create(ctx, sar); err != nil {
+		return http.StatusInternalServerError, errors.Wrap(err, errReviewAccess)
+	}
+	if !sar.Status.Allowed {
+		return http.StatusForbidden, errors.Errorf("%s: %s cannot %s %s.%s %q", errForbidden, u.Username, verb, resourceObjects, v1alpha2.Group, name)
+	}
+	return http.StatusOK, nil
+}
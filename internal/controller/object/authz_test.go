@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// reviewer returns an Authorizer whose TokenReviews and SubjectAccessReviews
+// result in the supplied outcome.
+func reviewer(authenticated, allowed bool) *Authorizer {
+	return NewAuthorizer(&test.MockClient{
+		MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			switch r := obj.(type) {
+			case *authenticationv1.TokenReview:
+				r.Status.Authenticated = authenticated && r.Spec.Token == "token"
+				r.Status.User = authenticationv1.UserInfo{Username: "alice", Groups: []string{"dev"}}
+			case *authorizationv1.SubjectAccessReview:
+				r.Status.Allowed = allowed && r.Spec.User == "alice"
+			}
+			return nil
+		},
+	})
+}
+
+// authenticated returns the supplied request with a bearer token.
+func authenticated(r *http.Request) *http.Request {
+	r.Header.Set("Authorization", "Bearer token")
+	return r
+}
+
+func TestAuthorize(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		authorizer *Authorizer
+		token      string
+		want       int
+	}{
+		"NoAuthorizer": {
+			reason: "We should reject requests that cannot be authorized.",
+			token:  "token",
+			want:   http.StatusInternalServerError,
+		},
+		"NoToken": {
+			reason:     "We should reject requests without a bearer token.",
+			authorizer: reviewer(true, true),
+			want:       http.StatusUnauthorized,
+		},
+		"Unauthenticated": {
+			reason:     "We should reject requests whose token is not authenticated.",
+			authorizer: reviewer(false, true),
+			token:      "token",
+			want:       http.StatusUnauthorized,
+		},
+		"Forbidden": {
+			reason:     "We should reject callers that are not allowed to access the Object.",
+			authorizer: reviewer(true, false),
+			token:      "token",
+			want:       http.StatusForbidden,
+		},
+		"Allowed": {
+			reason:     "We should accept callers that are allowed to access the Object.",
+			authorizer: reviewer(true, true),
+			token:      "token",
+			want:       http.StatusOK,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			got, err := tc.authorizer.Authorize(context.Background(), r, "update", "cool")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nAuthorize(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+			if (err == nil) != (tc.want == http.StatusOK) {
+				t.Errorf("\n%s\nAuthorize(...): unexpected error: %v", tc.reason, err)
+			}
+		})
+	}
+}
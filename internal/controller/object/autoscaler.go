@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/semaphore"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+const (
+	// autoscaleInterval is how often the queue depth is sampled.
+	autoscaleInterval = 10 * time.Second
+
+	metricWorkqueueDepth = "workqueue_depth"
+)
+
+// AutoscalerOptions configure the autoscaling of the number of Objects that
+// are reconciled at the same time by the depth of the reconcile queue.
+type AutoscalerOptions struct {
+	// MinWorkers is the number of reconciles that may run at the same time
+	// while the queue is short.
+	MinWorkers int
+	// MaxWorkers is the number of reconciles that may run at the same time
+	// at most. Zero or less disables autoscaling.
+	MaxWorkers int
+	// ScaleUpThreshold is the queue depth above which the number of workers
+	// is doubled.
+	ScaleUpThreshold int
+	// ScaleDownThreshold is the queue depth below which the number of
+	// workers is halved, once the queue stayed below it for
+	// ScaleDownCooldown.
+	ScaleDownThreshold int
+	ScaleDownCooldown  time.Duration
+}
+
+// Enabled returns whether autoscaling is enabled.
+func (o AutoscalerOptions) Enabled() bool {
+	return o.MaxWorkers > 0
+}
+
+// A workerAutoscaler limits the number of reconciles that run at the same
+// time to a target between MinWorkers and MaxWorkers. The controller runs
+// MaxWorkers workers, each of which needs to acquire a permit of a semaphore
+// with MaxWorkers permits to reconcile. The autoscaler holds the permits of
+// the workers that are scaled down.
+type workerAutoscaler struct {
+	opts  AutoscalerOptions
+	log   logging.Logger
+	depth func() (int, bool)
+	now   func() time.Time
+
+	sem    *semaphore.Weighted
+	target atomic.Int64
+
+	// Only accessed by the goroutine running the autoscaler.
+	held       int64
+	belowSince time.Time
+}
+
+func newWorkerAutoscaler(o AutoscalerOptions, log logging.Logger, depth func() (int, bool)) *workerAutoscaler {
+	if o.MinWorkers < 1 {
+		o.MinWorkers = 1
+	}
+	if o.MinWorkers > o.MaxWorkers {
+		o.MinWorkers = o.MaxWorkers
+	}
+	a := &workerAutoscaler{
+		opts:  o,
+		log:   log,
+		depth: depth,
+		now:   time.Now,
+		sem:   semaphore.NewWeighted(int64(o.MaxWorkers)),
+	}
+	// Start with the minimum number of workers. Nothing acquired permits
+	// yet, so this cannot fail.
+	a.held = int64(o.MaxWorkers - o.MinWorkers)
+	a.sem.TryAcquire(a.held)
+	a.target.Store(int64(o.MinWorkers))
+	return a
+}
+
+// Start samples the queue depth and scales the workers until ctx is done.
+func (a *workerAutoscaler) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, a.scale, autoscaleInterval)
+	return nil
+}
+
+// Target returns the number of reconciles that may currently run at the same
+// time.
+func (a *workerAutoscaler) Target() int {
+	return int(a.target.Load())
+}
+
+func (a *workerAutoscaler) scale(ctx context.Context) {
+	depth, ok := a.depth()
+	if !ok {
+		return
+	}
+	current := a.Target()
+	target := current
+	switch {
+	case depth > a.opts.ScaleUpThreshold:
+		a.belowSince = time.Time{}
+		target = current * 2
+	case depth < a.opts.ScaleDownThreshold:
+		if a.belowSince.IsZero() {
+			a.belowSince = a.now()
+		}
+		if a.now().Sub(a.belowSince) >= a.opts.ScaleDownCooldown {
+			a.belowSince = time.Time{}
+			target = current / 2
+		}
+	default:
+		a.belowSince = time.Time{}
+	}
+	if target > a.opts.MaxWorkers {
+		target = a.opts.MaxWorkers
+	}
+	if target < a.opts.MinWorkers {
+		target = a.opts.MinWorkers
+	}
+	if target == current {
+		return
+	}
+
+	held := int64(a.opts.MaxWorkers - target)
+	if held > a.held {
+		// Scaling down waits for running reconciles to finish.
+		if err := a.sem.Acquire(ctx, held-a.held); err != nil {
+			return
+		}
+	} else {
+		a.sem.Release(a.held - held)
+	}
+	a.held = held
+	a.target.Store(int64(target))
+	a.log.Info("Scaled Object reconcile workers", "from", current, "to", target, "queueDepth", depth)
+}
+
+// Wrap returns a reconciler that only runs while a permit of the autoscaler
+// is available.
+func (a *workerAutoscaler) Wrap(r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		if err := a.sem.Acquire(ctx, 1); err != nil {
+			return reconcile.Result{Requeue: true}, nil
+		}
+		defer a.sem.Release(1)
+		return r.Reconcile(ctx, req)
+	})
+}
+
+// queueDepth returns a function reading the depth of the work queue of the
+// supplied controller from the workqueue metrics of controller-runtime, which
+// does not expose its queues.
+func queueDepth(g prometheus.Gatherer, controller string) func() (int, bool) {
+	return func() (int, bool) {
+		mfs, err := g.Gather()
+		if err != nil {
+			return 0, false
+		}
+		for _, mf := range mfs {
+			if mf.GetName() != metricWorkqueueDepth {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				for _, l := range m.GetLabel() {
+					if l.GetName() == "name" && l.GetValue() == controller {
+						return int(m.GetGauge().GetValue()), true
+					}
+				}
+			}
+		}
+		return 0, false
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+func TestWorkerAutoscalerScale(t *testing.T) {
+	opts := AutoscalerOptions{
+		MinWorkers:         2,
+		MaxWorkers:         10,
+		ScaleUpThreshold:   100,
+		ScaleDownThreshold: 10,
+		ScaleDownCooldown:  time.Minute,
+	}
+
+	type sample struct {
+		depth int
+		after time.Duration
+	}
+	cases := map[string]struct {
+		reason  string
+		samples []sample
+		want    []int
+	}{
+		"ScaleUp": {
+			reason:  "We should double the workers while the queue is above the scale up threshold, up to the maximum.",
+			samples: []sample{{depth: 500}, {depth: 500}, {depth: 500}, {depth: 500}},
+			want:    []int{4, 8, 10, 10},
+		},
+		"Steady": {
+			reason:  "We should not scale while the queue is between the thresholds.",
+			samples: []sample{{depth: 50}, {depth: 50, after: time.Hour}},
+			want:    []int{2, 2},
+		},
+		"ScaleDownAfterCooldown": {
+			reason: "We should only halve the workers once the queue stayed below the scale down threshold for the cooldown, which restarts after scaling down.",
+			samples: []sample{
+				{depth: 500}, {depth: 500}, {depth: 500},
+				{depth: 5}, {depth: 5, after: 30 * time.Second}, {depth: 5, after: 30 * time.Second},
+				{depth: 5, after: 30 * time.Second}, {depth: 5, after: 30 * time.Second},
+				{depth: 5, after: time.Minute}, {depth: 5, after: time.Minute},
+			},
+			want: []int{4, 8, 10, 10, 10, 5, 5, 5, 2, 2},
+		},
+		"CooldownReset": {
+			reason: "We should restart the cooldown if the queue grows above the scale down threshold again.",
+			samples: []sample{
+				{depth: 500},
+				{depth: 5}, {depth: 50, after: 50 * time.Second}, {depth: 5, after: time.Second}, {depth: 5, after: 50 * time.Second},
+			},
+			want: []int{4, 4, 4, 4, 4},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			depth := 0
+			a := newWorkerAutoscaler(opts, logging.NewNopLogger(), func() (int, bool) { return depth, true })
+			a.now = func() time.Time { return now }
+
+			got := make([]int, 0, len(tc.samples))
+			for _, s := range tc.samples {
+				now = now.Add(s.after)
+				depth = s.depth
+				a.scale(context.Background())
+				got = append(got, a.Target())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\na.scale(...): -want targets, +got targets:\n%s", tc.reason, diff)
+			}
+
+			// Exactly the target number of reconciles may run.
+			if !a.sem.TryAcquire(int64(a.Target())) {
+				t.Errorf("\n%s\ncannot acquire %d permits", tc.reason, a.Target())
+			}
+			if a.sem.TryAcquire(1) {
+				t.Errorf("\n%s\nacquired more than %d permits", tc.reason, a.Target())
+			}
+		})
+	}
+}
+
+func TestQueueDepth(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	depth := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: metricWorkqueueDepth}, []string{"name"})
+	reg.MustRegister(depth)
+	depth.WithLabelValues("other").Set(3)
+	depth.WithLabelValues("managed/object").Set(42)
+
+	got, ok := queueDepth(reg, "managed/object")()
+	if !ok || got != 42 {
+		t.Errorf("queueDepth(...): want 42, true, got %d, %t", got, ok)
+	}
+	if _, ok := queueDepth(reg, "unknown")(); ok {
+		t.Errorf("queueDepth(...): want no depth of an unknown controller")
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// InformerBudgets holds the limits of GVKs the resource informers may watch
+// per cluster, as set by ClusterInformerBudgets, and reports their usage. No
+// limits apply until a budget is set, and no usage is reported while
+// watches are disabled.
+type InformerBudgets struct {
+	lock sync.RWMutex
+	// limits holds the max GVKs per cluster of provider configs by budget.
+	limits    map[string]map[string]int
+	informers *resourceInformers
+}
+
+// BudgetUsage is the usage of the informer budget of a cluster.
+type BudgetUsage struct {
+	// Cluster is the URL of the API server.
+	Cluster string
+	// Identity of the cluster, see kube.ClusterIdentity.
+	Identity string
+	// GVKs is the number of GVKs watched in the cluster.
+	GVKs int
+}
+
+// NewInformerBudgets returns informer budgets without any limits.
+func NewInformerBudgets() *InformerBudgets {
+	return &InformerBudgets{limits: make(map[string]map[string]int)}
+}
+
+func (b *InformerBudgets) setInformers(i *resourceInformers) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.informers = i
+}
+
+// SetBudget limits the clusters of the supplied provider configs to max
+// GVKs on behalf of the named budget, replacing the provider configs it
+// limited before.
+func (b *InformerBudgets) SetBudget(name string, max int, providerConfigs []string) {
+	l := make(map[string]int, len(providerConfigs))
+	for _, pc := range providerConfigs {
+		l[pc] = max
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.limits[name] = l
+}
+
+// RemoveBudget lifts the limits of the named budget.
+func (b *InformerBudgets) RemoveBudget(name string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.limits, name)
+}
+
+// Usage returns the usage of the cluster the supplied provider config
+// points to. It returns false if the provider config did not watch any
+// resources yet.
+func (b *InformerBudgets) Usage(providerConfig string) (BudgetUsage, bool) {
+	if b == nil {
+		return BudgetUsage{}, false
+	}
+	b.lock.RLock()
+	i := b.informers
+	b.lock.RUnlock()
+	if i == nil {
+		return BudgetUsage{}, false
+	}
+	return i.clusterUsage(providerConfig)
+}
+
+// limitFor returns the lowest limit of all budgets of the supplied provider
+// config, and false if none limits it.
+func (b *InformerBudgets) limitFor(providerConfig string) (int, bool) {
+	if b == nil {
+		return 0, false
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	limit, found := 0, false
+	for _, l := range b.limits {
+		max, ok := l[providerConfig]
+		if !ok {
+			continue
+		}
+		if !found || max < limit {
+			limit, found = max, true
+		}
+	}
+	return limit, found
+}
+
+// budgetExceededError is returned by WatchResources if watching resources
+// was blocked by an informer budget.
+type budgetExceededError struct {
+	error
+}
+
+func isBudgetExceeded(err error) bool {
+	var be *budgetExceededError
+	return errors.As(err, &be)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInformerBudgetsLimitFor(t *testing.T) {
+	type want struct {
+		limit int
+		ok    bool
+	}
+	cases := map[string]struct {
+		reason         string
+		budgets        *InformerBudgets
+		providerConfig string
+		want           want
+	}{
+		"NilBudgets": {
+			reason:         "No limits should apply without budgets.",
+			providerConfig: "a",
+		},
+		"NotSelected": {
+			reason:         "No limits should apply to provider configs no budget selects.",
+			budgets:        withBudgets(map[string]int{"small": 1}, "b"),
+			providerConfig: "a",
+		},
+		"LowestLimit": {
+			reason:         "The lowest limit of all budgets selecting a provider config should apply.",
+			budgets:        withBudgets(map[string]int{"small": 5, "large": 50}, "a"),
+			providerConfig: "a",
+			want: want{
+				limit: 5,
+				ok:    true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			limit, ok := tc.budgets.limitFor(tc.providerConfig)
+			if diff := cmp.Diff(tc.want, want{limit: limit, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nlimitFor(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestInformerBudgetsRemoveBudget(t *testing.T) {
+	b := withBudgets(map[string]int{"budget": 1}, "a")
+	b.RemoveBudget("budget")
+	if _, ok := b.limitFor("a"); ok {
+		t.Errorf("RemoveBudget(...): want no limit once the budget is removed")
+	}
+}
+
+func withBudgets(limits map[string]int, providerConfigs ...string) *InformerBudgets {
+	b := NewInformerBudgets()
+	for name, max := range limits {
+		b.SetBudget(name, max, providerConfigs)
+	}
+	return b
+}
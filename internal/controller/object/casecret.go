@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	runtimeevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+// caSecretHandler handles changes of the CA bundle, client certificate and
+// external kubeconfig Secrets referenced by ProviderConfigs, e.g. once the
+// External Secrets Operator rotated a kubeconfig, and of the ClusterIP of
+// the API server Services they reference. Clients are built for every
+// reconcile of an Object, so enqueueing the Objects of a ProviderConfig
+// rebuilds them with the new CA, certificate or endpoint. Resource informers
+// are long-lived though, so they are stopped to be started again with them.
+type caSecretHandler struct {
+	client    client.Reader
+	informers *resourceInformers
+	log       logging.Logger
+}
+
+var _ handler.EventHandler = &caSecretHandler{}
+
+func (h *caSecretHandler) Create(ctx context.Context, ev runtimeevent.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(ctx, ev.Object, q)
+}
+
+func (h *caSecretHandler) Update(ctx context.Context, ev runtimeevent.UpdateEvent, q workqueue.RateLimitingInterface) {
+	if caBundleEqual(ev.ObjectOld, ev.ObjectNew) || serviceEndpointEqual(ev.ObjectOld, ev.ObjectNew) {
+		return
+	}
+	h.enqueue(ctx, ev.ObjectNew, q)
+}
+
+func (h *caSecretHandler) Delete(ctx context.Context, ev runtimeevent.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(ctx, ev.Object, q)
+}
+
+func (h *caSecretHandler) Generic(_ context.Context, _ runtimeevent.GenericEvent, _ workqueue.RateLimitingInterface) {
+	// Secrets and Services are not sent as generic events.
+}
+
+func (h *caSecretHandler) enqueue(ctx context.Context, s client.Object, q workqueue.RateLimitingInterface) {
+	pcl := &apisv1alpha1.ProviderConfigList{}
+	if err := h.client.List(ctx, pcl); err != nil {
+		h.log.Debug("cannot list provider configs", "error", err)
+		return
+	}
+	pcs := map[string]bool{}
+	for _, pc := range pcl.Items {
+		refs := append(tlsSecretRefs(&pc), externalSecretRefs(&pc)...)
+		if _, ok := s.(*corev1.Service); ok {
+			refs = serviceRefs(&pc)
+		}
+		for _, ref := range refs {
+			if ref.Namespace == s.GetNamespace() && ref.Name == s.GetName() {
+				pcs[pc.Name] = true
+			}
+		}
+	}
+	if len(pcs) == 0 {
+		return
+	}
+
+	objs := &v1alpha2.ObjectList{}
+	if err := h.client.List(ctx, objs); err != nil {
+		h.log.Debug("cannot list objects", "error", err)
+		return
+	}
+	for pc := range pcs {
+		h.log.Info("Secret or Service of provider config changed, rebuilding clients", "provider config", pc, "name", types.NamespacedName{Namespace: s.GetNamespace(), Name: s.GetName()})
+		if h.informers != nil {
+			h.informers.stopResourceInformers(pc)
+		}
+	}
+	for _, o := range objs.Items {
+		if ref := o.GetProviderConfigReference(); ref != nil && pcs[ref.Name] {
+			q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: o.Name}})
+		}
+	}
+}
+
+// tlsSecretRefs returns the Secrets the supplied ProviderConfig reads CA
+// bundles and client certificates from.
+func tlsSecretRefs(pc *apisv1alpha1.ProviderConfig) []xpv1.SecretReference {
+	var refs []xpv1.SecretReference
+	if tc := pc.Spec.TLSConfig; tc != nil && tc.CASecretRef != nil {
+		refs = append(refs, tc.CASecretRef.SecretReference)
+	}
+	if m := pc.Spec.Credentials.MTLS; m != nil {
+		refs = append(refs, m.ClientCertSecretRef.SecretReference, m.ClientKeySecretRef.SecretReference)
+	}
+	return refs
+}
+
+// externalSecretRefs returns the Secret managed by the External Secrets
+// Operator the supplied ProviderConfig reads its kubeconfig from, if any.
+func externalSecretRefs(pc *apisv1alpha1.ProviderConfig) []xpv1.SecretReference {
+	ref := pc.Spec.Credentials.ExternalSecretRef
+	if ref == nil || pc.Spec.Credentials.Source != xpv1.CredentialsSourceSecret {
+		return nil
+	}
+	return []xpv1.SecretReference{{Namespace: ref.Namespace, Name: ref.Name}}
+}
+
+// serviceRefs returns the Service the supplied ProviderConfig connects to
+// the Kubernetes API through, if any.
+func serviceRefs(pc *apisv1alpha1.ProviderConfig) []xpv1.SecretReference {
+	ref := pc.Spec.Credentials.ServiceRef
+	if ref == nil {
+		return nil
+	}
+	return []xpv1.SecretReference{{Namespace: ref.Namespace, Name: ref.Name}}
+}
+
+// serviceEndpointEqual returns true if the ClusterIP and ports of the
+// supplied Services are the same.
+func serviceEndpointEqual(oldObj, newObj client.Object) bool {
+	o, ok := oldObj.(*corev1.Service)
+	if !ok {
+		return false
+	}
+	n, ok := newObj.(*corev1.Service)
+	if !ok || o.Spec.ClusterIP != n.Spec.ClusterIP || len(o.Spec.Ports) != len(n.Spec.Ports) {
+		return false
+	}
+	for i := range o.Spec.Ports {
+		if o.Spec.Ports[i].Port != n.Spec.Ports[i].Port {
+			return false
+		}
+	}
+	return true
+}
+
+// caBundleEqual returns true if the data of the supplied Secrets is the same.
+func caBundleEqual(oldObj, newObj client.Object) bool {
+	o, ok := oldObj.(*corev1.Secret)
+	if !ok {
+		return false
+	}
+	n, ok := newObj.(*corev1.Secret)
+	if !ok || len(o.Data) != len(n.Data) {
+		return false
+	}
+	for k, v := range o.Data {
+		if !bytes.Equal(v, n.Data[k]) {
+			return false
+		}
+	}
+	return true
+}
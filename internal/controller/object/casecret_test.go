@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	runtimeevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+func TestCASecretHandlerUpdate(t *testing.T) {
+	secret := func(name, ca string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: name},
+			Data:       map[string][]byte{"ca.crt": []byte(ca)},
+		}
+	}
+	service := func(name, ip string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: name},
+			Spec:       corev1.ServiceSpec{ClusterIP: ip, Ports: []corev1.ServicePort{{Port: 443}}},
+		}
+	}
+	list := func(_ context.Context, l client.ObjectList, _ ...client.ListOption) error {
+		switch l := l.(type) {
+		case *apisv1alpha1.ProviderConfigList:
+			pc := apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "remote"}}
+			pc.Spec.TLSConfig = &apisv1alpha1.TLSConfig{CASecretRef: &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "remote-ca"},
+				Key:             "ca.crt",
+			}}
+			mtls := apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "mtls"}}
+			mtls.Spec.Credentials.MTLS = &apisv1alpha1.MTLSConfig{
+				ClientCertSecretRef: xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "client-cert"}, Key: "tls.crt"},
+				ClientKeySecretRef:  xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "client-cert"}, Key: "tls.key"},
+			}
+			eso := apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "eso"}}
+			eso.Spec.Credentials.Source = xpv1.CredentialsSourceSecret
+			eso.Spec.Credentials.ExternalSecretRef = &apisv1alpha1.ExternalSecretReference{Namespace: "crossplane-system", Name: "eso-kubeconfig"}
+			svc := apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "svc"}}
+			svc.Spec.Credentials.ServiceRef = &apisv1alpha1.ServiceReference{Namespace: "crossplane-system", Name: "vcluster"}
+			l.Items = []apisv1alpha1.ProviderConfig{pc, mtls, eso, svc, {ObjectMeta: metav1.ObjectMeta{Name: "local"}}}
+		case *v1alpha2.ObjectList:
+			for name, pc := range map[string]string{"a": "remote", "b": "local", "c": "mtls", "d": "eso", "e": "svc"} {
+				o := v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{Name: name}}
+				o.Spec.ProviderConfigReference = &xpv1.Reference{Name: pc}
+				l.Items = append(l.Items, o)
+			}
+		}
+		return nil
+	}
+
+	type args struct {
+		old client.Object
+		new client.Object
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []reconcile.Request
+	}{
+		"CAChanged": {
+			reason: "We should enqueue the Objects of ProviderConfigs whose CA bundle changed.",
+			args: args{
+				old: secret("remote-ca", "old"),
+				new: secret("remote-ca", "new"),
+			},
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "a"}}},
+		},
+		"ClientCertificateChanged": {
+			reason: "We should enqueue the Objects of ProviderConfigs whose client certificate changed.",
+			args: args{
+				old: secret("client-cert", "old"),
+				new: secret("client-cert", "new"),
+			},
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "c"}}},
+		},
+		"ExternalSecretRotated": {
+			reason: "We should enqueue the Objects of ProviderConfigs whose External Secrets Operator managed kubeconfig was rotated.",
+			args: args{
+				old: secret("eso-kubeconfig", "old"),
+				new: secret("eso-kubeconfig", "new"),
+			},
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "d"}}},
+		},
+		"ServiceClusterIPChanged": {
+			reason: "We should enqueue the Objects of ProviderConfigs whose API server Service got a new ClusterIP.",
+			args: args{
+				old: service("vcluster", "10.0.0.10"),
+				new: service("vcluster", "10.0.0.11"),
+			},
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "e"}}},
+		},
+		"ServiceEndpointUnchanged": {
+			reason: "We should not enqueue anything if the ClusterIP and ports of the Service did not change.",
+			args: args{
+				old: service("vcluster", "10.0.0.10"),
+				new: func() client.Object {
+					s := service("vcluster", "10.0.0.10")
+					s.SetLabels(map[string]string{"updated": "true"})
+					return s
+				}(),
+			},
+		},
+		"ServiceNamedLikeSecret": {
+			reason: "We should not enqueue anything for Services named like a referenced Secret.",
+			args: args{
+				old: service("remote-ca", "10.0.0.10"),
+				new: service("remote-ca", "10.0.0.11"),
+			},
+		},
+		"CAUnchanged": {
+			reason: "We should not enqueue anything if the data of the Secret did not change.",
+			args: args{
+				old: secret("remote-ca", "same"),
+				new: secret("remote-ca", "same"),
+			},
+		},
+		"UnreferencedSecret": {
+			reason: "We should not enqueue anything for Secrets no ProviderConfig references.",
+			args: args{
+				old: secret("other", "old"),
+				new: secret("other", "new"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := &caSecretHandler{
+				client: &test.MockClient{MockList: list},
+				log:    logging.NewNopLogger(),
+			}
+			q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer q.ShutDown()
+
+			h.Update(context.Background(), runtimeevent.UpdateEvent{ObjectOld: tc.args.old, ObjectNew: tc.args.new}, q)
+
+			var got []reconcile.Request
+			for q.Len() > 0 {
+				item, _ := q.Get()
+				got = append(got, item.(reconcile.Request))
+				q.Done(item)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nh.Update(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
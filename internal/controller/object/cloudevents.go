@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/audit"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/events"
+)
+
+// A CloudEventsEmitter publishes CloudEvents about Objects.
+type CloudEventsEmitter interface {
+	Emit(ctx context.Context, endpoint string, t events.EventType, obj client.Object) error
+}
+
+// cloudEventsQueueSize is the number of CloudEvents waiting to be
+// published, before further ones are dropped.
+const cloudEventsQueueSize = 1000
+
+var cloudEventTypes = map[audit.Action]events.EventType{
+	audit.ActionCreate: events.TypeObjectCreated,
+	audit.ActionUpdate: events.TypeObjectUpdated,
+	audit.ActionDelete: events.TypeObjectDeleted,
+}
+
+// cloudEventsEndpoint returns the CloudEvents endpoint of the ProviderConfig
+// of the supplied Object, if any.
+func cloudEventsEndpoint(ctx context.Context, kube client.Client, cr *v1alpha2.Object) (string, error) {
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return "", errors.Wrap(err, errGetProviderConfig)
+	}
+	return pc.Spec.CloudEventsEndpoint, nil
+}
+
+// emitCloudEvent publishes the outcome of an action taken on the managed
+// resource of the supplied Object to the CloudEvents endpoint of its
+// ProviderConfig. Events are best effort, failing to publish or queue one
+// does not fail the reconcile.
+func (c *external) emitCloudEvent(ctx context.Context, cr *v1alpha2.Object, action audit.Action, err error) {
+	if c.cloudEvents == nil || c.cloudEventsEndpoint == "" {
+		return
+	}
+	t := cloudEventTypes[action]
+	if err != nil {
+		t = events.TypeObjectFailed
+	}
+	cr.SetGroupVersionKind(v1alpha2.ObjectGroupVersionKind)
+	if err := c.cloudEvents.Emit(ctx, c.cloudEventsEndpoint, t, cr); err != nil {
+		c.logger.Debug("Cannot publish CloudEvent", "type", t, "endpoint", c.cloudEventsEndpoint, "error", err)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/audit"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/events"
+)
+
+type fakeCloudEventsEmitter struct {
+	emitted []events.EventType
+}
+
+func (f *fakeCloudEventsEmitter) Emit(_ context.Context, _ string, t events.EventType, _ client.Object) error {
+	f.emitted = append(f.emitted, t)
+	return errors.New("boom")
+}
+
+func TestEmitCloudEvent(t *testing.T) {
+	type args struct {
+		endpoint string
+		action   audit.Action
+		err      error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []events.EventType
+	}{
+		"NoEndpoint": {
+			reason: "We should not publish events if the ProviderConfig has no CloudEvents endpoint.",
+			args: args{
+				action: audit.ActionCreate,
+			},
+		},
+		"Updated": {
+			reason: "We should publish the event type of a successful action.",
+			args: args{
+				endpoint: "http://broker.example",
+				action:   audit.ActionUpdate,
+			},
+			want: []events.EventType{events.TypeObjectUpdated},
+		},
+		"Failed": {
+			reason: "We should publish a failed event if the action failed.",
+			args: args{
+				endpoint: "http://broker.example",
+				action:   audit.ActionDelete,
+				err:      errors.New("boom"),
+			},
+			want: []events.EventType{events.TypeObjectFailed},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := &fakeCloudEventsEmitter{}
+			e := &external{logger: logging.NewNopLogger(), cloudEvents: f, cloudEventsEndpoint: tc.args.endpoint}
+			e.emitCloudEvent(context.Background(), &v1alpha2.Object{}, tc.args.action, tc.args.err)
+			if diff := cmp.Diff(tc.want, f.emitted); diff != "" {
+				t.Errorf("\n%s\ne.emitCloudEvent(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
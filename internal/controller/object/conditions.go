@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+// An ErrorCode identifies the kind of error a reconcile of an Object failed
+// with, so that monitoring can alert on it without parsing messages.
+type ErrorCode string
+
+// Error codes. Codes are never renumbered, new ones are appended.
+const (
+	ErrorCodeUnknown              ErrorCode = "E000_Unknown"
+	ErrorCodeAPIServerUnreachable ErrorCode = "E001_APIServerUnreachable"
+	ErrorCodeFieldConflict        ErrorCode = "E002_FieldConflict"
+	ErrorCodeValidationFailed     ErrorCode = "E003_ValidationFailed"
+	ErrorCodeAccessDenied         ErrorCode = "E004_AccessDenied"
+	ErrorCodeNotFound             ErrorCode = "E005_NotFound"
+)
+
+// Short returns the numeric part of the code, e.g. E001.
+func (c ErrorCode) Short() string {
+	s, _, _ := strings.Cut(string(c), "_")
+	return s
+}
+
+// ErrorCodeFor returns the code of the supplied error.
+func ErrorCodeFor(err error) ErrorCode {
+	var sv *schemaViolation
+	var ne net.Error
+	var ue *url.Error
+	switch {
+	case kerrors.IsConflict(err), kerrors.IsAlreadyExists(err):
+		return ErrorCodeFieldConflict
+	case kerrors.IsInvalid(err), kerrors.IsBadRequest(err), errors.As(err, &sv):
+		return ErrorCodeValidationFailed
+	case kerrors.IsForbidden(err), kerrors.IsUnauthorized(err):
+		return ErrorCodeAccessDenied
+	case kerrors.IsNotFound(err):
+		return ErrorCodeNotFound
+	case kerrors.IsServiceUnavailable(err),
+		kerrors.IsServerTimeout(err),
+		kerrors.IsTimeout(err),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &ne),
+		errors.As(err, &ue):
+		return ErrorCodeAPIServerUnreachable
+	}
+	return ErrorCodeUnknown
+}
+
+// A codedError prefixes the message of an error with its code.
+type codedError struct {
+	code  ErrorCode
+	cause error
+}
+
+func (e *codedError) Error() string {
+	return "[" + e.code.Short() + "] " + e.cause.Error()
+}
+
+func (e *codedError) Cause() error { return e.cause }
+
+// Unwrap provides compatibility for Go 1.13 error chains.
+func (e *codedError) Unwrap() error { return e.cause }
+
+// withErrorCode records the code of the supplied error in the status of the
+// supplied Object and returns the error prefixed with it. A nil error clears
+// the recorded code.
+func withErrorCode(mg resource.Managed, err error) error {
+	cr, ok := mg.(*v1alpha2.Object)
+	if err == nil {
+		if ok {
+			cr.Status.LastErrorCode = ""
+		}
+		return nil
+	}
+	code := ErrorCodeFor(err)
+	if ok {
+		cr.Status.LastErrorCode = string(code)
+	}
+	return &codedError{code: code, cause: err}
+}
+
+// An errorCodeConnecter adds error codes to the errors of the external
+// clients it connects.
+type errorCodeConnecter struct {
+	managed.ExternalConnecter
+}
+
+func (c *errorCodeConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, withErrorCode(mg, err)
+	}
+	return &errorCodeExternal{ExternalClient: ec}, nil
+}
+
+type errorCodeExternal struct {
+	managed.ExternalClient
+}
+
+func (e *errorCodeExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	return o, withErrorCode(mg, err)
+}
+
+func (e *errorCodeExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	c, err := e.ExternalClient.Create(ctx, mg)
+	return c, withErrorCode(mg, err)
+}
+
+func (e *errorCodeExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	u, err := e.ExternalClient.Update(ctx, mg)
+	return u, withErrorCode(mg, err)
+}
+
+func (e *errorCodeExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	return withErrorCode(mg, e.ExternalClient.Delete(ctx, mg))
+}
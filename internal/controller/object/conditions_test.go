@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+func TestErrorCodeFor(t *testing.T) {
+	gr := schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   ErrorCode
+	}{
+		"Unreachable": {
+			reason: "Failed connections to the API server should be reported as unreachable.",
+			err:    errors.Wrap(&url.Error{Op: "Get", URL: "https://10.0.0.1", Err: errors.New("dial tcp: connection refused")}, errGetObject),
+			want:   ErrorCodeAPIServerUnreachable,
+		},
+		"Conflict": {
+			reason: "Conflicts should be reported as field conflicts.",
+			err:    errors.Wrap(kerrors.NewConflict(gr, "app", errors.New("conflict")), errApplyObject),
+			want:   ErrorCodeFieldConflict,
+		},
+		"SchemaViolation": {
+			reason: "Manifests that violate their schema should fail validation.",
+			err:    &schemaViolation{msgs: []string{"spec.replicas in body must be of type integer"}},
+			want:   ErrorCodeValidationFailed,
+		},
+		"Forbidden": {
+			reason: "Missing permissions should be reported as access denied.",
+			err:    errors.Wrap(kerrors.NewForbidden(gr, "app", errors.New("no")), errCreateObject),
+			want:   ErrorCodeAccessDenied,
+		},
+		"Other": {
+			reason: "Other errors should be reported as unknown.",
+			err:    errors.New(errNotKubernetesObject),
+			want:   ErrorCodeUnknown,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ErrorCodeFor(tc.err); got != tc.want {
+				t.Errorf("\n%s\nErrorCodeFor(...): want %q, got %q", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestErrorCodeExternal(t *testing.T) {
+	gr := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	errConflict := kerrors.NewConflict(gr, "app", errors.New("conflict"))
+
+	cr := kubernetesObject()
+	e := &errorCodeExternal{ExternalClient: &managed.ExternalClientFns{
+		UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+			return managed.ExternalUpdate{}, errors.Wrap(errConflict, errApplyObject)
+		},
+		ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+			return managed.ExternalObservation{}, nil
+		},
+	}}
+
+	_, err := e.Update(context.Background(), cr)
+	if want := "[E002] " + errors.Wrap(errConflict, errApplyObject).Error(); err == nil || err.Error() != want {
+		t.Errorf("Update(...): want error %q, got %v", want, err)
+	}
+	if !kerrors.IsConflict(err) {
+		t.Errorf("Update(...): want error to wrap the conflict, got %v", err)
+	}
+	if got := cr.Status.LastErrorCode; got != string(ErrorCodeFieldConflict) {
+		t.Errorf("Update(...): want last error code %q, got %q", ErrorCodeFieldConflict, got)
+	}
+
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Errorf("Observe(...): unexpected error: %v", err)
+	}
+	if got := cr.Status.LastErrorCode; got != "" {
+		t.Errorf("Observe(...): want last error code to be cleared, got %q", got)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/discovery"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errDiscoverKind        = "cannot discover whether kind is served"
+	errNoCRDManifest       = "kind is not served and crdManifest is not set"
+	errUnmarshalCRD        = "cannot unmarshal crdManifest"
+	errNotCRD              = "crdManifest is not an apiextensions.k8s.io/v1 CustomResourceDefinition"
+	errGetCRD              = "cannot get CustomResourceDefinition"
+	errCreateCRD           = "cannot create CustomResourceDefinition"
+	errCRDNotEstablished   = "CustomResourceDefinition is not established yet"
+	errCRDDoesNotServeKind = "CustomResourceDefinition is established but does not serve kind"
+)
+
+var crdGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+// ensureCRD creates the CRD of the supplied Object on the target cluster if
+// it has autoInstallCRD enabled and the kind of its manifest is not served
+// yet. It returns an error until the kind is served, so that the manifest is
+// only applied once the CRD is established.
+func (c *external) ensureCRD(ctx context.Context, cr *v1alpha2.Object, desired *unstructured.Unstructured) error {
+	if !cr.Spec.ForProvider.AutoInstallCRD {
+		return nil
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(c.rest)
+	if err != nil {
+		return errors.Wrap(err, errNewRESTClient)
+	}
+	return c.installCRD(ctx, dc, cr, desired)
+}
+
+func (c *external) installCRD(ctx context.Context, dc discovery.ServerResourcesInterface, cr *v1alpha2.Object, desired *unstructured.Unstructured) error {
+	gvk := desired.GroupVersionKind()
+	served, err := kindServed(dc, gvk)
+	if err != nil || served {
+		return err
+	}
+
+	if cr.Spec.ForProvider.CRDManifest == nil || len(cr.Spec.ForProvider.CRDManifest.Raw) == 0 {
+		return errors.Errorf("%s: %s", errNoCRDManifest, gvk)
+	}
+	crd := &unstructured.Unstructured{}
+	if err := json.Unmarshal(cr.Spec.ForProvider.CRDManifest.Raw, crd); err != nil {
+		return errors.Wrap(err, errUnmarshalCRD)
+	}
+	if crd.GroupVersionKind() != crdGVK {
+		return errors.New(errNotCRD)
+	}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(crdGVK)
+	err = c.client.Get(ctx, types.NamespacedName{Name: crd.GetName()}, current)
+	if kerrors.IsNotFound(err) {
+		c.logger.Debug("Creating CustomResourceDefinition", "name", crd.GetName())
+		if err := c.client.Create(ctx, crd); err != nil && !kerrors.IsAlreadyExists(err) {
+			return errors.Wrap(err, errCreateCRD)
+		}
+		current = crd
+		err = nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetCRD)
+	}
+
+	if !crdEstablished(current) {
+		return errors.Errorf("%s: %s", errCRDNotEstablished, crd.GetName())
+	}
+	// The CRD may have been established since the kind was discovered.
+	if served, err = kindServed(dc, gvk); err != nil || served {
+		return err
+	}
+	return errors.Errorf("%s %s: %s", errCRDDoesNotServeKind, gvk, crd.GetName())
+}
+
+// crdNotInstalled returns whether err is returned for the manifest of an
+// Object whose CRD is yet to be installed.
+func crdNotInstalled(cr *v1alpha2.Object, err error) bool {
+	return cr.Spec.ForProvider.AutoInstallCRD && apimeta.IsNoMatchError(err)
+}
+
+// kindServed returns whether the API server behind dc serves the supplied
+// kind.
+func kindServed(dc discovery.ServerResourcesInterface, gvk schema.GroupVersionKind) (bool, error) {
+	l, err := dc.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, errDiscoverKind)
+	}
+	for _, r := range l.APIResources {
+		if r.Kind == gvk.Kind {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func crdEstablished(crd *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		m, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if m["type"] == "Established" && m["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
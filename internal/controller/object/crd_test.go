@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestInstallCRD(t *testing.T) {
+	errBoom := errors.New("boom")
+	crdManifest := `{"apiVersion":"apiextensions.k8s.io/v1","kind":"CustomResourceDefinition","metadata":{"name":"widgets.example.org"}}`
+
+	served := []*metav1.APIResourceList{{
+		GroupVersion: "example.org/v1",
+		APIResources: []metav1.APIResource{{Name: "widgets", Kind: "Widget"}},
+	}}
+	established := func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		_ = unstructured.SetNestedSlice(obj.(*unstructured.Unstructured).Object, []interface{}{
+			map[string]interface{}{"type": "Established", "status": "True"},
+		}, "status", "conditions")
+		return nil
+	}
+
+	type args struct {
+		resources   []*metav1.APIResourceList
+		crdManifest string
+		get         test.MockGetFn
+	}
+	type want struct {
+		err     error
+		created bool
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"KindServed": {
+			reason: "We should not install a CRD if the kind is already served.",
+			args: args{
+				resources:   served,
+				crdManifest: crdManifest,
+			},
+		},
+		"NoCRDManifest": {
+			reason: "We should return an error if the kind is not served and there is no CRD to install.",
+			want: want{
+				err: errors.Errorf("%s: %s", errNoCRDManifest, "example.org/v1, Kind=Widget"),
+			},
+		},
+		"NotCRD": {
+			reason: "We should return an error if crdManifest is not a CRD.",
+			args: args{
+				crdManifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"widgets"}}`,
+			},
+			want: want{
+				err: errors.New(errNotCRD),
+			},
+		},
+		"GetError": {
+			reason: "We should return an error if the CRD cannot be fetched.",
+			args: args{
+				crdManifest: crdManifest,
+				get:         test.NewMockGetFn(errBoom),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetCRD),
+			},
+		},
+		"CreateCRD": {
+			reason: "We should create a missing CRD and wait for it to be established.",
+			args: args{
+				crdManifest: crdManifest,
+				get:         test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "widgets.example.org")),
+			},
+			want: want{
+				err:     errors.Errorf("%s: %s", errCRDNotEstablished, "widgets.example.org"),
+				created: true,
+			},
+		},
+		"EstablishedWithoutKind": {
+			reason: "We should return an error if an established CRD does not serve the kind.",
+			args: args{
+				crdManifest: crdManifest,
+				get:         established,
+			},
+			want: want{
+				err: errors.Errorf("%s %s: %s", errCRDDoesNotServeKind, "example.org/v1, Kind=Widget", "widgets.example.org"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			created := false
+			e := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: tc.args.get,
+						MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+							created = true
+							if obj.GetName() != "widgets.example.org" {
+								t.Errorf("unexpected CRD %q", obj.GetName())
+							}
+							return nil
+						},
+					},
+				},
+			}
+			cr := kubernetesObject(func(o *v1alpha2.Object) {
+				o.Spec.ForProvider.AutoInstallCRD = true
+				if tc.args.crdManifest != "" {
+					o.Spec.ForProvider.CRDManifest = &runtime.RawExtension{Raw: []byte(tc.args.crdManifest)}
+				}
+			})
+			desired := &unstructured.Unstructured{}
+			desired.SetGroupVersionKind(schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Widget"})
+			dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: tc.args.resources}}
+
+			err := e.installCRD(context.Background(), dc, cr, desired)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.installCRD(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if created != tc.want.created {
+				t.Errorf("\n%s\ne.installCRD(...): want created %t, got %t", tc.reason, tc.want.created, created)
+			}
+		})
+	}
+}
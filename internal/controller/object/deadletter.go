@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/deadletter/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errGetDeadLetter    = "cannot get dead letter"
+	errCreateDeadLetter = "cannot create dead letter"
+	errDeleteDeadLetter = "cannot delete dead letter"
+	errGetFailedObject  = "cannot get failed Object"
+	errUpdateDeadLetter = "cannot update status of dead letter"
+)
+
+// DeadLetterOptions configure when Objects that keep failing are moved to a
+// DeadLetter.
+type DeadLetterOptions struct {
+	// Threshold of consecutive failed reconciles after which an Object is
+	// moved to a DeadLetter. Zero or less disables dead letters.
+	Threshold int
+	// RetryAfter is how long an Object stays in its DeadLetter before it is
+	// reconciled again.
+	RetryAfter time.Duration
+}
+
+// A failureTracker counts the consecutive failed reconciles of Objects.
+// Errors are recorded while a reconcile runs, which fails if any step of it
+// failed.
+type failureTracker struct {
+	lock        sync.Mutex
+	failing     map[reconcile.Request]ErrorCode
+	consecutive map[reconcile.Request]int
+}
+
+func newFailureTracker() *failureTracker {
+	return &failureTracker{
+		failing:     make(map[reconcile.Request]ErrorCode),
+		consecutive: make(map[reconcile.Request]int),
+	}
+}
+
+// fail records that the running reconcile of the supplied request failed
+// with the supplied error.
+func (t *failureTracker) fail(req reconcile.Request, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.failing[req] = ErrorCodeFor(err)
+}
+
+// done returns the number of consecutive failed reconciles of the supplied
+// request, including the one that just finished, and the code of the last
+// error. A successful reconcile resets the count.
+func (t *failureTracker) done(req reconcile.Request) (int, ErrorCode) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	code, failed := t.failing[req]
+	delete(t.failing, req)
+	if !failed {
+		delete(t.consecutive, req)
+		return 0, ""
+	}
+	t.consecutive[req]++
+	return t.consecutive[req], code
+}
+
+// A deadLetterReconciler stops reconciling Objects whose reconciles failed
+// more than the threshold times in a row, by creating a DeadLetter for them.
+// Objects with a DeadLetter are reconciled again once its retry period
+// elapsed, or once it is deleted.
+type deadLetterReconciler struct {
+	reconcile.Reconciler
+	client   client.Client
+	log      logging.Logger
+	failures *failureTracker
+	opts     DeadLetterOptions
+	now      func() time.Time
+}
+
+func (r *deadLetterReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if r.opts.Threshold <= 0 {
+		return r.Reconciler.Reconcile(ctx, req)
+	}
+
+	dl := &v1alpha1.DeadLetter{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: req.Name}, dl)
+	if resource.IgnoreNotFound(err) != nil {
+		return reconcile.Result{}, errors.Wrap(err, errGetDeadLetter)
+	}
+	if err == nil && !meta.WasDeleted(dl) && !r.objectDeleted(ctx, req) {
+		if wait := dl.GetCreationTimestamp().Add(dl.Spec.RetryAfter.Duration).Sub(r.now()); wait > 0 {
+			return reconcile.Result{RequeueAfter: wait}, nil
+		}
+		// The failure count is kept, so that a failed retry moves the
+		// Object back to a DeadLetter right away.
+		r.log.Info("Retrying Object of expired dead letter", "request", req)
+		if err := r.client.Delete(ctx, dl); resource.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, errors.Wrap(err, errDeleteDeadLetter)
+		}
+	}
+
+	result, err := r.Reconciler.Reconcile(ctx, req)
+	n, code := r.failures.done(req)
+	if n <= r.opts.Threshold {
+		return result, err
+	}
+
+	if err := r.createDeadLetter(ctx, req, n, code); err != nil {
+		return result, err
+	}
+	r.log.Info("Moved Object to dead letter, it failed too many times in a row", "request", req, "failures", n, "errorCode", code)
+	return reconcile.Result{}, nil
+}
+
+// objectDeleted returns true if the Object of the supplied request is being
+// deleted, which is not held back by its DeadLetter.
+func (r *deadLetterReconciler) objectDeleted(ctx context.Context, req reconcile.Request) bool {
+	o := &v1alpha2.Object{}
+	if err := r.client.Get(ctx, req.NamespacedName, o); err != nil {
+		return kerrors.IsNotFound(err)
+	}
+	return meta.WasDeleted(o)
+}
+
+func (r *deadLetterReconciler) createDeadLetter(ctx context.Context, req reconcile.Request, failures int, code ErrorCode) error {
+	o := &v1alpha2.Object{}
+	if err := r.client.Get(ctx, req.NamespacedName, o); err != nil {
+		return errors.Wrap(resource.IgnoreNotFound(err), errGetFailedObject)
+	}
+
+	dl := &v1alpha1.DeadLetter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            o.GetName(),
+			OwnerReferences: []metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(o, v1alpha2.ObjectGroupVersionKind))},
+		},
+		Spec: v1alpha1.DeadLetterSpec{
+			ObjectReference: xpv1.Reference{Name: o.GetName()},
+			RetryAfter:      metav1.Duration{Duration: r.opts.RetryAfter},
+		},
+	}
+	if err := r.client.Create(ctx, dl); err != nil {
+		if kerrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return errors.Wrap(err, errCreateDeadLetter)
+	}
+	dl.Status = v1alpha1.DeadLetterStatus{Failures: failures, LastErrorCode: string(code)}
+	return errors.Wrap(r.client.Status().Update(ctx, dl), errUpdateDeadLetter)
+}
+
+// enqueueObjectForDeadLetter enqueues the Object of a DeadLetter.
+func enqueueObjectForDeadLetter() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []reconcile.Request {
+		dl, ok := o.(*v1alpha1.DeadLetter)
+		if !ok {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: dl.Spec.ObjectReference.Name}}}
+	})
+}
+
+// deadLetterDeleted only passes deletions of DeadLetters, which retry their
+// Object.
+var deadLetterDeleted = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	UpdateFunc:  func(event.UpdateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return true },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/deadletter/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestDeadLetterReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Now()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: testObjectName}}
+	requeue := reconcile.Result{Requeue: true}
+
+	type args struct {
+		previous       int
+		fail           bool
+		deadLetter     *time.Time
+		objectDeleting bool
+	}
+	type want struct {
+		result     reconcile.Result
+		reconciled bool
+		created    bool
+		deleted    bool
+		failures   int
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"BelowThreshold": {
+			reason: "We should keep retrying an Object that did not fail more than the threshold times in a row.",
+			args: args{
+				previous: 1,
+				fail:     true,
+			},
+			want: want{
+				result:     requeue,
+				reconciled: true,
+				failures:   2,
+			},
+		},
+		"ExceedsThreshold": {
+			reason: "We should create a dead letter and stop requeueing an Object that failed more than the threshold times in a row.",
+			args: args{
+				previous: 2,
+				fail:     true,
+			},
+			want: want{
+				reconciled: true,
+				created:    true,
+				failures:   3,
+			},
+		},
+		"Succeeded": {
+			reason: "We should reset the failures of an Object once it reconciled successfully.",
+			args: args{
+				previous: 2,
+			},
+			want: want{
+				result:     requeue,
+				reconciled: true,
+			},
+		},
+		"HeldBack": {
+			reason: "We should not reconcile an Object whose dead letter did not expire yet.",
+			args: args{
+				previous:   3,
+				deadLetter: ptr.To(now.Add(-10 * time.Minute)),
+			},
+			want: want{
+				result:   reconcile.Result{RequeueAfter: 50 * time.Minute},
+				failures: 3,
+			},
+		},
+		"Expired": {
+			reason: "We should delete an expired dead letter and reconcile its Object again.",
+			args: args{
+				previous:   3,
+				deadLetter: ptr.To(now.Add(-2 * time.Hour)),
+			},
+			want: want{
+				result:     requeue,
+				reconciled: true,
+				deleted:    true,
+			},
+		},
+		"ObjectDeleting": {
+			reason: "We should not hold back the deletion of an Object with a dead letter.",
+			args: args{
+				previous:       3,
+				deadLetter:     ptr.To(now.Add(-10 * time.Minute)),
+				objectDeleting: true,
+			},
+			want: want{
+				result:     requeue,
+				reconciled: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			failures := newFailureTracker()
+			failures.consecutive[req] = tc.args.previous
+
+			var got want
+			r := &deadLetterReconciler{
+				Reconciler: reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+					got.reconciled = true
+					if tc.args.fail {
+						failures.fail(req, errBoom)
+					}
+					return requeue, nil
+				}),
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						switch o := obj.(type) {
+						case *v1alpha1.DeadLetter:
+							if tc.args.deadLetter == nil {
+								return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+							}
+							o.SetName(key.Name)
+							o.SetCreationTimestamp(metav1.NewTime(*tc.args.deadLetter))
+							o.Spec.RetryAfter = metav1.Duration{Duration: time.Hour}
+						case *v1alpha2.Object:
+							o.SetName(key.Name)
+							if tc.args.objectDeleting {
+								o.SetDeletionTimestamp(&metav1.Time{Time: now})
+							}
+						}
+						return nil
+					},
+					MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+						got.created = true
+						if dl := obj.(*v1alpha1.DeadLetter); dl.Spec.ObjectReference.Name != testObjectName || len(dl.GetOwnerReferences()) != 1 {
+							t.Errorf("unexpected dead letter %+v", dl)
+						}
+						return nil
+					},
+					MockDelete: func(_ context.Context, _ client.Object, _ ...client.DeleteOption) error {
+						got.deleted = true
+						return nil
+					},
+					MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+						if s := obj.(*v1alpha1.DeadLetter).Status; s.Failures != tc.want.failures || s.LastErrorCode != string(ErrorCodeUnknown) {
+							t.Errorf("unexpected dead letter status %+v", s)
+						}
+						return nil
+					},
+				},
+				log:      logging.NewNopLogger(),
+				failures: failures,
+				opts:     DeadLetterOptions{Threshold: 2, RetryAfter: time.Hour},
+				now:      func() time.Time { return now },
+			}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): unexpected error: %v", tc.reason, err)
+			}
+			got.result = result
+			got.failures = failures.consecutive[req]
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
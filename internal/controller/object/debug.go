@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// InformersHandler serves the state of all running resource informers as
+// JSON. It serves an empty list until the resource informers are started,
+// i.e. if the watches feature is disabled.
+type InformersHandler struct {
+	lock      sync.RWMutex
+	informers *resourceInformers
+	now       func() time.Time
+}
+
+// InformerState is the state of a resource informer.
+type InformerState struct {
+	ProviderConfig string `json:"providerConfig"`
+	RoutingKey     string `json:"routingKey,omitempty"`
+	GVK            string `json:"gvk"`
+	Cluster        string `json:"cluster,omitempty"`
+	Synced         bool   `json:"synced"`
+	// Objects is the number of objects in the cache of a synced informer.
+	Objects *int   `json:"objects,omitempty"`
+	Age     string `json:"age"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (h *InformersHandler) setInformers(i *resourceInformers) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.informers = i
+}
+
+// ServeHTTP implements http.Handler.
+func (h *InformersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.lock.RLock()
+	i := h.informers
+	h.lock.RUnlock()
+
+	now := time.Now
+	if h.now != nil {
+		now = h.now
+	}
+
+	states := []InformerState{}
+	if i != nil {
+		states = i.states(r, now())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	data, err := json.Marshal(states)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write(data)
+}
+
+// states returns the state of all running resource informers, sorted by
+// provider config and GVK.
+func (i *resourceInformers) states(r *http.Request, now time.Time) []InformerState {
+	i.lock.RLock()
+	resourceCaches := make(map[gvkWithConfig]resourceCache, len(i.resourceCaches))
+	for gc, ca := range i.resourceCaches {
+		resourceCaches[gc] = ca
+	}
+	i.lock.RUnlock()
+
+	states := make([]InformerState, 0, len(resourceCaches))
+	for gc, ca := range resourceCaches {
+		s := InformerState{
+			ProviderConfig: gc.providerConfig,
+			RoutingKey:     gc.routingKey,
+			GVK:            gc.gvk.String(),
+			Cluster:        ca.cluster,
+			Age:            now.Sub(ca.started).Round(time.Second).String(),
+		}
+
+		if ca.versions != nil {
+			s.Synced = ca.versions.HasSynced()
+			n := ca.versions.Len()
+			s.Objects = &n
+			states = append(states, s)
+			continue
+		}
+
+		u := &kunstructured.Unstructured{}
+		u.SetGroupVersionKind(gc.gvk)
+		inf, err := ca.cache.GetInformer(r.Context(), u, cache.BlockUntilSynced(false))
+		if err != nil {
+			s.Error = err.Error()
+			states = append(states, s)
+			continue
+		}
+		s.Synced = inf.HasSynced()
+
+		// Listing blocks until the cache synced.
+		if s.Synced {
+			l := &kunstructured.UnstructuredList{}
+			l.SetGroupVersionKind(gc.gvk.GroupVersion().WithKind(gc.gvk.Kind + "List"))
+			if err := ca.cache.List(r.Context(), l); err != nil {
+				s.Error = err.Error()
+			} else {
+				n := len(l.Items)
+				s.Objects = &n
+			}
+		}
+		states = append(states, s)
+	}
+
+	sort.Slice(states, func(a, b int) bool {
+		if states[a].ProviderConfig != states[b].ProviderConfig {
+			return states[a].ProviderConfig < states[b].ProviderConfig
+		}
+		if states[a].GVK != states[b].GVK {
+			return states[a].GVK < states[b].GVK
+		}
+		return states[a].RoutingKey < states[b].RoutingKey
+	})
+	return states
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+)
+
+func TestInformersHandler(t *testing.T) {
+	now := time.Now()
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	configMap := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+	fakeCache := func(gvk schema.GroupVersionKind, synced bool) *informertest.FakeInformers {
+		c := &informertest.FakeInformers{}
+		u := &kunstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		inf, err := c.FakeInformerFor(context.Background(), u)
+		if err != nil {
+			t.Fatalf("cannot create fake informer: %v", err)
+		}
+		inf.Synced = synced
+		return c
+	}
+
+	type args struct {
+		informers *resourceInformers
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []InformerState
+	}{
+		"NoInformers": {
+			reason: "We should serve an empty list if resource informers are not started.",
+			want:   []InformerState{},
+		},
+		"Informers": {
+			reason: "We should serve the state of every running informer, sorted by provider config and GVK.",
+			args: args{
+				informers: &resourceInformers{
+					resourceCaches: map[gvkWithConfig]resourceCache{
+						{providerConfig: "b", gvk: deployment}: {
+							cache:   fakeCache(deployment, true),
+							cluster: "https://b",
+							started: now.Add(-time.Minute),
+						},
+						{providerConfig: "a", gvk: configMap}: {
+							cache:   fakeCache(configMap, false),
+							started: now.Add(-time.Hour),
+						},
+					},
+				},
+			},
+			want: []InformerState{
+				{
+					ProviderConfig: "a",
+					GVK:            configMap.String(),
+					Age:            "1h0m0s",
+				},
+				{
+					ProviderConfig: "b",
+					GVK:            deployment.String(),
+					Cluster:        "https://b",
+					Synced:         true,
+					Objects:        ptr.To(0),
+					Age:            "1m0s",
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := &InformersHandler{now: func() time.Time { return now }}
+			if tc.args.informers != nil {
+				h.setInformers(tc.args.informers)
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/informers", nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("\n%s\nServeHTTP(...): want status %d, got %d", tc.reason, http.StatusOK, rec.Code)
+			}
+			got := []InformerState{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("\n%s\nServeHTTP(...): cannot parse response: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nServeHTTP(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errDiffDivergence = "cannot compute divergence of the observed resource"
+
+	// maxDivergencePatchSize caps the size of the divergence patch recorded
+	// in the status of Objects.
+	maxDivergencePatchSize = 32 * 1024
+)
+
+// serverManagedMetadata are the metadata fields set by the API server, which
+// a desired manifest never diverges on.
+var serverManagedMetadata = []string{"uid", "resourceVersion", "generation", "creationTimestamp", "deletionTimestamp", "deletionGracePeriodSeconds", "managedFields", "selfLink"}
+
+// setDivergence records the divergence of the observed resource from the
+// desired manifest on the supplied Object. It is recorded whether or not the
+// resource is updated because of it, so that operators can see which
+// differences were left in place, e.g. because of ignored fields. Annotations
+// ignored by the drift detection of the Object never diverge.
+func setDivergence(cr *v1alpha2.Object, desired, observed *unstructured.Unstructured) error {
+	if isSecret(observed) {
+		// Never copy secret data to the status.
+		cr.Status.Divergence = nil
+		return nil
+	}
+	d := normalizeForDivergence(withoutIgnoredAnnotations(cr, desired))
+	o := pruneToDesired(normalizeForDivergence(withoutIgnoredAnnotations(cr, observed)), d)
+
+	from, err := json.Marshal(o)
+	if err != nil {
+		return errors.Wrap(err, errDiffDivergence)
+	}
+	to, err := json.Marshal(d)
+	if err != nil {
+		return errors.Wrap(err, errDiffDivergence)
+	}
+	ops, err := jsonpatch.CreatePatch(from, to)
+	if err != nil {
+		return errors.Wrap(err, errDiffDivergence)
+	}
+
+	div := &v1alpha2.Divergence{FieldCount: len(ops)}
+	if len(ops) > 0 {
+		if div.Patch, div.Truncated, err = cappedPatch(ops); err != nil {
+			return errors.Wrap(err, errDiffDivergence)
+		}
+	}
+	cr.Status.Divergence = div
+	return nil
+}
+
+// cappedPatch marshals as many of the supplied operations as fit into
+// maxDivergencePatchSize. It returns true if operations were dropped.
+func cappedPatch(ops []jsonpatch.Operation) (string, bool, error) {
+	items := make([]json.RawMessage, 0, len(ops))
+	size := len("[]")
+	for _, op := range ops {
+		b, err := json.Marshal(op)
+		if err != nil {
+			return "", false, err
+		}
+		if size+len(b)+len(",") > maxDivergencePatchSize {
+			break
+		}
+		items = append(items, b)
+		size += len(b) + len(",")
+	}
+	b, err := json.Marshal(items)
+	return string(b), len(items) < len(ops), err
+}
+
+// normalizeForDivergence returns a copy of the supplied manifest without its
+// status and the fields managed by the API server.
+func normalizeForDivergence(u *unstructured.Unstructured) map[string]interface{} {
+	c := u.DeepCopy()
+	unstructured.RemoveNestedField(c.Object, "status")
+	for _, f := range serverManagedMetadata {
+		unstructured.RemoveNestedField(c.Object, "metadata", f)
+	}
+	unstructured.RemoveNestedField(c.Object, "metadata", "annotations", corev1.LastAppliedConfigAnnotation)
+	if len(c.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(c.Object, "metadata", "annotations")
+	}
+	return c.Object
+}
+
+// pruneToDesired returns the supplied observed value without the fields of
+// maps the desired value does not set. Items of lists are pruned by index.
+func pruneToDesired(observed, desired interface{}) interface{} {
+	switch o := observed.(type) {
+	case map[string]interface{}:
+		d, ok := desired.(map[string]interface{})
+		if !ok {
+			return observed
+		}
+		out := make(map[string]interface{}, len(d))
+		for k, dv := range d {
+			if ov, ok := o[k]; ok {
+				out[k] = pruneToDesired(ov, dv)
+			}
+		}
+		return out
+	case []interface{}:
+		d, ok := desired.([]interface{})
+		if !ok {
+			return observed
+		}
+		out := make([]interface{}, len(o))
+		for i := range o {
+			if i < len(d) {
+				out[i] = pruneToDesired(o[i], d[i])
+				continue
+			}
+			out[i] = o[i]
+		}
+		return out
+	}
+	return observed
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestSetDivergence(t *testing.T) {
+	desired := `{
+  "apiVersion": "apps/v1",
+  "kind": "Deployment",
+  "metadata": {"name": "app", "namespace": "default"},
+  "spec": {"replicas": 3, "template": {"spec": {"containers": [{"name": "app", "image": "app:2"}]}}}
+}`
+
+	cases := map[string]struct {
+		reason   string
+		desired  string
+		ignore   []string
+		observed string
+		want     *v1alpha2.Divergence
+	}{
+		"UpToDate": {
+			reason: "We should not report server managed, status or defaulted fields as divergence.",
+			observed: `{
+  "apiVersion": "apps/v1",
+  "kind": "Deployment",
+  "metadata": {"name": "app", "namespace": "default", "uid": "1", "resourceVersion": "42", "generation": 2, "managedFields": [{}],
+    "annotations": {"kubectl.kubernetes.io/last-applied-configuration": "{}"}},
+  "spec": {"replicas": 3, "strategy": {"type": "RollingUpdate"}, "template": {"spec": {"containers": [{"name": "app", "image": "app:2", "imagePullPolicy": "IfNotPresent"}]}}},
+  "status": {"replicas": 3}
+}`,
+			want: &v1alpha2.Divergence{},
+		},
+		"Diverged": {
+			reason: "We should report the patch from the observed resource to the desired manifest.",
+			observed: `{
+  "apiVersion": "apps/v1",
+  "kind": "Deployment",
+  "metadata": {"name": "app", "namespace": "default"},
+  "spec": {"replicas": 5, "template": {"spec": {"containers": [{"name": "app", "image": "app:1"}]}}}
+}`,
+			want: &v1alpha2.Divergence{
+				Patch:      `[{"op":"replace","path":"/spec/replicas","value":3},{"op":"replace","path":"/spec/template/spec/containers/0/image","value":"app:2"}]`,
+				FieldCount: 2,
+			},
+		},
+		"IgnoredAnnotation": {
+			reason:   "We should not report annotations ignored by the drift detection as divergence.",
+			desired:  `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "app", "annotations": {"example.org/owner": "team-a"}}}`,
+			ignore:   []string{"example.org/owner"},
+			observed: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "app", "annotations": {"example.org/owner": "team-b"}}}`,
+			want:     &v1alpha2.Divergence{},
+		},
+		"Secret": {
+			reason:   "We should never record the divergence of secrets.",
+			observed: `{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "app"}, "data": {"a": "Yg=="}}`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, o := &unstructured.Unstructured{}, &unstructured.Unstructured{}
+			if tc.desired == "" {
+				tc.desired = desired
+			}
+			if err := json.Unmarshal([]byte(tc.desired), &d.Object); err != nil {
+				t.Fatalf("cannot parse desired manifest: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tc.observed), &o.Object); err != nil {
+				t.Fatalf("cannot parse observed manifest: %v", err)
+			}
+			cr := &v1alpha2.Object{}
+			cr.Spec.DriftDetection.IgnoreAnnotations = tc.ignore
+			if err := setDivergence(cr, d, o); err != nil {
+				t.Fatalf("\n%s\nsetDivergence(...): unexpected error: %v", tc.reason, err)
+			}
+			if tc.want != nil && tc.want.Patch != "" {
+				// The order of operations is not stable.
+				var want, got []jsonpatch.Operation
+				_ = json.Unmarshal([]byte(tc.want.Patch), &want)
+				_ = json.Unmarshal([]byte(cr.Status.Divergence.Patch), &got)
+				if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b jsonpatch.Operation) bool { return a.Path < b.Path })); diff != "" {
+					t.Errorf("\n%s\nsetDivergence(...): -want patch, +got patch:\n%s", tc.reason, diff)
+				}
+				tc.want.Patch, cr.Status.Divergence.Patch = "", ""
+			}
+			if diff := cmp.Diff(tc.want, cr.Status.Divergence); diff != "" {
+				t.Errorf("\n%s\nsetDivergence(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCappedPatch(t *testing.T) {
+	ops := make([]jsonpatch.Operation, 0, 100)
+	for i := 0; i < 100; i++ {
+		ops = append(ops, jsonpatch.NewOperation("add", fmt.Sprintf("/data/key%d", i), strings.Repeat("x", 1024)))
+	}
+	got, truncated, err := cappedPatch(ops)
+	if err != nil {
+		t.Fatalf("cappedPatch(...): unexpected error: %v", err)
+	}
+	if !truncated || len(got) > maxDivergencePatchSize {
+		t.Errorf("cappedPatch(...): want a truncated patch of at most %d bytes, got truncated: %t, %d bytes", maxDivergencePatchSize, truncated, len(got))
+	}
+	var kept []jsonpatch.Operation
+	if err := json.Unmarshal([]byte(got), &kept); err != nil || len(kept) == 0 {
+		t.Errorf("cappedPatch(...): want a valid patch keeping the first operations, got error: %v", err)
+	}
+}
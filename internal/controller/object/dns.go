@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+const (
+	// annotationKeyExternalDNSHostname and annotationKeyExternalDNSTTL are
+	// the annotations ExternalDNS reads the hostnames and TTL of a Service
+	// from.
+	annotationKeyExternalDNSHostname = "external-dns.alpha.kubernetes.io/hostname"
+	annotationKeyExternalDNSTTL      = "external-dns.alpha.kubernetes.io/ttl"
+
+	dnsEndpointLabelKey   = "kubernetes.crossplane.io/dns-endpoint-of"
+	dnsEndpointFieldOwner = client.FieldOwner("kubernetes.crossplane.io/dns-propagation")
+
+	defaultDNSEndpointAPIVersion = "externaldns.k8s.io/v1alpha1"
+	defaultDNSEndpointKind       = "DNSEndpoint"
+
+	errNoDNSEndpointConfig = "cannot propagate load balancer to DNS, the ProviderConfig has no dnsEndpoint"
+	errNotLoadBalancer     = "cannot propagate load balancer to DNS, the managed resource is not a Service of type LoadBalancer"
+	errNoDNSHostnames      = "cannot propagate load balancer to DNS, neither spec.dnsPropagate.hostnames nor the " + annotationKeyExternalDNSHostname + " annotation of the Service set a hostname"
+	errApplyDNSEndpoint    = "cannot apply DNSEndpoint"
+	errDeleteDNSEndpoint   = "cannot delete DNSEndpoint"
+)
+
+// dnsPropagating returns whether the supplied Object registers the load
+// balancer of its Service in DNS.
+func dnsPropagating(cr *v1alpha2.Object) bool {
+	return cr.Spec.DNSPropagate != nil && cr.Spec.DNSPropagate.Enabled
+}
+
+// dnsEndpointConfig returns the DNSEndpoint configuration of the
+// ProviderConfig of the supplied Object, if any.
+func dnsEndpointConfig(ctx context.Context, kube client.Client, cr *v1alpha2.Object) (*apisv1alpha1.DNSEndpointConfig, error) {
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetProviderConfig)
+	}
+	return pc.Spec.DNSEndpoint, nil
+}
+
+// propagateDNS keeps the DNSEndpoint of the load balancer of the supplied
+// observed Service in sync, and removes it once the Object does not propagate
+// it anymore. Nothing is registered while the load balancer has no ingress
+// yet.
+func (c *external) propagateDNS(ctx context.Context, cr *v1alpha2.Object, observed *unstructured.Unstructured) error {
+	if !dnsPropagating(cr) {
+		return c.removeDNSEndpoint(ctx, cr)
+	}
+	if c.dnsEndpoint == nil {
+		return errors.New(errNoDNSEndpointConfig)
+	}
+	endpoints, err := dnsEndpoints(cr.Spec.DNSPropagate, observed)
+	if err != nil || len(endpoints) == 0 {
+		return err
+	}
+
+	ref := v1alpha2.DNSEndpointReference{
+		APIVersion: c.dnsEndpoint.APIVersion,
+		Kind:       c.dnsEndpoint.Kind,
+		Namespace:  c.dnsEndpoint.Namespace,
+		Name:       cr.GetName(),
+	}
+	if ref.APIVersion == "" {
+		ref.APIVersion = defaultDNSEndpointAPIVersion
+	}
+	if ref.Kind == "" {
+		ref.Kind = defaultDNSEndpointKind
+	}
+	if prev := cr.Status.DNSEndpointRef; prev != nil && *prev != ref {
+		// The ProviderConfig moved the DNSEndpoint, e.g. to another namespace.
+		if err := c.removeDNSEndpoint(ctx, cr); err != nil {
+			return err
+		}
+	}
+
+	de := &unstructured.Unstructured{}
+	de.SetAPIVersion(ref.APIVersion)
+	de.SetKind(ref.Kind)
+	de.SetNamespace(ref.Namespace)
+	de.SetName(ref.Name)
+	de.SetLabels(map[string]string{dnsEndpointLabelKey: cr.GetName()})
+	meta.AddOwnerReference(de, meta.AsOwner(meta.TypedReferenceTo(cr, v1alpha2.ObjectGroupVersionKind)))
+	if err := unstructured.SetNestedSlice(de.Object, endpoints, "spec", "endpoints"); err != nil {
+		return errors.Wrap(err, errApplyDNSEndpoint)
+	}
+	if err := c.localClient.Patch(ctx, de, client.Apply, dnsEndpointFieldOwner, client.ForceOwnership); err != nil {
+		return errors.Wrap(err, errApplyDNSEndpoint)
+	}
+	cr.Status.DNSEndpointRef = &ref
+	return nil
+}
+
+// removeDNSEndpoint deletes the DNSEndpoint created for the supplied Object,
+// if any. The owner reference to the Object deletes it as well, this makes
+// sure DNS records do not outlive the Object while it is being deleted.
+func (c *external) removeDNSEndpoint(ctx context.Context, cr *v1alpha2.Object) error {
+	ref := cr.Status.DNSEndpointRef
+	if ref == nil {
+		return nil
+	}
+	de := &unstructured.Unstructured{}
+	de.SetAPIVersion(ref.APIVersion)
+	de.SetKind(ref.Kind)
+	de.SetNamespace(ref.Namespace)
+	de.SetName(ref.Name)
+	if err := c.localClient.Delete(ctx, de); resource.IgnoreNotFound(err) != nil && !apimeta.IsNoMatchError(err) {
+		return errors.Wrap(err, errDeleteDNSEndpoint)
+	}
+	cr.Status.DNSEndpointRef = nil
+	return nil
+}
+
+// dnsEndpoints returns the endpoints of the DNSEndpoint of the supplied
+// Service, in the format of ExternalDNS. IPv4 and IPv6 ingress points of the
+// load balancer are registered as A and AAAA records, their hostnames as
+// CNAME records, unless the load balancer has IPs as well.
+func dnsEndpoints(p *v1alpha2.DNSPropagate, svc *unstructured.Unstructured) ([]interface{}, error) {
+	if svc.GroupVersionKind().GroupKind() != (schema.GroupKind{Kind: "Service"}) {
+		return nil, errors.New(errNotLoadBalancer)
+	}
+	if t, _, _ := unstructured.NestedString(svc.Object, "spec", "type"); t != "LoadBalancer" {
+		return nil, errors.New(errNotLoadBalancer)
+	}
+
+	hostnames := p.Hostnames
+	if len(hostnames) == 0 {
+		for _, h := range strings.Split(svc.GetAnnotations()[annotationKeyExternalDNSHostname], ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				hostnames = append(hostnames, h)
+			}
+		}
+	}
+	if len(hostnames) == 0 {
+		return nil, errors.New(errNoDNSHostnames)
+	}
+	ttl := p.RecordTTL
+	if ttl == nil {
+		if t, err := strconv.ParseInt(svc.GetAnnotations()[annotationKeyExternalDNSTTL], 10, 64); err == nil && t > 0 {
+			ttl = &t
+		}
+	}
+
+	ingress, _, _ := unstructured.NestedSlice(svc.Object, "status", "loadBalancer", "ingress")
+	targets := map[string][]interface{}{}
+	for _, i := range ingress {
+		m, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ip, _ := m["ip"].(string); ip != "" {
+			t := "A"
+			if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+				t = "AAAA"
+			}
+			targets[t] = append(targets[t], ip)
+			continue
+		}
+		if h, _ := m["hostname"].(string); h != "" {
+			targets["CNAME"] = append(targets["CNAME"], h)
+		}
+	}
+	if len(targets["A"]) > 0 || len(targets["AAAA"]) > 0 {
+		// A CNAME record cannot coexist with other records of its name.
+		delete(targets, "CNAME")
+	}
+
+	var endpoints []interface{}
+	for _, h := range hostnames {
+		for _, t := range []string{"A", "AAAA", "CNAME"} {
+			if len(targets[t]) == 0 {
+				continue
+			}
+			e := map[string]interface{}{
+				"dnsName":    h,
+				"recordType": t,
+				"targets":    targets[t],
+			}
+			if ttl != nil {
+				e["recordTTL"] = *ttl
+			}
+			endpoints = append(endpoints, e)
+		}
+	}
+	return endpoints, nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+func loadBalancer(annotations map[string]string, ingress ...interface{}) *unstructured.Unstructured {
+	svc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+		"spec":       map[string]interface{}{"type": "LoadBalancer"},
+		"status":     map[string]interface{}{"loadBalancer": map[string]interface{}{"ingress": ingress}},
+	}}
+	svc.SetAnnotations(annotations)
+	return svc
+}
+
+func TestDNSEndpoints(t *testing.T) {
+	type args struct {
+		p   *v1alpha2.DNSPropagate
+		svc *unstructured.Unstructured
+	}
+	type want struct {
+		endpoints []interface{}
+		err       error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotLoadBalancer": {
+			reason: "We should return an error for managed resources that are not a Service of type LoadBalancer.",
+			args: args{
+				p: &v1alpha2.DNSPropagate{Enabled: true, Hostnames: []string{"web.example.org"}},
+				svc: &unstructured.Unstructured{Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Service",
+					"spec":       map[string]interface{}{"type": "ClusterIP"},
+				}},
+			},
+			want: want{
+				err: errors.New(errNotLoadBalancer),
+			},
+		},
+		"NoHostnames": {
+			reason: "We should return an error if no hostname is configured.",
+			args: args{
+				p:   &v1alpha2.DNSPropagate{Enabled: true},
+				svc: loadBalancer(nil, map[string]interface{}{"ip": "192.0.2.1"}),
+			},
+			want: want{
+				err: errors.New(errNoDNSHostnames),
+			},
+		},
+		"Pending": {
+			reason: "We should not return endpoints while the load balancer has no ingress.",
+			args: args{
+				p:   &v1alpha2.DNSPropagate{Enabled: true, Hostnames: []string{"web.example.org"}},
+				svc: loadBalancer(nil),
+			},
+		},
+		"IPs": {
+			reason: "We should register IPv4 and IPv6 ingress points as A and AAAA records, with the TTL of the spec.",
+			args: args{
+				p:   &v1alpha2.DNSPropagate{Enabled: true, Hostnames: []string{"web.example.org"}, RecordTTL: ptr.To[int64](60)},
+				svc: loadBalancer(nil, map[string]interface{}{"ip": "192.0.2.1"}, map[string]interface{}{"ip": "2001:db8::1"}),
+			},
+			want: want{
+				endpoints: []interface{}{
+					map[string]interface{}{"dnsName": "web.example.org", "recordType": "A", "targets": []interface{}{"192.0.2.1"}, "recordTTL": int64(60)},
+					map[string]interface{}{"dnsName": "web.example.org", "recordType": "AAAA", "targets": []interface{}{"2001:db8::1"}, "recordTTL": int64(60)},
+				},
+			},
+		},
+		"Annotations": {
+			reason: "We should fall back to the ExternalDNS annotations of the Service, and register hostnames as CNAME records.",
+			args: args{
+				p: &v1alpha2.DNSPropagate{Enabled: true},
+				svc: loadBalancer(map[string]string{
+					annotationKeyExternalDNSHostname: "a.example.org, b.example.org",
+					annotationKeyExternalDNSTTL:      "300",
+				}, map[string]interface{}{"hostname": "lb.elb.example.com"}),
+			},
+			want: want{
+				endpoints: []interface{}{
+					map[string]interface{}{"dnsName": "a.example.org", "recordType": "CNAME", "targets": []interface{}{"lb.elb.example.com"}, "recordTTL": int64(300)},
+					map[string]interface{}{"dnsName": "b.example.org", "recordType": "CNAME", "targets": []interface{}{"lb.elb.example.com"}, "recordTTL": int64(300)},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := dnsEndpoints(tc.args.p, tc.args.svc)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ndnsEndpoints(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.endpoints, got); diff != "" {
+				t.Errorf("\n%s\ndnsEndpoints(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPropagateDNS(t *testing.T) {
+	errBoom := errors.New("boom")
+	ref := &v1alpha2.DNSEndpointReference{APIVersion: defaultDNSEndpointAPIVersion, Kind: defaultDNSEndpointKind, Namespace: "dns", Name: "cool"}
+	object := func(enabled bool, ref *v1alpha2.DNSEndpointReference) *v1alpha2.Object {
+		cr := &v1alpha2.Object{}
+		cr.SetName("cool")
+		cr.Spec.DNSPropagate = &v1alpha2.DNSPropagate{Enabled: enabled, Hostnames: []string{"web.example.org"}}
+		cr.Status.DNSEndpointRef = ref
+		return cr
+	}
+
+	type args struct {
+		client *test.MockClient
+		config *apisv1alpha1.DNSEndpointConfig
+		cr     *v1alpha2.Object
+	}
+	type want struct {
+		ref *v1alpha2.DNSEndpointReference
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoConfig": {
+			reason: "We should return an error if the ProviderConfig does not configure DNSEndpoints.",
+			args: args{
+				cr: object(true, nil),
+			},
+			want: want{
+				err: errors.New(errNoDNSEndpointConfig),
+			},
+		},
+		"Apply": {
+			reason: "We should apply a DNSEndpoint in the configured namespace and reference it.",
+			args: args{
+				client: &test.MockClient{
+					MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						u := obj.(*unstructured.Unstructured)
+						if u.GetNamespace() != "dns" || u.GetKind() != defaultDNSEndpointKind || len(u.GetOwnerReferences()) != 1 {
+							t.Errorf("unexpected DNSEndpoint %v", u)
+						}
+						return nil
+					},
+				},
+				config: &apisv1alpha1.DNSEndpointConfig{Namespace: "dns"},
+				cr:     object(true, nil),
+			},
+			want: want{
+				ref: ref,
+			},
+		},
+		"ApplyError": {
+			reason: "We should return an error if the DNSEndpoint cannot be applied.",
+			args: args{
+				client: &test.MockClient{
+					MockPatch: test.NewMockPatchFn(errBoom),
+				},
+				config: &apisv1alpha1.DNSEndpointConfig{Namespace: "dns"},
+				cr:     object(true, nil),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errApplyDNSEndpoint),
+			},
+		},
+		"Disabled": {
+			reason: "We should delete the DNSEndpoint once the Object does not propagate its load balancer anymore.",
+			args: args{
+				client: &test.MockClient{
+					MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+						if obj.GetNamespace() != "dns" || obj.GetName() != "cool" {
+							t.Errorf("unexpected deletion of %s/%s", obj.GetNamespace(), obj.GetName())
+						}
+						return nil
+					},
+				},
+				cr: object(false, ref),
+			},
+		},
+		"DeleteError": {
+			reason: "We should keep the reference if the DNSEndpoint cannot be deleted.",
+			args: args{
+				client: &test.MockClient{
+					MockDelete: test.NewMockDeleteFn(errBoom),
+				},
+				cr: object(false, ref),
+			},
+			want: want{
+				ref: ref,
+				err: errors.Wrap(errBoom, errDeleteDNSEndpoint),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{localClient: tc.args.client, dnsEndpoint: tc.args.config}
+			err := e.propagateDNS(context.Background(), tc.args.cr, loadBalancer(nil, map[string]interface{}{"ip": "192.0.2.1"}))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\npropagateDNS(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ref, tc.args.cr.Status.DNSEndpointRef); diff != "" {
+				t.Errorf("\n%s\npropagateDNS(...): -want ref, +got ref:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// An ErrorClass determines how fast the reconcile of an Object is retried
+// after it failed.
+type ErrorClass string
+
+// Error classes.
+const (
+	// ErrorClassDefault errors are retried with the backoff of the
+	// controller options.
+	ErrorClassDefault ErrorClass = "Default"
+
+	// ErrorClassTransient errors, e.g. timeouts or rate limits, are likely
+	// to resolve by themselves and are retried quickly.
+	ErrorClassTransient ErrorClass = "Transient"
+
+	// ErrorClassPermanent errors, e.g. missing permissions or invalid
+	// manifests, need a human to resolve and are retried slowly.
+	ErrorClassPermanent ErrorClass = "Permanent"
+
+	// ErrorClassTimeout errors are reconciles that exceeded the reconcile
+	// timeout of their Object. Their target cluster is likely slow and is
+	// not retried as quickly as after transient errors.
+	ErrorClassTimeout ErrorClass = "Timeout"
+)
+
+// An ErrorClassifier maps errors to ErrorClasses.
+type ErrorClassifier func(err error) ErrorClass
+
+// ClassifyError is the default ErrorClassifier. It classifies API errors by
+// their status, exceeded reconcile timeouts, network timeouts and schema
+// violations of manifests.
+func ClassifyError(err error) ErrorClass {
+	var ne net.Error
+	var sv *schemaViolation
+	var te *reconcileTimeoutError
+	switch {
+	case errors.As(err, &te):
+		return ErrorClassTimeout
+	case kerrors.IsTooManyRequests(err),
+		kerrors.IsServerTimeout(err),
+		kerrors.IsTimeout(err),
+		kerrors.IsServiceUnavailable(err),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &ne) && ne.Timeout():
+		return ErrorClassTransient
+	case kerrors.IsForbidden(err),
+		kerrors.IsUnauthorized(err),
+		kerrors.IsInvalid(err),
+		kerrors.IsBadRequest(err),
+		errors.As(err, &sv):
+		return ErrorClassPermanent
+	}
+	return ErrorClassDefault
+}
+
+// Backoff configures the exponential backoff of an ErrorClass.
+type Backoff struct {
+	// BaseDelay is the delay before the first retry, doubled with every
+	// subsequent failure.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between retries.
+	MaxDelay time.Duration
+	// Jitter randomly extends delays by up to this fraction of the delay.
+	Jitter float64
+}
+
+// BackoffOptions configure the backoff of the transient, permanent and
+// timeout error classes.
+type BackoffOptions struct {
+	Transient Backoff
+	Permanent Backoff
+	Timeout   Backoff
+}
+
+// A ClassifiedRateLimiter rate limits items by the class of the error their
+// last reconcile failed with. Items are rate limited by the fallback rate
+// limiter until they are classified, and after they were forgotten.
+type ClassifiedRateLimiter struct {
+	fallback workqueue.RateLimiter
+	limiters map[ErrorClass]jitteredRateLimiter
+
+	lock    sync.Mutex
+	classes map[interface{}]ErrorClass
+}
+
+type jitteredRateLimiter struct {
+	workqueue.RateLimiter
+	jitter float64
+}
+
+// NewClassifiedRateLimiter returns a ClassifiedRateLimiter that rate limits
+// ErrorClassDefault errors using the supplied fallback, and the other classes
+// with an exponential backoff as configured by the supplied options.
+func NewClassifiedRateLimiter(fallback workqueue.RateLimiter, o BackoffOptions) *ClassifiedRateLimiter {
+	return &ClassifiedRateLimiter{
+		fallback: fallback,
+		limiters: map[ErrorClass]jitteredRateLimiter{
+			ErrorClassTransient: {
+				RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(o.Transient.BaseDelay, o.Transient.MaxDelay),
+				jitter:      o.Transient.Jitter,
+			},
+			ErrorClassPermanent: {
+				RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(o.Permanent.BaseDelay, o.Permanent.MaxDelay),
+				jitter:      o.Permanent.Jitter,
+			},
+			ErrorClassTimeout: {
+				RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(o.Timeout.BaseDelay, o.Timeout.MaxDelay),
+				jitter:      o.Timeout.Jitter,
+			},
+		},
+		classes: make(map[interface{}]ErrorClass),
+	}
+}
+
+// Classify records the class of the error the last reconcile of the supplied
+// item failed with.
+func (r *ClassifiedRateLimiter) Classify(item interface{}, c ErrorClass) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.classes[item] = c
+}
+
+func (r *ClassifiedRateLimiter) limiterFor(item interface{}) (jitteredRateLimiter, bool) {
+	r.lock.Lock()
+	c := r.classes[item]
+	r.lock.Unlock()
+	l, ok := r.limiters[c]
+	return l, ok
+}
+
+// When returns how long the supplied item should wait before it is retried.
+func (r *ClassifiedRateLimiter) When(item interface{}) time.Duration {
+	l, ok := r.limiterFor(item)
+	if !ok {
+		return r.fallback.When(item)
+	}
+	d := l.When(item)
+	if l.jitter > 0 {
+		d += time.Duration(rand.Float64() * l.jitter * float64(d)) //nolint:gosec // No need for secure randomness
+	}
+	return d
+}
+
+// Forget the supplied item, resetting its backoff and class.
+func (r *ClassifiedRateLimiter) Forget(item interface{}) {
+	r.lock.Lock()
+	delete(r.classes, item)
+	r.lock.Unlock()
+
+	r.fallback.Forget(item)
+	for _, l := range r.limiters {
+		l.Forget(item)
+	}
+}
+
+// NumRequeues returns how often the supplied item was retried with the
+// backoff of its current class.
+func (r *ClassifiedRateLimiter) NumRequeues(item interface{}) int {
+	l, ok := r.limiterFor(item)
+	if !ok {
+		return r.fallback.NumRequeues(item)
+	}
+	return l.NumRequeues(item)
+}
+
+// A classifyingConnecter classifies the errors of the external clients it
+// connects, so that the reconcile of their managed resource is retried with
+// the backoff of the error's class.
+type classifyingConnecter struct {
+	managed.ExternalConnecter
+	classify ErrorClassifier
+	limiter  *ClassifiedRateLimiter
+	// failures, if set, counts the consecutive failures of managed
+	// resources.
+	failures *failureTracker
+}
+
+func (c *classifyingConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, c.record(mg, err)
+	}
+	return &classifyingExternal{ExternalClient: ec, record: c.record}, nil
+}
+
+// record classifies the supplied error of the supplied managed resource and
+// returns it.
+func (c *classifyingConnecter) record(mg resource.Managed, err error) error {
+	if err != nil {
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: mg.GetNamespace(), Name: mg.GetName()}}
+		c.limiter.Classify(req, c.classify(err))
+		if c.failures != nil {
+			c.failures.fail(req, err)
+		}
+	}
+	return err
+}
+
+type classifyingExternal struct {
+	managed.ExternalClient
+	record func(mg resource.Managed, err error) error
+}
+
+func (e *classifyingExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	return o, e.record(mg, err)
+}
+
+func (e *classifyingExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	c, err := e.ExternalClient.Create(ctx, mg)
+	return c, e.record(mg, err)
+}
+
+func (e *classifyingExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	u, err := e.ExternalClient.Update(ctx, mg)
+	return u, e.record(mg, err)
+}
+
+func (e *classifyingExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	return e.record(mg, e.ExternalClient.Delete(ctx, mg))
+}
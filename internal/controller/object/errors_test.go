@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestClassifyError(t *testing.T) {
+	gr := schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   ErrorClass
+	}{
+		"TooManyRequests": {
+			reason: "Rate limits should be transient.",
+			err:    errors.Wrap(kerrors.NewTooManyRequests("slow down", 1), errGetObject),
+			want:   ErrorClassTransient,
+		},
+		"DeadlineExceeded": {
+			reason: "Timeouts should be transient.",
+			err:    errors.Wrap(context.DeadlineExceeded, errGetObject),
+			want:   ErrorClassTransient,
+		},
+		"ReconcileTimedOut": {
+			reason: "Reconciles exceeding the reconcile timeout of their Object should be told apart from other timeouts.",
+			err:    &reconcileTimeoutError{timeout: time.Minute, cause: errors.Wrap(context.DeadlineExceeded, errGetObject)},
+			want:   ErrorClassTimeout,
+		},
+		"Forbidden": {
+			reason: "Missing permissions should be permanent.",
+			err:    errors.Wrap(kerrors.NewForbidden(gr, "app", errors.New("no")), errCreateObject),
+			want:   ErrorClassPermanent,
+		},
+		"SchemaViolation": {
+			reason: "Manifests that violate their schema should be permanent.",
+			err:    &schemaViolation{msgs: []string{"spec.replicas in body must be of type integer"}},
+			want:   ErrorClassPermanent,
+		},
+		"Other": {
+			reason: "Other errors should use the default backoff.",
+			err:    kerrors.NewConflict(gr, "app", errors.New("conflict")),
+			want:   ErrorClassDefault,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ClassifyError(tc.err); got != tc.want {
+				t.Errorf("\n%s\nClassifyError(...): want %q, got %q", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestClassifiedRateLimiter(t *testing.T) {
+	r := NewClassifiedRateLimiter(workqueue.NewItemExponentialFailureRateLimiter(time.Second, time.Minute), BackoffOptions{
+		Transient: Backoff{BaseDelay: 10 * time.Millisecond, MaxDelay: 20 * time.Millisecond},
+		Permanent: Backoff{BaseDelay: time.Minute, MaxDelay: time.Hour},
+	})
+
+	if got := r.When("a"); got != time.Second {
+		t.Errorf("When(...): want fallback delay %s for unclassified items, got %s", time.Second, got)
+	}
+
+	r.Classify("a", ErrorClassTransient)
+	for _, want := range []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond} {
+		if got := r.When("a"); got != want {
+			t.Errorf("When(...): want transient delay %s, got %s", want, got)
+		}
+	}
+
+	r.Classify("a", ErrorClassPermanent)
+	if got := r.When("a"); got != time.Minute {
+		t.Errorf("When(...): want permanent delay %s, got %s", time.Minute, got)
+	}
+
+	r.Forget("a")
+	if got := r.NumRequeues("a"); got != 0 {
+		t.Errorf("NumRequeues(...): want 0 requeues after Forget, got %d", got)
+	}
+	r.Classify("a", ErrorClassTransient)
+	if got := r.When("a"); got != 10*time.Millisecond {
+		t.Errorf("When(...): want reset transient delay %s after Forget, got %s", 10*time.Millisecond, got)
+	}
+}
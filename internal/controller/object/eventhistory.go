@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+// maxEventHistory is the number of Events recorded in the status of an
+// Object at most.
+const maxEventHistory = 20
+
+// recordsEventHistory returns true if the Events of the resource managed by
+// the supplied Object are recorded in its status.
+func recordsEventHistory(cr *v1alpha2.Object) bool {
+	return cr.Spec.EventHistory != nil && cr.Spec.EventHistory.Enabled
+}
+
+// updateEventHistory records the Events of the observed resource that were
+// emitted since the newest recorded one in the status of the supplied
+// Object. Only the latest maxEventHistory Events are kept.
+func (c *external) updateEventHistory(ctx context.Context, cr *v1alpha2.Object, observed *unstructured.Unstructured) error {
+	if !recordsEventHistory(cr) {
+		cr.Status.EventHistory = nil
+		return nil
+	}
+
+	opts := []client.ListOption{client.MatchingFields{fieldInvolvedObjectUID: string(observed.GetUID())}}
+	if ns := observed.GetNamespace(); ns != "" {
+		opts = append(opts, client.InNamespace(ns))
+	}
+	el := &v1.EventList{}
+	if err := c.client.List(ctx, el, opts...); err != nil {
+		return errors.Wrap(err, errListEvents)
+	}
+
+	cr.Status.EventHistory = appendEventHistory(cr.Status.EventHistory, el.Items)
+	return nil
+}
+
+// appendEventHistory appends the supplied Events to the supplied history,
+// oldest first, evicting the oldest entries beyond maxEventHistory. Events
+// that are neither Normal nor Warning, or that are already recorded, are
+// skipped. Events last emitted before the newest entry are already recorded,
+// or were evicted before.
+func appendEventHistory(history []v1alpha2.RecordedEvent, events []v1.Event) []v1alpha2.RecordedEvent {
+	var newest metav1.Time
+	recorded := make(map[string]bool, len(history))
+	for _, e := range history {
+		recorded[eventKey(e)] = true
+		if newest.Before(&e.Timestamp) {
+			newest = e.Timestamp
+		}
+	}
+
+	added := make([]v1alpha2.RecordedEvent, 0, len(events))
+	for _, e := range events {
+		if e.Type != v1.EventTypeNormal && e.Type != v1.EventTypeWarning {
+			continue
+		}
+		// The status keeps timestamps at second precision.
+		re := v1alpha2.RecordedEvent{
+			Type:      e.Type,
+			Reason:    e.Reason,
+			Message:   e.Message,
+			Timestamp: metav1.NewTime(lastSeen(e).Truncate(time.Second)),
+		}
+		k := eventKey(re)
+		if re.Timestamp.Before(&newest) || recorded[k] {
+			continue
+		}
+		recorded[k] = true
+		added = append(added, re)
+	}
+	if len(added) == 0 {
+		return history
+	}
+	sort.SliceStable(added, func(i, j int) bool {
+		return added[i].Timestamp.Before(&added[j].Timestamp)
+	})
+
+	out := append(append(make([]v1alpha2.RecordedEvent, 0, len(history)+len(added)), history...), added...)
+	if len(out) > maxEventHistory {
+		out = out[len(out)-maxEventHistory:]
+	}
+	return out
+}
+
+func eventKey(e v1alpha2.RecordedEvent) string {
+	return fmt.Sprintf("%s/%s/%d/%s", e.Type, e.Reason, e.Timestamp.Unix(), e.Message)
+}
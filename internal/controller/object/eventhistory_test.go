@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestAppendEventHistory(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	ev := func(typ, reason string, ago time.Duration) corev1.Event {
+		return corev1.Event{Type: typ, Reason: reason, Message: reason + " happened", LastTimestamp: metav1.NewTime(now.Add(-ago))}
+	}
+	rec := func(typ, reason string, ago time.Duration) v1alpha2.RecordedEvent {
+		return v1alpha2.RecordedEvent{Type: typ, Reason: reason, Message: reason + " happened", Timestamp: metav1.NewTime(now.Add(-ago))}
+	}
+
+	full := make([]v1alpha2.RecordedEvent, 0, maxEventHistory)
+	for i := maxEventHistory; i > 0; i-- {
+		full = append(full, rec(corev1.EventTypeNormal, fmt.Sprintf("Old%d", i), time.Duration(i)*time.Hour))
+	}
+
+	type args struct {
+		history []v1alpha2.RecordedEvent
+		events  []corev1.Event
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []v1alpha2.RecordedEvent
+	}{
+		"Append": {
+			reason: "We should append Normal and Warning Events, oldest first.",
+			args: args{
+				history: []v1alpha2.RecordedEvent{rec(corev1.EventTypeNormal, "ScalingReplicaSet", time.Hour)},
+				events: []corev1.Event{
+					ev(corev1.EventTypeWarning, "BackOff", time.Minute),
+					ev(corev1.EventTypeNormal, "Pulled", 2*time.Minute),
+					ev("Other", "Unknown", time.Minute),
+				},
+			},
+			want: []v1alpha2.RecordedEvent{
+				rec(corev1.EventTypeNormal, "ScalingReplicaSet", time.Hour),
+				rec(corev1.EventTypeNormal, "Pulled", 2*time.Minute),
+				rec(corev1.EventTypeWarning, "BackOff", time.Minute),
+			},
+		},
+		"AlreadyRecorded": {
+			reason: "We should not record Events again, nor Events older than the newest recorded one.",
+			args: args{
+				history: []v1alpha2.RecordedEvent{rec(corev1.EventTypeWarning, "BackOff", time.Minute)},
+				events: []corev1.Event{
+					ev(corev1.EventTypeWarning, "BackOff", time.Minute),
+					ev(corev1.EventTypeNormal, "Pulled", time.Hour),
+				},
+			},
+			want: []v1alpha2.RecordedEvent{rec(corev1.EventTypeWarning, "BackOff", time.Minute)},
+		},
+		"EmittedAgain": {
+			reason: "We should record an Event again once it is emitted again.",
+			args: args{
+				history: []v1alpha2.RecordedEvent{rec(corev1.EventTypeWarning, "BackOff", time.Minute)},
+				events:  []corev1.Event{ev(corev1.EventTypeWarning, "BackOff", 0)},
+			},
+			want: []v1alpha2.RecordedEvent{
+				rec(corev1.EventTypeWarning, "BackOff", time.Minute),
+				rec(corev1.EventTypeWarning, "BackOff", 0),
+			},
+		},
+		"Evict": {
+			reason: "We should evict the oldest Events beyond the capacity of the history.",
+			args: args{
+				history: full,
+				events:  []corev1.Event{ev(corev1.EventTypeWarning, "OOMKilling", 0)},
+			},
+			want: append(append([]v1alpha2.RecordedEvent{}, full[1:]...), rec(corev1.EventTypeWarning, "OOMKilling", 0)),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := appendEventHistory(tc.args.history, tc.args.events)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nappendEventHistory(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateEventHistory(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+
+	observed := &unstructured.Unstructured{}
+	observed.SetNamespace("default")
+	observed.SetUID(types.UID("uid"))
+
+	recording := func(history ...v1alpha2.RecordedEvent) *v1alpha2.Object {
+		cr := &v1alpha2.Object{}
+		cr.Spec.EventHistory = &v1alpha2.EventHistory{Enabled: true}
+		cr.Status.EventHistory = history
+		return cr
+	}
+
+	type want struct {
+		err     error
+		history []v1alpha2.RecordedEvent
+	}
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha2.Object
+		list   test.MockListFn
+		want   want
+	}{
+		"Disabled": {
+			reason: "We should clear the history of Objects that do not record one.",
+			cr: &v1alpha2.Object{Status: v1alpha2.ObjectStatus{
+				EventHistory: []v1alpha2.RecordedEvent{{Type: corev1.EventTypeNormal}},
+			}},
+		},
+		"ListError": {
+			reason: "We should return an error if events cannot be listed.",
+			cr:     recording(),
+			list:   test.NewMockListFn(errBoom),
+			want: want{
+				err: errors.Wrap(errBoom, errListEvents),
+			},
+		},
+		"Recorded": {
+			reason: "We should record the events of the observed resource.",
+			cr:     recording(),
+			list: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+				lo := &client.ListOptions{}
+				lo.ApplyOptions(opts)
+				if lo.Namespace != "default" || lo.FieldSelector.String() != fieldInvolvedObjectUID+"=uid" {
+					t.Errorf("unexpected list options %v", lo)
+				}
+				obj.(*corev1.EventList).Items = []corev1.Event{{
+					Reason:        "OOMKilling",
+					Message:       "Memory cgroup out of memory",
+					Type:          corev1.EventTypeWarning,
+					LastTimestamp: now,
+				}}
+				return nil
+			},
+			want: want{
+				history: []v1alpha2.RecordedEvent{{
+					Type:      corev1.EventTypeWarning,
+					Reason:    "OOMKilling",
+					Message:   "Memory cgroup out of memory",
+					Timestamp: now,
+				}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockList: tc.list,
+					},
+				},
+			}
+			err := e.updateEventHistory(context.Background(), tc.cr, observed)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.updateEventHistory(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.history, tc.cr.Status.EventHistory); diff != "" {
+				t.Errorf("\n%s\ne.updateEventHistory(...): -want history, +got history:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
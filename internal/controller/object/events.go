@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errListEvents = "cannot list events of object"
+
+	// fieldInvolvedObjectUID is the field selector of Events served by the
+	// API server that matches the UID of the resource they are about.
+	fieldInvolvedObjectUID = "involvedObject.uid"
+
+	defaultEventWindow = 10 * time.Minute
+)
+
+var eventGVK = v1.SchemeGroupVersion.WithKind("Event")
+
+// waitsForEvent returns true if the readiness of the supplied Object is
+// derived from the Events of its resource.
+func waitsForEvent(cr *v1alpha2.Object) bool {
+	return cr.Spec.Readiness.Policy == v1alpha2.ReadinessPolicyWaitForEvent && cr.Spec.Readiness.WaitForEvent != nil
+}
+
+// updateConditionFromEvents marks the supplied Object as available once an
+// Event matching its WaitForEvent readiness was emitted for the observed
+// resource. The Object stays available until its resource is created again.
+func (c *external) updateConditionFromEvents(ctx context.Context, cr *v1alpha2.Object, observed *unstructured.Unstructured) error {
+	if !waitsForEvent(cr) {
+		return nil
+	}
+	if cr.GetCondition(xpv1.TypeReady).Equal(xpv1.Available()) {
+		return nil
+	}
+
+	opts := []client.ListOption{client.MatchingFields{fieldInvolvedObjectUID: string(observed.GetUID())}}
+	if ns := observed.GetNamespace(); ns != "" {
+		opts = append(opts, client.InNamespace(ns))
+	}
+	el := &v1.EventList{}
+	if err := c.client.List(ctx, el, opts...); err != nil {
+		return errors.Wrap(err, errListEvents)
+	}
+
+	w := cr.Spec.Readiness.WaitForEvent
+	if hasMatchingEvent(el.Items, w, time.Now()) {
+		cr.SetConditions(xpv1.Available())
+		return nil
+	}
+	cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("waiting for a %s event with reason %q", eventType(w), w.Reason)))
+	return nil
+}
+
+// hasMatchingEvent returns true if one of the supplied Events matches w and
+// was last seen within its window.
+func hasMatchingEvent(events []v1.Event, w *v1alpha2.WaitForEvent, now time.Time) bool {
+	window := defaultEventWindow
+	if w.Window != nil {
+		window = w.Window.Duration
+	}
+	for _, e := range events {
+		if e.Reason != w.Reason || e.Type != eventType(w) {
+			continue
+		}
+		if !strings.Contains(e.Message, w.Message) {
+			continue
+		}
+		if now.Sub(lastSeen(e)) > window {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func eventType(w *v1alpha2.WaitForEvent) string {
+	if w.Type == "" {
+		return v1.EventTypeNormal
+	}
+	return w.Type
+}
+
+// lastSeen returns when the supplied Event was last emitted. Depending on
+// the API used to emit it, this is recorded in a different field.
+func lastSeen(e v1.Event) time.Time {
+	switch {
+	case e.Series != nil && !e.Series.LastObservedTime.IsZero():
+		return e.Series.LastObservedTime.Time
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+// involvedObjectOf returns the resource the supplied Event is about.
+func involvedObjectOf(ev client.Object) (unstructured.Unstructured, bool) {
+	u, ok := ev.(*unstructured.Unstructured)
+	if !ok || u.GroupVersionKind() != eventGVK {
+		return unstructured.Unstructured{}, false
+	}
+	ref, found, err := unstructured.NestedStringMap(u.Object, "involvedObject")
+	if err != nil || !found {
+		return unstructured.Unstructured{}, false
+	}
+	return unstructuredFromObjectRef(v1.ObjectReference{
+		APIVersion: ref["apiVersion"],
+		Kind:       ref["kind"],
+		Namespace:  ref["namespace"],
+		Name:       ref["name"],
+	}), true
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestHasMatchingEvent(t *testing.T) {
+	now := time.Now()
+	event := func(reason, typ, msg string, seen time.Time) corev1.Event {
+		return corev1.Event{Reason: reason, Type: typ, Message: msg, LastTimestamp: metav1.NewTime(seen)}
+	}
+	w := &v1alpha2.WaitForEvent{Reason: "SuccessfulCreate", Message: "web-0"}
+
+	cases := map[string]struct {
+		reason string
+		events []corev1.Event
+		want   bool
+	}{
+		"NoEvents": {
+			reason: "We should not match without events.",
+		},
+		"Match": {
+			reason: "We should match a recent event of the wanted reason, type and message.",
+			events: []corev1.Event{
+				event("SuccessfulDelete", corev1.EventTypeNormal, "delete Pod web-0", now),
+				event("SuccessfulCreate", corev1.EventTypeNormal, "create Pod web-0 in StatefulSet web successful", now.Add(-time.Minute)),
+			},
+			want: true,
+		},
+		"WrongType": {
+			reason: "We should not match events of another type.",
+			events: []corev1.Event{event("SuccessfulCreate", corev1.EventTypeWarning, "create Pod web-0", now)},
+		},
+		"WrongMessage": {
+			reason: "We should not match events whose message does not contain the wanted substring.",
+			events: []corev1.Event{event("SuccessfulCreate", corev1.EventTypeNormal, "create Pod web-1", now)},
+		},
+		"TooOld": {
+			reason: "We should ignore events last seen before the window.",
+			events: []corev1.Event{event("SuccessfulCreate", corev1.EventTypeNormal, "create Pod web-0", now.Add(-time.Hour))},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := hasMatchingEvent(tc.events, w, now); got != tc.want {
+				t.Errorf("\n%s\nhasMatchingEvent(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestUpdateConditionFromEvents(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	observed := &unstructured.Unstructured{}
+	observed.SetNamespace("default")
+	observed.SetUID(types.UID("uid"))
+
+	waiting := func(c ...xpv1.Condition) *v1alpha2.Object {
+		cr := &v1alpha2.Object{}
+		cr.Spec.Readiness = v1alpha2.Readiness{
+			Policy:       v1alpha2.ReadinessPolicyWaitForEvent,
+			WaitForEvent: &v1alpha2.WaitForEvent{Reason: "SuccessfulCreate"},
+		}
+		cr.SetConditions(c...)
+		return cr
+	}
+
+	type want struct {
+		err   error
+		ready corev1.ConditionStatus
+	}
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha2.Object
+		list   test.MockListFn
+		want   want
+	}{
+		"OtherPolicy": {
+			reason: "We should not list events of Objects that do not wait for one.",
+			cr:     &v1alpha2.Object{},
+			want: want{
+				ready: corev1.ConditionUnknown,
+			},
+		},
+		"AlreadyReady": {
+			reason: "We should not list events of Objects that already observed one.",
+			cr:     waiting(xpv1.Available()),
+			want: want{
+				ready: corev1.ConditionTrue,
+			},
+		},
+		"ListError": {
+			reason: "We should return an error if events cannot be listed.",
+			cr:     waiting(),
+			list:   test.NewMockListFn(errBoom),
+			want: want{
+				err:   errors.Wrap(errBoom, errListEvents),
+				ready: corev1.ConditionUnknown,
+			},
+		},
+		"NoMatchingEvent": {
+			reason: "We should mark the Object as unavailable until a matching event was emitted.",
+			cr:     waiting(),
+			list:   test.NewMockListFn(nil),
+			want: want{
+				ready: corev1.ConditionFalse,
+			},
+		},
+		"MatchingEvent": {
+			reason: "We should mark the Object as available once a matching event was emitted for its resource.",
+			cr:     waiting(),
+			list: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+				lo := &client.ListOptions{}
+				lo.ApplyOptions(opts)
+				if lo.Namespace != "default" || lo.FieldSelector.String() != fieldInvolvedObjectUID+"=uid" {
+					t.Errorf("unexpected list options %v", lo)
+				}
+				obj.(*corev1.EventList).Items = []corev1.Event{{
+					Reason:        "SuccessfulCreate",
+					Type:          corev1.EventTypeNormal,
+					LastTimestamp: metav1.Now(),
+				}}
+				return nil
+			},
+			want: want{
+				ready: corev1.ConditionTrue,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockList: tc.list,
+					},
+				},
+			}
+			err := e.updateConditionFromEvents(context.Background(), tc.cr, observed)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.updateConditionFromEvents(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if got := tc.cr.GetCondition(xpv1.TypeReady).Status; got != tc.want.ready {
+				t.Errorf("\n%s\ne.updateConditionFromEvents(...): want Ready %s, got %s", tc.reason, tc.want.ready, got)
+			}
+		})
+	}
+}
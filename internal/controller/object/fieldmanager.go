@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AnnotationKeyFieldManager is the annotation of an Object that sets the
+// field manager its managed resource is written with. By default, the API
+// server derives the field manager from the provider's user agent.
+const AnnotationKeyFieldManager = "provider-kubernetes.crossplane.io/field-manager"
+
+// A fieldManagerClient writes resources with a field manager.
+type fieldManagerClient struct {
+	client.Client
+	owner client.FieldOwner
+}
+
+// withFieldManager returns a client that writes resources with the field
+// manager the supplied annotations set, if any.
+func withFieldManager(c client.Client, annotations map[string]string) client.Client {
+	fm := annotations[AnnotationKeyFieldManager]
+	if fm == "" {
+		return c
+	}
+	return &fieldManagerClient{Client: c, owner: client.FieldOwner(fm)}
+}
+
+func (c *fieldManagerClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.Client.Create(ctx, obj, append(opts, c.owner)...)
+}
+
+func (c *fieldManagerClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.Client.Update(ctx, obj, append(opts, c.owner)...)
+}
+
+func (c *fieldManagerClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.Client.Patch(ctx, obj, patch, append(opts, c.owner)...)
+}
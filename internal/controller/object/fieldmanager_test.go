@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func Test_withFieldManager(t *testing.T) {
+	type want struct {
+		create string
+		update string
+		patch  string
+	}
+	cases := map[string]struct {
+		reason      string
+		annotations map[string]string
+		want        want
+	}{
+		"NoAnnotation": {
+			reason: "We should not set a field manager if the Object does not set one.",
+		},
+		"Annotation": {
+			reason: "We should write resources with the field manager of the Object's annotation.",
+			annotations: map[string]string{
+				AnnotationKeyFieldManager: "platform-team",
+			},
+			want: want{
+				create: "platform-team",
+				update: "platform-team",
+				patch:  "platform-team",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got want
+			c := withFieldManager(&test.MockClient{
+				MockCreate: func(_ context.Context, _ client.Object, opts ...client.CreateOption) error {
+					o := &client.CreateOptions{}
+					got.create = o.ApplyOptions(opts).FieldManager
+					return nil
+				},
+				MockUpdate: func(_ context.Context, _ client.Object, opts ...client.UpdateOption) error {
+					o := &client.UpdateOptions{}
+					got.update = o.ApplyOptions(opts).FieldManager
+					return nil
+				},
+				MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, opts ...client.PatchOption) error {
+					o := &client.PatchOptions{}
+					got.patch = o.ApplyOptions(opts).FieldManager
+					return nil
+				},
+			}, tc.annotations)
+
+			_ = c.Create(context.Background(), externalResource())
+			_ = c.Update(context.Background(), externalResource())
+			_ = c.Patch(context.Background(), externalResource(), client.Merge)
+
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nwithFieldManager(...): -want field managers, +got field managers:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
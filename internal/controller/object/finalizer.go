@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errFinalizePluginExists = "finalize plugin is already registered"
+	errFinalizePlugins      = "finalize plugins block removing the finalizer"
+)
+
+// A FinalizePlugin cleans up after a deleted Object, e.g. by deregistering
+// its resource from a service mesh or removing its DNS entries.
+type FinalizePlugin interface {
+	// Finalize cleans up after the supplied Object. Its finalizer is not
+	// removed while Finalize returns an error, in which case Finalize is
+	// called again.
+	Finalize(ctx context.Context, obj client.Object) error
+}
+
+// A FinalizePluginFn is a function that satisfies the FinalizePlugin
+// interface.
+type FinalizePluginFn func(ctx context.Context, obj client.Object) error
+
+// Finalize calls FinalizePluginFn.
+func (fn FinalizePluginFn) Finalize(ctx context.Context, obj client.Object) error {
+	return fn(ctx, obj)
+}
+
+// FinalizePlugins are registered at provider startup and called in order of
+// registration before the finalizer of a deleted Object is removed.
+type FinalizePlugins struct {
+	names   []string
+	plugins map[string]FinalizePlugin
+}
+
+// NewFinalizePlugins returns an empty set of FinalizePlugins.
+func NewFinalizePlugins() *FinalizePlugins {
+	return &FinalizePlugins{plugins: make(map[string]FinalizePlugin)}
+}
+
+// Register adds the supplied FinalizePlugin under the supplied name, which
+// identifies it in the status of Objects.
+func (p *FinalizePlugins) Register(name string, fp FinalizePlugin) error {
+	if _, ok := p.plugins[name]; ok {
+		return errors.Errorf("%s: %s", errFinalizePluginExists, name)
+	}
+	p.names = append(p.names, name)
+	p.plugins[name] = fp
+	return nil
+}
+
+// list sets the registered plugins in the status of the supplied Object,
+// keeping the status of those that already finalized it.
+func (p *FinalizePlugins) list(obj *v1alpha2.Object) {
+	if p == nil || len(p.names) == 0 {
+		return
+	}
+	finalized := make(map[string]bool, len(obj.Status.FinalizePlugins))
+	for _, s := range obj.Status.FinalizePlugins {
+		finalized[s.Name] = s.Finalized
+	}
+	status := make([]v1alpha2.FinalizePluginStatus, 0, len(p.names))
+	for _, n := range p.names {
+		status = append(status, v1alpha2.FinalizePluginStatus{Name: n, Finalized: finalized[n]})
+	}
+	obj.Status.FinalizePlugins = status
+}
+
+// finalize calls all registered plugins that did not finalize the supplied
+// Object yet. It returns an error if any of them failed.
+func (p *FinalizePlugins) finalize(ctx context.Context, obj *v1alpha2.Object) error {
+	if p == nil || len(p.names) == 0 {
+		return nil
+	}
+	p.list(obj)
+
+	var failed []string
+	for i := range obj.Status.FinalizePlugins {
+		s := &obj.Status.FinalizePlugins[i]
+		if s.Finalized {
+			continue
+		}
+		if err := p.plugins[s.Name].Finalize(ctx, obj); err != nil {
+			s.Message = err.Error()
+			failed = append(failed, s.Name+": "+s.Message)
+			continue
+		}
+		s.Finalized = true
+		s.Message = ""
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("%s: %s", errFinalizePlugins, strings.Join(failed, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestFinalizePlugins(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		plugins map[string]error
+		status  []v1alpha2.FinalizePluginStatus
+	}
+	type want struct {
+		err    bool
+		called []string
+		status []v1alpha2.FinalizePluginStatus
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoPlugins": {
+			reason: "We should not block finalization if no plugins are registered.",
+		},
+		"AllFinalized": {
+			reason: "We should call every plugin and record that it finalized the Object.",
+			args: args{
+				plugins: map[string]error{"dns": nil, "mesh": nil},
+			},
+			want: want{
+				called: []string{"dns", "mesh"},
+				status: []v1alpha2.FinalizePluginStatus{{Name: "dns", Finalized: true}, {Name: "mesh", Finalized: true}},
+			},
+		},
+		"PluginBlocks": {
+			reason: "We should block finalization and record the error if a plugin fails, still calling the others.",
+			args: args{
+				plugins: map[string]error{"dns": errBoom, "mesh": nil},
+			},
+			want: want{
+				err:    true,
+				called: []string{"dns", "mesh"},
+				status: []v1alpha2.FinalizePluginStatus{{Name: "dns", Message: errBoom.Error()}, {Name: "mesh", Finalized: true}},
+			},
+		},
+		"SkipFinalized": {
+			reason: "We should not call plugins again that already finalized the Object.",
+			args: args{
+				plugins: map[string]error{"dns": nil, "mesh": nil},
+				status:  []v1alpha2.FinalizePluginStatus{{Name: "dns", Message: errBoom.Error()}, {Name: "mesh", Finalized: true}},
+			},
+			want: want{
+				called: []string{"dns"},
+				status: []v1alpha2.FinalizePluginStatus{{Name: "dns", Finalized: true}, {Name: "mesh", Finalized: true}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var called []string
+			p := NewFinalizePlugins()
+			for _, n := range []string{"dns", "mesh"} {
+				n := n
+				err, ok := tc.args.plugins[n]
+				if !ok {
+					continue
+				}
+				if rerr := p.Register(n, FinalizePluginFn(func(_ context.Context, _ client.Object) error {
+					called = append(called, n)
+					return err
+				})); rerr != nil {
+					t.Fatalf("p.Register(...): unexpected error: %v", rerr)
+				}
+			}
+
+			obj := &v1alpha2.Object{Status: v1alpha2.ObjectStatus{FinalizePlugins: tc.args.status}}
+			err := p.finalize(context.Background(), obj)
+			if (err != nil) != tc.want.err {
+				t.Errorf("\n%s\np.finalize(...): want error: %t, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.called, called); diff != "" {
+				t.Errorf("\n%s\np.finalize(...): -want called, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.status, obj.Status.FinalizePlugins); diff != "" {
+				t.Errorf("\n%s\np.finalize(...): -want status, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFinalizePluginsRegister(t *testing.T) {
+	p := NewFinalizePlugins()
+	fn := FinalizePluginFn(func(_ context.Context, _ client.Object) error { return nil })
+	if err := p.Register("dns", fn); err != nil {
+		t.Fatalf("p.Register(...): unexpected error: %v", err)
+	}
+	if err := p.Register("dns", fn); err == nil {
+		t.Errorf("p.Register(...): want error registering a name twice, got nil")
+	}
+}
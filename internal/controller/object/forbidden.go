@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+const errForbiddenNamespace = "ProviderConfig %q forbids managing resources in namespace %q, add it to the allowedSystemNamespaces of the ProviderConfig and annotate the ProviderConfig with " + apisv1alpha1.AnnotationKeySystemNamespacesJustification + " to allow it"
+
+// A ForbiddenNamespaceValidator rejects Objects of resources in a namespace
+// their ProviderConfig forbids, by default the system namespaces of
+// Kubernetes. Objects of cluster scoped resources are always admitted.
+type ForbiddenNamespaceValidator struct {
+	client client.Reader
+}
+
+var _ admission.CustomValidator = &ForbiddenNamespaceValidator{}
+
+// NewForbiddenNamespaceValidator returns a ForbiddenNamespaceValidator that
+// reads ProviderConfigs using the supplied client.
+func NewForbiddenNamespaceValidator(c client.Reader) *ForbiddenNamespaceValidator {
+	return &ForbiddenNamespaceValidator{client: c}
+}
+
+// ValidateCreate rejects an Object if the namespace of its resource is
+// forbidden.
+func (v *ForbiddenNamespaceValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr, ok := obj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	return nil, v.validateNamespace(ctx, cr)
+}
+
+// ValidateUpdate rejects an Object that moves its resource to a forbidden
+// namespace, or changes to a ProviderConfig forbidding its namespace. Objects
+// that already manage resources in a namespace that became forbidden can
+// still be updated, e.g. to be migrated away from it.
+func (v *ForbiddenNamespaceValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	cr, ok := newObj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	if manifestNamespace(old) == manifestNamespace(cr) && providerConfigName(old) == providerConfigName(cr) {
+		return nil, nil
+	}
+	return nil, v.validateNamespace(ctx, cr)
+}
+
+// ValidateDelete never rejects an Object.
+func (v *ForbiddenNamespaceValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ForbiddenNamespaceValidator) validateNamespace(ctx context.Context, cr *v1alpha2.Object) error {
+	ns := manifestNamespace(cr)
+	name := providerConfigName(cr)
+	if ns == "" || name == "" {
+		return nil
+	}
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := v.client.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return errors.Wrap(err, errGetProviderConfig)
+		}
+		// Objects created before their ProviderConfig must not manage
+		// resources in the default forbidden namespaces either.
+		pc = &apisv1alpha1.ProviderConfig{}
+	}
+	if apisv1alpha1.NamespaceForbidden(pc, ns) {
+		return errors.Errorf(errForbiddenNamespace, name, ns)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	kubernetesv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+func TestForbiddenNamespaceValidator(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	inNamespace := func(ns string) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.Spec.ForProvider.Manifest.Raw = []byte(fmt.Sprintf(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm", "namespace": %q}}`, ns))
+		}
+	}
+	providerConfig := func(forbidden, allowed []string, justification string) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			pc := obj.(*kubernetesv1alpha1.ProviderConfig)
+			pc.Name = key.Name
+			pc.Spec.ForbiddenNamespaces = forbidden
+			pc.Spec.AllowedSystemNamespaces = allowed
+			if justification != "" {
+				pc.SetAnnotations(map[string]string{kubernetesv1alpha1.AnnotationKeySystemNamespacesJustification: justification})
+			}
+			return nil
+		}
+	}
+
+	type args struct {
+		get test.MockGetFn
+		old *v1alpha2.Object
+		obj *v1alpha2.Object
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   error
+	}{
+		"ClusterScoped": {
+			reason: "We should admit Objects of cluster scoped resources.",
+			args: args{
+				get: providerConfig(nil, nil, ""),
+				obj: kubernetesObject(),
+			},
+		},
+		"Allowed": {
+			reason: "We should admit Objects of resources in namespaces that are not forbidden.",
+			args: args{
+				get: providerConfig(nil, nil, ""),
+				obj: kubernetesObject(inNamespace("default")),
+			},
+		},
+		"DefaultForbidden": {
+			reason: "We should reject Objects of resources in the system namespaces by default.",
+			args: args{
+				get: providerConfig(nil, nil, ""),
+				obj: kubernetesObject(inNamespace("kube-system")),
+			},
+			want: errors.Errorf(errForbiddenNamespace, providerName, "kube-system"),
+		},
+		"ConfiguredForbidden": {
+			reason: "We should reject Objects of resources in the namespaces the ProviderConfig forbids.",
+			args: args{
+				get: providerConfig([]string{"payments"}, nil, ""),
+				obj: kubernetesObject(inNamespace("payments")),
+			},
+			want: errors.Errorf(errForbiddenNamespace, providerName, "payments"),
+		},
+		"AllowedWithoutJustification": {
+			reason: "We should not honor allowed system namespaces without a justification.",
+			args: args{
+				get: providerConfig(nil, []string{"kube-system"}, ""),
+				obj: kubernetesObject(inNamespace("kube-system")),
+			},
+			want: errors.Errorf(errForbiddenNamespace, providerName, "kube-system"),
+		},
+		"AllowedWithJustification": {
+			reason: "We should admit Objects of resources in allowed system namespaces with a justification.",
+			args: args{
+				get: providerConfig(nil, []string{"kube-system"}, "Manages the cluster autoscaler."),
+				obj: kubernetesObject(inNamespace("kube-system")),
+			},
+		},
+		"ProviderConfigNotFound": {
+			reason: "We should forbid the default namespaces if the ProviderConfig does not exist.",
+			args: args{
+				get: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, providerName)),
+				obj: kubernetesObject(inNamespace("kube-public")),
+			},
+			want: errors.Errorf(errForbiddenNamespace, providerName, "kube-public"),
+		},
+		"GetError": {
+			reason: "We should reject Objects if their ProviderConfig cannot be read.",
+			args: args{
+				get: test.NewMockGetFn(errBoom),
+				obj: kubernetesObject(inNamespace("default")),
+			},
+			want: errors.Wrap(errBoom, errGetProviderConfig),
+		},
+		"UpdateSameNamespace": {
+			reason: "We should admit updates of Objects that keep the namespace of their resource.",
+			args: args{
+				get: providerConfig(nil, nil, ""),
+				old: kubernetesObject(inNamespace("kube-system")),
+				obj: kubernetesObject(inNamespace("kube-system")),
+			},
+		},
+		"UpdateToForbidden": {
+			reason: "We should reject updates of Objects moving their resource to a forbidden namespace.",
+			args: args{
+				get: providerConfig(nil, nil, ""),
+				old: kubernetesObject(inNamespace("default")),
+				obj: kubernetesObject(inNamespace("kube-node-lease")),
+			},
+			want: errors.Errorf(errForbiddenNamespace, providerName, "kube-node-lease"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := NewForbiddenNamespaceValidator(&test.MockClient{MockGet: tc.args.get})
+			var err error
+			if tc.args.old != nil {
+				_, err = v.ValidateUpdate(context.Background(), tc.args.old, tc.args.obj)
+			} else {
+				_, err = v.ValidateCreate(context.Background(), tc.args.obj)
+			}
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// AnnotationKeyCompositeGeneration is the generation of the composite
+	// resource an Object's manifest was composed from. It is set on Objects
+	// by their composition, and recorded on the resources they manage.
+	AnnotationKeyCompositeGeneration = "provider-kubernetes.crossplane.io/composite-generation"
+
+	errStaleCompositeGeneration = "refusing to apply manifest of composite generation %d, the resource was already applied from generation %d"
+)
+
+// compositeGeneration returns the composite generation recorded on the
+// supplied object, if any.
+func compositeGeneration(o metav1.Object) (int64, bool) {
+	v, ok := o.GetAnnotations()[AnnotationKeyCompositeGeneration]
+	if !ok {
+		return 0, false
+	}
+	g, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return g, true
+}
+
+// pinCompositeGeneration records the composite generation of the supplied
+// Object on its desired resource. When updating a resource, it refuses to
+// apply a manifest of an older generation than the resource was last applied
+// from, e.g. by a reconcile of an outdated copy of the Object still in
+// flight. The resource version of the observed resource is kept as an
+// optimistic lock too, so that the apply fails if another reconcile applied a
+// newer generation in the meantime. The reconcile is retried then, and the
+// newer generation wins.
+func pinCompositeGeneration(cr *v1alpha2.Object, desired *unstructured.Unstructured, update bool) error {
+	g, ok := compositeGeneration(cr)
+	if !ok {
+		return nil
+	}
+	meta.AddAnnotations(desired, map[string]string{AnnotationKeyCompositeGeneration: strconv.FormatInt(g, 10)})
+	if !update || len(cr.Status.AtProvider.Manifest.Raw) == 0 {
+		return nil
+	}
+
+	observed := &unstructured.Unstructured{}
+	if err := json.Unmarshal(cr.Status.AtProvider.Manifest.Raw, &observed.Object); err != nil {
+		return errors.Wrap(err, errUnmarshalTemplate)
+	}
+	if applied, ok := compositeGeneration(observed); ok && applied > g {
+		return errors.Errorf(errStaleCompositeGeneration, g, applied)
+	}
+	desired.SetResourceVersion(observed.GetResourceVersion())
+	return nil
+}
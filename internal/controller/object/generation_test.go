@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestPinCompositeGeneration(t *testing.T) {
+	withCompositeGeneration := func(g string) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.SetAnnotations(map[string]string{AnnotationKeyCompositeGeneration: g})
+		}
+	}
+	withObserved := func(g string) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.Status.AtProvider.Manifest.Raw = []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"crossplane-system","resourceVersion":"42","annotations":{%q:%q}}}`, AnnotationKeyCompositeGeneration, g))
+		}
+	}
+
+	type args struct {
+		cr     *v1alpha2.Object
+		update bool
+	}
+	type want struct {
+		err             error
+		generation      string
+		resourceVersion string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotPinned": {
+			reason: "We should not touch resources of Objects without a composite generation.",
+			args: args{
+				cr:     kubernetesObject(withObserved("3")),
+				update: true,
+			},
+		},
+		"Create": {
+			reason: "We should record the composite generation on created resources.",
+			args: args{
+				cr: kubernetesObject(withCompositeGeneration("2")),
+			},
+			want: want{
+				generation: "2",
+			},
+		},
+		"NewerGeneration": {
+			reason: "We should lock updates of a newer generation to the observed resource version.",
+			args: args{
+				cr:     kubernetesObject(withCompositeGeneration("3"), withObserved("2")),
+				update: true,
+			},
+			want: want{
+				generation:      "3",
+				resourceVersion: "42",
+			},
+		},
+		"OlderGeneration": {
+			reason: "We should refuse to apply a manifest of an older generation than the resource was applied from.",
+			args: args{
+				cr:     kubernetesObject(withCompositeGeneration("2"), withObserved("3")),
+				update: true,
+			},
+			want: want{
+				err:        errors.Errorf(errStaleCompositeGeneration, 2, 3),
+				generation: "2",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			desired := &unstructured.Unstructured{}
+			err := pinCompositeGeneration(tc.args.cr, desired, tc.args.update)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\npinCompositeGeneration(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if got := desired.GetAnnotations()[AnnotationKeyCompositeGeneration]; got != tc.want.generation {
+				t.Errorf("\n%s\npinCompositeGeneration(...): want generation %q, got %q", tc.reason, tc.want.generation, got)
+			}
+			if got := desired.GetResourceVersion(); got != tc.want.resourceVersion {
+				t.Errorf("\n%s\npinCompositeGeneration(...): want resource version %q, got %q", tc.reason, tc.want.resourceVersion, got)
+			}
+		})
+	}
+}
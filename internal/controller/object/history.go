@@ -0,0 +1,287 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// LabelKeyHistoryOf is the UID of the Object a spec snapshot belongs to.
+	LabelKeyHistoryOf = "kubernetes.crossplane.io/history-of"
+	// LabelKeyHistoryGeneration is the generation of the Object a spec
+	// snapshot was taken of.
+	LabelKeyHistoryGeneration = "kubernetes.crossplane.io/history-generation"
+
+	historyKeySpec = "spec"
+
+	errCreateSnapshot  = "cannot create spec snapshot"
+	errListSnapshots   = "cannot list spec snapshots"
+	errDeleteSnapshot  = "cannot delete spec snapshot"
+	errGetHistory      = "cannot get Object"
+	errParseGeneration = "cannot parse generation"
+	errNoSnapshot      = "no spec snapshot of generation"
+	errDiffSnapshots   = "cannot diff spec snapshots"
+)
+
+// HistoryOptions configure the spec snapshots recorded of Objects.
+type HistoryOptions struct {
+	// Namespace the snapshots are stored in as ConfigMaps.
+	Namespace string
+	// Size is the number of snapshots kept per Object. Zero or less disables
+	// the history.
+	Size int
+}
+
+// Enabled returns whether the history is enabled.
+func (o HistoryOptions) Enabled() bool {
+	return o.Size > 0
+}
+
+// A historyRecorder records a snapshot of the spec of an Object for each of
+// its generations, keeping the latest ones. Snapshots are ConfigMaps named
+// after the UID and the generation of their Object, which owns them.
+type historyRecorder struct {
+	kube   client.Client
+	reader client.Reader
+	opts   HistoryOptions
+	log    logging.Logger
+
+	// recorded are the latest generations recorded by Object UID, so that
+	// the snapshots are only written when the spec changed.
+	recorded sync.Map
+}
+
+func newHistoryRecorder(kube client.Client, reader client.Reader, opts HistoryOptions, log logging.Logger) *historyRecorder {
+	return &historyRecorder{kube: kube, reader: reader, opts: opts, log: log}
+}
+
+func snapshotName(uid types.UID, generation int64) string {
+	return fmt.Sprintf("%s-history-%d", uid, generation)
+}
+
+// Record snapshots the spec of the supplied Object, unless its generation
+// was already recorded. Snapshots are diagnostics, failing to record one is
+// logged and retried with the next observation rather than failing it.
+func (h *historyRecorder) Record(ctx context.Context, cr *v1alpha2.Object) {
+	if h == nil {
+		return
+	}
+	if meta.WasDeleted(cr) {
+		// The snapshots are garbage collected along with the Object.
+		h.recorded.Delete(cr.GetUID())
+		return
+	}
+	if g, ok := h.recorded.Load(cr.GetUID()); ok && g.(int64) >= cr.GetGeneration() {
+		return
+	}
+	if err := h.record(ctx, cr); err != nil {
+		h.log.Debug("Cannot record spec snapshot", "name", cr.GetName(), "generation", cr.GetGeneration(), "error", err)
+		return
+	}
+	h.recorded.Store(cr.GetUID(), cr.GetGeneration())
+}
+
+func (h *historyRecorder) record(ctx context.Context, cr *v1alpha2.Object) error {
+	spec, err := json.Marshal(cr.Spec)
+	if err != nil {
+		return errors.Wrap(err, errCreateSnapshot)
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      snapshotName(cr.GetUID(), cr.GetGeneration()),
+			Namespace: h.opts.Namespace,
+			Labels: map[string]string{
+				LabelKeyHistoryOf:         string(cr.GetUID()),
+				LabelKeyHistoryGeneration: strconv.FormatInt(cr.GetGeneration(), 10),
+			},
+			OwnerReferences: []metav1.OwnerReference{meta.AsOwner(meta.TypedReferenceTo(cr, v1alpha2.ObjectGroupVersionKind))},
+		},
+		Data: map[string]string{historyKeySpec: string(spec)},
+	}
+	if err := h.kube.Create(ctx, cm); err != nil && !kerrors.IsAlreadyExists(err) {
+		return errors.Wrap(err, errCreateSnapshot)
+	}
+
+	snapshots, err := listSnapshots(ctx, h.reader, h.opts.Namespace, cr.GetUID())
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(snapshots)-h.opts.Size; i++ {
+		if err := h.kube.Delete(ctx, &snapshots[i].cm); client.IgnoreNotFound(err) != nil {
+			return errors.Wrap(err, errDeleteSnapshot)
+		}
+	}
+	return nil
+}
+
+type snapshot struct {
+	generation int64
+	cm         corev1.ConfigMap
+}
+
+// listSnapshots returns the snapshots of the Object with the supplied UID,
+// oldest first.
+func listSnapshots(ctx context.Context, r client.Reader, namespace string, uid types.UID) ([]snapshot, error) {
+	l := &corev1.ConfigMapList{}
+	if err := r.List(ctx, l, client.InNamespace(namespace), client.MatchingLabels{LabelKeyHistoryOf: string(uid)}); err != nil {
+		return nil, errors.Wrap(err, errListSnapshots)
+	}
+	snapshots := make([]snapshot, 0, len(l.Items))
+	for _, cm := range l.Items {
+		g, err := strconv.ParseInt(cm.GetLabels()[LabelKeyHistoryGeneration], 10, 64)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snapshot{generation: g, cm: cm})
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].generation < snapshots[j].generation })
+	return snapshots, nil
+}
+
+// A Snapshot of the spec of an Object.
+type Snapshot struct {
+	Generation int64           `json:"generation"`
+	Time       metav1.Time     `json:"time"`
+	Spec       json.RawMessage `json:"spec"`
+}
+
+// HistoryHandler serves the spec snapshots of Objects. GET
+// /history?object=<name> returns the recorded snapshots of an Object, oldest
+// first. GET /history?object=<name>&from=3&to=5 returns a JSON Patch document
+// from the spec of generation 3 to the one of generation 5. Snapshots may
+// contain inline manifests of Secrets, so callers must be allowed to get the
+// Object, see Authorizer.
+type HistoryHandler struct {
+	reader     client.Reader
+	namespace  string
+	authorizer *Authorizer
+	log        logging.Logger
+}
+
+// NewHistoryHandler returns a HistoryHandler serving the snapshots stored in
+// the namespace of the supplied options, for callers authorized by the
+// supplied Authorizer.
+func NewHistoryHandler(r client.Reader, opts HistoryOptions, a *Authorizer, log logging.Logger) *HistoryHandler {
+	return &HistoryHandler{reader: r, namespace: opts.Namespace, authorizer: a, log: log}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *HistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	q := r.URL.Query()
+	name := q.Get("object")
+	if name == "" {
+		http.Error(w, "the object query parameter is required", http.StatusBadRequest)
+		return
+	}
+	if code, err := h.authorizer.Authorize(r.Context(), r, "get", name); err != nil {
+		h.log.Debug("Rejected history request of Object", "name", name, "error", err)
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	out, code, err := h.history(r.Context(), name, q.Get("from"), q.Get("to"))
+	if err != nil {
+		h.log.Debug("Cannot serve history of Object", "name", name, "error", err)
+		http.Error(w, err.Error(), code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(out)
+}
+
+func (h *HistoryHandler) history(ctx context.Context, name, from, to string) ([]byte, int, error) {
+	cr := &v1alpha2.Object{}
+	if err := h.reader.Get(ctx, types.NamespacedName{Name: name}, cr); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, http.StatusNotFound, errors.Wrap(err, errGetHistory)
+		}
+		return nil, http.StatusInternalServerError, errors.Wrap(err, errGetHistory)
+	}
+	snapshots, err := listSnapshots(ctx, h.reader, h.namespace, cr.GetUID())
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if from == "" && to == "" {
+		out := make([]Snapshot, 0, len(snapshots))
+		for _, s := range snapshots {
+			out = append(out, Snapshot{Generation: s.generation, Time: s.cm.GetCreationTimestamp(), Spec: json.RawMessage(s.cm.Data[historyKeySpec])})
+		}
+		return marshalHistory(out)
+	}
+
+	a, code, err := snapshotSpec(snapshots, from)
+	if err != nil {
+		return nil, code, err
+	}
+	b, code, err := snapshotSpec(snapshots, to)
+	if err != nil {
+		return nil, code, err
+	}
+	patch, err := jsonpatch.CreatePatch(a, b)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.Wrap(err, errDiffSnapshots)
+	}
+	return marshalHistory(patch)
+}
+
+func marshalHistory(v interface{}) ([]byte, int, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return b, http.StatusOK, nil
+}
+
+// snapshotSpec returns the spec of the snapshot of the supplied generation.
+func snapshotSpec(snapshots []snapshot, generation string) ([]byte, int, error) {
+	g, err := strconv.ParseInt(generation, 10, 64)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.Wrap(err, errParseGeneration)
+	}
+	for _, s := range snapshots {
+		if s.generation == g {
+			return []byte(s.cm.Data[historyKeySpec]), http.StatusOK, nil
+		}
+	}
+	return nil, http.StatusNotFound, errors.Errorf("%s %d", errNoSnapshot, g)
+}
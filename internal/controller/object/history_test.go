@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func specSnapshot(uid types.UID, generation int64, spec string) corev1.ConfigMap {
+	return corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   snapshotName(uid, generation),
+			Labels: map[string]string{LabelKeyHistoryOf: string(uid), LabelKeyHistoryGeneration: strconv.FormatInt(generation, 10)},
+		},
+		Data: map[string]string{historyKeySpec: spec},
+	}
+}
+
+func listSnapshotsFn(snapshots ...corev1.ConfigMap) test.MockListFn {
+	return func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		list.(*corev1.ConfigMapList).Items = snapshots
+		return nil
+	}
+}
+
+func TestHistoryRecorderRecord(t *testing.T) {
+	uid := types.UID("uid")
+
+	type args struct {
+		recorded   int64
+		generation int64
+		existing   []corev1.ConfigMap
+	}
+	type want struct {
+		created []string
+		deleted []string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"AlreadyRecorded": {
+			reason: "We should not snapshot a generation that was already recorded.",
+			args: args{
+				recorded:   3,
+				generation: 3,
+			},
+		},
+		"Record": {
+			reason: "We should snapshot a new generation and delete the oldest snapshots beyond the size of the history.",
+			args: args{
+				recorded:   3,
+				generation: 4,
+				existing: []corev1.ConfigMap{
+					specSnapshot(uid, 4, "{}"), specSnapshot(uid, 1, "{}"), specSnapshot(uid, 3, "{}"), specSnapshot(uid, 2, "{}"),
+				},
+			},
+			want: want{
+				created: []string{snapshotName(uid, 4)},
+				deleted: []string{snapshotName(uid, 1), snapshotName(uid, 2)},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var created, deleted []string
+			kube := &test.MockClient{
+				MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					created = append(created, obj.GetName())
+					return nil
+				},
+				MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+					deleted = append(deleted, obj.GetName())
+					return nil
+				},
+				MockList: listSnapshotsFn(tc.args.existing...),
+			}
+			h := newHistoryRecorder(kube, kube, HistoryOptions{Namespace: "crossplane-system", Size: 2}, logging.NewNopLogger())
+			h.recorded.Store(uid, tc.args.recorded)
+
+			cr := &v1alpha2.Object{}
+			cr.SetUID(uid)
+			cr.SetGeneration(tc.args.generation)
+			h.Record(context.Background(), cr)
+
+			if diff := cmp.Diff(tc.want.created, created, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nRecord(...): -want created, +got created:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nRecord(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHistoryHandler(t *testing.T) {
+	uid := types.UID("uid")
+	reader := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.SetUID(uid)
+			return nil
+		},
+		MockList: listSnapshotsFn(
+			specSnapshot(uid, 5, `{"forProvider": {"manifest": {"data": {"a": "2"}}}}`),
+			specSnapshot(uid, 3, `{"forProvider": {"manifest": {"data": {"a": "1"}}}}`),
+		),
+	}
+
+	type want struct {
+		code int
+		body string
+	}
+	cases := map[string]struct {
+		reason     string
+		query      string
+		authorizer *Authorizer
+		want       want
+	}{
+		"Forbidden": {
+			reason:     "We should not serve the history of Objects the caller may not get.",
+			query:      "object=cool",
+			authorizer: reviewer(true, false),
+			want: want{
+				code: http.StatusForbidden,
+			},
+		},
+		"MissingObject": {
+			reason: "We should require the name of the Object.",
+			query:  "from=3&to=5",
+			want: want{
+				code: http.StatusBadRequest,
+			},
+		},
+		"List": {
+			reason: "We should serve all snapshots, oldest first.",
+			query:  "object=cool",
+			want: want{
+				code: http.StatusOK,
+				body: `[{"generation":3,"time":null,"spec":{"forProvider":{"manifest":{"data":{"a":"1"}}}}},{"generation":5,"time":null,"spec":{"forProvider":{"manifest":{"data":{"a":"2"}}}}}]`,
+			},
+		},
+		"Diff": {
+			reason: "We should serve the JSON Patch between two snapshots.",
+			query:  "object=cool&from=3&to=5",
+			want: want{
+				code: http.StatusOK,
+				body: `[{"op":"replace","path":"/forProvider/manifest/data/a","value":"2"}]`,
+			},
+		},
+		"NoSnapshot": {
+			reason: "We should return not found for generations without a snapshot.",
+			query:  "object=cool&from=3&to=4",
+			want: want{
+				code: http.StatusNotFound,
+			},
+		},
+		"InvalidGeneration": {
+			reason: "We should reject generations that are not numbers.",
+			query:  "object=cool&from=three&to=5",
+			want: want{
+				code: http.StatusBadRequest,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := tc.authorizer
+			if a == nil {
+				a = reviewer(true, true)
+			}
+			h := NewHistoryHandler(reader, HistoryOptions{Namespace: "crossplane-system", Size: 10}, a, logging.NewNopLogger())
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, authenticated(httptest.NewRequest(http.MethodGet, "/history?"+tc.query, nil)))
+
+			if rec.Code != tc.want.code {
+				t.Fatalf("\n%s\nServeHTTP(...): want status %d, got %d: %s", tc.reason, tc.want.code, rec.Code, rec.Body.String())
+			}
+			if tc.want.code != http.StatusOK {
+				return
+			}
+			if diff := cmp.Diff(tc.want.body, rec.Body.String()); diff != "" {
+				t.Errorf("\n%s\nServeHTTP(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/manifest"
+)
+
+const (
+	// AnnotationKeyEffectiveManifest is the manifest of an Object without
+	// its ignored fields, as last applied. It is only recorded for Objects
+	// that ignore fields.
+	AnnotationKeyEffectiveManifest = "provider-kubernetes.crossplane.io/effective-manifest"
+
+	errInvalidJSONPointer = "invalid JSON Pointer"
+	errMarshalEffective   = "cannot marshal effective manifest"
+	errRecordEffective    = "cannot record effective manifest"
+)
+
+// stripIgnoredFields removes the ignored fields of the supplied Object from
+// its desired manifest. Ignored fields that are not in the manifest are
+// skipped.
+func stripIgnoredFields(cr *v1alpha2.Object, desired *unstructured.Unstructured) error {
+	for _, p := range cr.Spec.IgnoredFields {
+		segments, err := parseJSONPointer(p)
+		if err != nil {
+			return err
+		}
+		desired.Object = removePath(desired.Object, segments).(map[string]interface{})
+	}
+	return nil
+}
+
+// effectiveManifest returns the manifest that is applied for the supplied
+// Object, given its desired manifest without ignored fields and mutated by
+// MutatingPolicies, if any. It is recorded as last applied, so that later
+// desired manifests are compared to it rather than to the manifest of the
+// Object's spec.
+func effectiveManifest(cr *v1alpha2.Object, desired *unstructured.Unstructured, mutated bool) (string, error) {
+	if len(cr.Spec.IgnoredFields) == 0 && !mutated && cr.Spec.ForProvider.ManifestURL == "" {
+		raw, err := manifest.Raw(cr.Spec.ForProvider)
+		return string(raw), err
+	}
+	b, err := desired.MarshalJSON()
+	if err != nil {
+		return "", errors.Wrap(err, errMarshalEffective)
+	}
+	return string(b), nil
+}
+
+// recordEffectiveManifest records the supplied effective manifest on the
+// supplied Object for debugging, or removes a previously recorded one if the
+// Object does not ignore fields anymore.
+func (c *external) recordEffectiveManifest(ctx context.Context, cr *v1alpha2.Object, effective string) error {
+	current, recorded := cr.GetAnnotations()[AnnotationKeyEffectiveManifest]
+	ignores := len(cr.Spec.IgnoredFields) > 0
+	if (ignores && current == effective) || (!ignores && !recorded) {
+		return nil
+	}
+
+	if !ignores {
+		return errors.Wrap(c.patchAnnotations(ctx, cr, nil, AnnotationKeyEffectiveManifest), errRecordEffective)
+	}
+	return errors.Wrap(c.patchAnnotations(ctx, cr, map[string]string{AnnotationKeyEffectiveManifest: effective}), errRecordEffective)
+}
+
+// parseJSONPointer returns the reference tokens of the supplied JSON Pointer
+// as defined by RFC 6901.
+func parseJSONPointer(p string) ([]string, error) {
+	if !strings.HasPrefix(p, "/") {
+		return nil, errors.Errorf("%s %q: must start with /", errInvalidJSONPointer, p)
+	}
+	segments := strings.Split(p[1:], "/")
+	for i, s := range segments {
+		if strings.Count(s, "~") != strings.Count(s, "~0")+strings.Count(s, "~1") {
+			return nil, errors.Errorf("%s %q: ~ must be escaped as ~0", errInvalidJSONPointer, p)
+		}
+		segments[i] = strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+	}
+	return segments, nil
+}
+
+// removePath returns v without the value at the supplied path. Paths that do
+// not exist in v are ignored.
+func removePath(v interface{}, path []string) interface{} {
+	if len(path) == 0 {
+		return v
+	}
+	switch t := v.(type) {
+	case map[string]interface{}:
+		child, ok := t[path[0]]
+		if !ok {
+			return t
+		}
+		if len(path) == 1 {
+			delete(t, path[0])
+			return t
+		}
+		t[path[0]] = removePath(child, path[1:])
+		return t
+	case []interface{}:
+		i, err := strconv.Atoi(path[0])
+		if err != nil || i < 0 || i >= len(t) {
+			return t
+		}
+		if len(path) == 1 {
+			return append(t[:i:i], t[i+1:]...)
+		}
+		t[i] = removePath(t[i], path[1:])
+		return t
+	}
+	return v
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestStripIgnoredFields(t *testing.T) {
+	manifest := `{
+  "apiVersion": "apps/v1",
+  "kind": "Deployment",
+  "metadata": {"name": "app", "annotations": {"example.org/a~b": "x", "example.org/c": "y"}},
+  "spec": {
+    "replicas": 3,
+    "template": {"spec": {"containers": [{"name": "a", "resources": {"limits": {"cpu": "1"}}}, {"name": "b"}]}}
+  }
+}`
+
+	type want struct {
+		manifest string
+		err      bool
+	}
+	cases := map[string]struct {
+		reason  string
+		ignored []string
+		want    want
+	}{
+		"NoIgnoredFields": {
+			reason: "We should not change a manifest if no fields are ignored.",
+			want: want{
+				manifest: manifest,
+			},
+		},
+		"IgnoreField": {
+			reason:  "We should remove ignored fields.",
+			ignored: []string{"/spec/replicas"},
+			want: want{
+				manifest: `{
+  "apiVersion": "apps/v1",
+  "kind": "Deployment",
+  "metadata": {"name": "app", "annotations": {"example.org/a~b": "x", "example.org/c": "y"}},
+  "spec": {
+    "template": {"spec": {"containers": [{"name": "a", "resources": {"limits": {"cpu": "1"}}}, {"name": "b"}]}}
+  }
+}`,
+			},
+		},
+		"IgnoreEscapedField": {
+			reason:  "We should unescape ~1 and ~0 in reference tokens.",
+			ignored: []string{"/metadata/annotations/example.org~1a~0b"},
+			want: want{
+				manifest: `{
+  "apiVersion": "apps/v1",
+  "kind": "Deployment",
+  "metadata": {"name": "app", "annotations": {"example.org/c": "y"}},
+  "spec": {
+    "replicas": 3,
+    "template": {"spec": {"containers": [{"name": "a", "resources": {"limits": {"cpu": "1"}}}, {"name": "b"}]}}
+  }
+}`,
+			},
+		},
+		"IgnoreListItemFields": {
+			reason:  "We should remove fields of and items from lists by index.",
+			ignored: []string{"/spec/template/spec/containers/0/resources", "/spec/template/spec/containers/1"},
+			want: want{
+				manifest: `{
+  "apiVersion": "apps/v1",
+  "kind": "Deployment",
+  "metadata": {"name": "app", "annotations": {"example.org/a~b": "x", "example.org/c": "y"}},
+  "spec": {
+    "replicas": 3,
+    "template": {"spec": {"containers": [{"name": "a"}]}}
+  }
+}`,
+			},
+		},
+		"IgnoreMissingField": {
+			reason:  "We should ignore fields that are not in the manifest.",
+			ignored: []string{"/spec/paused", "/spec/replicas/value", "/spec/template/spec/containers/5/name"},
+			want: want{
+				manifest: manifest,
+			},
+		},
+		"InvalidPointer": {
+			reason:  "We should return an error for pointers that do not start with a /.",
+			ignored: []string{"spec/replicas"},
+			want: want{
+				err: true,
+			},
+		},
+		"InvalidEscape": {
+			reason:  "We should return an error for pointers with an invalid escape sequence.",
+			ignored: []string{"/metadata/annotations/a~b"},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.Object{Spec: v1alpha2.ObjectSpec{IgnoredFields: tc.ignored}}
+			desired := &unstructured.Unstructured{}
+			if err := json.Unmarshal([]byte(manifest), &desired.Object); err != nil {
+				t.Fatalf("cannot parse test manifest: %v", err)
+			}
+
+			err := stripIgnoredFields(cr, desired)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nstripIgnoredFields(...): want error: %t, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if err != nil {
+				return
+			}
+
+			want := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(tc.want.manifest), &want); err != nil {
+				t.Fatalf("cannot parse wanted manifest: %v", err)
+			}
+			if diff := cmp.Diff(want, desired.Object); diff != "" {
+				t.Errorf("\n%s\nstripIgnoredFields(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// AnnotationKeyForceUpdate lets an immutable Object update its managed
+	// resource once. It is removed once the update was applied.
+	AnnotationKeyForceUpdate = "provider-kubernetes.crossplane.io/force-update"
+
+	reasonImmutableObject event.Reason = "ImmutableObject"
+
+	errRemoveForceUpdate = "cannot remove force update annotation"
+)
+
+// immutable returns true if the existing managed resource of the supplied
+// Object must not be updated. Deleted Objects are never immutable, so that
+// their managed resource can be cleaned up.
+func immutable(cr *v1alpha2.Object) bool {
+	return cr.Spec.Immutable && !meta.WasDeleted(cr) && cr.GetAnnotations()[AnnotationKeyForceUpdate] != "true"
+}
+
+// immutableObservation reports an immutable managed resource diverging from
+// the manifest of the supplied Object by an ImmutableObject event, and
+// returns an observation of an up to date resource. The diverging manifest is
+// not recorded as applied, so that it keeps being reported until it is
+// reverted or force updated.
+func (c *external) immutableObservation(ctx context.Context, cr *v1alpha2.Object) (managed.ExternalObservation, error) {
+	c.logger.Debug("Not updating immutable resource")
+	if c.recorder != nil {
+		c.recorder.Event(cr, event.Warning(reasonImmutableObject, errors.Errorf("The manifest diverges from the immutable managed resource, annotate the Object with %s: \"true\" to update it once", AnnotationKeyForceUpdate)))
+	}
+	return c.upToDate(ctx, cr)
+}
+
+// removeForceUpdate removes the force update annotation from the supplied
+// immutable Object once its managed resource was updated.
+func (c *external) removeForceUpdate(ctx context.Context, cr *v1alpha2.Object) error {
+	if _, ok := cr.GetAnnotations()[AnnotationKeyForceUpdate]; !ok || !cr.Spec.Immutable {
+		return nil
+	}
+
+	return errors.Wrap(c.patchAnnotations(ctx, cr, nil, AnnotationKeyForceUpdate), errRemoveForceUpdate)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestRemoveForceUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+	forced := func(obj *v1alpha2.Object) {
+		obj.SetAnnotations(map[string]string{AnnotationKeyForceUpdate: "true", "other": "kept"})
+	}
+	immutableObject := func(obj *v1alpha2.Object) {
+		obj.Spec.Immutable = true
+	}
+
+	type args struct {
+		cr    *v1alpha2.Object
+		patch error
+	}
+	type want struct {
+		err         error
+		patched     bool
+		annotations map[string]string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotForced": {
+			reason: "We should not patch immutable Objects without the force update annotation.",
+			args: args{
+				cr: kubernetesObject(immutableObject),
+			},
+		},
+		"NotImmutable": {
+			reason: "We should leave the annotation of Objects that are not immutable alone.",
+			args: args{
+				cr: kubernetesObject(forced),
+			},
+			want: want{
+				annotations: map[string]string{AnnotationKeyForceUpdate: "true", "other": "kept"},
+			},
+		},
+		"Forced": {
+			reason: "We should remove the force update annotation once an immutable Object was updated.",
+			args: args{
+				cr: kubernetesObject(immutableObject, forced),
+			},
+			want: want{
+				patched:     true,
+				annotations: map[string]string{"other": "kept"},
+			},
+		},
+		"PatchError": {
+			reason: "We should return an error if the annotation cannot be removed.",
+			args: args{
+				cr:    kubernetesObject(immutableObject, forced),
+				patch: errBoom,
+			},
+			want: want{
+				err:         errors.Wrap(errBoom, errRemoveForceUpdate),
+				patched:     true,
+				annotations: map[string]string{AnnotationKeyForceUpdate: "true", "other": "kept"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			patched := false
+			e := &external{
+				logger: logging.NewNopLogger(),
+				localClient: &test.MockClient{
+					MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						patched = true
+						return tc.args.patch
+					},
+				},
+			}
+			err := e.removeForceUpdate(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nremoveForceUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if patched != tc.want.patched {
+				t.Errorf("\n%s\nremoveForceUpdate(...): want patched: %t, got: %t", tc.reason, tc.want.patched, patched)
+			}
+			if diff := cmp.Diff(tc.want.annotations, tc.args.cr.GetAnnotations()); diff != "" {
+				t.Errorf("\n%s\nremoveForceUpdate(...): -want annotations, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
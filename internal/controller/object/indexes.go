@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -39,11 +40,16 @@ const (
 	// resourceRefsIndex is an index of resourceRefs that are referenced or
 	// managed by an Object.
 	resourceRefsIndex = "objectsRefs"
+
+	// ManifestNamespaceIndex is an index of the namespace of the resource
+	// managed by an Object.
+	ManifestNamespaceIndex = "objectsManifestNamespace"
 )
 
 var (
 	_ client.IndexerFunc = IndexByProviderGVK
 	_ client.IndexerFunc = IndexByProviderNamespacedNameGVK
+	_ client.IndexerFunc = IndexByManifestNamespace
 )
 
 // IndexByProviderGVK assumes the passed object is an Object. It returns keys
@@ -64,12 +70,30 @@ func IndexByProviderGVK(o client.Object) []string {
 		providerConfig := "" // references are always local (i.e. on the control plane), which we represent as an empty provider config.
 		keys = append(keys, refKeyProviderGVK(providerConfig, refKind, group, version))
 	}
+	if len(templateSecretRefs(obj)) > 0 {
+		keys = append(keys, refKeyProviderGVK("", secretGVK.Kind, secretGVK.Group, secretGVK.Version))
+	}
 
 	// Index the desired object.
 	// We don't expect errors here, as the getDesired function is already called
 	// in the reconciler and the desired object already validated.
-	d, _ := getDesired(obj)
-	keys = append(keys, refKeyProviderGVK(obj.Spec.ProviderConfigReference.Name, d.GetKind(), d.GroupVersionKind().Group, d.GroupVersionKind().Version)) // unification is done by the informer.
+	// Objects that stopped watching their resource do not keep the informer
+	// of its kind running.
+	if d, err := indexedManifest(obj); err == nil && !watchStopped(obj) {
+		keys = append(keys, refKeyProviderGVK(obj.Spec.ProviderConfigReference.Name, d.GetKind(), d.GroupVersionKind().Group, d.GroupVersionKind().Version)) // unification is done by the informer.
+	}
+
+	// Index the Events the readiness of the Object is derived from, that
+	// are propagated to it, or recorded in its status.
+	if waitsForEvent(obj) || propagatesEvents(obj) || recordsEventHistory(obj) {
+		keys = append(keys, refKeyProviderGVK(obj.Spec.ProviderConfigReference.Name, eventGVK.Kind, eventGVK.Group, eventGVK.Version))
+	}
+
+	// Index the nodes whose labels and taints are checked against the pod
+	// template of the managed resource.
+	if watchesNodes(obj) {
+		keys = append(keys, nodeGVKKey(obj.Spec.ProviderConfigReference.Name))
+	}
 
 	// unification is done by the informer.
 	return keys
@@ -96,12 +120,17 @@ func IndexByProviderNamespacedNameGVK(o client.Object) []string {
 		providerConfig := "" // references are always local (i.e. on the control plane), which we represent as an empty provider config.
 		keys = append(keys, refKeyProviderNamespacedNameGVK(providerConfig, refNamespace, refName, refKind, refAPIVersion))
 	}
+	// Secrets of template values live on the control plane, too.
+	for _, ref := range templateSecretRefs(obj) {
+		keys = append(keys, refKeyProviderNamespacedNameGVK("", ref.Namespace, ref.Name, secretGVK.Kind, secretGVK.GroupVersion().String()))
+	}
 
 	// Index the desired object.
 	// We don't expect errors here, as the getDesired function is already called
 	// in the reconciler and the desired object already validated.
-	d, _ := getDesired(obj)
-	keys = append(keys, refKeyProviderNamespacedNameGVK(obj.Spec.ProviderConfigReference.Name, d.GetNamespace(), d.GetName(), d.GetKind(), d.GetAPIVersion())) // unification is done by the informer.
+	if d, err := indexedManifest(obj); err == nil {
+		keys = append(keys, refKeyProviderNamespacedNameGVK(obj.Spec.ProviderConfigReference.Name, d.GetNamespace(), d.GetName(), d.GetKind(), d.GetAPIVersion())) // unification is done by the informer.
+	}
 
 	return keys
 }
@@ -113,18 +142,62 @@ func refKeyProviderNamespacedNameGVK(providerConfig, ns, name, kind, apiVersion
 func enqueueObjectsForReferences(ca cache.Cache, log logging.Logger) func(ctx context.Context, ev runtimeevent.GenericEvent, q workqueue.RateLimitingInterface) {
 	return func(ctx context.Context, ev runtimeevent.GenericEvent, q workqueue.RateLimitingInterface) {
 		pc, _ := ctx.Value(keyProviderConfigName).(string)
-		rGVK := ev.Object.GetObjectKind().GroupVersionKind()
-		key := refKeyProviderNamespacedNameGVK(pc, ev.Object.GetNamespace(), ev.Object.GetName(), rGVK.Kind, rGVK.GroupVersion().String())
+		var r client.Object = ev.Object
+		// Events are relevant to the Objects managing the resource they
+		// are about, if their readiness is derived from Events or Events
+		// are recorded in their status.
+		io, isEvent := involvedObjectOf(ev.Object)
+		if isEvent {
+			r = &io
+		}
+		rGVK := r.GetObjectKind().GroupVersionKind()
+		index, key := resourceRefsIndex, refKeyProviderNamespacedNameGVK(pc, r.GetNamespace(), r.GetName(), rGVK.Kind, rGVK.GroupVersion().String())
+		// Nodes are relevant to every Object watching the nodes of their
+		// cluster, whatever their name.
+		if isNode(r) {
+			index, key = resourceRefGVKsIndex, nodeGVKKey(pc)
+		}
 
 		objects := v1alpha2.ObjectList{}
-		if err := ca.List(ctx, &objects, client.MatchingFields{resourceRefsIndex: key}); err != nil {
-			log.Debug("cannot list objects related to a reference change", "error", err, "fieldSelector", resourceRefsIndex+"="+key)
+		if err := ca.List(ctx, &objects, client.MatchingFields{index: key}); err != nil {
+			log.Debug("cannot list objects related to a reference change", "error", err, "fieldSelector", index+"="+key)
 			return
 		}
 		// queue those Objects for reconciliation
-		for _, o := range objects.Items {
-			log.Info("Enqueueing Object because referenced resource changed", "name", o.GetName(), "referencedGVK", rGVK.String(), "referencedName", ev.Object.GetName(), "providerConfig", pc)
+		for i, o := range objects.Items {
+			if isEvent && !waitsForEvent(&objects.Items[i]) && !recordsEventHistory(&objects.Items[i]) {
+				continue
+			}
+			if isNode(r) && !watchesNodes(&objects.Items[i]) {
+				continue
+			}
+			log.Info("Enqueueing Object because referenced resource changed", "name", o.GetName(), "referencedGVK", rGVK.String(), "referencedName", r.GetName(), "providerConfig", pc)
 			q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: o.GetName()}})
 		}
 	}
 }
+
+// IndexByManifestNamespace assumes the passed object is an Object. It returns
+// the namespace of the resource managed by the Object, if it is namespaced.
+func IndexByManifestNamespace(o client.Object) []string {
+	obj, ok := o.(*v1alpha2.Object)
+	if !ok {
+		return nil // should never happen
+	}
+	d, err := indexedManifest(obj)
+	if err != nil || d.GetNamespace() == "" {
+		return nil
+	}
+	return []string{d.GetNamespace()}
+}
+
+// indexedManifest returns the desired manifest of the supplied Object. The
+// manifest of an Object fetched from its manifest URL is only known while
+// reconciling it, its last observed resource is returned instead. An error
+// is returned if it was not observed yet.
+func indexedManifest(obj *v1alpha2.Object) (*unstructured.Unstructured, error) {
+	if obj.Spec.ForProvider.ManifestURL == "" || len(obj.Status.AtProvider.Manifest.Raw) == 0 {
+		return getDesired(obj)
+	}
+	return desiredFromRaw(obj, obj.Status.AtProvider.Manifest.Raw)
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"net/http"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/rest"
+	kcache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+// informerFactory creates the informers of all GVKs watched in one cluster if
+// resourceInformers use a shared informer factory, see
+// useSharedInformerFactory. A cache per GVK comes with its own REST mapper,
+// which discovers the API of the cluster again for every GVK. The informers
+// of a factory share one dynamic client and REST mapper instead, so that the
+// cluster is discovered once. Their watches share the connection of the
+// HTTP client, like those of caches created from the same rest.Config do.
+//
+// The informers are created like a dynamicinformer.DynamicSharedInformerFactory
+// creates them, but run individually. The factory can only stop all of its
+// informers at once, while unreferenced GVKs must be stopped on their own.
+type informerFactory struct {
+	http   *http.Client
+	client dynamic.Interface
+	mapper apimeta.RESTMapper
+	// users is the number of shared caches using the factory. Protected by
+	// the lock of resourceInformers.
+	users int
+}
+
+func newInformerFactory(rc *rest.Config) (*informerFactory, error) {
+	hc, err := rest.HTTPClientFor(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating an HTTP client")
+	}
+	dc, err := dynamic.NewForConfigAndClient(rc, hc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating a dynamic client")
+	}
+	m, err := apiutil.NewDynamicRESTMapper(rc, hc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating a REST mapper")
+	}
+	return &informerFactory{http: hc, client: dc, mapper: m}, nil
+}
+
+// informerFor returns a new informer of the supplied GVK. It must be run by
+// the caller.
+func (f *informerFactory) informerFor(gvk schema.GroupVersionKind) (kcache.SharedIndexInformer, error) {
+	m, err := f.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed mapping the GVK to a resource")
+	}
+	inf := dynamicinformer.NewFilteredDynamicInformer(f.client, m.Resource, metav1.NamespaceAll, 0, kcache.Indexers{kcache.NamespaceIndex: kcache.MetaNamespaceIndexFunc}, nil)
+	return inf.Informer(), nil
+}
+
+// acquireFactory returns the informer factory of the supplied cluster,
+// creating it from rc if no shared cache uses one yet. Every call must be
+// paired with a call to releaseFactory.
+func (i *resourceInformers) acquireFactory(identity string, rc *rest.Config) (*informerFactory, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	f, ok := i.factories[identity]
+	if !ok {
+		var err error
+		if f, err = newInformerFactory(rc); err != nil {
+			return nil, err
+		}
+		if i.factories == nil {
+			i.factories = make(map[string]*informerFactory)
+		}
+		i.factories[identity] = f
+	}
+	f.users++
+	return f, nil
+}
+
+// releaseFactory releases the informer factory of the supplied cluster. The
+// factory is dropped, and its idle connections closed, once no shared cache
+// uses it anymore. The next informer of the cluster creates a new one, e.g.
+// with rotated credentials.
+func (i *resourceInformers) releaseFactory(identity string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	f, ok := i.factories[identity]
+	if !ok {
+		return
+	}
+	if f.users--; f.users > 0 {
+		return
+	}
+	delete(i.factories, identity)
+	f.http.CloseIdleConnections()
+}
+
+// newFactoryInformer creates the informer of sc from the factory of the
+// supplied cluster, passing its events to the supplied handlers. The informer
+// must be started by the caller, which must release the factory once it
+// stopped.
+func (i *resourceInformers) newFactoryInformer(identity string, rc *rest.Config, gvk schema.GroupVersionKind, sc *sharedCache, h kcache.ResourceEventHandler, watchFailed kcache.WatchErrorHandler) error {
+	f, err := i.acquireFactory(identity, rc)
+	if err != nil {
+		return errors.Wrap(err, "failed creating an informer factory")
+	}
+	inf, err := f.informerFor(gvk)
+	if err == nil {
+		err = inf.SetWatchErrorHandler(watchFailed)
+	}
+	if err == nil {
+		_, err = inf.AddEventHandler(h)
+	}
+	if err != nil {
+		i.releaseFactory(identity)
+		return errors.Wrap(err, "failed creating an informer")
+	}
+	sc.informer = inf
+	sc.factory = identity
+	return nil
+}
+
+// informerResourceVersion returns the resource version of the supplied
+// resource in the store of inf.
+func informerResourceVersion(inf kcache.SharedIndexInformer, key types.NamespacedName) (string, bool) {
+	k := key.Name
+	if key.Namespace != "" {
+		k = key.Namespace + "/" + key.Name
+	}
+	obj, ok, err := inf.GetStore().GetByKey(k)
+	if err != nil || !ok {
+		return "", false
+	}
+	u, ok := obj.(*kunstructured.Unstructured)
+	if !ok {
+		return "", false
+	}
+	return u.GetResourceVersion(), true
+}
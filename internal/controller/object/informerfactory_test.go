@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+const testGroup = "test.crossplane.io"
+
+// apiServerStats counts the requests made to a test API server.
+type apiServerStats struct {
+	connections atomic.Int64
+	discovery   atomic.Int64
+}
+
+// newTestAPIServer returns an API server serving discovery, lists and
+// watches of the supplied number of cluster scoped kinds of testGroup. Every
+// kind has one resource named "test" of resource version "42".
+func newTestAPIServer(t testing.TB, kinds int) (*rest.Config, *apiServerStats) {
+	t.Helper()
+
+	resources := make([]string, kinds)
+	for k := range resources {
+		resources[k] = fmt.Sprintf(`{"name":"kind%ds","singularName":"kind%d","namespaced":false,"kind":"Kind%d","verbs":["list","watch"]}`, k, k, k)
+	}
+	gv := testGroup + "/v1"
+
+	stats := &apiServerStats{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"kind":"APIVersions","versions":["v1"]}`)
+	})
+	mux.HandleFunc("/api/v1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"kind":"APIResourceList","groupVersion":"v1","resources":[]}`)
+	})
+	mux.HandleFunc("/apis", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"kind":"APIGroupList","groups":[{"name":%q,"versions":[{"groupVersion":%q,"version":"v1"}],"preferredVersion":{"groupVersion":%q,"version":"v1"}}]}`, testGroup, gv, gv)
+	})
+	mux.HandleFunc("/apis/"+gv, func(w http.ResponseWriter, _ *http.Request) {
+		stats.discovery.Add(1)
+		fmt.Fprintf(w, `{"kind":"APIResourceList","groupVersion":%q,"resources":[%s]}`, gv, strings.Join(resources, ","))
+	})
+	mux.HandleFunc("/apis/"+gv+"/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("watch") == "true" {
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+		plural := strings.TrimPrefix(r.URL.Path, "/apis/"+gv+"/")
+		kind := "K" + strings.TrimSuffix(plural, "s")[1:]
+		fmt.Fprintf(w, `{"kind":"%sList","apiVersion":%q,"metadata":{"resourceVersion":"42"},"items":[{"apiVersion":%q,"kind":%q,"metadata":{"name":"test","resourceVersion":"42"}}]}`, kind, gv, gv, kind)
+	})
+
+	srv := httptest.NewUnstartedServer(mux)
+	srv.EnableHTTP2 = true
+	srv.Config.ConnState = func(_ net.Conn, s http.ConnState) {
+		if s == http.StateNew {
+			stats.connections.Add(1)
+		}
+	}
+	srv.StartTLS()
+	t.Cleanup(func() {
+		// Close watches still open, otherwise Close blocks until they are.
+		srv.CloseClientConnections()
+		srv.Close()
+	})
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	return &rest.Config{Host: srv.URL, TLSClientConfig: rest.TLSClientConfig{CAData: ca}, QPS: -1}, stats
+}
+
+func testGVKs(kinds int) []schema.GroupVersionKind {
+	gvks := make([]schema.GroupVersionKind, kinds)
+	for k := range gvks {
+		gvks[k] = schema.GroupVersionKind{Group: testGroup, Version: "v1", Kind: fmt.Sprintf("Kind%d", k)}
+	}
+	return gvks
+}
+
+// waitForSynced waits until the resource informers of all supplied GVKs
+// synced.
+func waitForSynced(t testing.TB, i *resourceInformers, gvks []schema.GroupVersionKind) {
+	t.Helper()
+	deadline := time.Now().Add(30 * time.Second)
+	for _, gvk := range gvks {
+		for {
+			if _, ok := i.ResourceVersion(context.Background(), providerName, gvk, types.NamespacedName{Name: "test"}); ok {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("resource informer of %s did not sync", gvk)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+func Test_resourceInformers_SharedInformerFactory(t *testing.T) {
+	rc, stats := newTestAPIServer(t, 3)
+	gvks := testGVKs(3)
+
+	i := &resourceInformers{
+		log:                      logging.NewNopLogger(),
+		resourceCaches:           make(map[gvkWithConfig]resourceCache),
+		useSharedInformerFactory: true,
+	}
+	if err := i.WatchResources(rc, providerName, gvks...); err != nil {
+		t.Fatalf("WatchResources(...): unexpected error: %v", err)
+	}
+	waitForSynced(t, i, gvks)
+
+	i.lock.RLock()
+	factories := len(i.factories)
+	i.lock.RUnlock()
+	if factories != 1 {
+		t.Errorf("WatchResources(...): want the informers of a cluster to share 1 factory, got %d", factories)
+	}
+	if n := stats.discovery.Load(); n != 1 {
+		t.Errorf("WatchResources(...): want the informers of a cluster to discover its kinds once, got %d times", n)
+	}
+
+	i.stopResourceInformers(providerName)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		i.lock.RLock()
+		factories = len(i.factories)
+		i.lock.RUnlock()
+		if factories == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("stopResourceInformers(...): want the factory to be released once its informers stopped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// BenchmarkResourceInformers compares starting and syncing the informers of
+// many kinds with a cache per GVK to starting them from a shared informer
+// factory. Besides the time and allocations per operation, it reports the
+// connections made to the API server and the discovery requests of the
+// watched group version per operation.
+func BenchmarkResourceInformers(b *testing.B) {
+	for _, kinds := range []int{10, 100} {
+		for _, shared := range []bool{false, true} {
+			b.Run(fmt.Sprintf("Kinds=%d/SharedInformerFactory=%t", kinds, shared), func(b *testing.B) {
+				rc, stats := newTestAPIServer(b, kinds)
+				gvks := testGVKs(kinds)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for n := 0; n < b.N; n++ {
+					i := &resourceInformers{
+						log:                      logging.NewNopLogger(),
+						resourceCaches:           make(map[gvkWithConfig]resourceCache),
+						useSharedInformerFactory: shared,
+					}
+					if err := i.WatchResources(rc, providerName, gvks...); err != nil {
+						b.Fatalf("WatchResources(...): unexpected error: %v", err)
+					}
+					waitForSynced(b, i, gvks)
+					i.stopResourceInformers(providerName)
+				}
+				b.ReportMetric(float64(stats.connections.Load())/float64(b.N), "connections/op")
+				b.ReportMetric(float64(stats.discovery.Load())/float64(b.N), "discovery/op")
+			})
+		}
+	}
+}
@@ -18,12 +18,18 @@ package object
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/rest"
 	kcache "k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
@@ -38,6 +44,8 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
 	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/informers"
 )
 
 // resourceInformers manages resource informers referenced or managed
@@ -47,57 +55,270 @@ import (
 // them. In parallel, the Object reconcilers keep track of references to
 // resources, and inform resourceInformers about them via the
 // WatchReferencedResources method.
+//
+// There is one informer per GVK, since the API server serves watches per
+// resource only, and has no endpoint streaming the changes of all resources
+// at once. To save watch streams, provider configs pointing to the same
+// cluster share the informer of a GVK instead, see sharedCaches. With
+// useSharedInformerFactory, the informers of a cluster are moreover created
+// from one informerFactory, sharing its client and REST mapper.
+//
+// Log entries of resourceInformers always have the gvk, config and cluster
+// of the informer they are about. Changes an operator cares about, like
+// informers being started and stopped, and degraded states, like informers
+// failing to start or watch, are logged at info level with the error that
+// caused them. Everything else is logged at debug level.
 type resourceInformers struct {
 	log          logging.Logger
 	config       *rest.Config
 	objectsCache cache.Cache
-	sink         func(providerConfig string, ev runtimeevent.GenericEvent)
+	// sinks receive events of resource changes by routing key, see Routed.
+	sinks     map[string]resourceSink
+	sinksLock sync.RWMutex
+	// maxInformers limits the number of resource informers that may run at
+	// the same time. Zero or less means no limit. It may be changed at
+	// runtime.
+	maxInformers *atomic.Int64
+	// goroutinesBudget limits the number of goroutines resource informers
+	// may run at the same time. Every informer runs goroutinesPerCache while
+	// it syncs. Zero or less means no limit.
+	goroutinesBudget int64
+	goroutines       atomic.Int64
+	// cleanup configures how many resource informers are garbage collected
+	// at a time.
+	cleanup InformerCleanupOptions
+	// useSharedInformerFactory creates the informers of a cluster from a
+	// shared informerFactory instead of a cache per GVK. Kinds with a cache
+	// size limit keep using a ListWatcher.
+	useSharedInformerFactory bool
+	// lazyStart defers starting the informers of referenced resources until
+	// the first event of a managed resource referencing them, see
+	// WatchReferencedResources.
+	lazyStart bool
+	// paused holds the UIDs of resources whose events are not delivered
+	// anymore, except for their deletion, see PauseWatch.
+	paused sync.Map // types.UID -> struct{}
 
 	lock sync.RWMutex // everything below is protected by this lock
 	// resourceCaches holds the resource caches. These are dynamically started
 	// and stopped based on the Objects that reference or managing them.
 	resourceCaches map[gvkWithConfig]resourceCache
+	// sharedCaches holds the caches backing resourceCaches. Provider configs
+	// pointing to the same cluster share one cache per GVK.
+	sharedCaches map[gvkWithCluster]*sharedCache
+	// clusters holds the cluster each provider config that watched
+	// resources points to.
+	clusters map[string]BudgetUsage
+	// factories holds the informer factory of each cluster identity, see
+	// useSharedInformerFactory.
+	factories map[string]*informerFactory
+	// pending holds the referenced GVKs registered in lazy start mode, along
+	// with the informers of the managed resources referencing them. The
+	// first event of any of these informers starts the informer of the GVK.
+	pending map[schema.GroupVersionKind]sets.Set[gvkWithCluster]
+
+	// budgets limit the number of GVKs watched per cluster.
+	budgets *InformerBudgets
+
+	// owners are the Objects managing the resources the informers watch.
+	// Their resources are forgotten when the informers of a provider config
+	// are stopped.
+	owners *resourceOwners
 }
 
+// resourceSink receives events of resource changes. old is the previous
+// state of the resource for update events, and nil otherwise. deleted is true
+// for deletion events.
+type resourceSink func(providerConfig string, ev runtimeevent.GenericEvent, old client.Object, deleted bool)
+
 type gvkWithConfig struct {
 	// Which provider config was used to create this cache. We will use this
 	// information to figure out whether there are Objects relying on this cache
 	// left during garbage collection of caches.
 	providerConfig string
-	gvk            schema.GroupVersionKind
+	// routingKey of the sink the events of this cache are sent to, see
+	// Routed.
+	routingKey string
+	gvk        schema.GroupVersionKind
+}
+
+// sinkRoute is where the events of a shared cache are sent to.
+type sinkRoute struct {
+	providerConfig string
+	routingKey     string
+}
+
+func (gc gvkWithConfig) route() sinkRoute {
+	return sinkRoute{providerConfig: gc.providerConfig, routingKey: gc.routingKey}
 }
 
 type resourceCache struct {
+	cache cache.Cache
+	// versions replaces cache for kinds with a cache size limit. It only
+	// tracks the resource versions of resources, see informers.ListWatcher.
+	versions *informers.ListWatcher
+	// informer replaces cache for informers created from an
+	// informerFactory.
+	informer kcache.SharedIndexInformer
+	// cancelFn releases the shared cache, which is stopped once no provider
+	// config uses it anymore. It must be called with the lock held.
+	cancelFn context.CancelFunc
+	// cluster is the API server the cache watches.
+	cluster string
+	started time.Time
+}
+
+type gvkWithCluster struct {
+	// cluster is the identity of the API server the cache watches, see
+	// kube.ClusterIdentity.
+	cluster string
+	gvk     schema.GroupVersionKind
+}
+
+// sharedCache is a resource cache shared by all provider configs pointing to
+// the same cluster. Its events are fanned out to the Objects of each of them.
+type sharedCache struct {
+	// Either cache, versions or informer is set, see resourceCache.
 	cache    cache.Cache
+	versions *informers.ListWatcher
+	informer kcache.SharedIndexInformer
+	// factory is the cluster identity of the informerFactory informer was
+	// created from.
+	factory  string
 	cancelFn context.CancelFunc
+	started  time.Time
+	// routes are the provider configs using the cache, along with the
+	// routing key of the sink they watch for. Protected by the lock of
+	// resourceInformers.
+	routes sets.Set[sinkRoute]
+	// synced is true once the cache delivered the initial list of resources.
+	synced atomic.Bool
+}
+
+// start runs the shared cache until ctx is done.
+func (sc *sharedCache) start(ctx context.Context) error {
+	if sc.versions != nil {
+		return sc.versions.Start(ctx)
+	}
+	if sc.informer != nil {
+		sc.informer.Run(ctx.Done())
+		return nil
+	}
+	return sc.cache.Start(ctx)
+}
+
+// waitForSync waits until the shared cache synced. It returns false if ctx
+// is done before.
+func (sc *sharedCache) waitForSync(ctx context.Context) bool {
+	if sc.versions != nil {
+		return sc.versions.WaitForSync(ctx)
+	}
+	if sc.informer != nil {
+		return kcache.WaitForCacheSync(ctx.Done(), sc.informer.HasSynced)
+	}
+	return sc.cache.WaitForCacheSync(ctx)
 }
 
+// InformerCleanupOptions configure the garbage collection of resource
+// informers no Object references anymore. Informers are checked in batches
+// of BatchSize, waiting BatchInterval between batches, to spread the List
+// calls against the Object cache. A BatchSize of zero or less checks all
+// informers at once.
+type InformerCleanupOptions struct {
+	BatchSize     int
+	BatchInterval time.Duration
+}
+
+// goroutinesPerCache is the number of goroutines started for every resource
+// cache, one running the cache and one waiting for it to sync.
+const goroutinesPerCache = 2
+
 var _ source.Source = &resourceInformers{}
 
 // Start implements source.Source, i.e. starting resourceInformers as
-// source with h as the sink of update events. It keeps sending events until
-// ctx is done.
+// source with h as the sink of update events of the resources watched
+// without a routing key. It keeps sending events until ctx is done. Only
+// events all of ps let through are sent, see e.g. WithOwnerUIDFilter.
 func (i *resourceInformers) Start(ctx context.Context, h handler.EventHandler, q workqueue.RateLimitingInterface, ps ...predicate.Predicate) error {
-	if i.sink != nil {
-		return errors.New("source already started, cannot start it again")
+	return i.start(ctx, "", h, q, ps...)
+}
+
+// Routed returns a source that only receives the events of resources watched
+// with the supplied routing key, see WatchRoutedResources. Controllers
+// sharing resourceInformers use a routing key each, so that they are not
+// triggered by the resources the others watch.
+func (i *resourceInformers) Routed(routingKey string) source.Source {
+	return &routedSource{informers: i, routingKey: routingKey}
+}
+
+type routedSource struct {
+	informers  *resourceInformers
+	routingKey string
+}
+
+// Start implements source.Source.
+func (s *routedSource) Start(ctx context.Context, h handler.EventHandler, q workqueue.RateLimitingInterface, ps ...predicate.Predicate) error {
+	return s.informers.start(ctx, s.routingKey, h, q, ps...)
+}
+
+func (i *resourceInformers) start(ctx context.Context, routingKey string, h handler.EventHandler, q workqueue.RateLimitingInterface, ps ...predicate.Predicate) error {
+	i.sinksLock.Lock()
+	defer i.sinksLock.Unlock()
+	if _, ok := i.sinks[routingKey]; ok {
+		return errors.Errorf("source already started for routing key %q, cannot start it again", routingKey)
 	}
-	i.sink = func(providerConfig string, ev runtimeevent.GenericEvent) {
+	if i.sinks == nil {
+		i.sinks = make(map[string]resourceSink)
+	}
+	i.sinks[routingKey] = func(providerConfig string, ev runtimeevent.GenericEvent, old client.Object, deleted bool) {
 		for _, p := range ps {
-			if !p.Generic(ev) {
-				return
+			if pp, ok := p.(providerConfigPredicate); ok {
+				p = pp.ForProviderConfig(providerConfig)
+			}
+			switch {
+			case deleted:
+				if !p.Delete(runtimeevent.DeleteEvent{Object: ev.Object}) {
+					return
+				}
+			case old != nil:
+				if !p.Update(runtimeevent.UpdateEvent{ObjectOld: old, ObjectNew: ev.Object}) {
+					return
+				}
+			default:
+				if !p.Generic(ev) {
+					return
+				}
 			}
 		}
-		h.Generic(context.WithValue(ctx, keyProviderConfigName, providerConfig), ev, q)
+		ctx := context.WithValue(ctx, keyProviderConfigName, providerConfig)
+		if deleted {
+			h.Delete(ctx, runtimeevent.DeleteEvent{Object: ev.Object}, q)
+			return
+		}
+		h.Generic(ctx, ev, q)
 	}
 
 	go func() {
 		<-ctx.Done()
-		i.sink = nil
+		i.sinksLock.Lock()
+		delete(i.sinks, routingKey)
+		i.sinksLock.Unlock()
 	}()
 
 	return nil
 }
 
+// dispatch sends an event to the sink of the supplied route, if it is
+// started.
+func (i *resourceInformers) dispatch(r sinkRoute, ev runtimeevent.GenericEvent, old client.Object, deleted bool) {
+	i.sinksLock.RLock()
+	sink, ok := i.sinks[r.routingKey]
+	i.sinksLock.RUnlock()
+	if ok {
+		sink(r.providerConfig, ev, old, deleted)
+	}
+}
+
 // WatchResources starts informers for the given resource GVKs for the given
 // cluster (i.e. rest.Config & providerConfig).
 // The is wired into the Object reconciler, which will call this method on
@@ -107,63 +328,206 @@ func (i *resourceInformers) Start(ctx context.Context, h handler.EventHandler, q
 // Note that this complements cleanupResourceInformers which regularly
 // garbage collects resource informers that are no longer referenced by
 // any Object.
-func (i *resourceInformers) WatchResources(rc *rest.Config, providerConfig string, gvks ...schema.GroupVersionKind) { // nolint:gocyclo // we need to handle all cases.
+//
+// Events of the resources are sent to the source started without a routing
+// key, see WatchRoutedResources.
+//
+// No new informers are started once maxInformers are running, or while their
+// goroutines would exceed the goroutinesBudget. The GVKs that could not be
+// watched because of that are returned as an error. Informers deferred
+// because of the goroutine budget are started by a later call once other
+// informers synced or were cleaned up.
+func (i *resourceInformers) WatchResources(rc *rest.Config, providerConfig string, gvks ...schema.GroupVersionKind) error {
+	return i.WatchRoutedResources(rc, providerConfig, "", gvks...)
+}
+
+// WatchReferencedResources watches the supplied GVKs of resources referenced
+// by an Object. Referenced resources always live on the control plane. The
+// Object manages a resource of the trigger GVK in the cluster behind rc.
+//
+// Without lazyStart, or without a trigger, this is WatchResources. Otherwise
+// GVKs not watched yet are only registered as pending. They are started by
+// the first event of the informer of the trigger GVK after it synced, i.e.
+// once a managed resource referencing them changes, see startPending. The
+// initial list of resources of an informer does not start pending GVKs.
+func (i *resourceInformers) WatchReferencedResources(rc *rest.Config, trigger schema.GroupVersionKind, gvks ...schema.GroupVersionKind) error {
+	if !i.lazyStart || trigger.Empty() {
+		return i.WatchResources(nil, "", gvks...)
+	}
 	if rc == nil {
 		rc = i.config
 	}
+	tl := gvkWithCluster{cluster: kube.ClusterIdentity(rc), gvk: trigger}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	for _, gvk := range gvks {
+		if _, ok := i.resourceCaches[gvkWithConfig{gvk: gvk}]; ok {
+			continue
+		}
+		if i.pending == nil {
+			i.pending = make(map[schema.GroupVersionKind]sets.Set[gvkWithCluster])
+		}
+		triggers, ok := i.pending[gvk]
+		if !ok {
+			triggers = sets.New[gvkWithCluster]()
+			i.pending[gvk] = triggers
+			i.logFor(gvkWithConfig{gvk: gvk}, "").Debug("Deferring resource watch until a managed resource referencing it changes", "trigger", trigger.String())
+		}
+		triggers.Insert(tl)
+	}
+	return nil
+}
+
+// startPending starts the informers of the pending GVKs triggered by the
+// informer of the supplied GVK and cluster, see WatchReferencedResources.
+// It is called from event handlers and thus does not block.
+func (i *resourceInformers) startPending(gl gvkWithCluster) {
+	i.lock.RLock()
+	n := len(i.pending)
+	i.lock.RUnlock()
+	if n == 0 {
+		return
+	}
+
+	var gvks []schema.GroupVersionKind
+	i.lock.Lock()
+	for gvk, triggers := range i.pending {
+		if triggers.Has(gl) {
+			gvks = append(gvks, gvk)
+			delete(i.pending, gvk)
+		}
+	}
+	i.lock.Unlock()
+	if len(gvks) == 0 {
+		return
+	}
+
+	go func() {
+		// GVKs that cannot be watched now are registered again by the next
+		// reconcile of an Object referencing them.
+		if err := i.WatchResources(nil, "", gvks...); err != nil {
+			i.log.Info("Cannot start pending resource watches", "trigger", gl.gvk.String(), "error", err)
+		}
+	}()
+}
+
+// WatchRoutedResources is WatchResources, sending the events of the
+// resources only to the source of the supplied routing key, see Routed.
+func (i *resourceInformers) WatchRoutedResources(rc *rest.Config, providerConfig, routingKey string, gvks ...schema.GroupVersionKind) error { // nolint:gocyclo // we need to handle all cases.
+	if rc == nil {
+		rc = i.config
+	}
+	var cluster string
+	if rc != nil {
+		cluster = rc.Host
+	}
+	identity := kube.ClusterIdentity(rc)
+
+	i.lock.Lock()
+	if i.clusters == nil {
+		i.clusters = make(map[string]BudgetUsage)
+	}
+	i.clusters[providerConfig] = BudgetUsage{Cluster: cluster, Identity: identity}
+	i.lock.Unlock()
+
+	var rejected, deferred, overBudget []string
 
 	// start new informers
 	for _, gvk := range gvks {
+		gc := gvkWithConfig{providerConfig: providerConfig, routingKey: routingKey, gvk: gvk}
+		gl := gvkWithCluster{cluster: identity, gvk: gvk}
 		i.lock.RLock()
-		_, found := i.resourceCaches[gvkWithConfig{providerConfig: providerConfig, gvk: gvk}]
+		_, found := i.resourceCaches[gc]
+		running := len(i.sharedCaches)
 		i.lock.RUnlock()
 		if found {
 			continue
 		}
 
-		log := i.log.WithValues("providerConfig", providerConfig, "gvk", gvk.String())
+		log := i.logFor(gc, cluster)
 
-		ca, err := cache.New(rc, cache.Options{
-			DefaultWatchErrorHandler: func(r *kcache.Reflector, err error) {
-				if errors.Is(io.EOF, err) {
-					// Watch closed normally.
-					return
-				}
-				log.Debug("Watch error - probably remote cluster api is gone", "error", err)
-			},
-		})
-		if err != nil {
-			log.Debug("failed creating a cache", "error", err)
+		if i.joinSharedCache(gc, gl, cluster) {
+			log.Debug("Sharing resource watch with other provider configs of the same cluster")
+			continue
+		}
+
+		if limit, ok := i.budgets.limitFor(providerConfig); ok && i.clusterGVKs(identity) >= limit {
+			log.Info("Cannot start resource watch, informer budget of the cluster exhausted", "maxGVKsPerCluster", limit)
+			overBudget = append(overBudget, gvk.String())
+			continue
+		}
+
+		if limit := i.informerLimit(); limit > 0 && int64(running) >= limit {
+			log.Info("Cannot start resource watch, informer limit reached", "maxInformers", limit)
+			informerLimitExceeded.Inc()
+			rejected = append(rejected, gvk.String())
 			continue
 		}
 
+		if !i.reserveGoroutines(goroutinesPerCache) {
+			log.Info("Deferring resource watch, goroutine budget exhausted", "goroutinesBudget", i.goroutinesBudget)
+			deferred = append(deferred, gvk.String())
+			continue
+		}
+
+		resyncs := newResyncTracker(log)
+		watchFailed := func(err error) {
+			if errors.Is(io.EOF, err) {
+				// Watch closed normally.
+				return
+			}
+			if kerrors.IsResourceExpired(err) || kerrors.IsGone(err) {
+				resyncs.watchExpired(err)
+				return
+			}
+			log.Info("Resource watch failed, probably the remote cluster API is gone", "error", err)
+		}
+
 		// don't forget to call cancelFn in error cases to avoid leaks. In the
 		// happy case it's called from the go routine starting the cache below.
 		ctx, cancelFn := context.WithCancel(context.Background())
 
-		u := kunstructured.Unstructured{}
-		u.SetGroupVersionKind(gvk)
-		inf, err := ca.GetInformer(ctx, &u, cache.BlockUntilSynced(false)) // don't block. We wait in the go routine below.
-		if err != nil {
-			cancelFn()
-			log.Debug("failed getting informer", "error", err)
-			continue
+		sc := &sharedCache{cancelFn: cancelFn, started: time.Now(), routes: sets.New(gc.route())}
+		sink := func(ev runtimeevent.GenericEvent, old client.Object, deleted bool) {
+			i.lock.RLock()
+			routes := sc.routes.UnsortedList()
+			i.lock.RUnlock()
+			for _, r := range routes {
+				i.dispatch(r, ev, old, deleted)
+			}
 		}
 
-		if _, err := inf.AddEventHandler(kcache.ResourceEventHandlerFuncs{
+		handlers := kcache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
 				ev := runtimeevent.GenericEvent{
 					Object: obj.(client.Object),
 				}
+				if i.watchPaused(ev.Object) {
+					return
+				}
 
-				i.sink(providerConfig, ev)
+				resyncs.observe(nil, ev.Object)
+				sink(ev, nil, false)
+				if sc.synced.Load() {
+					i.startPending(gl)
+				}
 			},
 			UpdateFunc: func(oldObj, newObj interface{}) {
 				ev := runtimeevent.GenericEvent{
 					Object: newObj.(client.Object),
 				}
+				if i.watchPaused(ev.Object) {
+					return
+				}
 
-				i.sink(providerConfig, ev)
+				old := oldObj.(client.Object)
+				if resyncs.observe(old, ev.Object) {
+					// Changes may have been missed, do not filter by them.
+					old = nil
+				}
+				sink(ev, old, false)
+				i.startPending(gl)
 			},
 			DeleteFunc: func(obj interface{}) {
 				if final, ok := obj.(kcache.DeletedFinalStateUnknown); ok {
@@ -172,44 +536,296 @@ func (i *resourceInformers) WatchResources(rc *rest.Config, providerConfig strin
 				ev := runtimeevent.GenericEvent{
 					Object: obj.(client.Object),
 				}
+				i.paused.Delete(ev.Object.GetUID())
 
-				i.sink(providerConfig, ev)
+				resyncs.observe(nil, ev.Object)
+				sink(ev, nil, true)
+				i.startPending(gl)
 			},
-		}); err != nil {
+		}
+
+		var err error
+		if size, ok := i.cacheSizeLimit(providerConfig, gvk); ok {
+			log = log.WithValues("maxCacheSize", size)
+			err = i.newListWatcher(rc, gvk, sc, handlers, informers.Options{MaxCacheSize: size, WatchErrorHandler: watchFailed})
+		} else if i.useSharedInformerFactory {
+			err = i.newFactoryInformer(identity, rc, gvk, sc, handlers, func(_ *kcache.Reflector, err error) { watchFailed(err) })
+		} else {
+			err = i.newCache(ctx, rc, gvk, sc, handlers, func(_ *kcache.Reflector, err error) { watchFailed(err) })
+		}
+		if err != nil {
 			cancelFn()
-			log.Debug("failed adding event handler", "error", err)
+			i.releaseGoroutines(goroutinesPerCache)
+			log.Info("Cannot start resource watch", "error", err)
 			continue
 		}
 
 		go func() {
+			defer i.releaseGoroutines(1)
 			defer cancelFn()
+			if sc.informer != nil {
+				defer i.releaseFactory(sc.factory)
+			}
 
 			log.Info("Starting resource watch")
-			_ = ca.Start(ctx)
+			_ = sc.start(ctx)
 		}()
 
 		i.lock.Lock()
-		_, ok := i.resourceCaches[gvkWithConfig{providerConfig: providerConfig, gvk: gvk}]
-		if ok {
+		_, ok := i.resourceCaches[gc]
+		if ok || i.sharedCaches[gl] != nil {
 			// Another goroutine already started the cache in parallel. We
-			// should cancel the new one.
+			// should cancel the new one, which never waits for its sync.
 			cancelFn()
 			i.lock.Unlock()
+			i.releaseGoroutines(1)
+			if !ok {
+				i.joinSharedCache(gc, gl, cluster)
+			}
 			continue
 		}
-		i.resourceCaches[gvkWithConfig{providerConfig: providerConfig, gvk: gvk}] = resourceCache{
-			cache:    ca,
-			cancelFn: cancelFn,
+		if i.sharedCaches == nil {
+			i.sharedCaches = make(map[gvkWithCluster]*sharedCache)
+		}
+		i.sharedCaches[gl] = sc
+		i.resourceCaches[gc] = resourceCache{
+			cache:    sc.cache,
+			versions: sc.versions,
+			informer: sc.informer,
+			cancelFn: i.releaseSharedCache(gl, gc.route()),
+			cluster:  cluster,
+			started:  sc.started,
 		}
 		i.lock.Unlock()
 
 		// wait for in the background.
 		go func() {
-			if synced := ca.WaitForCacheSync(ctx); synced {
+			defer i.releaseGoroutines(1)
+			if synced := sc.waitForSync(ctx); synced {
+				sc.synced.Store(true)
 				log.Debug("Resource cache synced")
 			}
 		}()
 	}
+
+	var msgs []string
+	if len(rejected) > 0 {
+		msgs = append(msgs, fmt.Sprintf("limit of %d resource informers reached, cannot watch %s", i.informerLimit(), strings.Join(rejected, ", ")))
+	}
+	if len(deferred) > 0 {
+		msgs = append(msgs, fmt.Sprintf("budget of %d informer goroutines exhausted, deferred watching %s", i.goroutinesBudget, strings.Join(deferred, ", ")))
+	}
+	if len(overBudget) > 0 {
+		limit, _ := i.budgets.limitFor(providerConfig)
+		msgs = append(msgs, fmt.Sprintf("informer budget of %d GVKs per cluster exhausted, cannot watch %s", limit, strings.Join(overBudget, ", ")))
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	err := errors.New(strings.Join(msgs, "; "))
+	if len(overBudget) > 0 {
+		return &budgetExceededError{error: err}
+	}
+	return err
+}
+
+// newCache creates the cache of sc with an informer of the supplied GVK,
+// passing its events to the supplied handlers. The cache must be started by
+// the caller.
+func (i *resourceInformers) newCache(ctx context.Context, rc *rest.Config, gvk schema.GroupVersionKind, sc *sharedCache, h kcache.ResourceEventHandler, watchFailed kcache.WatchErrorHandler) error {
+	ca, err := cache.New(rc, cache.Options{DefaultWatchErrorHandler: watchFailed})
+	if err != nil {
+		return errors.Wrap(err, "failed creating a cache")
+	}
+
+	u := kunstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	inf, err := ca.GetInformer(ctx, &u, cache.BlockUntilSynced(false)) // don't block. We wait in the go routine of the caller.
+	if err != nil {
+		return errors.Wrap(err, "failed getting an informer")
+	}
+	if _, err := inf.AddEventHandler(h); err != nil {
+		return errors.Wrap(err, "failed adding an event handler")
+	}
+	sc.cache = ca
+	return nil
+}
+
+// newListWatcher creates a ListWatcher of the supplied GVK for sc, passing
+// its events to the supplied handlers. It must be started by the caller.
+func (i *resourceInformers) newListWatcher(rc *rest.Config, gvk schema.GroupVersionKind, sc *sharedCache, h kcache.ResourceEventHandler, o informers.Options) error {
+	lw, err := informers.NewListWatcher(rc, gvk, h, o)
+	if err != nil {
+		return errors.Wrap(err, "failed creating a list watcher")
+	}
+	sc.versions = lw
+	return nil
+}
+
+// cacheSizeLimit returns the lowest cache size limit the Objects watching
+// the supplied GVK with the supplied provider config set, if any.
+func (i *resourceInformers) cacheSizeLimit(providerConfig string, gvk schema.GroupVersionKind) (int, bool) {
+	if i.objectsCache == nil {
+		return 0, false
+	}
+	list := v1alpha2.ObjectList{}
+	key := refKeyProviderGVK(providerConfig, gvk.Kind, gvk.Group, gvk.Version)
+	if err := i.objectsCache.List(context.Background(), &list, client.MatchingFields{resourceRefGVKsIndex: key}); err != nil {
+		return 0, false
+	}
+	limit, found := 0, false
+	for _, o := range list.Items {
+		wo := o.Spec.WatchOptions
+		if wo == nil || wo.MaxCacheSize == nil {
+			continue
+		}
+		if n := int(*wo.MaxCacheSize); !found || n < limit {
+			limit, found = n, true
+		}
+	}
+	return limit, found
+}
+
+// PauseWatch stops delivering events of the resource with the supplied UID,
+// e.g. because it reached a terminal state. Its deletion is still delivered.
+func (i *resourceInformers) PauseWatch(uid types.UID) {
+	i.paused.Store(uid, struct{}{})
+}
+
+func (i *resourceInformers) watchPaused(obj client.Object) bool {
+	_, ok := i.paused.Load(obj.GetUID())
+	return ok
+}
+
+// ResourceVersion returns the resource version of the supplied resource in
+// the cache watching its GVK for the supplied provider config. It returns
+// false if no synced cache watches the GVK, or the resource is not in it.
+func (i *resourceInformers) ResourceVersion(ctx context.Context, providerConfig string, gvk schema.GroupVersionKind, key types.NamespacedName) (string, bool) {
+	i.lock.RLock()
+	ca, ok := i.resourceCaches[gvkWithConfig{providerConfig: providerConfig, gvk: gvk}]
+	i.lock.RUnlock()
+	if !ok || ca.versions != nil {
+		// Caches with a size limit keep no resource versions.
+		return "", false
+	}
+	if ca.informer != nil {
+		if !ca.informer.HasSynced() {
+			return "", false
+		}
+		return informerResourceVersion(ca.informer, key)
+	}
+
+	u := &kunstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	inf, err := ca.cache.GetInformer(ctx, u, cache.BlockUntilSynced(false))
+	if err != nil || !inf.HasSynced() {
+		return "", false
+	}
+	if err := ca.cache.Get(ctx, key, u); err != nil {
+		return "", false
+	}
+	return u.GetResourceVersion(), true
+}
+
+// clusterGVKs returns the number of GVKs watched in the supplied cluster.
+func (i *resourceInformers) clusterGVKs(identity string) int {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	n := 0
+	for gl := range i.sharedCaches {
+		if gl.cluster == identity {
+			n++
+		}
+	}
+	return n
+}
+
+// clusterUsage returns the number of GVKs watched in the cluster the
+// supplied provider config points to.
+func (i *resourceInformers) clusterUsage(providerConfig string) (BudgetUsage, bool) {
+	i.lock.RLock()
+	u, ok := i.clusters[providerConfig]
+	i.lock.RUnlock()
+	if !ok {
+		return BudgetUsage{}, false
+	}
+	u.GVKs = i.clusterGVKs(u.Identity)
+	return u, true
+}
+
+// joinSharedCache makes the supplied provider config use the cache another
+// provider config of the same cluster already started for the GVK, if any.
+func (i *resourceInformers) joinSharedCache(gc gvkWithConfig, gl gvkWithCluster, cluster string) bool {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	sc, ok := i.sharedCaches[gl]
+	if !ok {
+		return false
+	}
+	if _, ok := i.resourceCaches[gc]; ok {
+		return true
+	}
+	sc.routes.Insert(gc.route())
+	i.resourceCaches[gc] = resourceCache{
+		cache:    sc.cache,
+		versions: sc.versions,
+		informer: sc.informer,
+		cancelFn: i.releaseSharedCache(gl, gc.route()),
+		cluster:  cluster,
+		started:  sc.started,
+	}
+	return true
+}
+
+// releaseSharedCache returns a function that stops the supplied route from
+// using a shared cache, stopping the cache once no route uses it anymore. The
+// function must be called with the lock held.
+func (i *resourceInformers) releaseSharedCache(gl gvkWithCluster, r sinkRoute) context.CancelFunc {
+	return func() {
+		sc, ok := i.sharedCaches[gl]
+		if !ok {
+			return
+		}
+		sc.routes.Delete(r)
+		if sc.routes.Len() > 0 {
+			return
+		}
+		sc.cancelFn()
+		delete(i.sharedCaches, gl)
+	}
+}
+
+// logFor returns a logger for the informer of the supplied GVK and provider
+// config, watching the supplied cluster.
+func (i *resourceInformers) logFor(gc gvkWithConfig, cluster string) logging.Logger {
+	return i.log.WithValues("gvk", gc.gvk.String(), "config", gc.providerConfig, "cluster", cluster)
+}
+
+func (i *resourceInformers) informerLimit() int64 {
+	if i.maxInformers == nil {
+		return 0
+	}
+	return i.maxInformers.Load()
+}
+
+// reserveGoroutines reserves n goroutines of the budget. It returns false if
+// the budget does not allow for them.
+func (i *resourceInformers) reserveGoroutines(n int64) bool {
+	for {
+		cur := i.goroutines.Load()
+		if i.goroutinesBudget > 0 && cur+n > i.goroutinesBudget {
+			return false
+		}
+		if i.goroutines.CompareAndSwap(cur, cur+n) {
+			goroutinesActive.Set(float64(cur + n))
+			return true
+		}
+	}
+}
+
+// releaseGoroutines returns n goroutines to the budget.
+func (i *resourceInformers) releaseGoroutines(n int64) {
+	goroutinesActive.Set(float64(i.goroutines.Add(-n)))
 }
 
 // cleanupResourceInformers garbage collects resource informers that are
@@ -220,35 +836,116 @@ func (i *resourceInformers) WatchResources(rc *rest.Config, providerConfig strin
 func (i *resourceInformers) cleanupResourceInformers(ctx context.Context) {
 	// copy map to avoid locking it for the entire duration of the loop
 	i.lock.RLock()
+	gcs := make([]gvkWithConfig, 0, len(i.resourceCaches))
 	resourceCaches := make(map[gvkWithConfig]resourceCache, len(i.resourceCaches))
 	for gc, ca := range i.resourceCaches {
+		gcs = append(gcs, gc)
 		resourceCaches[gc] = ca
 	}
 	i.lock.RUnlock()
 
+	size := i.cleanup.BatchSize
+	if size <= 0 {
+		size = len(gcs)
+	}
+
+	var tick <-chan time.Time
+	if size < len(gcs) && i.cleanup.BatchInterval > 0 {
+		t := time.NewTicker(i.cleanup.BatchInterval)
+		defer t.Stop()
+		tick = t.C
+	}
+
 	// stop old informers
-	i.log.Debug("Running garbage collection for resource informers", "count", len(i.resourceCaches))
-	for gc, ca := range resourceCaches {
+	i.log.Debug("Running garbage collection for resource informers", "count", len(gcs), "batchSize", size)
+	for start := 0; start < len(gcs); start += size {
+		if start > 0 && tick != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick:
+			}
+		}
+		end := start + size
+		if end > len(gcs) {
+			end = len(gcs)
+		}
+		for _, gc := range gcs[start:end] {
+			i.cleanupResourceInformer(ctx, gc, resourceCaches[gc])
+		}
+	}
+
+	i.cleanupPending(ctx)
+}
+
+// cleanupPending drops the pending GVKs no Object references anymore, see
+// WatchReferencedResources.
+func (i *resourceInformers) cleanupPending(ctx context.Context) {
+	i.lock.RLock()
+	gvks := make([]schema.GroupVersionKind, 0, len(i.pending))
+	for gvk := range i.pending {
+		gvks = append(gvks, gvk)
+	}
+	i.lock.RUnlock()
+
+	for _, gvk := range gvks {
 		list := v1alpha2.ObjectList{}
-		if err := i.objectsCache.List(ctx, &list, client.MatchingFields{resourceRefGVKsIndex: refKeyProviderGVK(gc.providerConfig, gc.gvk.Kind, gc.gvk.Group, gc.gvk.Version)}); err != nil {
-			i.log.Debug("cannot list objects referencing a certain resource GVK", "error", err, "fieldSelector", resourceRefGVKsIndex+"="+refKeyProviderGVK(gc.providerConfig, gc.gvk.Kind, gc.gvk.Group, gc.gvk.Version))
+		key := refKeyProviderGVK("", gvk.Kind, gvk.Group, gvk.Version)
+		if err := i.objectsCache.List(ctx, &list, client.MatchingFields{resourceRefGVKsIndex: key}); err != nil || len(list.Items) > 0 {
 			continue
 		}
+		i.lock.Lock()
+		delete(i.pending, gvk)
+		i.lock.Unlock()
+		i.logFor(gvkWithConfig{gvk: gvk}, "").Debug("Dropped pending resource watch, no Object references it")
+	}
+}
+
+// cleanupResourceInformer stops the supplied resource informer if no Object
+// references its GVK anymore.
+func (i *resourceInformers) cleanupResourceInformer(ctx context.Context, gc gvkWithConfig, ca resourceCache) {
+	log := i.logFor(gc, ca.cluster)
+	list := v1alpha2.ObjectList{}
+	key := refKeyProviderGVK(gc.providerConfig, gc.gvk.Kind, gc.gvk.Group, gc.gvk.Version)
+	if err := i.objectsCache.List(ctx, &list, client.MatchingFields{resourceRefGVKsIndex: key}); err != nil {
+		log.Info("Cannot garbage collect resource watch, failed listing the Objects referencing it", "error", err, "fieldSelector", resourceRefGVKsIndex+"="+key)
+		return
+	}
 
-		if len(list.Items) > 0 {
+	if len(list.Items) > 0 {
+		return
+	}
+
+	i.lock.Lock()
+	ca.cancelFn()
+	delete(i.resourceCaches, gc)
+	i.lock.Unlock()
+	log.Info("Stopped resource watch, no Object references it")
+}
+
+// stopResourceInformers stops all resource informers of the supplied
+// provider config, e.g. because its credentials changed. The Object
+// reconcilers start them again with a fresh rest.Config.
+func (i *resourceInformers) stopResourceInformers(providerConfig string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	for gc, ca := range i.resourceCaches {
+		if gc.providerConfig != providerConfig {
 			continue
 		}
-
 		ca.cancelFn()
-		i.log.Info("Stopped resource watch", "provider config", gc.providerConfig, "gvk", gc.gvk)
-		i.lock.Lock()
+		i.logFor(gc, ca.cluster).Info("Stopped resource watch of provider config")
 		delete(i.resourceCaches, gc)
-		i.lock.Unlock()
 	}
+	i.owners.ForgetProviderConfig(providerConfig)
 }
 
+// parseAPIVersion splits an API version like "apps/v1" or "v1" into its
+// group and version. An empty API version has neither, one with more than
+// one "/" is invalid and returns neither either.
 func parseAPIVersion(v string) (string, string) {
-	parts := strings.SplitN(v, "/", 2)
+	parts := strings.Split(v, "/")
 	switch len(parts) {
 	case 1:
 		return "", parts[0]
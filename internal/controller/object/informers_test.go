@@ -0,0 +1,619 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.uber.org/goleak"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	runtimeevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+)
+
+func Test_resourceInformers_WatchResources(t *testing.T) {
+	running := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	other := schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+
+	type args struct {
+		maxInformers     int
+		goroutinesBudget int64
+		providerConfig   string
+		budget           int
+		gvks             []schema.GroupVersionKind
+	}
+	type want struct {
+		err            bool
+		budgetExceeded bool
+		caches         int
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"AlreadyWatched": {
+			reason: "We should not need a new informer for a kind that is already watched.",
+			args: args{
+				maxInformers:   1,
+				providerConfig: providerName,
+				gvks:           []schema.GroupVersionKind{running},
+			},
+			want: want{
+				caches: 1,
+			},
+		},
+		"SharedWithSameCluster": {
+			reason: "We should reuse the informer of another provider config pointing to the same cluster, even once the limit is reached.",
+			args: args{
+				maxInformers:   1,
+				providerConfig: "other-config",
+				gvks:           []schema.GroupVersionKind{running},
+			},
+			want: want{
+				caches: 2,
+			},
+		},
+		"LimitReached": {
+			reason: "We should reject new informers once the limit is reached.",
+			args: args{
+				maxInformers:   1,
+				providerConfig: providerName,
+				gvks:           []schema.GroupVersionKind{running, other},
+			},
+			want: want{
+				err:    true,
+				caches: 1,
+			},
+		},
+		"InformerBudgetExhausted": {
+			reason: "We should reject new informers once a cluster watches as many GVKs as its budget allows.",
+			args: args{
+				providerConfig: providerName,
+				budget:         1,
+				gvks:           []schema.GroupVersionKind{running, other},
+			},
+			want: want{
+				err:            true,
+				budgetExceeded: true,
+				caches:         1,
+			},
+		},
+		"GoroutineBudgetExhausted": {
+			reason: "We should defer new informers while their goroutines would exceed the budget.",
+			args: args{
+				goroutinesBudget: goroutinesPerCache - 1,
+				providerConfig:   providerName,
+				gvks:             []schema.GroupVersionKind{running, other},
+			},
+			want: want{
+				err:    true,
+				caches: 1,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			maxInformers := &atomic.Int64{}
+			maxInformers.Store(int64(tc.args.maxInformers))
+			budgets := NewInformerBudgets()
+			if tc.args.budget > 0 {
+				budgets.SetBudget("budget", tc.args.budget, []string{providerName})
+			}
+			i := &resourceInformers{
+				log:          logging.NewNopLogger(),
+				maxInformers: maxInformers,
+
+				goroutinesBudget: tc.args.goroutinesBudget,
+				resourceCaches: map[gvkWithConfig]resourceCache{
+					{providerConfig: providerName, gvk: running}: {},
+				},
+				sharedCaches: map[gvkWithCluster]*sharedCache{
+					{cluster: kube.ClusterIdentity(nil), gvk: running}: {routes: sets.New(sinkRoute{providerConfig: providerName})},
+				},
+				budgets: budgets,
+			}
+			err := i.WatchResources(nil, tc.args.providerConfig, tc.args.gvks...)
+			if (err != nil) != tc.want.err {
+				t.Errorf("\n%s\nWatchResources(...): want error: %t, got: %v", tc.reason, tc.want.err, err)
+			}
+			if isBudgetExceeded(err) != tc.want.budgetExceeded {
+				t.Errorf("\n%s\nWatchResources(...): want budget exceeded: %t, got: %v", tc.reason, tc.want.budgetExceeded, err)
+			}
+			if len(i.resourceCaches) != tc.want.caches {
+				t.Errorf("\n%s\nWatchResources(...): want %d resource caches, got %d", tc.reason, tc.want.caches, len(i.resourceCaches))
+			}
+			if len(i.sharedCaches) != 1 {
+				t.Errorf("\n%s\nWatchResources(...): want 1 running informer, got %d", tc.reason, len(i.sharedCaches))
+			}
+		})
+	}
+}
+
+func Test_resourceInformers_WatchReferencedResources(t *testing.T) {
+	running := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	referenced := schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+	trigger := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	type args struct {
+		lazyStart bool
+		trigger   schema.GroupVersionKind
+		gvks      []schema.GroupVersionKind
+	}
+	type want struct {
+		err     bool
+		pending map[schema.GroupVersionKind]sets.Set[gvkWithCluster]
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Eager": {
+			reason: "We should start informers right away without lazy start.",
+			args: args{
+				trigger: trigger,
+				gvks:    []schema.GroupVersionKind{referenced},
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"NoTrigger": {
+			reason: "We should start informers right away if no managed resource is watched that could trigger them.",
+			args: args{
+				lazyStart: true,
+				gvks:      []schema.GroupVersionKind{referenced},
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"AlreadyWatched": {
+			reason: "We should not defer kinds that are already watched.",
+			args: args{
+				lazyStart: true,
+				trigger:   trigger,
+				gvks:      []schema.GroupVersionKind{running},
+			},
+		},
+		"Deferred": {
+			reason: "We should defer new informers until the informer of the managed resource sends an event.",
+			args: args{
+				lazyStart: true,
+				trigger:   trigger,
+				gvks:      []schema.GroupVersionKind{running, referenced},
+			},
+			want: want{
+				pending: map[schema.GroupVersionKind]sets.Set[gvkWithCluster]{
+					referenced: sets.New(gvkWithCluster{cluster: kube.ClusterIdentity(nil), gvk: trigger}),
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// The limit rejects informers started right away, i.e. without
+			// connecting to a cluster.
+			maxInformers := &atomic.Int64{}
+			maxInformers.Store(1)
+			i := &resourceInformers{
+				log:          logging.NewNopLogger(),
+				maxInformers: maxInformers,
+				lazyStart:    tc.args.lazyStart,
+				resourceCaches: map[gvkWithConfig]resourceCache{
+					{gvk: running}: {},
+				},
+				sharedCaches: map[gvkWithCluster]*sharedCache{
+					{cluster: kube.ClusterIdentity(nil), gvk: running}: {routes: sets.New(sinkRoute{})},
+				},
+			}
+			err := i.WatchReferencedResources(nil, tc.args.trigger, tc.args.gvks...)
+			if (err != nil) != tc.want.err {
+				t.Errorf("\n%s\nWatchReferencedResources(...): want error: %t, got: %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.pending, i.pending, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nWatchReferencedResources(...): -want pending, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func Test_resourceInformers_startPending(t *testing.T) {
+	referenced := schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+	trigger := gvkWithCluster{cluster: kube.ClusterIdentity(nil), gvk: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}}
+	other := gvkWithCluster{cluster: kube.ClusterIdentity(nil), gvk: schema.GroupVersionKind{Version: "v1", Kind: "Service"}}
+
+	i := &resourceInformers{
+		log: logging.NewNopLogger(),
+		// The budget rejects the started informers, i.e. without connecting
+		// to a cluster.
+		goroutinesBudget: goroutinesPerCache - 1,
+		resourceCaches:   map[gvkWithConfig]resourceCache{},
+		pending: map[schema.GroupVersionKind]sets.Set[gvkWithCluster]{
+			referenced: sets.New(trigger),
+		},
+	}
+
+	i.startPending(other)
+	if len(i.pending) != 1 {
+		t.Errorf("startPending(...): want pending kinds to wait for an event of the informers referencing them")
+	}
+	i.startPending(trigger)
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	if len(i.pending) != 0 {
+		t.Errorf("startPending(...): want pending kinds to be started by an event of an informer referencing them, got %v", i.pending)
+	}
+}
+
+func Test_resourceInformers_reserveGoroutines(t *testing.T) {
+	i := &resourceInformers{goroutinesBudget: 3}
+
+	if !i.reserveGoroutines(goroutinesPerCache) {
+		t.Errorf("reserveGoroutines(...): want reservation within budget to succeed")
+	}
+	if i.reserveGoroutines(goroutinesPerCache) {
+		t.Errorf("reserveGoroutines(...): want reservation exceeding budget to fail")
+	}
+	i.releaseGoroutines(1)
+	if !i.reserveGoroutines(goroutinesPerCache) {
+		t.Errorf("reserveGoroutines(...): want reservation to succeed once goroutines were released")
+	}
+	if got := i.goroutines.Load(); got != 3 {
+		t.Errorf("goroutines: want 3 reserved goroutines, got %d", got)
+	}
+}
+
+// listCache is a cache whose List calls return an Object for the referenced
+// keys only.
+type listCache struct {
+	informertest.FakeInformers
+	referenced map[string]bool
+	lists      atomic.Int64
+}
+
+func (c *listCache) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	c.lists.Add(1)
+	lo := &client.ListOptions{}
+	lo.ApplyOptions(opts)
+	key, _ := lo.FieldSelector.RequiresExactMatch(resourceRefGVKsIndex)
+	if c.referenced[key] {
+		list.(*v1alpha2.ObjectList).Items = []v1alpha2.Object{{}}
+	}
+	return nil
+}
+
+func Test_resourceInformers_cleanupResourceInformers(t *testing.T) {
+	type args struct {
+		caches   int
+		cleanup  InformerCleanupOptions
+		canceled bool
+	}
+	type want struct {
+		lists     int64
+		remaining int
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"AllAtOnce": {
+			reason: "We should check every informer and stop the unreferenced ones if batching is disabled.",
+			args: args{
+				caches: 5,
+			},
+			want: want{
+				lists:     5,
+				remaining: 1,
+			},
+		},
+		"Batched": {
+			reason: "We should check every informer in batches.",
+			args: args{
+				caches:  5,
+				cleanup: InformerCleanupOptions{BatchSize: 2, BatchInterval: time.Millisecond},
+			},
+			want: want{
+				lists:     5,
+				remaining: 1,
+			},
+		},
+		"Canceled": {
+			reason: "We should stop checking informers between batches once the context is canceled.",
+			args: args{
+				caches:   5,
+				cleanup:  InformerCleanupOptions{BatchSize: 2, BatchInterval: time.Hour},
+				canceled: true,
+			},
+			want: want{
+				lists:     2,
+				remaining: 3,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			referenced := schema.GroupVersionKind{Version: "v1", Kind: "Kind0"}
+			oc := &listCache{referenced: map[string]bool{
+				refKeyProviderGVK("pc", referenced.Kind, referenced.Group, referenced.Version): true,
+			}}
+			i := &resourceInformers{
+				log:            logging.NewNopLogger(),
+				objectsCache:   oc,
+				cleanup:        tc.args.cleanup,
+				resourceCaches: make(map[gvkWithConfig]resourceCache),
+			}
+			for n := 0; n < tc.args.caches; n++ {
+				gvk := schema.GroupVersionKind{Version: "v1", Kind: fmt.Sprintf("Kind%d", n)}
+				i.resourceCaches[gvkWithConfig{providerConfig: "pc", gvk: gvk}] = resourceCache{cancelFn: func() {}}
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tc.args.canceled {
+				cancel()
+			}
+			defer cancel()
+			i.cleanupResourceInformers(ctx)
+
+			if got := oc.lists.Load(); got != tc.want.lists {
+				t.Errorf("\n%s\ncleanupResourceInformers(...): want %d List calls, got %d", tc.reason, tc.want.lists, got)
+			}
+			remaining := len(i.resourceCaches)
+			if tc.args.canceled {
+				// Which informers are checked first is not defined.
+				if remaining < tc.want.remaining {
+					t.Errorf("\n%s\ncleanupResourceInformers(...): want at least %d remaining informers, got %d", tc.reason, tc.want.remaining, remaining)
+				}
+				return
+			}
+			if remaining != tc.want.remaining {
+				t.Errorf("\n%s\ncleanupResourceInformers(...): want %d remaining informers, got %d", tc.reason, tc.want.remaining, remaining)
+			}
+		})
+	}
+}
+
+func Test_resourceInformers_releaseSharedCache(t *testing.T) {
+	gl := gvkWithCluster{cluster: "https://cluster:443#system", gvk: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}}
+	canceled := false
+	i := &resourceInformers{
+		sharedCaches: map[gvkWithCluster]*sharedCache{
+			gl: {cancelFn: func() { canceled = true }, routes: sets.New(sinkRoute{providerConfig: "a"}, sinkRoute{providerConfig: "b"})},
+		},
+	}
+
+	i.releaseSharedCache(gl, sinkRoute{providerConfig: "a"})()
+	if canceled || len(i.sharedCaches) != 1 {
+		t.Errorf("releaseSharedCache(...): want the cache to keep running while provider config %q uses it", "b")
+	}
+	i.releaseSharedCache(gl, sinkRoute{providerConfig: "b"})()
+	if !canceled || len(i.sharedCaches) != 0 {
+		t.Errorf("releaseSharedCache(...): want the cache to be stopped once no provider config uses it")
+	}
+}
+
+func Test_resourceInformers_dispatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	got := map[string][]string{}
+	sinkFor := func(routingKey string) handler.EventHandler {
+		return handler.Funcs{
+			GenericFunc: func(ctx context.Context, ev runtimeevent.GenericEvent, _ workqueue.RateLimitingInterface) {
+				pc, _ := ctx.Value(keyProviderConfigName).(string)
+				got[routingKey] = append(got[routingKey], pc+"/"+ev.Object.GetName())
+			},
+		}
+	}
+
+	i := &resourceInformers{}
+	if err := i.Start(ctx, sinkFor(""), nil); err != nil {
+		t.Fatalf("Start(...): unexpected error: %v", err)
+	}
+	if err := i.Routed("composites").Start(ctx, sinkFor("composites"), nil); err != nil {
+		t.Fatalf("Routed(...).Start(...): unexpected error: %v", err)
+	}
+	if err := i.Routed("composites").Start(ctx, sinkFor("composites"), nil); err == nil {
+		t.Errorf("Routed(...).Start(...): want error starting a routing key twice")
+	}
+
+	cm := &kunstructured.Unstructured{}
+	cm.SetName("cm")
+	ev := runtimeevent.GenericEvent{Object: cm}
+	i.dispatch(sinkRoute{providerConfig: "a"}, ev, nil, false)
+	i.dispatch(sinkRoute{providerConfig: "b", routingKey: "composites"}, ev, nil, false)
+	i.dispatch(sinkRoute{providerConfig: "c", routingKey: "unknown"}, ev, nil, false)
+
+	want := map[string][]string{"": {"a/cm"}, "composites": {"b/cm"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("dispatch(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestParseAPIVersion(t *testing.T) {
+	type want struct {
+		Group   string
+		Version string
+	}
+	cases := map[string]struct {
+		reason string
+		in     string
+		want   want
+	}{
+		"Empty": {
+			reason: "An empty API version should have neither group nor version.",
+		},
+		"CoreGroup": {
+			reason: "An API version without group should be of the core group.",
+			in:     "v1",
+			want:   want{Version: "v1"},
+		},
+		"Group": {
+			reason: "An API version with group should be split into group and version.",
+			in:     "apps/v1",
+			want:   want{Group: "apps", Version: "v1"},
+		},
+		"TooManySegments": {
+			reason: "An API version with more than one slash should have neither group nor version.",
+			in:     "example.org/v1/extra",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g, v := parseAPIVersion(tc.in)
+			if diff := cmp.Diff(tc.want, want{Group: g, Version: v}); diff != "" {
+				t.Errorf("\n%s\nparseAPIVersion(%q): -want, +got:\n%s", tc.reason, tc.in, diff)
+			}
+		})
+	}
+}
+
+func TestParseAPIVersionProperties(t *testing.T) {
+	segment := func(s string) string { return strings.ReplaceAll(s, "/", "") }
+
+	properties := map[string]interface{}{
+		"SingleSegment": func(s string) bool {
+			g, v := parseAPIVersion(segment(s))
+			return g == "" && v == segment(s)
+		},
+		"TwoSegments": func(group, version string) bool {
+			g, v := parseAPIVersion(segment(group) + "/" + segment(version))
+			return g == segment(group) && v == segment(version)
+		},
+		"MoreSegments": func(a, b string, rest []string) bool {
+			segs := []string{segment(a), segment(b), ""}
+			for _, s := range rest {
+				segs = append(segs, segment(s))
+			}
+			g, v := parseAPIVersion(strings.Join(segs, "/"))
+			return g == "" && v == ""
+		},
+	}
+	for name, p := range properties {
+		t.Run(name, func(t *testing.T) {
+			if err := quick.Check(p, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func Test_resourceInformers_NoGoroutineLeak(t *testing.T) {
+	const sinks, kinds = 3, 4
+
+	cases := map[string]struct {
+		reason                   string
+		useSharedInformerFactory bool
+	}{
+		"Caches": {
+			reason: "Stopping all informers and sinks should stop the goroutines of their caches and sync waiters.",
+		},
+		"SharedInformerFactory": {
+			reason:                   "Stopping all informers and sinks should stop the goroutines of their informer factory.",
+			useSharedInformerFactory: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Cleanups run last in first out, i.e. only once the test API
+			// server closed its connections.
+			running := goleak.IgnoreCurrent()
+			t.Cleanup(func() { goleak.VerifyNone(t, running) })
+
+			rc, _ := newTestAPIServer(t, kinds)
+			gvks := testGVKs(kinds)
+
+			i := &resourceInformers{
+				log:                      logging.NewNopLogger(),
+				config:                   rc,
+				resourceCaches:           make(map[gvkWithConfig]resourceCache),
+				useSharedInformerFactory: tc.useSharedInformerFactory,
+			}
+			h := handler.Funcs{}
+			q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer q.ShutDown()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if err := i.Start(ctx, h, q); err != nil {
+				t.Fatalf("Start(...): unexpected error: %v", err)
+			}
+			for s := 0; s < sinks; s++ {
+				key := fmt.Sprintf("sink-%d", s)
+				if err := i.Routed(key).Start(ctx, h, q); err != nil {
+					t.Fatalf("Routed(%q).Start(...): unexpected error: %v", key, err)
+				}
+				if err := i.WatchRoutedResources(rc, providerName, key, gvks...); err != nil {
+					t.Fatalf("WatchRoutedResources(...): unexpected error: %v", err)
+				}
+			}
+			if err := i.WatchReferencedResources(nil, schema.GroupVersionKind{}, gvks...); err != nil {
+				t.Fatalf("WatchReferencedResources(...): unexpected error: %v", err)
+			}
+			deadline := time.Now().Add(30 * time.Second)
+			for !allSynced(i) {
+				if time.Now().After(deadline) {
+					t.Fatalf("\n%s\nwant resource informers to sync", tc.reason)
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			cancel()
+			i.stopResourceInformers(providerName)
+			i.stopResourceInformers("")
+
+			for i.goroutines.Load() != 0 {
+				if time.Now().After(deadline) {
+					t.Fatalf("\n%s\nwant all informer goroutines to be released, got %d", tc.reason, i.goroutines.Load())
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		})
+	}
+}
+
+// allSynced returns true if all shared caches of the supplied resource
+// informers synced.
+func allSynced(i *resourceInformers) bool {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	for _, sc := range i.sharedCaches {
+		if !sc.synced.Load() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/audit"
+)
+
+const (
+	kindList = "List"
+
+	errListItems      = "cannot get items of List manifest"
+	errListItemName   = "items of List manifests must have a kind and name"
+	errMarshalItem    = "cannot marshal List item"
+	errGetListItem    = "cannot get List item"
+	errApplyListItem  = "cannot apply List item"
+	errDeleteListItem = "cannot delete List item"
+)
+
+// isList returns true if the supplied desired manifest is of kind List, in
+// which case every one of its items is a managed resource of the Object.
+func isList(desired *unstructured.Unstructured) bool {
+	return desired.GetKind() == kindList
+}
+
+// listItems returns the items of the supplied manifest of kind List.
+func listItems(list *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	l, err := list.ToList()
+	if err != nil {
+		return nil, errors.Wrap(err, errListItems)
+	}
+	items := make([]*unstructured.Unstructured, len(l.Items))
+	for i := range l.Items {
+		if l.Items[i].GetKind() == "" || l.Items[i].GetName() == "" {
+			return nil, errors.Errorf("%s: item %d", errListItemName, i)
+		}
+		items[i] = &l.Items[i]
+	}
+	return items, nil
+}
+
+// itemKey identifies the resource of a List item in errors.
+func itemKey(item *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s %s", item.GetKind(), types.NamespacedName{Namespace: item.GetNamespace(), Name: item.GetName()})
+}
+
+func itemStatus(item *unstructured.Unstructured) v1alpha2.ItemStatus {
+	return v1alpha2.ItemStatus{
+		APIVersion: item.GetAPIVersion(),
+		Kind:       item.GetKind(),
+		Namespace:  item.GetNamespace(),
+		Name:       item.GetName(),
+	}
+}
+
+// observeList observes the resources of the items of the supplied manifest
+// of kind List and records their state in the status of the Object. The
+// resources exist once all of them exist, and are up to date once the
+// manifests last applied to all of them are those of their items. While the
+// Object is deleted they exist as long as any of them does.
+func (c *external) observeList(ctx context.Context, cr *v1alpha2.Object, list *unstructured.Unstructured, deferred bool) (managed.ExternalObservation, error) {
+	items, err := listItems(list)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	status := make([]v1alpha2.ItemStatus, len(items))
+	anyExists, allExist, allUpToDate := false, true, true
+	for i, item := range items {
+		status[i] = itemStatus(item)
+		if i < len(cr.Status.Items) && cr.Status.Items[i].Name == status[i].Name && cr.Status.Items[i].Kind == status[i].Kind {
+			// Keep the error the item was last applied with, until it is
+			// found to be up to date.
+			status[i].Message = cr.Status.Items[i].Message
+		}
+
+		observed := item.DeepCopy()
+		err := c.client.Get(ctx, types.NamespacedName{Namespace: item.GetNamespace(), Name: item.GetName()}, observed)
+		if kerrors.IsNotFound(err) {
+			allExist, allUpToDate = false, false
+			continue
+		}
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrapf(err, "%s %s", errGetListItem, itemKey(item))
+		}
+		last, err := getLastApplied(cr, observed)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetLastApplied)
+		}
+		status[i].Exists = true
+		status[i].UpToDate = c.lastAppliedUpToDate(cr, last, item, observed)
+		if status[i].UpToDate {
+			status[i].Message = ""
+		}
+		anyExists = true
+		allUpToDate = allUpToDate && status[i].UpToDate
+	}
+	cr.Status.Items = status
+
+	switch {
+	case meta.WasDeleted(cr):
+		return managed.ExternalObservation{ResourceExists: anyExists}, nil
+	case !allExist:
+		return c.deferredObservation(deferred, managed.ExternalObservation{ResourceExists: false}), nil
+	case allUpToDate:
+		return c.upToDate(ctx, cr)
+	case immutable(cr):
+		return c.immutableObservation(ctx, cr)
+	}
+	return c.deferredObservation(deferred, managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}), nil
+}
+
+// applyList applies the resources of the items of the supplied manifest of
+// kind List in order, both when creating and updating them. Applying stops
+// at the first item that fails, whose error is recorded in its status.
+func (c *external) applyList(ctx context.Context, cr *v1alpha2.Object, list *unstructured.Unstructured, action audit.Action) error {
+	items, err := listItems(list)
+	if err != nil {
+		return err
+	}
+
+	status := make([]v1alpha2.ItemStatus, len(items))
+	for i, item := range items {
+		status[i] = itemStatus(item)
+	}
+	cr.Status.Items = status
+
+	for i, item := range items {
+		raw, err := item.MarshalJSON()
+		if err != nil {
+			return errors.Wrap(err, errMarshalItem)
+		}
+		if err := c.setOwnerReference(ctx, cr, item); err != nil {
+			return err
+		}
+		meta.AddAnnotations(item, map[string]string{
+			v1.LastAppliedConfigAnnotation: string(raw),
+		})
+
+		err = c.client.Apply(ctx, item)
+		c.logAudit(ctx, cr, item, action, nil, err)
+		if err != nil {
+			err = errors.Wrapf(CleanErr(err), "%s %s", errApplyListItem, itemKey(item))
+			status[i].Message = err.Error()
+			c.emitCloudEvent(ctx, cr, action, err)
+			return err
+		}
+		status[i].Exists, status[i].UpToDate = true, true
+	}
+	c.emitCloudEvent(ctx, cr, action, nil)
+	return nil
+}
+
+// deleteList deletes the resources of the items of the supplied manifest of
+// kind List in reverse order, so that e.g. a Namespace listed before its
+// resources is deleted last.
+func (c *external) deleteList(ctx context.Context, cr *v1alpha2.Object, list *unstructured.Unstructured) error {
+	items, err := listItems(list)
+	if err != nil {
+		return err
+	}
+	for i := len(items) - 1; i >= 0; i-- {
+		err := resource.IgnoreNotFound(c.client.Delete(ctx, items[i]))
+		c.logAudit(ctx, cr, items[i], audit.ActionDelete, nil, err)
+		if err != nil {
+			err = errors.Wrapf(err, "%s %s", errDeleteListItem, itemKey(items[i]))
+			c.emitCloudEvent(ctx, cr, audit.ActionDelete, err)
+			return err
+		}
+	}
+	c.emitCloudEvent(ctx, cr, audit.ActionDelete, nil)
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func listItem(name string) string {
+	return fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":%q,"namespace":"default"}}`, name)
+}
+
+func listObject(om ...kubernetesObjectModifier) *v1alpha2.Object {
+	return kubernetesObject(append([]kubernetesObjectModifier{func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.Manifest = runtime.RawExtension{
+			Raw: []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"List","items":[%s,%s]}`, listItem("a"), listItem("b"))),
+		}
+	}}, om...)...)
+}
+
+func TestObserveList(t *testing.T) {
+	item := func(name string, exists, upToDate bool) v1alpha2.ItemStatus {
+		return v1alpha2.ItemStatus{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: name, Exists: exists, UpToDate: upToDate}
+	}
+
+	type want struct {
+		out   managed.ExternalObservation
+		items []v1alpha2.ItemStatus
+	}
+	cases := map[string]struct {
+		reason  string
+		deleted bool
+		// lastApplied manifests of the resources of the items, by name.
+		// Resources without one do not exist.
+		lastApplied map[string]string
+		want        want
+	}{
+		"ItemMissing": {
+			reason:      "The resources should not exist until the resources of all items do.",
+			lastApplied: map[string]string{"a": listItem("a")},
+			want: want{
+				out:   managed.ExternalObservation{ResourceExists: false},
+				items: []v1alpha2.ItemStatus{item("a", true, true), item("b", false, false)},
+			},
+		},
+		"UpToDate": {
+			reason:      "The resources should be up to date if the manifests of all items were applied.",
+			lastApplied: map[string]string{"a": listItem("a"), "b": listItem("b")},
+			want: want{
+				out:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				items: []v1alpha2.ItemStatus{item("a", true, true), item("b", true, true)},
+			},
+		},
+		"ItemDrifted": {
+			reason:      "The resources should not be up to date if the manifest of any item was not applied.",
+			lastApplied: map[string]string{"a": listItem("a"), "b": listItem("c")},
+			want: want{
+				out:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				items: []v1alpha2.ItemStatus{item("a", true, true), item("b", true, false)},
+			},
+		},
+		"Deleting": {
+			reason:      "The resources should exist while the resource of any item does once the Object is deleted.",
+			deleted:     true,
+			lastApplied: map[string]string{"b": listItem("b")},
+			want: want{
+				out:   managed.ExternalObservation{ResourceExists: true},
+				items: []v1alpha2.ItemStatus{item("a", false, false), item("b", true, true)},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := listObject()
+			if tc.deleted {
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+			}
+			c := &test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					last, ok := tc.lastApplied[key.Name]
+					if !ok {
+						return kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+					}
+					u := obj.(*unstructured.Unstructured)
+					u.SetAnnotations(map[string]string{corev1.LastAppliedConfigAnnotation: last})
+					return nil
+				},
+			}
+			e := &external{
+				logger:      logging.NewNopLogger(),
+				client:      resource.ClientApplicator{Client: c},
+				localClient: c,
+			}
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.out, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.items, cr.Status.Items); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want items, +got items:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateList(t *testing.T) {
+	cr := listObject()
+	applied := []string{}
+	e := &external{
+		logger: logging.NewNopLogger(),
+		client: resource.ClientApplicator{
+			Client: &test.MockClient{},
+			Applicator: resource.ApplyFn(func(_ context.Context, obj client.Object, _ ...resource.ApplyOption) error {
+				if obj.GetName() == "b" {
+					return errBoom
+				}
+				applied = append(applied, obj.GetName())
+				return nil
+			}),
+		},
+	}
+
+	_, err := e.Create(context.Background(), cr)
+	wantErr := "cannot apply List item ConfigMap default/b: boom"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("e.Create(...): want error %q, got %v", wantErr, err)
+	}
+	if diff := cmp.Diff([]string{"a"}, applied); diff != "" {
+		t.Errorf("e.Create(...): -want applied, +got applied:\n%s", diff)
+	}
+	want := []v1alpha2.ItemStatus{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "a", Exists: true, UpToDate: true},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "b", Message: wantErr},
+	}
+	if diff := cmp.Diff(want, cr.Status.Items); diff != "" {
+		t.Errorf("e.Create(...): -want items, +got items:\n%s", diff)
+	}
+}
+
+func TestDeleteList(t *testing.T) {
+	deleted := []string{}
+	e := &external{
+		logger: logging.NewNopLogger(),
+		client: resource.ClientApplicator{Client: &test.MockClient{
+			MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+				deleted = append(deleted, obj.GetName())
+				return kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, obj.GetName())
+			},
+		}},
+	}
+
+	if err := e.Delete(context.Background(), listObject()); err != nil {
+		t.Fatalf("e.Delete(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"b", "a"}, deleted); diff != "" {
+		t.Errorf("e.Delete(...): items should be deleted in reverse order, -want, +got:\n%s", diff)
+	}
+}
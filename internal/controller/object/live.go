@@ -0,0 +1,274 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+)
+
+const (
+	errResourceAlreadyOwned = "ResourceAlreadyOwned: %s %q already exists on the target cluster and is controlled by %s %q"
+
+	// liveCacheTTL is how long the state of a resource on a target cluster
+	// is reused for the admission of further Objects.
+	liveCacheTTL = 5 * time.Second
+)
+
+// A LiveValidator rejects Objects whose resource already exists on the
+// target cluster and is controlled by something else than the Object. Objects
+// whose resource already exists otherwise are admitted with a warning, as
+// they adopt it. Objects whose target cluster cannot be reached are admitted
+// with a warning, too.
+type LiveValidator struct {
+	client              client.Client
+	clientForProviderFn func(ctx context.Context, local client.Client, providerConfigName string) (client.Client, *rest.Config, error)
+	now                 func() time.Time
+
+	lock  sync.Mutex
+	cache map[liveKey]liveEntry
+}
+
+type liveKey struct {
+	providerConfig string
+	gvk            schema.GroupVersionKind
+	name           types.NamespacedName
+}
+
+type liveEntry struct {
+	fetched time.Time
+	// observed is nil if the resource does not exist.
+	observed *unstructured.Unstructured
+}
+
+var _ admission.CustomValidator = &LiveValidator{}
+
+// NewLiveValidator returns a LiveValidator that reads ProviderConfigs using
+// the supplied client.
+func NewLiveValidator(c client.Client) *LiveValidator {
+	return &LiveValidator{
+		client:              c,
+		clientForProviderFn: kube.ClientForProvider,
+		now:                 time.Now,
+		cache:               make(map[liveKey]liveEntry),
+	}
+}
+
+// ValidateCreate validates an Object against the state of its resource on
+// the target cluster.
+func (v *LiveValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr, ok := obj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	return v.validateLive(ctx, cr)
+}
+
+// ValidateUpdate validates an Object that changes its resource or target
+// cluster against the state of the new resource on the target cluster.
+func (v *LiveValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	cr, ok := newObj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	ok, err := sameResource(old, cr)
+	if err != nil || ok {
+		// Invalid manifests are reported by the reconciler.
+		return nil, nil
+	}
+	return v.validateLive(ctx, cr)
+}
+
+// ValidateDelete never rejects an Object.
+func (v *LiveValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *LiveValidator) validateLive(ctx context.Context, cr *v1alpha2.Object) (admission.Warnings, error) {
+	if !managesResource(cr) {
+		return nil, nil
+	}
+	desired, err := getDesired(cr)
+	if err != nil {
+		// Invalid manifests are reported by the reconciler.
+		return nil, nil
+	}
+	key := liveKey{
+		providerConfig: providerConfigName(cr),
+		gvk:            desired.GroupVersionKind(),
+		name:           types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()},
+	}
+	observed, err := v.observe(ctx, key)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("cannot validate %s %q against the target cluster: %s", key.gvk.Kind, key.name, err)}, nil
+	}
+	if observed == nil {
+		return nil, nil
+	}
+	if ref := metav1.GetControllerOf(observed); ref != nil {
+		if isControlledBy(ref, cr) {
+			return nil, nil
+		}
+		return nil, errors.Errorf(errResourceAlreadyOwned, key.gvk.Kind, key.name, ref.Kind, ref.Name)
+	}
+	return admission.Warnings{fmt.Sprintf("%s %q already exists on the target cluster and will be adopted by the Object", key.gvk.Kind, key.name)}, nil
+}
+
+// observe returns the resource of the supplied key as found on the target
+// cluster, or nil if it does not exist. Results are cached for liveCacheTTL.
+func (v *LiveValidator) observe(ctx context.Context, key liveKey) (*unstructured.Unstructured, error) {
+	now := v.now()
+	v.lock.Lock()
+	e, ok := v.cache[key]
+	v.lock.Unlock()
+	if ok && now.Sub(e.fetched) < liveCacheTTL {
+		return e.observed, nil
+	}
+
+	k, _, err := v.clientForProviderFn(ctx, v.client, key.providerConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewKubernetesClient)
+	}
+	observed := &unstructured.Unstructured{}
+	observed.SetGroupVersionKind(key.gvk)
+	err = k.Get(ctx, key.name, observed)
+	if kerrors.IsNotFound(err) {
+		observed = nil
+		err = nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errGetObject)
+	}
+
+	v.lock.Lock()
+	for k, e := range v.cache {
+		if now.Sub(e.fetched) >= liveCacheTTL {
+			delete(v.cache, k)
+		}
+	}
+	v.cache[key] = liveEntry{fetched: now, observed: observed}
+	v.lock.Unlock()
+	return observed, nil
+}
+
+// managesResource returns false for Objects that only observe their
+// resource, which may well be controlled by something else.
+func managesResource(cr *v1alpha2.Object) bool {
+	if len(cr.Spec.ManagementPolicies) == 0 {
+		return true
+	}
+	for _, p := range cr.Spec.ManagementPolicies {
+		if p == xpv1.ManagementActionAll || p == xpv1.ManagementActionCreate || p == xpv1.ManagementActionUpdate {
+			return true
+		}
+	}
+	return false
+}
+
+// isControlledBy returns true if the supplied controller reference points to
+// the supplied Object.
+func isControlledBy(ref *metav1.OwnerReference, cr *v1alpha2.Object) bool {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return false
+	}
+	return gv.Group == v1alpha2.Group && ref.Kind == v1alpha2.ObjectKind && ref.Name == cr.GetName()
+}
+
+// sameResource returns true if both Objects manage the same resource on the
+// same cluster.
+func sameResource(a, b *v1alpha2.Object) (bool, error) {
+	da, err := getDesired(a)
+	if err != nil {
+		return false, err
+	}
+	db, err := getDesired(b)
+	if err != nil {
+		return false, err
+	}
+	return providerConfigName(a) == providerConfigName(b) &&
+		da.GroupVersionKind() == db.GroupVersionKind() &&
+		da.GetNamespace() == db.GetNamespace() &&
+		da.GetName() == db.GetName(), nil
+}
+
+// Validators runs several validators, admitting an Object only if all of
+// them do. The warnings of all validators are returned.
+type Validators []admission.CustomValidator
+
+var _ admission.CustomValidator = Validators{}
+
+// ValidateCreate runs ValidateCreate of all validators.
+func (vs Validators) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	var ws admission.Warnings
+	for _, v := range vs {
+		w, err := v.ValidateCreate(ctx, obj)
+		ws = append(ws, w...)
+		if err != nil {
+			return ws, err
+		}
+	}
+	return ws, nil
+}
+
+// ValidateUpdate runs ValidateUpdate of all validators.
+func (vs Validators) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	var ws admission.Warnings
+	for _, v := range vs {
+		w, err := v.ValidateUpdate(ctx, oldObj, newObj)
+		ws = append(ws, w...)
+		if err != nil {
+			return ws, err
+		}
+	}
+	return ws, nil
+}
+
+// ValidateDelete runs ValidateDelete of all validators.
+func (vs Validators) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	var ws admission.Warnings
+	for _, v := range vs {
+		w, err := v.ValidateDelete(ctx, obj)
+		ws = append(ws, w...)
+		if err != nil {
+			return ws, err
+		}
+	}
+	return ws, nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestLiveValidator(t *testing.T) {
+	controlledBy := func(apiVersion, kind, name string) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: apiVersion, Kind: kind, Name: name, Controller: ptr.To(true)}})
+			return nil
+		}
+	}
+
+	type want struct {
+		warnings bool
+		err      error
+	}
+	cases := map[string]struct {
+		reason string
+		obj    *v1alpha2.Object
+		get    test.MockGetFn
+		want   want
+	}{
+		"ObserveOnly": {
+			reason: "We should not check the resources of Objects that only observe them.",
+			obj: kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionObserve}
+			}),
+		},
+		"NotFound": {
+			reason: "We should admit Objects whose resource does not exist yet.",
+			obj:    kubernetesObject(),
+			get:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, externalResourceName)),
+		},
+		"Unreachable": {
+			reason: "We should admit Objects with a warning if their resource cannot be checked.",
+			obj:    kubernetesObject(),
+			get:    test.NewMockGetFn(errBoom),
+			want: want{
+				warnings: true,
+			},
+		},
+		"Adopt": {
+			reason: "We should admit Objects with a warning if their resource exists without a controller.",
+			obj:    kubernetesObject(),
+			get:    test.NewMockGetFn(nil),
+			want: want{
+				warnings: true,
+			},
+		},
+		"ControlledByObject": {
+			reason: "We should admit Objects whose resource is controlled by them.",
+			obj:    kubernetesObject(),
+			get:    controlledBy(v1alpha2.SchemeGroupVersion.String(), v1alpha2.ObjectKind, testObjectName),
+		},
+		"AlreadyOwned": {
+			reason: "We should reject Objects whose resource is controlled by something else.",
+			obj:    kubernetesObject(),
+			get:    controlledBy("apps/v1", "Deployment", "web"),
+			want: want{
+				err: errors.Errorf(errResourceAlreadyOwned, "Namespace", "/"+externalResourceName, "Deployment", "web"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := &LiveValidator{
+				clientForProviderFn: func(_ context.Context, _ client.Client, _ string) (client.Client, *rest.Config, error) {
+					return &test.MockClient{MockGet: tc.get}, nil, nil
+				},
+				now:   time.Now,
+				cache: make(map[liveKey]liveEntry),
+			}
+			w, err := v.ValidateCreate(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nv.ValidateCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if got := len(w) > 0; got != tc.want.warnings {
+				t.Errorf("\n%s\nv.ValidateCreate(...): want warnings %t, got %v", tc.reason, tc.want.warnings, w)
+			}
+		})
+	}
+}
+
+func TestLiveValidatorCache(t *testing.T) {
+	now := time.Now()
+	gets := 0
+	v := &LiveValidator{
+		clientForProviderFn: func(_ context.Context, _ client.Client, _ string) (client.Client, *rest.Config, error) {
+			return &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, _ client.Object) error {
+				gets++
+				return kerrors.NewNotFound(schema.GroupResource{}, externalResourceName)
+			}}, nil, nil
+		},
+		now:   func() time.Time { return now },
+		cache: make(map[liveKey]liveEntry),
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := v.ValidateCreate(context.Background(), kubernetesObject()); err != nil {
+			t.Fatalf("v.ValidateCreate(...): unexpected error: %v", err)
+		}
+	}
+	if gets != 1 {
+		t.Errorf("want 1 GET within the cache TTL, got %d", gets)
+	}
+
+	now = now.Add(liveCacheTTL)
+	if _, err := v.ValidateCreate(context.Background(), kubernetesObject()); err != nil {
+		t.Fatalf("v.ValidateCreate(...): unexpected error: %v", err)
+	}
+	if gets != 2 {
+		t.Errorf("want another GET once the cache TTL elapsed, got %d", gets)
+	}
+}
+
+func TestValidators(t *testing.T) {
+	admit := &mockValidator{warnings: admission.Warnings{"a"}}
+	reject := &mockValidator{warnings: admission.Warnings{"b"}, err: errBoom}
+
+	w, err := Validators{admit, reject, admit}.ValidateCreate(context.Background(), kubernetesObject())
+	if diff := cmp.Diff(errBoom, err, test.EquateErrors()); diff != "" {
+		t.Errorf("ValidateCreate(...): -want error, +got error:\n%s", diff)
+	}
+	if diff := cmp.Diff(admission.Warnings{"a", "b"}, w); diff != "" {
+		t.Errorf("ValidateCreate(...): -want warnings, +got warnings:\n%s", diff)
+	}
+}
+
+type mockValidator struct {
+	admission.CustomValidator
+	warnings admission.Warnings
+	err      error
+}
+
+func (v *mockValidator) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return v.warnings, v.err
+}
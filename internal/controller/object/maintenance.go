@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const errParseMaintenanceSchedule = "cannot parse maintenance window schedule"
+
+// maintenanceWindowAt returns whether the supplied maintenance window is open
+// at the supplied time. If it is not, it also returns when it opens next.
+// Schedules are evaluated in the location of the supplied time unless they
+// are prefixed with a time zone.
+func maintenanceWindowAt(w *v1alpha2.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	s, err := cron.ParseStandard(w.Schedule)
+	if err != nil {
+		return false, time.Time{}, errors.Wrap(err, errParseMaintenanceSchedule)
+	}
+	// The window is open if it started within its duration before now.
+	if start := s.Next(now.Add(-w.Duration.Duration)); !start.After(now) {
+		return true, time.Time{}, nil
+	}
+	return false, s.Next(now), nil
+}
+
+// deferToMaintenanceWindow returns true if the managed resource of the
+// supplied Object must not be changed at the supplied time, because it is
+// outside of the Object's maintenance window. It updates the
+// OutsideMaintenanceWindow condition of the Object accordingly. Changes of
+// deleted Objects are never deferred.
+func deferToMaintenanceWindow(cr *v1alpha2.Object, now time.Time) (bool, error) {
+	open := true
+	var next time.Time
+	if w := cr.Spec.MaintenanceWindow; w != nil && !meta.WasDeleted(cr) {
+		var err error
+		if open, next, err = maintenanceWindowAt(w, now); err != nil {
+			return false, err
+		}
+	}
+	if !open {
+		cr.SetConditions(v1alpha2.OutsideMaintenanceWindow(next))
+		return true, nil
+	}
+	if cr.GetCondition(v1alpha2.TypeOutsideMaintenanceWindow).Status == corev1.ConditionTrue {
+		cr.SetConditions(v1alpha2.InsideMaintenanceWindow())
+	}
+	return false, nil
+}
+
+// deferredObservation returns an observation of an up to date resource if
+// creating or updating the managed resource is deferred to the maintenance
+// window, otherwise the supplied one.
+func (c *external) deferredObservation(deferred bool, obs managed.ExternalObservation) managed.ExternalObservation {
+	if !deferred {
+		return obs
+	}
+	c.logger.Debug("Deferring changes to the maintenance window", "resourceExists", obs.ResourceExists)
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}
+}
+
+// untilMaintenanceWindow returns how long the supplied Object waits for its
+// next maintenance window, if it is outside of it.
+func untilMaintenanceWindow(cr *v1alpha2.Object, now time.Time) (time.Duration, bool) {
+	if cr.Spec.MaintenanceWindow == nil || cr.GetCondition(v1alpha2.TypeOutsideMaintenanceWindow).Status != corev1.ConditionTrue {
+		return 0, false
+	}
+	open, next, err := maintenanceWindowAt(cr.Spec.MaintenanceWindow, now)
+	if err != nil || open {
+		return 0, false
+	}
+	return next.Sub(now), true
+}
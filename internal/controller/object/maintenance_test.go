@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestDeferToMaintenanceWindow(t *testing.T) {
+	// A Sunday.
+	sunday := time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC)
+	sundays := func(schedule string) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.Spec.MaintenanceWindow = &v1alpha2.MaintenanceWindow{Schedule: schedule, Duration: metav1.Duration{Duration: 2 * time.Hour}}
+		}
+	}
+	outside := func(obj *v1alpha2.Object) {
+		obj.SetConditions(v1alpha2.OutsideMaintenanceWindow(sunday))
+	}
+	deleted := func(obj *v1alpha2.Object) {
+		obj.SetDeletionTimestamp(&metav1.Time{Time: sunday})
+	}
+
+	type args struct {
+		cr  *v1alpha2.Object
+		now time.Time
+	}
+	type want struct {
+		deferred   bool
+		err        bool
+		conditions []xpv1.Condition
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoWindow": {
+			reason: "We should never defer changes of Objects without a maintenance window.",
+			args: args{
+				cr:  kubernetesObject(),
+				now: sunday,
+			},
+		},
+		"WindowRemoved": {
+			reason: "We should clear the condition once the maintenance window is removed.",
+			args: args{
+				cr:  kubernetesObject(outside),
+				now: sunday,
+			},
+			want: want{
+				conditions: []xpv1.Condition{v1alpha2.InsideMaintenanceWindow()},
+			},
+		},
+		"InvalidSchedule": {
+			reason: "We should return an error if the schedule cannot be parsed.",
+			args: args{
+				cr:  kubernetesObject(sundays("every sunday")),
+				now: sunday,
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"BeforeWindow": {
+			reason: "We should defer changes until the next window starts.",
+			args: args{
+				cr:  kubernetesObject(sundays("0 2 * * 0")),
+				now: sunday.Add(time.Hour),
+			},
+			want: want{
+				deferred:   true,
+				conditions: []xpv1.Condition{v1alpha2.OutsideMaintenanceWindow(sunday.Add(2 * time.Hour))},
+			},
+		},
+		"WindowStarts": {
+			reason: "We should not defer changes once the window starts.",
+			args: args{
+				cr:  kubernetesObject(sundays("0 2 * * 0"), outside),
+				now: sunday.Add(2 * time.Hour),
+			},
+			want: want{
+				conditions: []xpv1.Condition{v1alpha2.InsideMaintenanceWindow()},
+			},
+		},
+		"InsideWindow": {
+			reason: "We should not defer changes during the window.",
+			args: args{
+				cr:  kubernetesObject(sundays("0 2 * * 0")),
+				now: sunday.Add(3*time.Hour + 59*time.Minute),
+			},
+		},
+		"WindowEnded": {
+			reason: "We should defer changes to the next week once the window ended.",
+			args: args{
+				cr:  kubernetesObject(sundays("0 2 * * 0")),
+				now: sunday.Add(4 * time.Hour),
+			},
+			want: want{
+				deferred:   true,
+				conditions: []xpv1.Condition{v1alpha2.OutsideMaintenanceWindow(sunday.Add(7*24*time.Hour + 2*time.Hour))},
+			},
+		},
+		"TimeZone": {
+			reason: "We should evaluate schedules in the time zone they are prefixed with.",
+			args: args{
+				cr:  kubernetesObject(sundays("CRON_TZ=Europe/Berlin 0 2 * * 0")),
+				now: sunday.Add(3 * time.Hour),
+			},
+			want: want{
+				deferred:   true,
+				conditions: []xpv1.Condition{v1alpha2.OutsideMaintenanceWindow(sunday.Add(7*24*time.Hour + time.Hour))},
+			},
+		},
+		"Deleted": {
+			reason: "We should not defer the deletion of an Object.",
+			args: args{
+				cr:  kubernetesObject(sundays("0 2 * * 0"), deleted),
+				now: sunday,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			deferred, err := deferToMaintenanceWindow(tc.args.cr, tc.args.now)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\ndeferToMaintenanceWindow(...): want error: %t, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if deferred != tc.want.deferred {
+				t.Errorf("\n%s\ndeferToMaintenanceWindow(...): want deferred %t, got %t", tc.reason, tc.want.deferred, deferred)
+			}
+			if diff := cmp.Diff(tc.want.conditions, tc.args.cr.Status.Conditions, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\ndeferToMaintenanceWindow(...): -want conditions, +got conditions:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
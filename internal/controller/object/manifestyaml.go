@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/manifest"
+)
+
+// A ManifestValidator rejects Objects that do not set exactly one of
+// manifest, manifestYAML and manifestURL, whose manifestYAML is not a plain
+// YAML document, or whose manifestURL is not an HTTPS URL.
+type ManifestValidator struct {
+	allowHTTP bool
+}
+
+var _ admission.CustomValidator = &ManifestValidator{}
+
+// NewManifestValidator returns a ManifestValidator. Plain HTTP manifest URLs
+// are accepted if allowHTTP is true, e.g. for development.
+func NewManifestValidator(allowHTTP bool) *ManifestValidator {
+	return &ManifestValidator{allowHTTP: allowHTTP}
+}
+
+// ValidateCreate rejects an Object with an invalid manifest.
+func (v *ManifestValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr, ok := obj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	return nil, manifest.Validate(cr.Spec.ForProvider, v.allowHTTP)
+}
+
+// ValidateUpdate rejects an Object with an invalid manifest.
+func (v *ManifestValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+// ValidateDelete does nothing.
+func (v *ManifestValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// informerLimitExceeded counts the resource watches that could not be
+// started because the limit of resource informers was reached.
+var informerLimitExceeded = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "provider_kubernetes_informer_limit_exceeded_total",
+	Help: "The number of resource watches that were not started because the limit of resource informers was reached.",
+})
+
+// applyDuration observes how long creating or applying managed resources
+// takes, including the admission webhooks of the target cluster.
+var applyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "provider_kubernetes_apply_duration_seconds",
+	Help:    "The duration of creating or applying managed resources, by GVK.",
+	Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+}, []string{"groupVersion", "kind"})
+
+// goroutinesActive is the number of goroutines run by resource informers.
+var goroutinesActive = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "provider_kubernetes_goroutines_active",
+	Help: "The number of goroutines run by resource informers.",
+})
+
+// reconcileTimeouts counts the reconciles of Objects that exceeded their
+// reconcile timeout.
+var reconcileTimeouts = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "provider_kubernetes_reconcile_timeouts_total",
+	Help: "The number of reconciles of Objects that exceeded their reconcile timeout.",
+})
+
+func init() {
+	metrics.Registry.MustRegister(informerLimitExceeded, applyDuration, goroutinesActive, reconcileTimeouts)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/mutatingpolicy/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errNotMutatingPolicy     = "managed resource is not a MutatingPolicy"
+	errListMutatingPolicies  = "cannot list MutatingPolicies"
+	errApplyMutatingPolicy   = "cannot apply MutatingPolicy"
+	errInvalidObjectSelector = "invalid objectSelector"
+	errInvalidPatch          = "invalid patch"
+)
+
+// applyMutatingPolicies patches the desired manifest of the supplied Object
+// with the MutatingPolicies selecting it, in the order of their priority. It
+// returns whether any policy was applied.
+func (c *external) applyMutatingPolicies(ctx context.Context, cr *v1alpha2.Object, desired *unstructured.Unstructured) (bool, error) {
+	if c.policies == nil {
+		return false, nil
+	}
+	l := &v1alpha1.MutatingPolicyList{}
+	if err := c.policies.List(ctx, l); err != nil {
+		return false, errors.Wrap(err, errListMutatingPolicies)
+	}
+
+	policies := make([]v1alpha1.MutatingPolicy, 0, len(l.Items))
+	for _, p := range l.Items {
+		if policySelects(p, cr, desired) {
+			policies = append(policies, p)
+		}
+	}
+	if len(policies) == 0 {
+		return false, nil
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].Spec.Priority != policies[j].Spec.Priority {
+			return policies[i].Spec.Priority < policies[j].Spec.Priority
+		}
+		return policies[i].GetName() < policies[j].GetName()
+	})
+
+	doc, err := desired.MarshalJSON()
+	if err != nil {
+		return false, errors.Wrap(err, errMarshalEffective)
+	}
+	for _, p := range policies {
+		patch, err := decodePatch(p.Spec.Patches)
+		if err != nil {
+			return false, errors.Wrapf(err, "%s %q", errApplyMutatingPolicy, p.GetName())
+		}
+		if doc, err = patch.Apply(doc); err != nil {
+			return false, errors.Wrapf(err, "%s %q", errApplyMutatingPolicy, p.GetName())
+		}
+	}
+	u := &unstructured.Unstructured{}
+	if err := u.UnmarshalJSON(doc); err != nil {
+		return false, errors.Wrap(err, errUnmarshalTemplate)
+	}
+	desired.Object = u.Object
+	return true, nil
+}
+
+// policySelects returns whether the supplied policy patches the desired
+// manifest of the supplied Object.
+func policySelects(p v1alpha1.MutatingPolicy, cr *v1alpha2.Object, desired *unstructured.Unstructured) bool {
+	gvk, t := desired.GroupVersionKind(), p.Spec.TargetGVK
+	if gvk.Group != t.Group || gvk.Kind != t.Kind || (t.Version != "" && gvk.Version != t.Version) {
+		return false
+	}
+	if p.Spec.ObjectSelector == nil {
+		return true
+	}
+	sel, err := metav1.LabelSelectorAsSelector(p.Spec.ObjectSelector)
+	if err != nil {
+		// Rejected by the MutatingPolicyValidator.
+		return false
+	}
+	return sel.Matches(labels.Set(cr.GetLabels()))
+}
+
+func decodePatch(ops []v1alpha1.JSONPatchOperation) (jsonpatch.Patch, error) {
+	b, err := json.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+	return jsonpatch.DecodePatch(b)
+}
+
+// A MutatingPolicyValidator validates the JSON Patch operations and the
+// object selector of MutatingPolicies.
+type MutatingPolicyValidator struct{}
+
+var _ admission.CustomValidator = &MutatingPolicyValidator{}
+
+// NewMutatingPolicyValidator returns a MutatingPolicyValidator.
+func NewMutatingPolicyValidator() *MutatingPolicyValidator {
+	return &MutatingPolicyValidator{}
+}
+
+// ValidateCreate rejects a MutatingPolicy with invalid patches.
+func (v *MutatingPolicyValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, validateMutatingPolicy(obj)
+}
+
+// ValidateUpdate rejects a MutatingPolicy with invalid patches.
+func (v *MutatingPolicyValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, validateMutatingPolicy(newObj)
+}
+
+// ValidateDelete does nothing.
+func (v *MutatingPolicyValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateMutatingPolicy(obj runtime.Object) error {
+	p, ok := obj.(*v1alpha1.MutatingPolicy)
+	if !ok {
+		return errors.New(errNotMutatingPolicy)
+	}
+	if p.Spec.ObjectSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(p.Spec.ObjectSelector); err != nil {
+			return errors.Wrap(err, errInvalidObjectSelector)
+		}
+	}
+	for i, op := range p.Spec.Patches {
+		if err := validatePatchOperation(op); err != nil {
+			return errors.Wrapf(err, "%s %d", errInvalidPatch, i)
+		}
+	}
+	_, err := decodePatch(p.Spec.Patches)
+	return errors.Wrap(err, errInvalidPatch)
+}
+
+func validatePatchOperation(op v1alpha1.JSONPatchOperation) error {
+	if _, err := parseJSONPointer(op.Path); err != nil {
+		return err
+	}
+	switch op.Op {
+	case "move", "copy":
+		if _, err := parseJSONPointer(op.From); err != nil {
+			return errors.Wrapf(err, "%s requires from", op.Op)
+		}
+	case "add", "replace", "test":
+		if op.Value == nil || len(op.Value.Raw) == 0 {
+			return errors.Errorf("%s requires a value", op.Op)
+		}
+	}
+	return nil
+}
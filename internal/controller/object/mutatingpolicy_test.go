@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/mutatingpolicy/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func mutatingPolicy(name string, priority int32, kind string, selector *metav1.LabelSelector, ops ...v1alpha1.JSONPatchOperation) v1alpha1.MutatingPolicy {
+	return v1alpha1.MutatingPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.MutatingPolicySpec{
+			TargetGVK:      v1alpha1.GroupVersionKind{Group: "apps", Kind: kind},
+			ObjectSelector: selector,
+			Patches:        ops,
+			Priority:       priority,
+		},
+	}
+}
+
+func addOp(path, value string) v1alpha1.JSONPatchOperation {
+	return v1alpha1.JSONPatchOperation{Op: "add", Path: path, Value: &runtime.RawExtension{Raw: []byte(value)}}
+}
+
+func TestApplyMutatingPolicies(t *testing.T) {
+	manifest := `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "app"}, "spec": {"replicas": 1}}`
+
+	type want struct {
+		mutated  bool
+		manifest string
+		err      bool
+	}
+	cases := map[string]struct {
+		reason   string
+		policies []v1alpha1.MutatingPolicy
+		labels   map[string]string
+		want     want
+	}{
+		"NoPolicies": {
+			reason: "We should not change a manifest if there are no policies.",
+			want: want{
+				manifest: manifest,
+			},
+		},
+		"OtherKind": {
+			reason:   "We should not apply policies targeting another kind.",
+			policies: []v1alpha1.MutatingPolicy{mutatingPolicy("p", 0, "StatefulSet", nil, addOp("/spec/paused", "true"))},
+			want: want{
+				manifest: manifest,
+			},
+		},
+		"NotSelected": {
+			reason: "We should not apply policies whose selector does not match the labels of the Object.",
+			policies: []v1alpha1.MutatingPolicy{
+				mutatingPolicy("p", 0, "Deployment", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}, addOp("/spec/paused", "true")),
+			},
+			labels: map[string]string{"team": "b"},
+			want: want{
+				manifest: manifest,
+			},
+		},
+		"OrderedByPriority": {
+			reason: "We should apply the matching policies by priority, then by name.",
+			policies: []v1alpha1.MutatingPolicy{
+				mutatingPolicy("c", 10, "Deployment", nil, addOp("/spec/paused", "false")),
+				mutatingPolicy("b", 0, "Deployment", &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}, addOp("/spec/replicas", "2")),
+				mutatingPolicy("a", 0, "Deployment", nil, addOp("/spec/replicas", "5"), addOp("/spec/paused", "true")),
+			},
+			labels: map[string]string{"team": "a"},
+			want: want{
+				mutated:  true,
+				manifest: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "app"}, "spec": {"replicas": 2, "paused": false}}`,
+			},
+		},
+		"TestFailed": {
+			reason: "We should return an error if a policy cannot be applied.",
+			policies: []v1alpha1.MutatingPolicy{
+				mutatingPolicy("p", 0, "Deployment", nil, v1alpha1.JSONPatchOperation{Op: "test", Path: "/spec/replicas", Value: &runtime.RawExtension{Raw: []byte("2")}}),
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger: logging.NewNopLogger(),
+				policies: &test.MockClient{
+					MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+						list.(*v1alpha1.MutatingPolicyList).Items = tc.policies
+						return nil
+					},
+				},
+			}
+			cr := &v1alpha2.Object{}
+			cr.SetLabels(tc.labels)
+			desired := &unstructured.Unstructured{}
+			if err := json.Unmarshal([]byte(manifest), &desired.Object); err != nil {
+				t.Fatalf("cannot parse test manifest: %v", err)
+			}
+
+			mutated, err := e.applyMutatingPolicies(context.Background(), cr, desired)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\napplyMutatingPolicies(...): want error: %t, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if mutated != tc.want.mutated {
+				t.Errorf("\n%s\napplyMutatingPolicies(...): want mutated: %t, got: %t", tc.reason, tc.want.mutated, mutated)
+			}
+			want := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(tc.want.manifest), &want); err != nil {
+				t.Fatalf("cannot parse wanted manifest: %v", err)
+			}
+			if diff := cmp.Diff(want, desired.Object); diff != "" {
+				t.Errorf("\n%s\napplyMutatingPolicies(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateMutatingPolicy(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		ops    []v1alpha1.JSONPatchOperation
+		sel    *metav1.LabelSelector
+		err    bool
+	}{
+		"Valid": {
+			reason: "We should accept valid patches.",
+			ops: []v1alpha1.JSONPatchOperation{
+				addOp("/spec/template/spec/containers/-", `{"name": "sidecar"}`),
+				{Op: "remove", Path: "/metadata/annotations/example.org~1a"},
+				{Op: "copy", From: "/metadata/labels", Path: "/spec/template/metadata/labels"},
+			},
+		},
+		"InvalidPath": {
+			reason: "We should reject paths that are not JSON Pointers.",
+			ops:    []v1alpha1.JSONPatchOperation{addOp("spec/replicas", "1")},
+			err:    true,
+		},
+		"MissingFrom": {
+			reason: "We should reject move and copy operations without from.",
+			ops:    []v1alpha1.JSONPatchOperation{{Op: "move", Path: "/spec/replicas"}},
+			err:    true,
+		},
+		"MissingValue": {
+			reason: "We should reject add, replace and test operations without a value.",
+			ops:    []v1alpha1.JSONPatchOperation{{Op: "replace", Path: "/spec/replicas"}},
+			err:    true,
+		},
+		"InvalidSelector": {
+			reason: "We should reject invalid object selectors.",
+			ops:    []v1alpha1.JSONPatchOperation{addOp("/spec/replicas", "1")},
+			sel: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: "Near"},
+			}},
+			err: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := mutatingPolicy("p", 0, "Deployment", tc.sel, tc.ops...)
+			_, err := NewMutatingPolicyValidator().ValidateCreate(context.Background(), &p)
+			if (err != nil) != tc.err {
+				t.Errorf("\n%s\nValidateCreate(...): want error: %t, got error: %v", tc.reason, tc.err, err)
+			}
+		})
+	}
+}
+
+func TestObserveMutatingPolicyChanged(t *testing.T) {
+	labeled := func(team string) string {
+		return `{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"crossplane-system","labels":{"team":"` + team + `"}}}`
+	}
+	policy := func(team string) v1alpha1.MutatingPolicy {
+		p := mutatingPolicy("team", 0, "Namespace", nil, addOp("/metadata/labels", `{"team":"`+team+`"}`))
+		p.Spec.TargetGVK.Group = ""
+		return p
+	}
+
+	// The resource never changes, only the policies matching it do.
+	var policies []v1alpha1.MutatingPolicy
+	lastApplied := string(externalResourceRaw)
+	c := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			u := externalResourceWithLastAppliedConfigAnnotation(lastApplied)
+			u.SetResourceVersion("42")
+			*obj.(*unstructured.Unstructured) = *u
+			return nil
+		}),
+		MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			list.(*v1alpha1.MutatingPolicyList).Items = policies
+			return nil
+		},
+		MockPatch: test.NewMockPatchFn(nil),
+	}
+	e := &external{
+		logger:      logging.NewNopLogger(),
+		client:      resource.ClientApplicator{Client: c},
+		localClient: c,
+		policies:    c,
+	}
+	cr := kubernetesObject()
+
+	steps := []struct {
+		reason      string
+		policies    []v1alpha1.MutatingPolicy
+		lastApplied string
+		upToDate    bool
+	}{
+		{reason: "The resource should be up to date without policies.", lastApplied: string(externalResourceRaw), upToDate: true},
+		{reason: "Adding a matching policy should make the resource drift.", policies: []v1alpha1.MutatingPolicy{policy("a")}, lastApplied: string(externalResourceRaw)},
+		{reason: "The resource should be up to date once the mutated manifest was applied.", policies: []v1alpha1.MutatingPolicy{policy("a")}, lastApplied: labeled("a"), upToDate: true},
+		{reason: "Editing a matching policy should make the resource drift.", policies: []v1alpha1.MutatingPolicy{policy("b")}, lastApplied: labeled("a")},
+		{reason: "Removing the matching policy should make the resource drift.", lastApplied: labeled("a")},
+	}
+	for _, s := range steps {
+		policies, lastApplied = s.policies, s.lastApplied
+		got, err := e.Observe(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("\n%s\ne.Observe(...): unexpected error: %v", s.reason, err)
+		}
+		if got.ResourceUpToDate != s.upToDate {
+			t.Errorf("\n%s\ne.Observe(...): want up to date %t, got %t", s.reason, s.upToDate, got.ResourceUpToDate)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+)
+
+const (
+	errGetNamespace      = "cannot get namespace"
+	errCreateNamespace   = "cannot create namespace"
+	errDeleteNamespace   = "cannot delete namespace"
+	errListObjects       = "cannot list Objects"
+	errNoClusterIdentity = "cannot tell the cluster of other ProviderConfigs"
+
+	// LabelKeyManagedNamespace marks namespaces created by the provider for
+	// Objects with autoCreateNamespace. They are deleted together with the
+	// last Object targeting them.
+	LabelKeyManagedNamespace = "provider-kubernetes.crossplane.io/managed-namespace"
+)
+
+// ensureNamespace creates the namespace of the supplied manifest on the
+// target cluster if it does not exist yet.
+func (c *external) ensureNamespace(ctx context.Context, name string) error {
+	ns := &corev1.Namespace{}
+	err := c.client.Get(ctx, types.NamespacedName{Name: name}, ns)
+	if err == nil {
+		return nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return errors.Wrap(err, errGetNamespace)
+	}
+
+	ns = &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{LabelKeyManagedNamespace: "true"},
+		},
+	}
+	c.logger.Debug("Creating namespace", "namespace", name)
+	if err := c.client.Create(ctx, ns); err != nil && !kerrors.IsAlreadyExists(err) {
+		return errors.Wrap(err, errCreateNamespace)
+	}
+	return nil
+}
+
+// cleanupNamespace deletes the namespace targeted by the supplied Object if
+// the provider created it and no other Object targets it anymore. Objects of
+// any ProviderConfig pointing to the same cluster count, see
+// kube.ClusterIdentity. Objects that are being deleted themselves do not keep
+// the namespace. The namespace is kept if it cannot be told whether another
+// Object still targets it.
+func (c *external) cleanupNamespace(ctx context.Context, cr *v1alpha2.Object, name string) error {
+	ns := &corev1.Namespace{}
+	if err := c.client.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		return errors.Wrap(resource.IgnoreNotFound(err), errGetNamespace)
+	}
+	if ns.GetLabels()[LabelKeyManagedNamespace] != "true" || meta.WasDeleted(ns) {
+		return nil
+	}
+
+	l := &v1alpha2.ObjectList{}
+	if err := c.localClient.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListObjects)
+	}
+	cluster := kube.ClusterIdentity(c.rest)
+	clusters := map[string]string{providerConfigName(cr): cluster}
+	for i := range l.Items {
+		o := &l.Items[i]
+		if o.GetUID() == cr.GetUID() || meta.WasDeleted(o) {
+			continue
+		}
+		pc := providerConfigName(o)
+		if _, ok := clusters[pc]; !ok {
+			id, err := c.clusterIdentity(ctx, pc)
+			if err != nil {
+				c.logger.Debug("Keeping namespace, cannot tell the cluster of an Object", "namespace", name, "object", o.GetName(), "error", err)
+				return nil
+			}
+			clusters[pc] = id
+		}
+		if clusters[pc] != cluster {
+			continue
+		}
+		namespaces, ok := targetNamespaces(o)
+		if !ok || namespaces.Has(name) {
+			return nil
+		}
+	}
+
+	c.logger.Debug("Deleting namespace", "namespace", name)
+	return errors.Wrap(resource.IgnoreNotFound(c.client.Delete(ctx, ns)), errDeleteNamespace)
+}
+
+// clusterIdentity returns the identity of the cluster of the supplied
+// ProviderConfig.
+func (c *external) clusterIdentity(ctx context.Context, providerConfig string) (string, error) {
+	if c.configForProviderFn == nil {
+		return "", errors.New(errNoClusterIdentity)
+	}
+	rc, err := c.configForProviderFn(ctx, c.localClient, providerConfig)
+	if err != nil {
+		return "", err
+	}
+	return kube.ClusterIdentity(rc), nil
+}
+
+// targetNamespaces returns the namespaces the resources of the supplied
+// Object are in, as last observed or recorded in its status. The namespace
+// of Objects that were not observed yet is read from their inline manifest.
+// It returns false if the namespaces cannot be told, e.g. for Objects with
+// a manifest URL that were not observed yet.
+func targetNamespaces(o *v1alpha2.Object) (sets.Set[string], bool) {
+	if len(o.Status.Items) > 0 {
+		namespaces := sets.New[string]()
+		for _, item := range o.Status.Items {
+			namespaces.Insert(item.Namespace)
+		}
+		return namespaces, true
+	}
+	if raw := o.Status.AtProvider.Manifest.Raw; len(raw) > 0 {
+		observed := &unstructured.Unstructured{}
+		if err := json.Unmarshal(raw, &observed.Object); err != nil {
+			return nil, false
+		}
+		return sets.New(observed.GetNamespace()), true
+	}
+	if o.Spec.ForProvider.ManifestURL != "" || len(o.Spec.ForProvider.TemplateValues) > 0 {
+		return nil, false
+	}
+	desired, err := getDesired(o)
+	if err != nil {
+		return nil, false
+	}
+	return sets.New(desired.GetNamespace()), true
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestEnsureNamespace(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err     error
+		created bool
+	}
+	cases := map[string]struct {
+		reason string
+		getErr error
+		want   want
+	}{
+		"Exists": {
+			reason: "We should not create a namespace that already exists.",
+		},
+		"GetError": {
+			reason: "We should return an error if the namespace cannot be fetched.",
+			getErr: errBoom,
+			want: want{
+				err: errors.Wrap(errBoom, errGetNamespace),
+			},
+		},
+		"NotFound": {
+			reason: "We should create a missing namespace with the managed namespace label.",
+			getErr: kerrors.NewNotFound(schema.GroupResource{}, "ns"),
+			want: want{
+				created: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			created := false
+			e := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(tc.getErr),
+						MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+							created = true
+							if obj.GetName() != "ns" || obj.GetLabels()[LabelKeyManagedNamespace] != "true" {
+								t.Errorf("unexpected namespace %q with labels %v", obj.GetName(), obj.GetLabels())
+							}
+							return nil
+						},
+					},
+				},
+			}
+			err := e.ensureNamespace(context.Background(), "ns")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.ensureNamespace(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if created != tc.want.created {
+				t.Errorf("\n%s\ne.ensureNamespace(...): want created %t, got %t", tc.reason, tc.want.created, created)
+			}
+		})
+	}
+}
+
+func TestCleanupNamespace(t *testing.T) {
+	now := metav1.Now()
+	cr := kubernetesObject(func(o *v1alpha2.Object) {
+		o.SetUID("self")
+	})
+	targeting := func(uid types.UID, pc, namespace string, deleting bool) v1alpha2.Object {
+		o := v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{UID: uid}}
+		o.SetProviderConfigReference(&xpv1.Reference{Name: pc})
+		o.Spec.ForProvider.Manifest = runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"` + namespace + `"}}`)}
+		if deleting {
+			o.SetDeletionTimestamp(&now)
+		}
+		return o
+	}
+	fromURL := func(uid types.UID, observed string) v1alpha2.Object {
+		o := v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{UID: uid}}
+		o.SetProviderConfigReference(&xpv1.Reference{Name: providerName})
+		o.Spec.ForProvider.ManifestURL = "https://example.org/cm.yaml"
+		if observed != "" {
+			o.Status.AtProvider.Manifest = runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"` + observed + `"}}`)}
+		}
+		return o
+	}
+	hosts := map[string]string{
+		providerName:    "https://cluster-a",
+		"same-cluster":  "https://CLUSTER-A:443/",
+		"other-cluster": "https://cluster-b",
+	}
+
+	cases := map[string]struct {
+		reason  string
+		labels  map[string]string
+		objects []v1alpha2.Object
+		deleted bool
+	}{
+		"NotManaged": {
+			reason: "We should not delete namespaces the provider did not create.",
+		},
+		"StillTargeted": {
+			reason:  "We should not delete a namespace another Object targets.",
+			labels:  map[string]string{LabelKeyManagedNamespace: "true"},
+			objects: []v1alpha2.Object{targeting("self", providerName, "ns", true), targeting("other", providerName, "ns", false)},
+		},
+		"StillTargetedThroughOtherProviderConfig": {
+			reason:  "We should not delete a namespace an Object of another ProviderConfig for the same cluster targets.",
+			labels:  map[string]string{LabelKeyManagedNamespace: "true"},
+			objects: []v1alpha2.Object{targeting("other", "same-cluster", "ns", false)},
+		},
+		"TargetedOnOtherCluster": {
+			reason:  "We should delete a namespace if only Objects on other clusters target a namespace of that name.",
+			labels:  map[string]string{LabelKeyManagedNamespace: "true"},
+			objects: []v1alpha2.Object{targeting("other", "other-cluster", "ns", false)},
+			deleted: true,
+		},
+		"StillTargetedThroughManifestURL": {
+			reason:  "We should not delete a namespace an Object with a manifest URL was observed in.",
+			labels:  map[string]string{LabelKeyManagedNamespace: "true"},
+			objects: []v1alpha2.Object{fromURL("other", "ns")},
+		},
+		"ObservedInOtherNamespace": {
+			reason:  "We should delete a namespace if other Objects were observed in other namespaces.",
+			labels:  map[string]string{LabelKeyManagedNamespace: "true"},
+			objects: []v1alpha2.Object{fromURL("other", "other-ns")},
+			deleted: true,
+		},
+		"UnknownNamespace": {
+			reason:  "We should not delete a namespace if we cannot tell the namespace of another Object.",
+			labels:  map[string]string{LabelKeyManagedNamespace: "true"},
+			objects: []v1alpha2.Object{fromURL("other", "")},
+		},
+		"UnknownCluster": {
+			reason:  "We should not delete a namespace if we cannot tell the cluster of another Object.",
+			labels:  map[string]string{LabelKeyManagedNamespace: "true"},
+			objects: []v1alpha2.Object{targeting("other", "missing", "other-ns", false)},
+		},
+		"LastObject": {
+			reason:  "We should delete a managed namespace once no other Object targets it.",
+			labels:  map[string]string{LabelKeyManagedNamespace: "true"},
+			objects: []v1alpha2.Object{targeting("self", providerName, "ns", true), targeting("other", providerName, "other-ns", false), targeting("deleting", providerName, "ns", true)},
+			deleted: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			deleted := false
+			e := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+							ns := obj.(*corev1.Namespace)
+							ns.SetName(key.Name)
+							ns.SetLabels(tc.labels)
+							return nil
+						},
+						MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+							deleted = true
+							return nil
+						},
+					},
+				},
+				rest: &rest.Config{Host: hosts[providerName]},
+				localClient: &test.MockClient{
+					MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+						list.(*v1alpha2.ObjectList).Items = tc.objects
+						return nil
+					},
+				},
+				configForProviderFn: func(_ context.Context, _ client.Client, pc string) (*rest.Config, error) {
+					host, ok := hosts[pc]
+					if !ok {
+						return nil, errors.New("boom")
+					}
+					return &rest.Config{Host: host}, nil
+				},
+			}
+			if err := e.cleanupNamespace(context.Background(), cr, "ns"); err != nil {
+				t.Errorf("\n%s\ne.cleanupNamespace(...): unexpected error: %v", tc.reason, err)
+			}
+			if deleted != tc.deleted {
+				t.Errorf("\n%s\ne.cleanupNamespace(...): want deleted %t, got %t", tc.reason, tc.deleted, deleted)
+			}
+		})
+	}
+}
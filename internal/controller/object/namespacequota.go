@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	quotav1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/namespaceobjectquota/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errListNamespaceQuotas = "cannot list NamespaceObjectQuotas"
+	errCountObjects        = "cannot count Objects in namespace"
+	errNoNamespaceIndex    = "cannot count Objects in namespace %q: the cache has no " + ManifestNamespaceIndex + " index, it is added by the Object controller"
+	errNamespaceQuota      = "NamespaceObjectQuota %q limits namespace %q to %d Objects, %d already manage resources in it"
+	warnNamespaceQuota     = "NamespaceObjectQuota %q limits namespace %q to %d Objects, this is Object %d"
+)
+
+// A NamespaceQuotaValidator rejects Objects of namespaced resources if as
+// many Objects as a NamespaceObjectQuota of their namespace allows already
+// manage resources in it. Objects are counted using the
+// ManifestNamespaceIndex, which Setup adds to the cache.
+type NamespaceQuotaValidator struct {
+	client client.Reader
+}
+
+var _ admission.CustomValidator = &NamespaceQuotaValidator{}
+
+// NewNamespaceQuotaValidator returns a NamespaceQuotaValidator that reads
+// NamespaceObjectQuotas and Objects using the supplied client.
+func NewNamespaceQuotaValidator(c client.Reader) *NamespaceQuotaValidator {
+	return &NamespaceQuotaValidator{client: c}
+}
+
+// ValidateCreate rejects an Object if the namespace of its resource is
+// exhausted.
+func (v *NamespaceQuotaValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr, ok := obj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	return v.validateNamespaceQuota(ctx, manifestNamespace(cr))
+}
+
+// ValidateUpdate rejects an Object that moves its resource to a namespace
+// that is exhausted.
+func (v *NamespaceQuotaValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	cr, ok := newObj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	ns := manifestNamespace(cr)
+	if manifestNamespace(old) == ns {
+		return nil, nil
+	}
+	return v.validateNamespaceQuota(ctx, ns)
+}
+
+// ValidateDelete never rejects an Object.
+func (v *NamespaceQuotaValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *NamespaceQuotaValidator) validateNamespaceQuota(ctx context.Context, ns string) (admission.Warnings, error) {
+	if ns == "" {
+		return nil, nil
+	}
+	ql := &quotav1alpha1.NamespaceObjectQuotaList{}
+	if err := v.client.List(ctx, ql); err != nil {
+		return nil, errors.Wrap(err, errListNamespaceQuotas)
+	}
+
+	var n int64 = -1
+	var warnings admission.Warnings
+	for i := range ql.Items {
+		q := &ql.Items[i]
+		if q.Spec.Namespace != ns {
+			continue
+		}
+		if n < 0 {
+			ol := &v1alpha2.ObjectList{}
+			if err := v.client.List(ctx, ol, client.MatchingFields{ManifestNamespaceIndex: ns}); err != nil {
+				if isMissingIndex(err) {
+					return nil, errors.Errorf(errNoNamespaceIndex, ns)
+				}
+				return nil, errors.Wrapf(err, "%s %q", errCountObjects, ns)
+			}
+			n = int64(len(ol.Items))
+		}
+		if n >= q.Spec.MaxObjects {
+			return nil, errors.Errorf(errNamespaceQuota, q.GetName(), ns, q.Spec.MaxObjects, n)
+		}
+		if q.GraceExceeded(n + 1) {
+			warnings = append(warnings, fmt.Sprintf(warnNamespaceQuota, q.GetName(), ns, q.Spec.MaxObjects, n+1))
+		}
+	}
+	return warnings, nil
+}
+
+// isMissingIndex returns true if the supplied error of listing from a cache
+// is caused by an index that was never added to it. The cache returns no
+// typed error for this.
+func isMissingIndex(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "index with name") && strings.Contains(msg, "does not exist")
+}
+
+// manifestNamespace returns the namespace of the resource managed by the
+// supplied Object, if any.
+func manifestNamespace(cr *v1alpha2.Object) string {
+	if ns := IndexByManifestNamespace(cr); len(ns) > 0 {
+		return ns[0]
+	}
+	return ""
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	quotav1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/namespaceobjectquota/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestNamespaceQuotaValidator(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	inNamespace := func(ns string) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.Spec.ForProvider.Manifest.Raw = []byte(fmt.Sprintf(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm", "namespace": %q}}`, ns))
+		}
+	}
+	quota := func(ns string, maxObjects int64, grace *int32) quotav1alpha1.NamespaceObjectQuota {
+		q := quotav1alpha1.NamespaceObjectQuota{}
+		q.SetName("quota")
+		q.Spec = quotav1alpha1.NamespaceObjectQuotaSpec{Namespace: ns, MaxObjects: maxObjects, Grace: grace}
+		return q
+	}
+	list := func(quotas []quotav1alpha1.NamespaceObjectQuota, objects int) test.MockListFn {
+		return func(_ context.Context, l client.ObjectList, opts ...client.ListOption) error {
+			switch l := l.(type) {
+			case *quotav1alpha1.NamespaceObjectQuotaList:
+				l.Items = quotas
+			case *v1alpha2.ObjectList:
+				lo := &client.ListOptions{}
+				lo.ApplyOptions(opts)
+				if lo.FieldSelector.String() != ManifestNamespaceIndex+"="+testNamespace {
+					t.Errorf("unexpected field selector %q", lo.FieldSelector)
+				}
+				l.Items = make([]v1alpha2.Object, objects)
+			}
+			return nil
+		}
+	}
+
+	type args struct {
+		list test.MockListFn
+		old  *v1alpha2.Object
+		obj  *v1alpha2.Object
+	}
+	type want struct {
+		warnings admission.Warnings
+		err      error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ClusterScoped": {
+			reason: "We should admit Objects of cluster scoped resources.",
+			args: args{
+				list: test.NewMockListFn(errBoom),
+				obj:  kubernetesObject(),
+			},
+		},
+		"ListError": {
+			reason: "We should reject Objects if the quotas cannot be listed.",
+			args: args{
+				list: test.NewMockListFn(errBoom),
+				obj:  kubernetesObject(inNamespace(testNamespace)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListNamespaceQuotas),
+			},
+		},
+		"MissingIndex": {
+			reason: "We should reject Objects with a clear error if Objects cannot be counted because the index is missing.",
+			args: args{
+				list: func(_ context.Context, l client.ObjectList, _ ...client.ListOption) error {
+					if ql, ok := l.(*quotav1alpha1.NamespaceObjectQuotaList); ok {
+						ql.Items = []quotav1alpha1.NamespaceObjectQuota{quota(testNamespace, 10, nil)}
+						return nil
+					}
+					return errors.Errorf("index with name field:%s does not exist", ManifestNamespaceIndex)
+				},
+				obj: kubernetesObject(inNamespace(testNamespace)),
+			},
+			want: want{
+				err: errors.Errorf(errNoNamespaceIndex, testNamespace),
+			},
+		},
+		"OtherNamespace": {
+			reason: "We should admit Objects if no quota applies to their namespace.",
+			args: args{
+				list: list([]quotav1alpha1.NamespaceObjectQuota{quota("other", 0, nil)}, 0),
+				obj:  kubernetesObject(inNamespace(testNamespace)),
+			},
+		},
+		"BelowLimit": {
+			reason: "We should admit Objects below the limit of the quota.",
+			args: args{
+				list: list([]quotav1alpha1.NamespaceObjectQuota{quota(testNamespace, 10, nil)}, 5),
+				obj:  kubernetesObject(inNamespace(testNamespace)),
+			},
+		},
+		"GraceExceeded": {
+			reason: "We should warn about Objects above the grace of the quota.",
+			args: args{
+				list: list([]quotav1alpha1.NamespaceObjectQuota{quota(testNamespace, 10, ptr.To[int32](80))}, 8),
+				obj:  kubernetesObject(inNamespace(testNamespace)),
+			},
+			want: want{
+				warnings: admission.Warnings{fmt.Sprintf(warnNamespaceQuota, "quota", testNamespace, 10, 9)},
+			},
+		},
+		"LimitReached": {
+			reason: "We should reject Objects once the limit of the quota is reached.",
+			args: args{
+				list: list([]quotav1alpha1.NamespaceObjectQuota{quota(testNamespace, 10, nil)}, 10),
+				obj:  kubernetesObject(inNamespace(testNamespace)),
+			},
+			want: want{
+				err: errors.Errorf(errNamespaceQuota, "quota", testNamespace, 10, 10),
+			},
+		},
+		"UpdateSameNamespace": {
+			reason: "We should admit updates of Objects that keep the namespace of their resource.",
+			args: args{
+				list: list([]quotav1alpha1.NamespaceObjectQuota{quota(testNamespace, 10, nil)}, 10),
+				old:  kubernetesObject(inNamespace(testNamespace)),
+				obj:  kubernetesObject(inNamespace(testNamespace)),
+			},
+		},
+		"UpdateOtherNamespace": {
+			reason: "We should reject updates of Objects moving their resource to an exhausted namespace.",
+			args: args{
+				list: list([]quotav1alpha1.NamespaceObjectQuota{quota(testNamespace, 10, nil)}, 10),
+				old:  kubernetesObject(inNamespace("other")),
+				obj:  kubernetesObject(inNamespace(testNamespace)),
+			},
+			want: want{
+				err: errors.Errorf(errNamespaceQuota, "quota", testNamespace, 10, 10),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := NewNamespaceQuotaValidator(&test.MockClient{MockList: tc.args.list})
+			var warnings admission.Warnings
+			var err error
+			if tc.args.old != nil {
+				warnings, err = v.ValidateUpdate(context.Background(), tc.args.old, tc.args.obj)
+			} else {
+				warnings, err = v.ValidateCreate(context.Background(), tc.args.obj)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.warnings, warnings); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want warnings, +got warnings:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	runtimeevent "sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errListNodes        = "cannot list nodes"
+	errParseTolerations = "cannot parse tolerations of pod template"
+)
+
+var nodeGVK = v1.SchemeGroupVersion.WithKind("Node")
+
+// watchesNodes returns true if the supplied Object checks whether the nodes
+// of its cluster can run the pods of its managed workload.
+func watchesNodes(cr *v1alpha2.Object) bool {
+	return cr.Spec.NodeAffinity != nil && cr.Spec.NodeAffinity.WatchNodes
+}
+
+// podScheduling returns the node selector and tolerations of the pod
+// template of the supplied workload, or of the supplied Pod. It returns
+// false for resources without pods.
+func podScheduling(u *unstructured.Unstructured) (map[string]string, []v1.Toleration, bool, error) {
+	path := []string{"spec", "template", "spec"}
+	if u.GroupVersionKind() == v1.SchemeGroupVersion.WithKind("Pod") {
+		path = []string{"spec"}
+	}
+	spec, ok, _ := unstructured.NestedMap(u.Object, path...)
+	if !ok {
+		return nil, nil, false, nil
+	}
+	sel, _, _ := unstructured.NestedStringMap(spec, "nodeSelector")
+	raw, _, _ := unstructured.NestedSlice(spec, "tolerations")
+	tols := make([]v1.Toleration, 0, len(raw))
+	for _, r := range raw {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, nil, false, errors.New(errParseTolerations)
+		}
+		t := v1.Toleration{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &t); err != nil {
+			return nil, nil, false, errors.Wrap(err, errParseTolerations)
+		}
+		tols = append(tols, t)
+	}
+	return sel, tols, true, nil
+}
+
+// checkNodeAffinity reports through the NodeAffinityUnsatisfiable condition
+// whether no node of the target cluster matches the node selector of the
+// desired workload and tolerates its tolerations.
+func (c *external) checkNodeAffinity(ctx context.Context, cr *v1alpha2.Object, desired *unstructured.Unstructured) error {
+	if !watchesNodes(cr) || meta.WasDeleted(cr) {
+		return nil
+	}
+	sel, tols, ok, err := podScheduling(desired)
+	if err != nil || !ok {
+		return err
+	}
+
+	nodes := &v1.NodeList{}
+	if err := c.client.List(ctx, nodes, client.MatchingLabels(sel)); err != nil {
+		return errors.Wrap(err, errListNodes)
+	}
+	for i := range nodes.Items {
+		if toleratesNode(tols, &nodes.Items[i]) {
+			if cr.GetCondition(v1alpha2.TypeNodeAffinityUnsatisfiable).Status == v1.ConditionTrue {
+				cr.SetConditions(v1alpha2.NodeAffinitySatisfiable())
+			}
+			return nil
+		}
+	}
+
+	cond := v1alpha2.NodeAffinityUnsatisfiable(labels.SelectorFromSet(sel).String())
+	if c.recorder != nil && cr.GetCondition(v1alpha2.TypeNodeAffinityUnsatisfiable).Status != v1.ConditionTrue {
+		c.recorder.Event(cr, event.Warning(event.Reason(cond.Reason), errors.New(cond.Message)))
+	}
+	cr.SetConditions(cond)
+	return nil
+}
+
+// toleratesNode returns true if the supplied tolerations tolerate every
+// taint of the supplied node that keeps pods from being scheduled on it.
+func toleratesNode(tols []v1.Toleration, n *v1.Node) bool {
+	for i := range n.Spec.Taints {
+		t := &n.Spec.Taints[i]
+		if t.Effect == v1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range tols {
+			if tols[j].ToleratesTaint(t) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeSchedulingChanged returns true if the supplied update of a node
+// changed its labels or taints, and thus which pods it can run.
+func nodeSchedulingChanged(ev runtimeevent.UpdateEvent) bool {
+	if !equality.Semantic.DeepEqual(ev.ObjectOld.GetLabels(), ev.ObjectNew.GetLabels()) {
+		return true
+	}
+	o, ok := ev.ObjectOld.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+	n, ok := ev.ObjectNew.(*unstructured.Unstructured)
+	if !ok {
+		return true
+	}
+	ot, _, _ := unstructured.NestedFieldNoCopy(o.Object, "spec", "taints")
+	nt, _, _ := unstructured.NestedFieldNoCopy(n.Object, "spec", "taints")
+	return !equality.Semantic.DeepEqual(ot, nt)
+}
+
+func isNode(obj client.Object) bool {
+	return obj.GetObjectKind().GroupVersionKind() == nodeGVK
+}
+
+// nodeGVKKey returns the key of the resourceRefGVKsIndex of Objects watching
+// the nodes of the cluster of the supplied provider config.
+func nodeGVKKey(providerConfig string) string {
+	return refKeyProviderGVK(providerConfig, nodeGVK.Kind, nodeGVK.Group, nodeGVK.Version)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	runtimeevent "sigs.k8s.io/controller-runtime/pkg/event"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestCheckNodeAffinity(t *testing.T) {
+	errBoom := errors.New("boom")
+	deployment := func(tolerations ...interface{}) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"nodeSelector": map[string]interface{}{"pool": "gpu"},
+						"tolerations":  tolerations,
+					},
+				},
+			},
+		}}
+		return u
+	}
+	gpuTaint := v1.Taint{Key: "gpu", Value: "true", Effect: v1.TaintEffectNoSchedule}
+	listNodes := func(nodes ...v1.Node) test.MockListFn {
+		return func(_ context.Context, l client.ObjectList, opts ...client.ListOption) error {
+			lo := &client.ListOptions{}
+			lo.ApplyOptions(opts)
+			if got := lo.LabelSelector.String(); got != "pool=gpu" {
+				return errors.Errorf("unexpected label selector %q", got)
+			}
+			l.(*v1.NodeList).Items = nodes
+			return nil
+		}
+	}
+	watchNodes := func(obj *v1alpha2.Object) {
+		obj.Spec.NodeAffinity = &v1alpha2.NodeAffinity{WatchNodes: true}
+	}
+	unsatisfiable := func(obj *v1alpha2.Object) {
+		watchNodes(obj)
+		obj.SetConditions(v1alpha2.NodeAffinityUnsatisfiable("pool=gpu"))
+	}
+
+	type args struct {
+		cr      *v1alpha2.Object
+		desired *unstructured.Unstructured
+		list    test.MockListFn
+	}
+	type want struct {
+		err       error
+		condition xpv1.Condition
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotWatchingNodes": {
+			reason: "We should not check the nodes of Objects that do not watch them.",
+			args: args{
+				cr:      kubernetesObject(),
+				desired: deployment(),
+			},
+			want: want{
+				condition: xpv1.Condition{Type: v1alpha2.TypeNodeAffinityUnsatisfiable, Status: v1.ConditionUnknown},
+			},
+		},
+		"NoPodTemplate": {
+			reason: "We should not check the nodes for resources without pods.",
+			args: args{
+				cr:      kubernetesObject(watchNodes),
+				desired: externalResource(),
+			},
+			want: want{
+				condition: xpv1.Condition{Type: v1alpha2.TypeNodeAffinityUnsatisfiable, Status: v1.ConditionUnknown},
+			},
+		},
+		"ErrorListNodes": {
+			reason: "We should return an error if the nodes cannot be listed.",
+			args: args{
+				cr:      kubernetesObject(watchNodes),
+				desired: deployment(),
+				list:    test.NewMockListFn(errBoom),
+			},
+			want: want{
+				err:       errors.Wrap(errBoom, errListNodes),
+				condition: xpv1.Condition{Type: v1alpha2.TypeNodeAffinityUnsatisfiable, Status: v1.ConditionUnknown},
+			},
+		},
+		"NoMatchingNode": {
+			reason: "We should report the node affinity unsatisfiable if no node matches the node selector.",
+			args: args{
+				cr:      kubernetesObject(watchNodes),
+				desired: deployment(),
+				list:    listNodes(),
+			},
+			want: want{
+				condition: v1alpha2.NodeAffinityUnsatisfiable("pool=gpu"),
+			},
+		},
+		"TaintNotTolerated": {
+			reason: "We should report the node affinity unsatisfiable if the matching nodes are tainted.",
+			args: args{
+				cr:      kubernetesObject(watchNodes),
+				desired: deployment(),
+				list:    listNodes(v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{gpuTaint}}}),
+			},
+			want: want{
+				condition: v1alpha2.NodeAffinityUnsatisfiable("pool=gpu"),
+			},
+		},
+		"TaintTolerated": {
+			reason: "We should report the node affinity satisfiable again once a matching node is tolerated.",
+			args: args{
+				cr:      kubernetesObject(unsatisfiable),
+				desired: deployment(map[string]interface{}{"key": "gpu", "operator": "Exists", "effect": "NoSchedule"}),
+				list: listNodes(v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{
+					gpuTaint,
+					{Key: "spot", Effect: v1.TaintEffectPreferNoSchedule},
+				}}}),
+			},
+			want: want{
+				condition: v1alpha2.NodeAffinitySatisfiable(),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{Client: &test.MockClient{MockList: tc.args.list}},
+			}
+			err := e.checkNodeAffinity(context.Background(), tc.args.cr, tc.args.desired)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ncheckNodeAffinity(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			got := tc.args.cr.GetCondition(v1alpha2.TypeNodeAffinityUnsatisfiable)
+			if diff := cmp.Diff(tc.want.condition, got, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ncheckNodeAffinity(...): -want condition, +got condition:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNodeSchedulingChanged(t *testing.T) {
+	node := func(labels map[string]string, taints ...interface{}) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Node",
+			"spec":       map[string]interface{}{"taints": taints},
+		}}
+		u.SetName("node-1")
+		u.SetLabels(labels)
+		return u
+	}
+	taint := map[string]interface{}{"key": "gpu", "effect": "NoSchedule"}
+	heartbeat := node(map[string]string{"pool": "gpu"})
+	heartbeat.SetAnnotations(map[string]string{"node.alpha.kubernetes.io/ttl": "0"})
+	heartbeat.SetResourceVersion("2")
+
+	cases := map[string]struct {
+		reason string
+		old    *unstructured.Unstructured
+		new    *unstructured.Unstructured
+		want   bool
+	}{
+		"LabelsChanged": {
+			reason: "We should let updates through that change the labels of a node.",
+			old:    node(map[string]string{"pool": "default"}),
+			new:    node(map[string]string{"pool": "gpu"}),
+			want:   true,
+		},
+		"TaintsChanged": {
+			reason: "We should let updates through that change the taints of a node.",
+			old:    node(map[string]string{"pool": "gpu"}),
+			new:    node(map[string]string{"pool": "gpu"}, taint),
+			want:   true,
+		},
+		"StatusChanged": {
+			reason: "We should filter updates of nodes that keep their labels and taints, e.g. heartbeats.",
+			old:    node(map[string]string{"pool": "gpu"}),
+			new:    heartbeat,
+			want:   false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &watchPredicate{log: logging.NewNopLogger()}
+			got := p.ForProviderConfig(providerName).Update(runtimeevent.UpdateEvent{ObjectOld: tc.old, ObjectNew: tc.new})
+			if got != tc.want {
+				t.Errorf("\n%s\nUpdate(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
@@ -22,12 +22,14 @@ import (
 	"fmt"
 	"math/rand"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -37,10 +39,13 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	runtimeevent "sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
@@ -53,10 +58,15 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
+	deadletterv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/deadletter/v1alpha1"
 	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
 	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/audit"
 	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/drain"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/events"
 	"github.com/crossplane-contrib/provider-kubernetes/internal/features"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/manifest"
 )
 
 type key int
@@ -94,52 +104,179 @@ const (
 	errGetValueAtFieldPath  = "cannot get value at fieldPath"
 	errDecodeSecretData     = "cannot decode secret data"
 	errSanitizeSecretData   = "cannot sanitize secret data"
+
+	errGetSchema = "cannot get schema to validate manifest against"
+
+	errNoManifestFetcher = "manifestURL is not supported here"
+
+	errOwnerNotOnTargetCluster = "cannot set owner reference: the Object does not exist on the target cluster"
+	errGetOwnerOnTargetCluster = "cannot get the Object on the target cluster to set owner reference"
+
+	// defaultDeletionTimeout is how long a deleted Object with
+	// waitForDeletion waits for its managed resource by default.
+	defaultDeletionTimeout = 10 * time.Minute
 )
 
 // KindObserver tracks kinds of referenced composed resources in order to start
 // watches for them for realtime events.
 type KindObserver interface {
 	// WatchResources starts a watch of the given kinds to trigger reconciles
-	// when a referenced or managed objects of those kinds changes.
-	WatchResources(rc *rest.Config, providerConfig string, gvks ...schema.GroupVersionKind)
+	// when a referenced or managed objects of those kinds changes. It
+	// returns an error if some of the kinds cannot be watched.
+	WatchResources(rc *rest.Config, providerConfig string, gvks ...schema.GroupVersionKind) error
+}
+
+// A ReferenceWatcher is a KindObserver that may defer watching the kinds of
+// referenced resources until a managed resource referencing them changes.
+type ReferenceWatcher interface {
+	// WatchReferencedResources watches the given kinds of resources
+	// referenced by an Object managing a resource of the trigger kind in
+	// the cluster behind rc.
+	WatchReferencedResources(rc *rest.Config, trigger schema.GroupVersionKind, gvks ...schema.GroupVersionKind) error
+}
+
+// A ResourceVersionReader reads the resource version of a resource as last
+// seen by the watch of its kind.
+type ResourceVersionReader interface {
+	// ResourceVersion returns the resource version of the supplied resource
+	// of the cluster of the supplied provider config. It returns false if
+	// the resource is not known to a synced watch.
+	ResourceVersion(ctx context.Context, providerConfig string, gvk schema.GroupVersionKind, key types.NamespacedName) (string, bool)
+}
+
+// SetupOptions configure the Object controller beyond the controller.Options
+// shared by all controllers.
+type SetupOptions struct {
+	// SanitizeSecrets redacts the data of managed Secrets in the status of
+	// their Objects.
+	SanitizeSecrets bool
+
+	// PollJitter is the maximum jitter added to the poll interval.
+	PollJitter time.Duration
+
+	// SyncPeriod is how often watched and up to date Objects are polled.
+	SyncPeriod time.Duration
+
+	// SlowAdmissionThreshold is the apply duration above which applies are
+	// reported as slow.
+	SlowAdmissionThreshold time.Duration
+
+	// MaxInformers limits the number of resource informers, GoroutinesBudget
+	// the goroutines they may use.
+	MaxInformers     *atomic.Int64
+	GoroutinesBudget int
+
+	// UseSharedInformerFactory creates resource informers from a shared
+	// informer factory per cluster, LazyInformerStart starts them on first
+	// use.
+	UseSharedInformerFactory bool
+	LazyInformerStart        bool
+
+	InformerCleanup  InformerCleanupOptions
+	InformersHandler *InformersHandler
+	InformerBudgets  *InformerBudgets
+
+	FinalizePlugins *FinalizePlugins
+	Backoff         BackoffOptions
+	DeadLetters     DeadLetterOptions
+	Autoscaling     AutoscalerOptions
+	History         HistoryOptions
+	Auditor         audit.AuditLogger
+	Drainer         *drain.Drainer
+	Manifests       *manifest.Fetcher
 }
 
 // Setup adds a controller that reconciles Object managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options, sanitizeSecrets bool, pollJitter time.Duration) error {
+func Setup(mgr ctrl.Manager, o controller.Options, so SetupOptions) error {
 	name := managed.ControllerName(v1alpha2.ObjectGroupKind)
 	l := o.Logger.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 
 	reconcilerOptions := []managed.ReconcilerOption{
-		managed.WithFinalizer(&objFinalizer{client: mgr.GetClient()}),
+		managed.WithFinalizer(&objFinalizer{client: mgr.GetClient(), plugins: so.FinalizePlugins}),
 		managed.WithPollInterval(o.PollInterval),
 		managed.WithPollIntervalHook(func(mg resource.Managed, pollInterval time.Duration) time.Duration {
-			if mg.GetCondition(xpv1.TypeReady).Status != v1.ConditionTrue {
-				// If the resource is not ready, we should poll more frequently not to delay time to readiness.
-				pollInterval = 30 * time.Second
+			// Watched resources that are up to date trigger a reconcile
+			// when they change, they are only polled every sync period.
+			if cr, ok := mg.(*v1alpha2.Object); ok && o.Features.Enabled(features.EnableAlphaWatches) && so.SyncPeriod > pollInterval && watchedUpToDate(cr) {
+				pollInterval = so.SyncPeriod
 			}
-			// This is the same as runtime default poll interval with jitter, see:
-			// https://github.com/crossplane/crossplane-runtime/blob/7fcb8c5cad6fc4abb6649813b92ab92e1832d368/pkg/reconciler/managed/reconciler.go#L573
-			return pollInterval + time.Duration((rand.Float64()-0.5)*2*float64(pollJitter)) //nolint G404 // No need for secure randomness
+			return objectPollInterval(mg, pollInterval, so.PollJitter)
 		}),
 		managed.WithLogger(l),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...),
+		// Objects limit their reconciles by their own timeout, see
+		// timeoutConnecter.
+		managed.WithTimeout(maxReconcileTimeout),
+	}
+
+	ce, err := events.NewCloudEventsEmitter()
+	if err != nil {
+		return err
+	}
+	// Events are published in the background, not to hold up reconciles on
+	// slow endpoints.
+	eq := events.NewQueue(ce, cloudEventsQueueSize, l)
+	if err := mgr.Add(eq); err != nil {
+		return errors.Wrap(err, "cannot add CloudEvents queue")
 	}
 
 	conn := &connector{
-		logger:              o.Logger,
-		sanitizeSecrets:     sanitizeSecrets,
-		kube:                mgr.GetClient(),
-		usage:               resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-		clientForProviderFn: kube.ClientForProvider,
+		logger:                 o.Logger,
+		sanitizeSecrets:        so.SanitizeSecrets,
+		kube:                   mgr.GetClient(),
+		policies:               mgr.GetClient(),
+		usage:                  resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+		clientForProviderFn:    kube.ClientForProvider,
+		schemas:                newSchemaValidator(),
+		readiness:              newReadinessPrograms(),
+		targetOwners:           &targetOwners{},
+		recorder:               recorder,
+		slowAdmissionThreshold: so.SlowAdmissionThreshold,
+		auditor:                so.Auditor,
+		cloudEvents:            eq,
+		manifests:              so.Manifests,
+	}
+
+	if so.History.Enabled() {
+		conn.history = newHistoryRecorder(mgr.GetClient(), mgr.GetAPIReader(), so.History, l)
+	}
+
+	caSecrets := &caSecretHandler{client: mgr.GetClient(), log: l}
+
+	// Retry failed reconciles with the backoff of the class of their error.
+	copts := o.ForControllerRuntime()
+	rl := NewClassifiedRateLimiter(copts.RateLimiter, so.Backoff)
+	copts.RateLimiter = rl
+	var autoscaler *workerAutoscaler
+	if so.Autoscaling.Enabled() {
+		autoscaler = newWorkerAutoscaler(so.Autoscaling, l, queueDepth(metrics.Registry, name))
+		copts.MaxConcurrentReconciles = so.Autoscaling.MaxWorkers
+		if err := mgr.Add(autoscaler); err != nil {
+			return errors.Wrap(err, "cannot add reconcile worker autoscaler")
+		}
+	}
+	var failures *failureTracker
+	if so.DeadLetters.Threshold > 0 {
+		failures = newFailureTracker()
+	}
+
+	// Quotas, reports and the NamespaceQuotaValidator list Objects by the
+	// namespace of their resource.
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha2.Object{}, ManifestNamespaceIndex, IndexByManifestNamespace); err != nil {
+		return errors.Wrap(err, "cannot add index for the namespaces of Objects")
 	}
 
 	cb := ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o.ForControllerRuntime()).
-		For(&v1alpha2.Object{})
+		WithOptions(copts).
+		For(&v1alpha2.Object{}).
+		Watches(&v1.Secret{}, caSecrets).
+		Watches(&v1.Service{}, caSecrets).
+		Watches(&deadletterv1alpha1.DeadLetter{}, enqueueObjectForDeadLetter(), builder.WithPredicates(deadLetterDeleted))
 
 	if o.Features.Enabled(features.EnableAlphaWatches) {
 		ca := mgr.GetCache()
@@ -156,8 +293,27 @@ func Setup(mgr ctrl.Manager, o controller.Options, sanitizeSecrets bool, pollJit
 
 			objectsCache:   ca,
 			resourceCaches: make(map[gvkWithConfig]resourceCache),
+			maxInformers:   so.MaxInformers,
+
+			goroutinesBudget: int64(so.GoroutinesBudget),
+			cleanup:          so.InformerCleanup,
+			budgets:          so.InformerBudgets,
+
+			useSharedInformerFactory: so.UseSharedInformerFactory,
+			lazyStart:                so.LazyInformerStart,
 		}
 		conn.kindObserver = &i
+		conn.resourceVersions = &i
+		owners := &resourceOwners{}
+		conn.owners = owners
+		i.owners = owners
+		caSecrets.informers = &i
+		if so.InformersHandler != nil {
+			so.InformersHandler.setInformers(&i)
+		}
+		if so.InformerBudgets != nil {
+			so.InformerBudgets.setInformers(&i)
+		}
 
 		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
 			wait.UntilWithContext(ctx, i.cleanupResourceInformers, time.Minute)
@@ -166,31 +322,69 @@ func Setup(mgr ctrl.Manager, o controller.Options, sanitizeSecrets bool, pollJit
 			return errors.Wrap(err, "cannot add cleanup referenced resource informers runnable")
 		}
 
+		ep := newEventPropagator(ca, recorder, l)
 		cb = cb.WatchesRawSource(&i, handler.Funcs{
 			GenericFunc: func(ctx context.Context, ev runtimeevent.GenericEvent, q workqueue.RateLimitingInterface) {
 				enqueueObjectsForReferences(ca, l)(ctx, ev, q)
+				pc, _ := ctx.Value(keyProviderConfigName).(string)
+				ep.Propagate(ctx, pc, ev.Object)
 			},
-		})
+			DeleteFunc: func(ctx context.Context, ev runtimeevent.DeleteEvent, q workqueue.RateLimitingInterface) {
+				enqueueObjectsForReferences(ca, l)(ctx, runtimeevent.GenericEvent{Object: ev.Object}, q)
+				owners.EnqueueOwner(l, ev.Object, q)
+			},
+		}, builder.WithPredicates(&watchPredicate{objects: ca, log: l}))
 	}
-	reconcilerOptions = append(reconcilerOptions, managed.WithExternalConnecter(conn))
+	reconcilerOptions = append(reconcilerOptions, managed.WithExternalConnecter(&classifyingConnecter{
+		ExternalConnecter: &timeoutConnecter{ExternalConnecter: &errorCodeConnecter{ExternalConnecter: conn}},
+		classify:          ClassifyError,
+		limiter:           rl,
+		failures:          failures,
+	}))
 
 	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
 		reconcilerOptions = append(reconcilerOptions, managed.WithManagementPolicies())
 	}
 
-	return cb.Complete(ratelimiter.NewReconciler(name, managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha2.ObjectGroupVersionKind),
-		reconcilerOptions...,
-	), o.GlobalRateLimiter))
+	var r reconcile.Reconciler = ratelimiter.NewReconciler(name, &deadLetterReconciler{
+		// Status updates that would not change the status of an Object
+		// are skipped, and rapid successive ones held back.
+		Reconciler: managed.NewReconciler(&statusSyncManager{Manager: mgr, client: newStatusSyncClient(mgr.GetClient(), l)},
+			resource.ManagedKind(v1alpha2.ObjectGroupVersionKind),
+			reconcilerOptions...,
+		),
+		client:   mgr.GetClient(),
+		log:      l,
+		failures: failures,
+		opts:     so.DeadLetters,
+		now:      time.Now,
+	}, o.GlobalRateLimiter)
+	if autoscaler != nil {
+		r = autoscaler.Wrap(r)
+	}
+	return cb.Complete(so.Drainer.Wrap(r))
 }
 
 type connector struct {
 	kube            client.Client
+	policies        client.Reader
 	usage           resource.Tracker
 	logger          logging.Logger
 	sanitizeSecrets bool
 
-	kindObserver KindObserver
+	kindObserver     KindObserver
+	resourceVersions ResourceVersionReader
+	owners           *resourceOwners
+	schemas          *schemaValidator
+	readiness        *readinessPrograms
+	history          *historyRecorder
+	manifests        *manifest.Fetcher
+	targetOwners     *targetOwners
+
+	recorder               event.Recorder
+	slowAdmissionThreshold time.Duration
+	auditor                audit.AuditLogger
+	cloudEvents            CloudEventsEmitter
 
 	clientForProviderFn func(ctx context.Context, local client.Client, providerConfigName string) (client.Client, *rest.Config, error)
 }
@@ -213,6 +407,20 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, errors.Wrap(err, errNewKubernetesClient)
 	}
+	k = withFieldManager(k, cr.GetAnnotations())
+
+	var endpoint string
+	if c.cloudEvents != nil {
+		if endpoint, err = cloudEventsEndpoint(ctx, c.kube, cr); err != nil {
+			return nil, err
+		}
+	}
+	var dns *apisv1alpha1.DNSEndpointConfig
+	if dnsPropagating(cr) {
+		if dns, err = dnsEndpointConfig(ctx, c.kube, cr); err != nil {
+			return nil, err
+		}
+	}
 
 	return &external{
 		logger: c.logger,
@@ -220,11 +428,27 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 			Client:     k,
 			Applicator: resource.NewAPIPatchingApplicator(k),
 		},
-		rest:            rc,
-		localClient:     c.kube,
-		sanitizeSecrets: c.sanitizeSecrets,
-
-		kindObserver: c.kindObserver,
+		rest:                rc,
+		localClient:         c.kube,
+		configForProviderFn: kube.ConfigForProvider,
+		policies:            c.policies,
+		sanitizeSecrets:     c.sanitizeSecrets,
+
+		kindObserver:     c.kindObserver,
+		resourceVersions: c.resourceVersions,
+		owners:           c.owners,
+		schemas:          c.schemas,
+		readiness:        c.readiness,
+		history:          c.history,
+		manifests:        c.manifests,
+		targetOwners:     c.targetOwners,
+
+		recorder:               c.recorder,
+		slowAdmissionThreshold: c.slowAdmissionThreshold,
+		auditor:                c.auditor,
+		cloudEvents:            c.cloudEvents,
+		cloudEventsEndpoint:    endpoint,
+		dnsEndpoint:            dns,
 	}, nil
 }
 
@@ -233,10 +457,32 @@ type external struct {
 	client resource.ClientApplicator
 	rest   *rest.Config
 	// localClient is specifically used to connect to local cluster, a.k.a control plane.
-	localClient     client.Client
-	sanitizeSecrets bool
-
-	kindObserver KindObserver
+	localClient client.Client
+	// configForProviderFn returns the config of other ProviderConfigs, e.g.
+	// to find Objects targeting the same cluster.
+	configForProviderFn func(ctx context.Context, local client.Client, providerConfigName string) (*rest.Config, error)
+	policies            client.Reader
+	sanitizeSecrets     bool
+
+	kindObserver     KindObserver
+	resourceVersions ResourceVersionReader
+	owners           *resourceOwners
+	schemas          *schemaValidator
+	readiness        *readinessPrograms
+	history          *historyRecorder
+	manifests        *manifest.Fetcher
+	targetOwners     *targetOwners
+
+	recorder               event.Recorder
+	slowAdmissionThreshold time.Duration
+	auditor                audit.AuditLogger
+	cloudEvents            CloudEventsEmitter
+	cloudEventsEndpoint    string
+	dnsEndpoint            *apisv1alpha1.DNSEndpointConfig
+
+	// templateValues are the template values of the Object, resolved once
+	// per reconcile.
+	templateValues map[string]string
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -246,21 +492,80 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	c.logger.Debug("Observing", "resource", cr)
+	c.history.Record(ctx, cr)
+
+	// Until the current generation turns out to be up to date, a bumped
+	// generation is not synced yet, whatever the outcome of this observation.
+	updateSpecGenerationSynced(cr, false)
+
+	// Outside of its maintenance window the managed resource is observed,
+	// but neither created nor updated.
+	deferred, err := deferToMaintenanceWindow(cr, time.Now())
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
 
 	if !meta.WasDeleted(cr) {
 		// If the object is not being deleted, we need to resolve references
 		if err := c.resolveReferencies(ctx, cr); err != nil {
 			return managed.ExternalObservation{}, errors.Wrap(err, errResolveResourceReferences)
 		}
+		if err := c.recordCompositionRevision(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	} else if err := c.removeDNSEndpoint(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
 	}
 
-	desired, err := getDesired(cr)
+	desired, err := c.fetchDesired(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	mutated, err := c.applyMutatingPolicies(ctx, cr, desired)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
+	if err := stripIgnoredFields(cr, desired); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if isList(desired) {
+		return c.observeList(ctx, cr, desired, deferred)
+	}
+
+	if c.shouldWatch(cr) && !watchStopped(cr) {
+		gvks := []schema.GroupVersionKind{desired.GroupVersionKind()}
+		if waitsForEvent(cr) || propagatesEvents(cr) || recordsEventHistory(cr) {
+			gvks = append(gvks, eventGVK)
+		}
+		c.watchResources(cr, c.rest, cr.Spec.ProviderConfigReference.Name, gvks...)
+	} else if c.kindObserver != nil && propagatesEvents(cr) {
+		// Events are propagated from their informer, even if changes of the
+		// managed resource are not watched.
+		c.watchResources(cr, c.rest, cr.Spec.ProviderConfigReference.Name, eventGVK)
+	}
+	if c.kindObserver != nil && watchesNodes(cr) && !meta.WasDeleted(cr) {
+		c.watchResources(cr, c.rest, cr.Spec.ProviderConfigReference.Name, nodeGVK)
+	}
+	// Nodes change independently of the Object and its resource, so they
+	// are checked before observing the resource may be skipped.
+	if err := c.checkNodeAffinity(ctx, cr, desired); err != nil {
+		return managed.ExternalObservation{}, err
+	}
 
-	if c.shouldWatch(cr) {
-		c.kindObserver.WatchResources(c.rest, cr.Spec.ProviderConfigReference.Name, desired.GroupVersionKind())
+	hash, err := specHash(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	hash = withTemplateValues(hash, c.templateValues)
+	if cr.Spec.ForProvider.ManifestURL != "" || mutated {
+		if hash, err = withDesiredManifest(hash, desired); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+	if c.canSkipObserve(ctx, cr, desired, hash) {
+		c.logger.Debug("SkippedObserve", "resourceVersion", cr.GetAnnotations()[AnnotationKeyLastObservedResourceVersion])
+		c.recordOwner(cr, types.UID(observedUID(cr)))
+		return c.upToDate(ctx, cr)
 	}
 
 	observed := desired.DeepCopy()
@@ -270,23 +575,83 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		Name:      observed.GetName(),
 	}, observed)
 
-	if kerrors.IsNotFound(err) {
-		return managed.ExternalObservation{ResourceExists: false}, nil
+	if kerrors.IsNotFound(err) || crdNotInstalled(cr, err) {
+		cr.Status.Divergence = nil
+		return c.deferredObservation(deferred, managed.ExternalObservation{ResourceExists: false}), nil
 	}
 
+	if apimeta.IsNoMatchError(err) {
+		if merr := c.checkAPIVersion(cr, desired.GroupVersionKind()); merr != nil {
+			return managed.ExternalObservation{}, merr
+		}
+	}
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetObject)
 	}
+	clearAPIVersionMismatch(cr)
+
+	if stopWaitingForDeletion(cr, observed) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err = c.handleOwnerConflicts(ctx, cr, desired, observed); err != nil {
+		return managed.ExternalObservation{}, err
+	}
 
 	if err = c.setObserved(cr, observed); err != nil {
 		return managed.ExternalObservation{}, err
 	}
+	if err = setDivergence(cr, desired, observed); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err = c.observeTerminal(cr, observed); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err = c.updateConditionFromEvents(ctx, cr, observed); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if err = c.updateEventHistory(ctx, cr, observed); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if !meta.WasDeleted(cr) {
+		if err = c.ensureSidecars(ctx, cr, observed); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if err = c.propagateDNS(ctx, cr, observed); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
+	sigHash, err := significantFieldHash(cr, observed)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if significantFieldsDrifted(cr, hash, sigHash) {
+		c.logger.Debug("SignificantFieldsDrifted", "resourceVersion", observed.GetResourceVersion())
+		if immutable(cr) {
+			return c.immutableObservation(ctx, cr)
+		}
+		return c.deferredObservation(deferred, managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}), nil
+	}
+	if canSkipApply(cr, observed, hash, sigHash) {
+		c.logger.Debug("SkippedApply", "resourceVersion", observed.GetResourceVersion())
+		return c.upToDate(ctx, cr)
+	}
 
 	var last *unstructured.Unstructured
 	if last, err = getLastApplied(cr, observed); err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetLastApplied)
 	}
-	return c.handleLastApplied(ctx, cr, last, desired)
+	obs, err := c.handleLastApplied(ctx, cr, last, desired, observed)
+	if err != nil {
+		return obs, err
+	}
+	if !obs.ResourceUpToDate {
+		if immutable(cr) {
+			return c.immutableObservation(ctx, cr)
+		}
+		return c.deferredObservation(deferred, obs), nil
+	}
+	return obs, c.recordUpToDate(ctx, cr, observed, hash, sigHash)
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -297,18 +662,62 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	c.logger.Debug("Creating", "resource", cr)
 
-	obj, err := getDesired(cr)
+	obj, err := c.fetchDesired(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	mutated, err := c.applyMutatingPolicies(ctx, cr, obj)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := stripIgnoredFields(cr, obj); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if isList(obj) {
+		return managed.ExternalCreation{}, c.applyList(ctx, cr, obj, audit.ActionCreate)
+	}
+	effective, err := effectiveManifest(cr, obj, mutated)
 	if err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
+	if err := c.ensureCRD(ctx, cr, obj); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := c.validateManifest(ctx, cr, obj); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := c.setOwnerReference(ctx, cr, obj); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := pinCompositeGeneration(cr, obj, false); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if ns := obj.GetNamespace(); cr.Spec.ForProvider.AutoCreateNamespace && ns != "" {
+		if err := c.ensureNamespace(ctx, ns); err != nil {
+			return managed.ExternalCreation{}, err
+		}
+	}
+
 	meta.AddAnnotations(obj, map[string]string{
-		v1.LastAppliedConfigAnnotation: string(cr.Spec.ForProvider.Manifest.Raw),
+		v1.LastAppliedConfigAnnotation: effective,
 	})
 
-	if err := c.client.Create(ctx, obj); err != nil {
+	start := time.Now()
+	err = c.client.Create(ctx, obj)
+	c.observeAdmission(cr, obj, time.Since(start))
+	c.logAudit(ctx, cr, obj, audit.ActionCreate, nil, err)
+	c.emitCloudEvent(ctx, cr, audit.ActionCreate, err)
+	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateObject)
 	}
+	if err := c.recordEffectiveManifest(ctx, cr, effective); err != nil {
+		return managed.ExternalCreation{}, err
+	}
 
 	return managed.ExternalCreation{}, c.setObserved(cr, obj)
 }
@@ -321,18 +730,67 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	c.logger.Debug("Updating", "resource", cr)
 
-	obj, err := getDesired(cr)
+	obj, err := c.fetchDesired(ctx, cr)
 	if err != nil {
 		return managed.ExternalUpdate{}, err
 	}
+	mutated, err := c.applyMutatingPolicies(ctx, cr, obj)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := stripIgnoredFields(cr, obj); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if isList(obj) {
+		if err := c.applyList(ctx, cr, obj, audit.ActionUpdate); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		return managed.ExternalUpdate{}, c.removeForceUpdate(ctx, cr)
+	}
+	effective, err := effectiveManifest(cr, obj, mutated)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := c.ensureCRD(ctx, cr, obj); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := c.validateManifest(ctx, cr, obj); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := c.setOwnerReference(ctx, cr, obj); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := pinCompositeGeneration(cr, obj, true); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 
 	meta.AddAnnotations(obj, map[string]string{
-		v1.LastAppliedConfigAnnotation: string(cr.Spec.ForProvider.Manifest.Raw),
+		v1.LastAppliedConfigAnnotation: effective,
 	})
 
-	if err := c.client.Apply(ctx, obj); err != nil {
+	from := lastAppliedManifest(cr)
+	start := time.Now()
+	if cr.Spec.ForProvider.PreserveUnmanagedFields {
+		err = c.applyPreservingUnmanagedFields(ctx, obj)
+	} else {
+		err = c.client.Apply(ctx, obj)
+	}
+	c.observeAdmission(cr, obj, time.Since(start))
+	c.logAudit(ctx, cr, obj, audit.ActionUpdate, from, err)
+	c.emitCloudEvent(ctx, cr, audit.ActionUpdate, err)
+	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(CleanErr(err), errApplyObject)
 	}
+	if err := c.recordEffectiveManifest(ctx, cr, effective); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := c.removeForceUpdate(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 
 	return managed.ExternalUpdate{}, c.setObserved(cr, obj)
 }
@@ -344,18 +802,272 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	}
 
 	c.logger.Debug("Deleting", "resource", cr)
+	c.targetOwners.Forget(cr.GetUID())
+	c.owners.Forget(cr.GetName())
+
+	obj, err := c.fetchDesired(ctx, cr)
+	if err != nil && cr.Spec.ForProvider.ManifestURL != "" && len(cr.Status.AtProvider.Manifest.Raw) > 0 {
+		// The resource is still deleted if its manifest cannot be fetched
+		// anymore, identified by its last observed state.
+		c.logger.Debug("Cannot fetch manifest, deleting the last observed resource", "error", err)
+		obj, err = desiredFromRaw(cr, cr.Status.AtProvider.Manifest.Raw)
+	}
+	if err != nil {
+		return err
+	}
+	if isList(obj) {
+		return c.deleteList(ctx, cr, obj)
+	}
 
-	obj, err := getDesired(cr)
+	err = resource.IgnoreNotFound(c.client.Delete(ctx, obj))
+	c.logAudit(ctx, cr, obj, audit.ActionDelete, nil, err)
+	c.emitCloudEvent(ctx, cr, audit.ActionDelete, err)
 	if err != nil {
+		return errors.Wrap(err, errDeleteObject)
+	}
+	c.readiness.Forget(cr.GetUID())
+
+	if ns := obj.GetNamespace(); cr.Spec.ForProvider.AutoCreateNamespace && ns != "" {
+		return c.cleanupNamespace(ctx, cr, ns)
+	}
+	return nil
+}
+
+// recordOwner records that the resource with the supplied UID is managed by
+// the supplied Object. The resources of Objects that are deleted are
+// forgotten instead, orphaned ones are not deleted through the Object.
+func (c *external) recordOwner(cr *v1alpha2.Object, uid types.UID) {
+	if meta.WasDeleted(cr) {
+		c.owners.Forget(cr.GetName())
+		return
+	}
+	c.owners.Record(uid, cr.GetName(), cr.GetProviderConfigReference().Name)
+}
+
+// observeAdmission records how long applying the desired manifest took, and
+// reports applies slower than the slow admission threshold, which are
+// usually caused by admission webhooks of the target cluster.
+func (c *external) observeAdmission(cr *v1alpha2.Object, obj *unstructured.Unstructured, d time.Duration) {
+	gvk := obj.GroupVersionKind()
+	applyDuration.WithLabelValues(gvk.GroupVersion().String(), gvk.Kind).Observe(d.Seconds())
+
+	if c.slowAdmissionThreshold <= 0 {
+		return
+	}
+	if d > c.slowAdmissionThreshold {
+		cond := v1alpha2.SlowAdmission(d, c.slowAdmissionThreshold)
+		cr.SetConditions(cond)
+		if c.recorder != nil {
+			c.recorder.Event(cr, event.Warning(event.Reason(cond.Reason), errors.New(cond.Message)))
+		}
+		return
+	}
+	if cr.GetCondition(v1alpha2.TypeSlowAdmission).Status == v1.ConditionTrue {
+		cr.SetConditions(v1alpha2.FastAdmission())
+	}
+}
+
+// watchResources watches the supplied kinds for the Object. Watches only
+// speed up reacting to changes, so failing to watch is reported through the
+// InformerLimitExceeded condition rather than failing the reconcile.
+func (c *external) watchResources(cr *v1alpha2.Object, rc *rest.Config, providerConfig string, gvks ...schema.GroupVersionKind) {
+	c.setWatchConditions(cr, c.kindObserver.WatchResources(rc, providerConfig, gvks...))
+}
+
+// watchReferencedResources watches the supplied kinds of resources the
+// Object references. The watch may be deferred until the managed resource
+// changes, if the kindObserver supports it.
+func (c *external) watchReferencedResources(cr *v1alpha2.Object, gvks ...schema.GroupVersionKind) {
+	w, ok := c.kindObserver.(ReferenceWatcher)
+	if !ok {
+		// Referenced resources always live on the control plane (i.e. local
+		// cluster), so we don't pass an extra rest config (defaulting local
+		// rest config) or provider config with the watch call.
+		c.watchResources(cr, nil, "", gvks...)
+		return
+	}
+	// Without a watched managed resource there are no events to start the
+	// watch with.
+	var trigger schema.GroupVersionKind
+	if d, err := indexedManifest(cr); err == nil && !watchStopped(cr) {
+		trigger = d.GroupVersionKind()
+	}
+	c.setWatchConditions(cr, w.WatchReferencedResources(c.rest, trigger, gvks...))
+}
+
+// setWatchConditions reports the outcome of watching resources.
+func (c *external) setWatchConditions(cr *v1alpha2.Object, err error) {
+	switch {
+	case isBudgetExceeded(err):
+		cr.SetConditions(v1alpha2.BudgetExceeded(err))
+		return
+	case err != nil:
+		cr.SetConditions(v1alpha2.InformerLimitExceeded(err))
+		return
+	}
+	if cr.GetCondition(v1alpha2.TypeInformerLimitExceeded).Status == v1.ConditionTrue {
+		cr.SetConditions(v1alpha2.InformerAvailable())
+	}
+	if cr.GetCondition(v1alpha2.TypeBudgetExceeded).Status == v1.ConditionTrue {
+		cr.SetConditions(v1alpha2.WithinBudget())
+	}
+}
+
+// validateManifest validates the desired manifest against the schema served
+// by the target cluster, if the Object asks for it.
+func (c *external) validateManifest(ctx context.Context, cr *v1alpha2.Object, obj *unstructured.Unstructured) error {
+	if !cr.Spec.Validation.ValidateAgainstSchema || c.schemas == nil {
+		return nil
+	}
+
+	s, err := c.schemas.SchemaFor(ctx, c.rest, cr.Spec.ProviderConfigReference.Name, obj.GroupVersionKind())
+	if err != nil {
+		return errors.Wrap(err, errGetSchema)
+	}
+
+	if err := validateAgainstSchema(s, obj); err != nil {
+		cr.SetConditions(v1alpha2.ValidationFailed(err))
 		return err
 	}
+	cr.SetConditions(v1alpha2.ValidationSucceeded())
+	return nil
+}
+
+// notReadyPollInterval is how often Objects that are not ready are polled,
+// not to delay their time to readiness.
+const notReadyPollInterval = 30 * time.Second
+
+// objectPollInterval returns when the supplied Object is reconciled next.
+// The reconcile period of an Object overrides the poll interval, and is not
+// jittered.
+func objectPollInterval(mg resource.Managed, pollInterval, pollJitter time.Duration) (d time.Duration) {
+	notReady := mg.GetCondition(xpv1.TypeReady).Status != v1.ConditionTrue
+
+	if cr, ok := mg.(*v1alpha2.Object); ok {
+		// Objects outside of their maintenance window are reconciled as
+		// soon as it opens.
+		if until, ok := untilMaintenanceWindow(cr, time.Now()); ok {
+			defer func() {
+				if until < d {
+					d = until
+				}
+			}()
+		}
+	}
+
+	if cr, ok := mg.(*v1alpha2.Object); ok && cr.Spec.ReconcilePolicy != nil && cr.Spec.ReconcilePolicy.Period != nil {
+		period := cr.Spec.ReconcilePolicy.Period.Duration
+		if notReady && period > notReadyPollInterval {
+			return notReadyPollInterval
+		}
+		return period
+	}
+
+	if notReady {
+		pollInterval = notReadyPollInterval
+	}
+	// This is the same as runtime default poll interval with jitter, see:
+	// https://github.com/crossplane/crossplane-runtime/blob/7fcb8c5cad6fc4abb6649813b92ab92e1832d368/pkg/reconciler/managed/reconciler.go#L573
+	return pollInterval + time.Duration((rand.Float64()-0.5)*2*float64(pollJitter)) //nolint G404 // No need for secure randomness
+}
 
-	return errors.Wrap(resource.IgnoreNotFound(c.client.Delete(ctx, obj)), errDeleteObject)
+func shouldSetOwnerReference(cr *v1alpha2.Object) bool {
+	// The garbage collector would delete orphaned resources anyway.
+	return cr.Spec.SetOwnerReference && cr.GetDeletionPolicy() != xpv1.DeletionOrphan
+}
+
+func hasOwnerReference(observed *unstructured.Unstructured, cr *v1alpha2.Object) bool {
+	for _, ref := range observed.GetOwnerReferences() {
+		if ref.UID == cr.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
+// setOwnerReference adds an owner reference to the Object to the desired
+// manifest, if the Object asks for it. The garbage collector resolves owner
+// references on the cluster of the dependent, so the Object must exist on
+// the target cluster, otherwise the managed resource would be collected
+// right away. Objects found on the target cluster once are not got again.
+func (c *external) setOwnerReference(ctx context.Context, cr *v1alpha2.Object, obj *unstructured.Unstructured) error {
+	if !shouldSetOwnerReference(cr) {
+		return nil
+	}
+
+	cluster := kube.ClusterIdentity(c.rest)
+	if !c.targetOwners.Found(cr.GetUID(), cluster) {
+		owner := &unstructured.Unstructured{}
+		owner.SetGroupVersionKind(v1alpha2.ObjectGroupVersionKind)
+		err := c.client.Get(ctx, types.NamespacedName{Name: cr.GetName()}, owner)
+		if kerrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return errors.New(errOwnerNotOnTargetCluster)
+		}
+		if err != nil {
+			return errors.Wrap(err, errGetOwnerOnTargetCluster)
+		}
+		if owner.GetUID() != cr.GetUID() {
+			return errors.New(errOwnerNotOnTargetCluster)
+		}
+		c.targetOwners.Record(cr.GetUID(), cluster)
+	}
+
+	meta.AddOwnerReference(obj, meta.AsOwner(meta.TypedReferenceTo(cr, v1alpha2.ObjectGroupVersionKind)))
+	return nil
+}
+
+// updateSpecGenerationSynced records the latest generation of the Object's
+// spec as reconciled if upToDate, and sets the SpecGenerationSynced condition
+// accordingly.
+func updateSpecGenerationSynced(obj *v1alpha2.Object, upToDate bool) {
+	if upToDate {
+		obj.Status.ObservedGeneration = obj.GetGeneration()
+	}
+	if obj.GetGeneration() > obj.Status.ObservedGeneration {
+		obj.Status.SetConditions(v1alpha2.SpecGenerationPending(obj.GetGeneration()))
+		return
+	}
+	obj.Status.SetConditions(v1alpha2.SpecGenerationSynced())
 }
 
 func getDesired(obj *v1alpha2.Object) (*unstructured.Unstructured, error) {
+	raw, err := manifest.Raw(obj.Spec.ForProvider)
+	if err != nil {
+		return nil, err
+	}
+	return desiredFromRaw(obj, raw)
+}
+
+// fetchDesired returns the desired manifest of the supplied Object like
+// getDesired, fetching it from its manifest URL if it has one. Templates in
+// its string fields are rendered with the template values of the Object.
+func (c *external) fetchDesired(ctx context.Context, obj *v1alpha2.Object) (*unstructured.Unstructured, error) {
+	var desired *unstructured.Unstructured
+	var err error
+	if obj.Spec.ForProvider.ManifestURL == "" {
+		desired, err = getDesired(obj)
+	} else {
+		if c.manifests == nil {
+			return nil, errors.New(errNoManifestFetcher)
+		}
+		var raw []byte
+		if raw, err = c.manifests.Fetch(ctx, obj.Spec.ForProvider); err != nil {
+			return nil, err
+		}
+		desired, err = desiredFromRaw(obj, raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := c.renderTemplateValues(ctx, obj, desired); err != nil {
+		return nil, err
+	}
+	return desired, nil
+}
+
+func desiredFromRaw(obj *v1alpha2.Object, raw []byte) (*unstructured.Unstructured, error) {
 	desired := &unstructured.Unstructured{}
-	if err := json.Unmarshal(obj.Spec.ForProvider.Manifest.Raw, desired); err != nil {
+	if err := json.Unmarshal(raw, desired); err != nil {
 		return nil, errors.Wrap(err, errUnmarshalTemplate)
 	}
 
@@ -399,6 +1111,7 @@ func (c *external) setObserved(obj *v1alpha2.Object, observed *unstructured.Unst
 	if obj.Status.AtProvider.Manifest.Raw, err = observed.MarshalJSON(); err != nil {
 		return errors.Wrap(err, errFailedToMarshalExisting)
 	}
+	c.recordOwner(obj, observed.GetUID())
 
 	if err := c.updateConditionFromObserved(obj, observed); err != nil {
 		return err
@@ -442,6 +1155,24 @@ func (c *external) updateConditionFromObserved(obj *v1alpha2.Object, observed *u
 		} else {
 			obj.SetConditions(xpv1.Unavailable())
 		}
+	case v1alpha2.ReadinessPolicyCustom:
+		prg, err := c.readiness.ProgramFor(obj)
+		if err != nil {
+			return err
+		}
+		ready, msg, err := evaluateReadiness(prg, observed)
+		if err != nil {
+			c.logger.Debug("Got error while evaluating custom readiness expression, setting it as Unavailable", "error", err, "observed", observed)
+			obj.SetConditions(xpv1.Unavailable().WithMessage(err.Error()))
+			return nil
+		}
+		if !ready {
+			obj.SetConditions(xpv1.Unavailable().WithMessage(msg))
+			return nil
+		}
+		obj.SetConditions(xpv1.Available().WithMessage(msg))
+	case v1alpha2.ReadinessPolicyWaitForEvent:
+		// do nothing, will be handled by c.updateConditionFromEvents method
 	case v1alpha2.ReadinessPolicySuccessfulCreate, "":
 		// do nothing, will be handled by c.handleLastApplied method
 		// "" should never happen, but just in case we will treat it as SuccessfulCreate for backward compatibility
@@ -514,17 +1245,19 @@ func (c *external) resolveReferencies(ctx context.Context, obj *v1alpha2.Object)
 		})
 	}
 
+	// Secrets of template values are watched like referenced resources.
+	if len(templateSecretRefs(obj)) > 0 {
+		gvks = append(gvks, secretGVK)
+	}
+
 	if c.shouldWatch(obj) {
-		// Referenced resources always live on the control plane (i.e. local cluster),
-		// so we don't pass an extra rest config (defaulting local rest config)
-		// or provider config with the watch call.
-		c.kindObserver.WatchResources(nil, "", gvks...)
+		c.watchReferencedResources(obj, gvks...)
 	}
 
 	return nil
 }
 
-func (c *external) handleLastApplied(ctx context.Context, obj *v1alpha2.Object, last, desired *unstructured.Unstructured) (managed.ExternalObservation, error) {
+func (c *external) handleLastApplied(ctx context.Context, obj *v1alpha2.Object, last, desired, observed *unstructured.Unstructured) (managed.ExternalObservation, error) {
 	isUpToDate := false
 
 	if !sets.New[xpv1.ManagementAction](obj.GetManagementPolicies()...).
@@ -532,39 +1265,54 @@ func (c *external) handleLastApplied(ctx context.Context, obj *v1alpha2.Object,
 		// Treated as up-to-date as we don't update or create the resource
 		isUpToDate = true
 	}
-	if last != nil && equality.Semantic.DeepEqual(last, desired) {
+	if c.lastAppliedUpToDate(obj, last, desired, observed) {
 		// Mark as up-to-date since last is equal to desired
 		isUpToDate = true
 	}
 
 	if isUpToDate {
 		c.logger.Debug("Up to date!")
+		return c.upToDate(ctx, obj)
+	}
 
-		if p := obj.Spec.Readiness.Policy; p == v1alpha2.ReadinessPolicySuccessfulCreate || p == "" {
-			obj.Status.SetConditions(xpv1.Available())
-		}
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: false,
+	}, nil
+}
 
-		cd, err := connectionDetails(ctx, c.client, obj.Spec.ConnectionDetails)
-		if err != nil {
-			return managed.ExternalObservation{}, errors.Wrap(err, errGetConnectionDetails)
-		}
+// lastAppliedUpToDate returns true if the supplied last applied manifest of
+// the observed resource equals the desired one.
+func (c *external) lastAppliedUpToDate(obj *v1alpha2.Object, last, desired, observed *unstructured.Unstructured) bool {
+	return last != nil && equality.Semantic.DeepEqual(last, desired) &&
+		(!shouldSetOwnerReference(obj) || hasOwnerReference(observed, obj))
+}
+
+// upToDate records the current generation of the supplied Object as synced
+// and returns an observation of its up to date resource.
+func (c *external) upToDate(ctx context.Context, obj *v1alpha2.Object) (managed.ExternalObservation, error) {
+	updateSpecGenerationSynced(obj, true)
 
-		return managed.ExternalObservation{
-			ResourceExists:    true,
-			ResourceUpToDate:  true,
-			ConnectionDetails: cd,
-		}, nil
+	if p := obj.Spec.Readiness.Policy; p == v1alpha2.ReadinessPolicySuccessfulCreate || p == "" {
+		obj.Status.SetConditions(xpv1.Available())
+	}
+
+	cd, err := connectionDetails(ctx, c.client, obj.Spec.ConnectionDetails)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetConnectionDetails)
 	}
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: false,
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: cd,
 	}, nil
 }
 
 type objFinalizer struct {
 	resource.Finalizer
-	client client.Client
+	client  client.Client
+	plugins *FinalizePlugins
 }
 
 type refFinalizerFn func(context.Context, *unstructured.Unstructured, string) error
@@ -610,12 +1358,17 @@ func (f *objFinalizer) AddFinalizer(ctx context.Context, res resource.Object) er
 		return errors.New(errNotKubernetesObject)
 	}
 
+	// Updating the Object resets its status to the stored one, so list the
+	// plugins once we are done.
+	defer f.plugins.list(obj)
+
 	if meta.FinalizerExists(obj, objFinalizerName) {
 		return nil
 	}
-	meta.AddFinalizer(obj, objFinalizerName)
-
-	err := f.client.Update(ctx, obj)
+	err := conflictRetry(ctx, f.client, obj, func() error {
+		meta.AddFinalizer(obj, objFinalizerName)
+		return f.client.Update(ctx, obj)
+	})
 	if err != nil {
 		return errors.Wrap(err, errAddFinalizer)
 	}
@@ -640,6 +1393,13 @@ func (f *objFinalizer) RemoveFinalizer(ctx context.Context, res resource.Object)
 		return errors.New(errNotKubernetesObject)
 	}
 
+	// Plugins block finalization until they cleaned up after the Object.
+	// Their status is stored along with the error the managed reconciler
+	// sets, so those that succeeded are not called again.
+	if err := f.plugins.finalize(ctx, obj); err != nil {
+		return errors.Wrap(err, errRemoveFinalizer)
+	}
+
 	// Remove finalizer from referenced resources if exists
 	err := f.handleRefFinalizer(ctx, obj, func(
 		ctx context.Context, res *unstructured.Unstructured, finalizer string) error {
@@ -658,9 +1418,10 @@ func (f *objFinalizer) RemoveFinalizer(ctx context.Context, res resource.Object)
 	if !meta.FinalizerExists(obj, objFinalizerName) {
 		return nil
 	}
-	meta.RemoveFinalizer(obj, objFinalizerName)
-
-	err = f.client.Update(ctx, obj)
+	err = conflictRetry(ctx, f.client, obj, func() error {
+		meta.RemoveFinalizer(obj, objFinalizerName)
+		return f.client.Update(ctx, obj)
+	})
 	return errors.Wrap(err, errRemoveFinalizer)
 }
 
@@ -695,6 +1456,28 @@ func connectionDetails(ctx context.Context, kube client.Client, connDetails []v1
 	return mcd, nil
 }
 
+// stopWaitingForDeletion reports whether a deleted Object with waitForDeletion
+// should stop waiting for its still terminating managed resource, because the
+// deletion timeout has elapsed.
+func stopWaitingForDeletion(cr *v1alpha2.Object, observed *unstructured.Unstructured) bool {
+	if !meta.WasDeleted(cr) || !cr.Spec.WaitForDeletion || observed.GetDeletionTimestamp() == nil {
+		return false
+	}
+
+	timeout := defaultDeletionTimeout
+	if cr.Spec.DeletionTimeout != nil {
+		timeout = cr.Spec.DeletionTimeout.Duration
+	}
+
+	if time.Since(cr.GetDeletionTimestamp().Time) > timeout {
+		cr.SetConditions(v1alpha2.DeletionTimedOut(timeout))
+		return true
+	}
+
+	cr.SetConditions(v1alpha2.TerminationWaiting())
+	return false
+}
+
 func (c *external) shouldWatch(cr *v1alpha2.Object) bool {
 	return c.kindObserver != nil && cr.Spec.Watch
 }
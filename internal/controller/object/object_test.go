@@ -371,6 +371,25 @@ func Test_helmExternal_Observe(t *testing.T) {
 				err: nil,
 			},
 		},
+		"OutsideMaintenanceWindow": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.MaintenanceWindow = &v1alpha2.MaintenanceWindow{Schedule: "0 0 1 1 *", Duration: metav1.Duration{Duration: time.Second}}
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							*obj.(*unstructured.Unstructured) = *externalResource()
+							return nil
+						}),
+					},
+				},
+			},
+			want: want{
+				out: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				err: nil,
+			},
+		},
 		"NotUpToDate": {
 			args: args{
 				mg: kubernetesObject(),
@@ -391,6 +410,98 @@ func Test_helmExternal_Observe(t *testing.T) {
 				err: nil,
 			},
 		},
+		"Immutable": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.Immutable = true
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							*obj.(*unstructured.Unstructured) =
+								*externalResourceWithLastAppliedConfigAnnotation(
+									`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"crossplane-system", "labels": {"old-label":"gone"}}}`,
+								)
+							return nil
+						}),
+					},
+				},
+			},
+			want: want{
+				out: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				err: nil,
+			},
+		},
+		"ImmutableForceUpdate": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Spec.Immutable = true
+					obj.SetAnnotations(map[string]string{AnnotationKeyForceUpdate: "true"})
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							*obj.(*unstructured.Unstructured) =
+								*externalResourceWithLastAppliedConfigAnnotation(
+									`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"crossplane-system", "labels": {"old-label":"gone"}}}`,
+								)
+							return nil
+						}),
+					},
+				},
+			},
+			want: want{
+				out: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				err: nil,
+			},
+		},
+		"WaitingForDeletion": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.SetDeletionTimestamp(&metav1.Time{Time: time.Now().Add(-time.Minute)})
+					obj.Spec.WaitForDeletion = true
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							*obj.(*unstructured.Unstructured) = *upToDateExternalResource()
+							obj.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+							return nil
+						}),
+					},
+				},
+			},
+			want: want{
+				out: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				err: nil,
+			},
+		},
+		"DeletionTimedOut": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.SetDeletionTimestamp(&metav1.Time{Time: time.Now().Add(-time.Hour)})
+					obj.Spec.WaitForDeletion = true
+					obj.Spec.DeletionTimeout = &metav1.Duration{Duration: 10 * time.Minute}
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							*obj.(*unstructured.Unstructured) = *upToDateExternalResource()
+							obj.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+							return nil
+						}),
+					},
+				},
+			},
+			want: want{
+				out: managed.ExternalObservation{ResourceExists: false},
+				err: nil,
+			},
+		},
 		"UpToDateNameDefaultsToObjectName": {
 			args: args{
 				mg: kubernetesObject(func(obj *v1alpha2.Object) {
@@ -845,6 +956,63 @@ func Test_helmExternal_Update(t *testing.T) {
 				err: nil,
 			},
 		},
+		"OwnerNotOnTargetCluster": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.SetUID("object-uid")
+					obj.Spec.SetOwnerReference = true
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, testObjectName)),
+					},
+				},
+			},
+			want: want{
+				err: errors.New(errOwnerNotOnTargetCluster),
+			},
+		},
+		"OwnerGetError": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.SetUID("object-uid")
+					obj.Spec.SetOwnerReference = true
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(errBoom),
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetOwnerOnTargetCluster),
+			},
+		},
+		"SuccessWithOwnerReference": {
+			args: args{
+				mg: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.SetUID("object-uid")
+					obj.Spec.SetOwnerReference = true
+				}),
+				client: resource.ClientApplicator{
+					Client: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							obj.SetUID("object-uid")
+							return nil
+						}),
+					},
+					Applicator: resource.ApplyFn(func(ctx context.Context, obj client.Object, op ...resource.ApplyOption) error {
+						if refs := obj.GetOwnerReferences(); len(refs) != 1 || refs[0].UID != "object-uid" {
+							t.Errorf("expected owner reference to the Object, got %v", refs)
+						}
+						return nil
+					}),
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"Success": {
 			args: args{
 				mg: kubernetesObject(),
@@ -1681,3 +1849,219 @@ func Test_updateConditionFromObserved(t *testing.T) {
 		})
 	}
 }
+
+func Test_updateSpecGenerationSynced(t *testing.T) {
+	type args struct {
+		obj      *v1alpha2.Object
+		upToDate bool
+	}
+	type want struct {
+		observedGeneration int64
+		conditions         []xpv1.Condition
+	}
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"PendingIfGenerationBumped": {
+			args: args{
+				obj: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Generation = 2
+					obj.Status.ObservedGeneration = 1
+				}),
+			},
+			want: want{
+				observedGeneration: 1,
+				conditions: []xpv1.Condition{
+					{
+						Type:    v1alpha2.TypeSpecGenerationSynced,
+						Status:  corev1.ConditionFalse,
+						Reason:  v1alpha2.ReasonGenerationPending,
+						Message: "Generation 2 was not yet reconciled",
+					},
+				},
+			},
+		},
+		"SyncedIfGenerationUpToDate": {
+			args: args{
+				obj: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Generation = 2
+					obj.Status.ObservedGeneration = 1
+				}),
+				upToDate: true,
+			},
+			want: want{
+				observedGeneration: 2,
+				conditions: []xpv1.Condition{
+					{
+						Type:   v1alpha2.TypeSpecGenerationSynced,
+						Status: corev1.ConditionTrue,
+						Reason: v1alpha2.ReasonGenerationSynced,
+					},
+				},
+			},
+		},
+		"SyncedIfGenerationAlreadyObserved": {
+			args: args{
+				obj: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.Generation = 2
+					obj.Status.ObservedGeneration = 2
+				}),
+			},
+			want: want{
+				observedGeneration: 2,
+				conditions: []xpv1.Condition{
+					{
+						Type:   v1alpha2.TypeSpecGenerationSynced,
+						Status: corev1.ConditionTrue,
+						Reason: v1alpha2.ReasonGenerationSynced,
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updateSpecGenerationSynced(tc.args.obj, tc.args.upToDate)
+			if diff := cmp.Diff(tc.want.observedGeneration, tc.args.obj.Status.ObservedGeneration); diff != "" {
+				t.Errorf("updateSpecGenerationSynced(...): -want observed generation, +got observed generation: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, tc.args.obj.Status.Conditions, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("updateSpecGenerationSynced(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_observeAdmission(t *testing.T) {
+	type args struct {
+		obj       *v1alpha2.Object
+		threshold time.Duration
+		d         time.Duration
+	}
+	cases := map[string]struct {
+		args
+		want []xpv1.Condition
+	}{
+		"Disabled": {
+			args: args{
+				obj: kubernetesObject(),
+				d:   time.Minute,
+			},
+		},
+		"Fast": {
+			args: args{
+				obj:       kubernetesObject(),
+				threshold: 5 * time.Second,
+				d:         time.Second,
+			},
+		},
+		"Slow": {
+			args: args{
+				obj:       kubernetesObject(),
+				threshold: 5 * time.Second,
+				d:         7 * time.Second,
+			},
+			want: []xpv1.Condition{
+				{
+					Type:    v1alpha2.TypeSlowAdmission,
+					Status:  corev1.ConditionTrue,
+					Reason:  v1alpha2.ReasonSlowAdmission,
+					Message: "Applying the manifest took 7s, more than the 5s threshold",
+				},
+			},
+		},
+		"NoLongerSlow": {
+			args: args{
+				obj: kubernetesObject(func(obj *v1alpha2.Object) {
+					obj.SetConditions(v1alpha2.SlowAdmission(7*time.Second, 5*time.Second))
+				}),
+				threshold: 5 * time.Second,
+				d:         time.Second,
+			},
+			want: []xpv1.Condition{
+				{
+					Type:   v1alpha2.TypeSlowAdmission,
+					Status: corev1.ConditionFalse,
+					Reason: v1alpha2.ReasonFastAdmission,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger:                 logging.NewNopLogger(),
+				slowAdmissionThreshold: tc.args.threshold,
+			}
+			e.observeAdmission(tc.args.obj, externalResource(), tc.args.d)
+			if diff := cmp.Diff(tc.want, tc.args.obj.Status.Conditions, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("observeAdmission(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_objectPollInterval(t *testing.T) {
+	period := func(d time.Duration) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.Spec.ReconcilePolicy = &v1alpha2.ReconcilePolicy{Period: &metav1.Duration{Duration: d}}
+		}
+	}
+	ready := func(obj *v1alpha2.Object) {
+		obj.SetConditions(xpv1.Available())
+	}
+
+	type args struct {
+		obj *v1alpha2.Object
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   time.Duration
+	}{
+		"ReadyDefault": {
+			reason: "We should use the poll interval if the Object has no reconcile period.",
+			args: args{
+				obj: kubernetesObject(ready),
+			},
+			want: 10 * time.Minute,
+		},
+		"NotReadyDefault": {
+			reason: "We should poll Objects that are not ready more frequently.",
+			args: args{
+				obj: kubernetesObject(),
+			},
+			want: notReadyPollInterval,
+		},
+		"ReadyWithPeriod": {
+			reason: "We should use the reconcile period of the Object instead of the poll interval.",
+			args: args{
+				obj: kubernetesObject(ready, period(time.Hour)),
+			},
+			want: time.Hour,
+		},
+		"NotReadyWithLongPeriod": {
+			reason: "We should poll Objects that are not ready more frequently than their reconcile period.",
+			args: args{
+				obj: kubernetesObject(period(time.Hour)),
+			},
+			want: notReadyPollInterval,
+		},
+		"NotReadyWithShortPeriod": {
+			reason: "We should keep a reconcile period shorter than the not ready poll interval.",
+			args: args{
+				obj: kubernetesObject(period(5 * time.Second)),
+			},
+			want: 5 * time.Second,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := objectPollInterval(tc.args.obj, 10*time.Minute, 0)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nobjectPollInterval(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	reasonConflictingOwner        event.Reason = "ConflictingOwner"
+	reasonConflictingOwnerRemoved event.Reason = "ConflictingOwnerRemoved"
+
+	errRemoveConflictingOwners = "cannot remove conflicting owner references"
+)
+
+// handleOwnerConflicts reports owner references other controllers, e.g. a
+// StatefulSet controller adopting a Pod, added to the observed resource by
+// the OwnerReferenceConflict condition. Such owners may delete the resource
+// behind the back of the Object, or block its deletion. If the Object asks
+// for it, the conflicting owner references are removed instead.
+func (c *external) handleOwnerConflicts(ctx context.Context, cr *v1alpha2.Object, desired, observed *unstructured.Unstructured) error {
+	conflicts := conflictingOwnerReferences(desired, observed)
+	if len(conflicts) == 0 {
+		if cr.GetCondition(v1alpha2.TypeOwnerReferenceConflict).Status == corev1.ConditionTrue {
+			cr.SetConditions(v1alpha2.NoOwnerReferenceConflict())
+		}
+		return nil
+	}
+
+	owners := make([]string, 0, len(conflicts))
+	for _, ref := range conflicts {
+		owners = append(owners, fmt.Sprintf("%s/%s/%s", ref.Kind, ref.Name, ref.UID))
+	}
+
+	if cr.Spec.OwnerConflictResolution != v1alpha2.OwnerConflictResolutionRemove || !managesResource(cr) {
+		if cr.GetCondition(v1alpha2.TypeOwnerReferenceConflict).Status != corev1.ConditionTrue && c.recorder != nil {
+			c.recorder.Event(cr, event.Warning(reasonConflictingOwner, errors.Errorf("The managed resource was adopted by %v", owners)))
+		}
+		cr.SetConditions(v1alpha2.OwnerReferenceConflict(owners))
+		return nil
+	}
+
+	// The owner references are replaced as a whole, the optimistic lock
+	// makes sure none added in the meantime are dropped.
+	orig := observed.DeepCopy()
+	observed.SetOwnerReferences(withoutOwnerReferences(observed.GetOwnerReferences(), conflicts))
+	if err := c.client.Patch(ctx, observed, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{})); err != nil {
+		return errors.Wrap(err, errRemoveConflictingOwners)
+	}
+	if c.recorder != nil {
+		c.recorder.Event(cr, event.Normal(reasonConflictingOwnerRemoved, fmt.Sprintf("Removed the owner references of %v from the managed resource", owners)))
+	}
+	cr.SetConditions(v1alpha2.NoOwnerReferenceConflict())
+	return nil
+}
+
+// conflictingOwnerReferences returns the owner references of the observed
+// resource that neither point to an Object nor are part of the desired
+// manifest.
+func conflictingOwnerReferences(desired, observed *unstructured.Unstructured) []metav1.OwnerReference {
+	var conflicts []metav1.OwnerReference
+	for _, ref := range observed.GetOwnerReferences() {
+		if isObjectReference(ref) || hasOwnerReferenceUID(desired.GetOwnerReferences(), ref.UID) {
+			continue
+		}
+		conflicts = append(conflicts, ref)
+	}
+	return conflicts
+}
+
+// isObjectReference returns true if the supplied owner reference points to
+// an Object, i.e. was set by the provider.
+func isObjectReference(ref metav1.OwnerReference) bool {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return false
+	}
+	return gv.Group == v1alpha2.Group && ref.Kind == v1alpha2.ObjectKind
+}
+
+func hasOwnerReferenceUID(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, ref := range refs {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutOwnerReferences(refs, remove []metav1.OwnerReference) []metav1.OwnerReference {
+	kept := make([]metav1.OwnerReference, 0, len(refs))
+	for _, ref := range refs {
+		if !hasOwnerReferenceUID(remove, ref.UID) {
+			kept = append(kept, ref)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestHandleOwnerConflicts(t *testing.T) {
+	errBoom := errors.New("boom")
+	objectOwner := metav1.OwnerReference{APIVersion: v1alpha2.SchemeGroupVersion.String(), Kind: v1alpha2.ObjectKind, Name: testObjectName, UID: "object-uid"}
+	desiredOwner := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "config", UID: "config-uid"}
+	adopter := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "web", UID: "web-uid"}
+	withOwners := func(refs ...metav1.OwnerReference) *unstructured.Unstructured {
+		u := externalResource()
+		u.SetOwnerReferences(refs)
+		return u
+	}
+	removeConflicts := func(obj *v1alpha2.Object) {
+		obj.Spec.OwnerConflictResolution = v1alpha2.OwnerConflictResolutionRemove
+	}
+	conflicting := func(obj *v1alpha2.Object) {
+		obj.SetConditions(v1alpha2.OwnerReferenceConflict([]string{"StatefulSet/web/web-uid"}))
+	}
+
+	type args struct {
+		cr       *v1alpha2.Object
+		client   *test.MockClient
+		observed *unstructured.Unstructured
+	}
+	type want struct {
+		err        error
+		conditions []xpv1.Condition
+		owners     []metav1.OwnerReference
+		events     []event.Event
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoConflict": {
+			reason: "We should not set a condition if only the provider and the manifest own the resource.",
+			args: args{
+				cr:       kubernetesObject(),
+				observed: withOwners(objectOwner, desiredOwner),
+			},
+			want: want{
+				owners: []metav1.OwnerReference{objectOwner, desiredOwner},
+			},
+		},
+		"ConflictResolved": {
+			reason: "We should clear the condition once the conflicting owner is gone.",
+			args: args{
+				cr:       kubernetesObject(conflicting),
+				observed: withOwners(objectOwner),
+			},
+			want: want{
+				conditions: []xpv1.Condition{v1alpha2.NoOwnerReferenceConflict()},
+				owners:     []metav1.OwnerReference{objectOwner},
+			},
+		},
+		"Conflict": {
+			reason: "We should report owners added by other controllers by kind, name and UID.",
+			args: args{
+				cr:       kubernetesObject(),
+				observed: withOwners(objectOwner, adopter),
+			},
+			want: want{
+				conditions: []xpv1.Condition{v1alpha2.OwnerReferenceConflict([]string{"StatefulSet/web/web-uid"})},
+				owners:     []metav1.OwnerReference{objectOwner, adopter},
+				events:     []event.Event{event.Warning(reasonConflictingOwner, errors.New("The managed resource was adopted by [StatefulSet/web/web-uid]"))},
+			},
+		},
+		"ConflictReported": {
+			reason: "We should not emit another event for a conflict that was already reported.",
+			args: args{
+				cr:       kubernetesObject(conflicting),
+				observed: withOwners(adopter),
+			},
+			want: want{
+				conditions: []xpv1.Condition{v1alpha2.OwnerReferenceConflict([]string{"StatefulSet/web/web-uid"})},
+				owners:     []metav1.OwnerReference{adopter},
+			},
+		},
+		"ObserveOnly": {
+			reason: "We should not remove owners from resources the Object does not manage.",
+			args: args{
+				cr: kubernetesObject(removeConflicts, func(obj *v1alpha2.Object) {
+					obj.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionObserve}
+				}),
+				observed: withOwners(adopter),
+			},
+			want: want{
+				conditions: []xpv1.Condition{v1alpha2.OwnerReferenceConflict([]string{"StatefulSet/web/web-uid"})},
+				owners:     []metav1.OwnerReference{adopter},
+				events:     []event.Event{event.Warning(reasonConflictingOwner, errors.New("The managed resource was adopted by [StatefulSet/web/web-uid]"))},
+			},
+		},
+		"Remove": {
+			reason: "We should remove conflicting owners if the Object asks for it.",
+			args: args{
+				cr: kubernetesObject(removeConflicts, conflicting),
+				client: &test.MockClient{
+					MockPatch: func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if diff := cmp.Diff([]metav1.OwnerReference{objectOwner}, obj.GetOwnerReferences()); diff != "" {
+							t.Errorf("Patch(...): -want owners, +got owners:\n%s", diff)
+						}
+						return nil
+					},
+				},
+				observed: withOwners(objectOwner, adopter),
+			},
+			want: want{
+				conditions: []xpv1.Condition{v1alpha2.NoOwnerReferenceConflict()},
+				owners:     []metav1.OwnerReference{objectOwner},
+				events:     []event.Event{event.Normal(reasonConflictingOwnerRemoved, "Removed the owner references of [StatefulSet/web/web-uid] from the managed resource")},
+			},
+		},
+		"RemoveError": {
+			reason: "We should return an error if the conflicting owners cannot be removed.",
+			args: args{
+				cr: kubernetesObject(removeConflicts),
+				client: &test.MockClient{
+					MockPatch: test.NewMockPatchFn(errBoom),
+				},
+				observed: withOwners(adopter),
+			},
+			want: want{
+				err:    errors.Wrap(errBoom, errRemoveConflictingOwners),
+				owners: []metav1.OwnerReference{},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &eventRecorder{}
+			e := &external{logger: logging.NewNopLogger(), client: resource.ClientApplicator{Client: tc.args.client}, recorder: rec}
+			err := e.handleOwnerConflicts(context.Background(), tc.args.cr, externalResource(func(u *unstructured.Unstructured) {
+				u.SetOwnerReferences([]metav1.OwnerReference{desiredOwner})
+			}), tc.args.observed)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.handleOwnerConflicts(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, tc.args.cr.Status.Conditions, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\ne.handleOwnerConflicts(...): -want conditions, +got conditions:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.owners, tc.args.observed.GetOwnerReferences(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\ne.handleOwnerConflicts(...): -want owners, +got owners:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, rec.events, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.handleOwnerConflicts(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+)
+
+const (
+	errGetProviderConfigCluster = "cannot get the cluster of the ProviderConfig"
+	errOwnerReferenceCluster    = "setOwnerReference requires ProviderConfig %q to target the cluster the Object lives in"
+)
+
+// An OwnerReferenceValidator rejects Objects that ask for an owner reference
+// to be set on their managed resource while their ProviderConfig targets
+// another cluster than the one the Object lives in. The garbage collector of
+// that cluster would delete the resource right away. Objects whose
+// ProviderConfig does not exist yet are admitted, the reconciler refuses to
+// set the owner reference for them later if need be.
+type OwnerReferenceValidator struct {
+	client client.Client
+	local  *rest.Config
+
+	configForProviderFn func(ctx context.Context, local client.Client, providerConfigName string) (*rest.Config, error)
+}
+
+var _ admission.CustomValidator = &OwnerReferenceValidator{}
+
+// NewOwnerReferenceValidator returns an OwnerReferenceValidator that reads
+// ProviderConfigs using the supplied client, and compares the clusters they
+// target with the one of the supplied config.
+func NewOwnerReferenceValidator(c client.Client, local *rest.Config) *OwnerReferenceValidator {
+	return &OwnerReferenceValidator{client: c, local: local, configForProviderFn: kube.ConfigForProvider}
+}
+
+// ValidateCreate rejects an Object that sets an owner reference on a
+// resource on another cluster.
+func (v *OwnerReferenceValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr, ok := obj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	return nil, v.validateOwnerReference(ctx, cr)
+}
+
+// ValidateUpdate rejects an Object that starts setting an owner reference on
+// a resource on another cluster, or changes to a ProviderConfig of another
+// cluster while setting one.
+func (v *OwnerReferenceValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	cr, ok := newObj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	if shouldSetOwnerReference(old) && providerConfigName(old) == providerConfigName(cr) {
+		return nil, nil
+	}
+	return nil, v.validateOwnerReference(ctx, cr)
+}
+
+// ValidateDelete never rejects an Object.
+func (v *OwnerReferenceValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *OwnerReferenceValidator) validateOwnerReference(ctx context.Context, cr *v1alpha2.Object) error {
+	name := providerConfigName(cr)
+	if !shouldSetOwnerReference(cr) || name == "" {
+		return nil
+	}
+	rc, err := v.configForProviderFn(ctx, v.client, name)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetProviderConfigCluster)
+	}
+	if kube.ClusterIdentity(rc) != kube.ClusterIdentity(v.local) {
+		return errors.Errorf(errOwnerReferenceCluster, name)
+	}
+	return nil
+}
+
+// targetOwners remembers the Objects that were found on the target cluster of
+// their managed resource, so that setting their owner reference does not get
+// them from that cluster on every reconcile. UIDs are never reused, so an
+// Object found once stays there until it is deleted. A nil targetOwners
+// remembers nothing.
+type targetOwners struct {
+	found sync.Map // targetOwner -> struct{}
+}
+
+type targetOwner struct {
+	uid     types.UID
+	cluster string
+}
+
+// Found returns true if the Object with the supplied UID was found on the
+// supplied cluster before.
+func (o *targetOwners) Found(uid types.UID, cluster string) bool {
+	if o == nil {
+		return false
+	}
+	_, ok := o.found.Load(targetOwner{uid: uid, cluster: cluster})
+	return ok
+}
+
+// Record that the Object with the supplied UID exists on the supplied
+// cluster.
+func (o *targetOwners) Record(uid types.UID, cluster string) {
+	if o == nil {
+		return
+	}
+	o.found.Store(targetOwner{uid: uid, cluster: cluster}, struct{}{})
+}
+
+// Forget the Object with the supplied UID, e.g. once it is deleted.
+func (o *targetOwners) Forget(uid types.UID) {
+	if o == nil {
+		return
+	}
+	o.found.Range(func(k, _ any) bool {
+		if k.(targetOwner).uid == uid {
+			o.found.Delete(k)
+		}
+		return true
+	})
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+)
+
+func TestOwnerReferenceValidator(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	setOwnerReference := func(obj *v1alpha2.Object) {
+		obj.Spec.SetOwnerReference = true
+	}
+	targeting := func(host string, err error) func(context.Context, client.Client, string) (*rest.Config, error) {
+		return func(context.Context, client.Client, string) (*rest.Config, error) {
+			if err != nil {
+				return nil, err
+			}
+			return &rest.Config{Host: host}, nil
+		}
+	}
+
+	type args struct {
+		configForProvider func(context.Context, client.Client, string) (*rest.Config, error)
+		old               *v1alpha2.Object
+		obj               *v1alpha2.Object
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   error
+	}{
+		"NoOwnerReference": {
+			reason: "We should admit Objects that do not set an owner reference.",
+			args: args{
+				configForProvider: targeting("https://remote", nil),
+				obj:               kubernetesObject(),
+			},
+		},
+		"Orphan": {
+			reason: "We should admit Objects whose owner reference is ignored because they orphan their resource.",
+			args: args{
+				configForProvider: targeting("https://remote", nil),
+				obj: kubernetesObject(setOwnerReference, func(obj *v1alpha2.Object) {
+					obj.SetDeletionPolicy(xpv1.DeletionOrphan)
+				}),
+			},
+		},
+		"SameCluster": {
+			reason: "We should admit Objects setting an owner reference on a resource on their own cluster.",
+			args: args{
+				configForProvider: targeting("https://LOCAL:443", nil),
+				obj:               kubernetesObject(setOwnerReference),
+			},
+		},
+		"OtherCluster": {
+			reason: "We should reject Objects setting an owner reference on a resource on another cluster.",
+			args: args{
+				configForProvider: targeting("https://remote", nil),
+				obj:               kubernetesObject(setOwnerReference),
+			},
+			want: errors.Errorf(errOwnerReferenceCluster, providerName),
+		},
+		"ProviderConfigNotFound": {
+			reason: "We should admit Objects created before their ProviderConfig.",
+			args: args{
+				configForProvider: targeting("", errors.Wrap(kerrors.NewNotFound(schema.GroupResource{}, providerName), "cannot get ProviderConfig")),
+				obj:               kubernetesObject(setOwnerReference),
+			},
+		},
+		"ConfigError": {
+			reason: "We should reject Objects if the cluster of their ProviderConfig cannot be told.",
+			args: args{
+				configForProvider: targeting("", errBoom),
+				obj:               kubernetesObject(setOwnerReference),
+			},
+			want: errors.Wrap(errBoom, errGetProviderConfigCluster),
+		},
+		"UpdateUnchanged": {
+			reason: "We should admit updates of Objects that already set an owner reference with the same ProviderConfig.",
+			args: args{
+				configForProvider: targeting("https://remote", nil),
+				old:               kubernetesObject(setOwnerReference),
+				obj:               kubernetesObject(setOwnerReference),
+			},
+		},
+		"UpdateEnabled": {
+			reason: "We should reject updates of Objects that start setting an owner reference on a resource on another cluster.",
+			args: args{
+				configForProvider: targeting("https://remote", nil),
+				old:               kubernetesObject(),
+				obj:               kubernetesObject(setOwnerReference),
+			},
+			want: errors.Errorf(errOwnerReferenceCluster, providerName),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := NewOwnerReferenceValidator(&test.MockClient{}, &rest.Config{Host: "https://local"})
+			v.configForProviderFn = tc.args.configForProvider
+			var err error
+			if tc.args.old != nil {
+				_, err = v.ValidateUpdate(context.Background(), tc.args.old, tc.args.obj)
+			} else {
+				_, err = v.ValidateCreate(context.Background(), tc.args.obj)
+			}
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetOwnerReferenceFound(t *testing.T) {
+	cr := kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.SetUID("object-uid")
+		obj.Spec.SetOwnerReference = true
+	})
+	gets := 0
+	e := &external{
+		logger: logging.NewNopLogger(),
+		client: resource.ClientApplicator{
+			Client: &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					gets++
+					obj.SetUID("object-uid")
+					return nil
+				},
+			},
+		},
+		rest:         &rest.Config{Host: "https://local"},
+		targetOwners: &targetOwners{},
+	}
+
+	for i := 0; i < 2; i++ {
+		obj := &unstructured.Unstructured{}
+		if err := e.setOwnerReference(context.Background(), cr, obj); err != nil {
+			t.Fatalf("e.setOwnerReference(...): unexpected error: %v", err)
+		}
+		if refs := obj.GetOwnerReferences(); len(refs) != 1 || refs[0].UID != "object-uid" {
+			t.Errorf("e.setOwnerReference(...): want owner reference to the Object, got %v", refs)
+		}
+	}
+	if gets != 1 {
+		t.Errorf("e.setOwnerReference(...): want the Object got once from the target cluster, got %d times", gets)
+	}
+
+	e.targetOwners.Forget(cr.GetUID())
+	if e.targetOwners.Found(cr.GetUID(), kube.ClusterIdentity(e.rest)) {
+		t.Errorf("e.targetOwners.Forget(...): want the Object forgotten")
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// resourceOwners maps the UIDs of managed resources to the names of the
+// Objects managing them. It is updated whenever an Object observes its
+// resource, and traces deletions of resources back to their Object without
+// relying on the name and namespace in its manifest, which may e.g. omit the
+// namespace of the resource. A nil resourceOwners records nothing.
+type resourceOwners struct {
+	objects sync.Map // types.UID -> resourceOwner
+}
+
+// A resourceOwner is the Object managing a resource, through a provider
+// config.
+type resourceOwner struct {
+	object         string
+	providerConfig string
+}
+
+// Record that the resource with the supplied UID is managed by the supplied
+// Object through the supplied provider config.
+func (o *resourceOwners) Record(uid types.UID, object, providerConfig string) {
+	if o == nil || uid == "" {
+		return
+	}
+	o.objects.Store(uid, resourceOwner{object: object, providerConfig: providerConfig})
+}
+
+// Forget the resources managed by the supplied Object, e.g. because it is
+// deleted.
+func (o *resourceOwners) Forget(object string) {
+	o.forget(func(ro resourceOwner) bool { return ro.object == object })
+}
+
+// ForgetProviderConfig forgets the resources managed through the supplied
+// provider config, e.g. because its informers were stopped. Objects record
+// their resources again when they observe them.
+func (o *resourceOwners) ForgetProviderConfig(providerConfig string) {
+	o.forget(func(ro resourceOwner) bool { return ro.providerConfig == providerConfig })
+}
+
+func (o *resourceOwners) forget(matches func(ro resourceOwner) bool) {
+	if o == nil {
+		return
+	}
+	o.objects.Range(func(uid, v any) bool {
+		if matches(v.(resourceOwner)) {
+			o.objects.Delete(uid)
+		}
+		return true
+	})
+}
+
+// EnqueueOwner enqueues the Object managing the supplied deleted resource, if
+// it is known.
+func (o *resourceOwners) EnqueueOwner(log logging.Logger, deleted client.Object, q workqueue.RateLimitingInterface) {
+	if o == nil {
+		return
+	}
+	v, ok := o.objects.LoadAndDelete(deleted.GetUID())
+	if !ok {
+		return
+	}
+	name := v.(resourceOwner).object
+	log.Info("Enqueueing Object because its resource was deleted", "name", name, "gvk", deleted.GetObjectKind().GroupVersionKind().String(), "resourceName", deleted.GetName(), "resourceNamespace", deleted.GetNamespace())
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+func TestResourceOwnersEnqueueOwner(t *testing.T) {
+	deleted := &unstructured.Unstructured{}
+	deleted.SetUID("resource-uid")
+
+	cases := map[string]struct {
+		reason  string
+		record  map[types.UID]string
+		deleted *unstructured.Unstructured
+		want    []reconcile.Request
+	}{
+		"KnownOwner": {
+			reason:  "We should enqueue the Object that last observed the deleted resource.",
+			record:  map[types.UID]string{"resource-uid": "my-object", "other-uid": "other-object"},
+			deleted: deleted,
+			want:    []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "my-object"}}},
+		},
+		"UnknownOwner": {
+			reason:  "We should not enqueue anything for resources no Object observed.",
+			record:  map[types.UID]string{"other-uid": "other-object"},
+			deleted: deleted,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := &resourceOwners{}
+			for uid, object := range tc.record {
+				o.Record(uid, object, providerName)
+			}
+
+			q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer q.ShutDown()
+			o.EnqueueOwner(logging.NewNopLogger(), tc.deleted, q)
+
+			var got []reconcile.Request
+			for q.Len() > 0 {
+				item, _ := q.Get()
+				got = append(got, item.(reconcile.Request))
+				q.Done(item)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\no.EnqueueOwner(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			// A resource is only deleted once, the owner must not be
+			// enqueued again.
+			o.EnqueueOwner(logging.NewNopLogger(), tc.deleted, q)
+			if q.Len() != 0 {
+				t.Errorf("\n%s\no.EnqueueOwner(...): want Object to be enqueued once", tc.reason)
+			}
+		})
+	}
+}
+
+func TestResourceOwnersForget(t *testing.T) {
+	record := func(o *resourceOwners) {
+		o.Record("a-uid", "a", "cluster-a")
+		o.Record("a-recreated-uid", "a", "cluster-a")
+		o.Record("b-uid", "b", "cluster-a")
+		o.Record("c-uid", "c", "cluster-b")
+	}
+
+	cases := map[string]struct {
+		reason string
+		forget func(o *resourceOwners)
+		want   map[types.UID]string
+	}{
+		"Object": {
+			reason: "We should forget every resource a deleted Object managed.",
+			forget: func(o *resourceOwners) { o.Forget("a") },
+			want:   map[types.UID]string{"b-uid": "b", "c-uid": "c"},
+		},
+		"ProviderConfig": {
+			reason: "We should forget the resources managed through a provider config whose informers stopped.",
+			forget: func(o *resourceOwners) {
+				i := &resourceInformers{log: logging.NewNopLogger(), owners: o}
+				i.stopResourceInformers("cluster-a")
+			},
+			want: map[types.UID]string{"c-uid": "c"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := &resourceOwners{}
+			record(o)
+			tc.forget(o)
+
+			got := map[types.UID]string{}
+			o.objects.Range(func(uid, v any) bool {
+				got[uid.(types.UID)] = v.(resourceOwner).object
+				return true
+			})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nresourceOwners: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
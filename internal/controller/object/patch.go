@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// conflictBackoff is how often and fast writes of Objects are retried on a
+// conflict.
+var conflictBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 10 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+}
+
+// conflictRetry calls write until it does not fail with a conflict, at most
+// conflictBackoff.Steps times. write must mutate and write the supplied
+// Object, which is refreshed to its latest version before every retry.
+// Objects are written concurrently by other controllers, e.g. the TTL
+// controller updating their status.
+func conflictRetry(ctx context.Context, c client.Reader, obj client.Object, write func() error) error {
+	attempt := 0
+	return retry.RetryOnConflict(conflictBackoff, func() error {
+		attempt++
+		if attempt > 1 {
+			if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+				return err
+			}
+		}
+		return write()
+	})
+}
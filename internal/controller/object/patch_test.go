@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestConflictRetry(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := v1alpha2.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("cannot add scheme: %v", err)
+	}
+
+	type want struct {
+		conflict  bool
+		finalizer bool
+	}
+	cases := map[string]struct {
+		reason string
+		// concurrent is the number of writes racing the retried one.
+		concurrent int
+		want       want
+	}{
+		"NoConflict": {
+			reason:     "We should write the Object if nothing else does.",
+			concurrent: 0,
+			want: want{
+				finalizer: true,
+			},
+		},
+		"Conflicts": {
+			reason:     "We should retry on conflicts with the latest version of the Object.",
+			concurrent: 3,
+			want: want{
+				finalizer: true,
+			},
+		},
+		"TooManyConflicts": {
+			reason:     "We should give up after conflicting on every retry.",
+			concurrent: conflictBackoff.Steps,
+			want: want{
+				conflict: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			stored := &v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{Name: "racy"}}
+			racing := tc.concurrent
+			c := fake.NewClientBuilder().
+				WithScheme(s).
+				WithObjects(stored).
+				WithStatusSubresource(stored).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+						if racing > 0 {
+							// Another controller updates the status in
+							// between, e.g. to set a condition.
+							racing--
+							o := &v1alpha2.Object{}
+							if err := c.Get(ctx, client.ObjectKeyFromObject(obj), o); err != nil {
+								return err
+							}
+							o.Status.SetConditions(xpv1.ReconcileSuccess())
+							if err := c.Status().Update(ctx, o); err != nil {
+								return err
+							}
+						}
+						return c.Update(ctx, obj, opts...)
+					},
+				}).
+				Build()
+
+			obj := &v1alpha2.Object{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(stored), obj); err != nil {
+				t.Fatalf("cannot get Object: %v", err)
+			}
+			err := conflictRetry(context.Background(), c, obj, func() error {
+				meta.AddFinalizer(obj, objFinalizerName)
+				return c.Update(context.Background(), obj)
+			})
+			if got := kerrors.IsConflict(err); got != tc.want.conflict {
+				t.Fatalf("\n%s\nconflictRetry(...): want conflict: %t, got error: %v", tc.reason, tc.want.conflict, err)
+			}
+			if !tc.want.conflict && err != nil {
+				t.Fatalf("\n%s\nconflictRetry(...): unexpected error: %v", tc.reason, err)
+			}
+
+			got := &v1alpha2.Object{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(stored), got); err != nil {
+				t.Fatalf("cannot get Object: %v", err)
+			}
+			if meta.FinalizerExists(got, objFinalizerName) != tc.want.finalizer {
+				t.Errorf("\n%s\nconflictRetry(...): want finalizer: %t, got finalizers: %v", tc.reason, tc.want.finalizer, got.GetFinalizers())
+			}
+			if tc.concurrent > 0 && got.Status.GetCondition(xpv1.TypeSynced).Reason != xpv1.ReasonReconcileSuccess {
+				t.Errorf("\n%s\nconflictRetry(...): lost the status written concurrently", tc.reason)
+			}
+		})
+	}
+}
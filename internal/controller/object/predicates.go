@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	runtimeevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+// providerConfigPredicate is implemented by predicates of resourceInformers
+// that depend on the provider config of the cluster an event originates
+// from.
+type providerConfigPredicate interface {
+	ForProviderConfig(providerConfig string) predicate.Predicate
+}
+
+var _ providerConfigPredicate = &watchPredicate{}
+
+// watchPredicate filters updates of watched resources by the watch
+// predicates of the Objects referencing or managing them. An update is
+// relevant if at least one of these Objects has no watch predicate, or if the
+// resource starts or stops matching the labels of its watch predicate.
+// Updates of referenced resources with a watch trigger are only relevant to
+// the referencing Object if they match the trigger instead.
+type watchPredicate struct {
+	objects client.Reader
+	log     logging.Logger
+}
+
+// Create implements predicate.Predicate.
+func (p *watchPredicate) Create(runtimeevent.CreateEvent) bool { return true }
+
+// Delete implements predicate.Predicate.
+func (p *watchPredicate) Delete(runtimeevent.DeleteEvent) bool { return true }
+
+// Update implements predicate.Predicate. Without a provider config it cannot
+// tell which Objects are affected, so it lets every update through.
+func (p *watchPredicate) Update(runtimeevent.UpdateEvent) bool { return true }
+
+// Generic implements predicate.Predicate.
+func (p *watchPredicate) Generic(runtimeevent.GenericEvent) bool { return true }
+
+// ForProviderConfig returns the predicate for events of resources on the
+// cluster of the supplied provider config.
+func (p *watchPredicate) ForProviderConfig(providerConfig string) predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(ev runtimeevent.UpdateEvent) bool {
+			return p.relevantUpdate(providerConfig, ev)
+		},
+		CreateFunc:  p.Create,
+		DeleteFunc:  p.Delete,
+		GenericFunc: p.Generic,
+	}
+}
+
+func (p *watchPredicate) relevantUpdate(providerConfig string, ev runtimeevent.UpdateEvent) bool {
+	// Nodes are only relevant to Objects checking whether they can run the
+	// pods of their managed resource.
+	if isNode(ev.ObjectNew) {
+		return nodeSchedulingChanged(ev)
+	}
+	// Updated Events were emitted again, e.g. because an image pull keeps
+	// failing.
+	if _, ok := involvedObjectOf(ev.ObjectNew); ok {
+		return true
+	}
+	// Other controllers adopting a resource are reported by every Object
+	// managing it, regardless of its watch predicate.
+	if (ownerReferencesChangedPredicate{}).Update(ev) {
+		return true
+	}
+	gvk := ev.ObjectNew.GetObjectKind().GroupVersionKind()
+	key := refKeyProviderNamespacedNameGVK(providerConfig, ev.ObjectNew.GetNamespace(), ev.ObjectNew.GetName(), gvk.Kind, gvk.GroupVersion().String())
+
+	objects := v1alpha2.ObjectList{}
+	if err := p.objects.List(context.Background(), &objects, client.MatchingFields{resourceRefsIndex: key}); err != nil {
+		p.log.Debug("cannot list objects related to a reference change", "error", err, "fieldSelector", resourceRefsIndex+"="+key)
+		return true
+	}
+
+	for _, o := range objects.Items {
+		if t, ok := referenceTrigger(&o, providerConfig, key); ok {
+			if t.Update(ev) {
+				return true
+			}
+			continue
+		}
+		wp := o.Spec.WatchPredicate
+		if wp == nil || len(wp.MatchLabels) == 0 {
+			return true
+		}
+		sel := labels.SelectorFromSet(wp.MatchLabels)
+		if sel.Matches(labels.Set(ev.ObjectOld.GetLabels())) != sel.Matches(labels.Set(ev.ObjectNew.GetLabels())) {
+			return true
+		}
+	}
+	return false
+}
+
+// referenceTrigger returns the predicate of the watch trigger of the
+// reference of the supplied Object to the resource with the supplied key, if
+// it has one.
+func referenceTrigger(o *v1alpha2.Object, providerConfig, key string) (predicate.Predicate, bool) {
+	// References are always local, i.e. on the control plane.
+	if providerConfig != "" {
+		return nil, false
+	}
+	for _, ref := range o.Spec.References {
+		if ref.WatchTrigger == nil || len(ref.WatchTrigger.AnnotationKeys) == 0 {
+			continue
+		}
+		apiVersion, kind, namespace, name := getReferenceInfo(ref)
+		if refKeyProviderNamespacedNameGVK("", namespace, name, kind, apiVersion) == key {
+			return annotationChangedPredicate{keys: ref.WatchTrigger.AnnotationKeys}, true
+		}
+	}
+	return nil, false
+}
+
+// annotationChangedPredicate only lets updates through that change the value
+// of at least one of the supplied annotations, including adding or removing
+// it.
+type annotationChangedPredicate struct {
+	predicate.Funcs
+	keys []string
+}
+
+// Update implements predicate.Predicate.
+func (p annotationChangedPredicate) Update(ev runtimeevent.UpdateEvent) bool {
+	if ev.ObjectOld == nil || ev.ObjectNew == nil {
+		return true
+	}
+	oldAnnotations, newAnnotations := ev.ObjectOld.GetAnnotations(), ev.ObjectNew.GetAnnotations()
+	for _, k := range p.keys {
+		ov, oldOk := oldAnnotations[k]
+		nv, newOk := newAnnotations[k]
+		if oldOk != newOk || ov != nv {
+			return true
+		}
+	}
+	return false
+}
+
+// ownerReferencesChangedPredicate only lets updates through that change the
+// owner references of a resource, e.g. because another controller adopted
+// it.
+type ownerReferencesChangedPredicate struct {
+	predicate.Funcs
+}
+
+// Update implements predicate.Predicate.
+func (ownerReferencesChangedPredicate) Update(ev runtimeevent.UpdateEvent) bool {
+	if ev.ObjectOld == nil || ev.ObjectNew == nil {
+		return true
+	}
+	return !equality.Semantic.DeepEqual(ev.ObjectOld.GetOwnerReferences(), ev.ObjectNew.GetOwnerReferences())
+}
+
+// WithOwnerUIDFilter returns a predicate for the sources of resourceInformers
+// that only lets the events of resources through that are owned by the
+// resource with the supplied UID. Controllers sharing the informers of
+// resources created by many owners, e.g. the Pods of many composites, use it
+// not to reconcile the resources of other owners. Updates are filtered by
+// the owner references of the new state of the resource.
+func WithOwnerUIDFilter(uid types.UID) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		for _, ref := range obj.GetOwnerReferences() {
+			if ref.UID == uid {
+				return true
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	runtimeevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func Test_watchPredicate_Update(t *testing.T) {
+	withLabels := func(l map[string]string) *unstructured.Unstructured {
+		u := externalResource()
+		u.SetLabels(l)
+		return u
+	}
+	listObjects := func(objs ...v1alpha2.Object) test.MockListFn {
+		return func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			list.(*v1alpha2.ObjectList).Items = objs
+			return nil
+		}
+	}
+	withPredicate := *kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.Spec.WatchPredicate = &v1alpha2.WatchPredicate{MatchLabels: map[string]string{"env": "prod"}}
+	})
+
+	type args struct {
+		list test.MockListFn
+		old  *unstructured.Unstructured
+		new  *unstructured.Unstructured
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"ListError": {
+			reason: "We should let updates through if we cannot tell which Objects are affected.",
+			args: args{
+				list: test.NewMockListFn(errors.New("boom")),
+				old:  withLabels(nil),
+				new:  withLabels(nil),
+			},
+			want: true,
+		},
+		"NoPredicate": {
+			reason: "We should let updates through if an Object has no watch predicate.",
+			args: args{
+				list: listObjects(*kubernetesObject(), withPredicate),
+				old:  withLabels(nil),
+				new:  withLabels(nil),
+			},
+			want: true,
+		},
+		"StartsMatching": {
+			reason: "We should let updates through if the resource starts matching the labels.",
+			args: args{
+				list: listObjects(withPredicate),
+				old:  withLabels(nil),
+				new:  withLabels(map[string]string{"env": "prod"}),
+			},
+			want: true,
+		},
+		"StopsMatching": {
+			reason: "We should let updates through if the resource stops matching the labels.",
+			args: args{
+				list: listObjects(withPredicate),
+				old:  withLabels(map[string]string{"env": "prod"}),
+				new:  withLabels(map[string]string{"env": "dev"}),
+			},
+			want: true,
+		},
+		"KeepsMatching": {
+			reason: "We should filter updates that do not change whether the resource matches the labels.",
+			args: args{
+				list: listObjects(withPredicate),
+				old:  withLabels(map[string]string{"env": "prod"}),
+				new:  withLabels(map[string]string{"env": "prod", "other": "label"}),
+			},
+			want: false,
+		},
+		"OwnerReferencesChanged": {
+			reason: "We should let updates through that change the owner references, whatever the watch predicate.",
+			args: args{
+				list: listObjects(withPredicate),
+				old:  withLabels(map[string]string{"env": "prod"}),
+				new: func() *unstructured.Unstructured {
+					u := withLabels(map[string]string{"env": "prod"})
+					u.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "web", UID: "web-uid"}})
+					return u
+				}(),
+			},
+			want: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &watchPredicate{
+				objects: &test.MockClient{MockList: tc.args.list},
+				log:     logging.NewNopLogger(),
+			}
+			got := p.ForProviderConfig(providerName).Update(runtimeevent.UpdateEvent{ObjectOld: tc.args.old, ObjectNew: tc.args.new})
+			if got != tc.want {
+				t.Errorf("\n%s\nUpdate(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_watchPredicate_UpdateWatchTrigger(t *testing.T) {
+	withAnnotations := func(a map[string]string) *unstructured.Unstructured {
+		u := externalResource()
+		u.SetAnnotations(a)
+		return u
+	}
+	withTrigger := *kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.Spec.References = []v1alpha2.Reference{{
+			DependsOn: &v1alpha2.DependsOn{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+				Name:       externalResourceName,
+			},
+			WatchTrigger: &v1alpha2.WatchTrigger{AnnotationKeys: []string{"example.org/config-hash"}},
+		}}
+	})
+
+	type args struct {
+		providerConfig string
+		old            *unstructured.Unstructured
+		new            *unstructured.Unstructured
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"AnnotationChanged": {
+			reason: "We should let updates through that change a trigger annotation.",
+			args: args{
+				old: withAnnotations(map[string]string{"example.org/config-hash": "a"}),
+				new: withAnnotations(map[string]string{"example.org/config-hash": "b"}),
+			},
+			want: true,
+		},
+		"AnnotationAdded": {
+			reason: "We should let updates through that add a trigger annotation.",
+			args: args{
+				old: withAnnotations(nil),
+				new: withAnnotations(map[string]string{"example.org/config-hash": ""}),
+			},
+			want: true,
+		},
+		"OtherAnnotationChanged": {
+			reason: "We should filter updates that do not change a trigger annotation.",
+			args: args{
+				old: withAnnotations(map[string]string{"example.org/config-hash": "a", "other": "a"}),
+				new: withAnnotations(map[string]string{"example.org/config-hash": "a", "other": "b"}),
+			},
+			want: false,
+		},
+		"RemoteResource": {
+			reason: "We should not apply triggers of references to resources of the target cluster.",
+			args: args{
+				providerConfig: providerName,
+				old:            withAnnotations(nil),
+				new:            withAnnotations(nil),
+			},
+			want: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &watchPredicate{
+				objects: &test.MockClient{MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+					list.(*v1alpha2.ObjectList).Items = []v1alpha2.Object{withTrigger}
+					return nil
+				}},
+				log: logging.NewNopLogger(),
+			}
+			got := p.ForProviderConfig(tc.args.providerConfig).Update(runtimeevent.UpdateEvent{ObjectOld: tc.args.old, ObjectNew: tc.args.new})
+			if got != tc.want {
+				t.Errorf("\n%s\nUpdate(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWithOwnerUIDFilter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got []string
+	h := handler.Funcs{
+		GenericFunc: func(_ context.Context, ev runtimeevent.GenericEvent, _ workqueue.RateLimitingInterface) {
+			got = append(got, "generic/"+ev.Object.GetName())
+		},
+		DeleteFunc: func(_ context.Context, ev runtimeevent.DeleteEvent, _ workqueue.RateLimitingInterface) {
+			got = append(got, "delete/"+ev.Object.GetName())
+		},
+	}
+	i := &resourceInformers{}
+	if err := i.Start(ctx, h, nil, WithOwnerUIDFilter("composite-a")); err != nil {
+		t.Fatalf("Start(...): unexpected error: %v", err)
+	}
+
+	pod := func(name string, owners ...types.UID) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetName(name)
+		for _, uid := range owners {
+			u.SetOwnerReferences(append(u.GetOwnerReferences(), metav1.OwnerReference{APIVersion: "example.org/v1", Kind: "XApp", Name: string(uid), UID: uid}))
+		}
+		return u
+	}
+	r := sinkRoute{providerConfig: providerName}
+	i.dispatch(r, runtimeevent.GenericEvent{Object: pod("owned", "composite-b", "composite-a")}, nil, false)
+	i.dispatch(r, runtimeevent.GenericEvent{Object: pod("other", "composite-b")}, nil, false)
+	i.dispatch(r, runtimeevent.GenericEvent{Object: pod("orphan")}, nil, false)
+	i.dispatch(r, runtimeevent.GenericEvent{Object: pod("released")}, pod("released", "composite-a"), false)
+	i.dispatch(r, runtimeevent.GenericEvent{Object: pod("adopted", "composite-a")}, pod("adopted"), false)
+	i.dispatch(r, runtimeevent.GenericEvent{Object: pod("deleted", "composite-a")}, nil, true)
+	i.dispatch(r, runtimeevent.GenericEvent{Object: pod("deleted-other", "composite-b")}, nil, true)
+
+	want := []string{"generic/owned", "generic/adopted", "delete/deleted"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("WithOwnerUIDFilter(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errCreateThreeWayPatch = "cannot create three-way merge patch"
+)
+
+// applyPreservingUnmanagedFields updates the resource of the supplied desired
+// manifest with a three-way merge of its last applied manifest, the desired
+// manifest and the live resource. Unlike the merge patch of the desired
+// manifest, this keeps items of lists that are not in the desired manifest,
+// e.g. containers injected by other controllers, while still removing fields
+// that were removed from the manifest. The desired manifest is updated to
+// the resulting resource.
+func (c *external) applyPreservingUnmanagedFields(ctx context.Context, desired *unstructured.Unstructured) error {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(desired.GroupVersionKind())
+	err := c.client.Get(ctx, types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}, current)
+	if kerrors.IsNotFound(err) {
+		return errors.Wrap(c.client.Create(ctx, desired), errCreateObject)
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetObject)
+	}
+
+	var original []byte
+	if last, ok := current.GetAnnotations()[v1.LastAppliedConfigAnnotation]; ok {
+		original = []byte(last)
+	}
+	modified, err := desired.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, errCreateThreeWayPatch)
+	}
+	live, err := current.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, errCreateThreeWayPatch)
+	}
+
+	pt, patch, err := threeWayPatch(desired.GroupVersionKind(), original, modified, live)
+	if err != nil {
+		return errors.Wrap(err, errCreateThreeWayPatch)
+	}
+	if err := c.client.Patch(ctx, current, client.RawPatch(pt, patch)); err != nil {
+		return err
+	}
+	desired.Object = current.Object
+	return nil
+}
+
+// threeWayPatch returns a strategic merge patch for kinds known to the
+// client-go scheme, whose lists are merged by their keys. Other kinds, e.g.
+// those of CRDs, get a JSON merge patch, which replaces lists as a whole.
+func threeWayPatch(gvk schema.GroupVersionKind, original, modified, current []byte) (types.PatchType, []byte, error) {
+	typed, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		patch, err := jsonmergepatch.CreateThreeWayJSONMergePatch(original, modified, current)
+		return types.MergePatchType, patch, err
+	}
+	lookup, err := strategicpatch.NewPatchMetaFromStruct(typed)
+	if err != nil {
+		return "", nil, err
+	}
+	patch, err := strategicpatch.CreateThreeWayMergePatch(original, modified, current, lookup, true)
+	return types.StrategicMergePatchType, patch, err
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestThreeWayPatch(t *testing.T) {
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	type args struct {
+		gvk                         schema.GroupVersionKind
+		original, modified, current string
+	}
+	type want struct {
+		pt      types.PatchType
+		patched string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"StrategicMerge": {
+			reason: "We should keep list items added by others and remove fields removed from the manifest of known kinds.",
+			args: args{
+				gvk:      deployment,
+				original: `{"spec":{"replicas":1,"paused":true,"template":{"spec":{"containers":[{"name":"app","image":"app:1"}]}}}}`,
+				modified: `{"spec":{"replicas":2,"template":{"spec":{"containers":[{"name":"app","image":"app:2"}]}}}}`,
+				current:  `{"spec":{"replicas":1,"paused":true,"template":{"spec":{"containers":[{"name":"app","image":"app:1"},{"name":"sidecar","image":"proxy:1"}]}}}}`,
+			},
+			want: want{
+				pt:      types.StrategicMergePatchType,
+				patched: `{"spec":{"replicas":2,"template":{"spec":{"containers":[{"name":"app","image":"app:2"},{"name":"sidecar","image":"proxy:1"}]}}}}`,
+			},
+		},
+		"JSONMerge": {
+			reason: "We should keep fields set by others and remove fields removed from the manifest of unknown kinds.",
+			args: args{
+				gvk:      schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Widget"},
+				original: `{"spec":{"size":1,"color":"red"}}`,
+				modified: `{"spec":{"size":2}}`,
+				current:  `{"spec":{"size":1,"color":"red","zone":"a"}}`,
+			},
+			want: want{
+				pt:      types.MergePatchType,
+				patched: `{"spec":{"size":2,"zone":"a"}}`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pt, patch, err := threeWayPatch(tc.args.gvk, []byte(tc.args.original), []byte(tc.args.modified), []byte(tc.args.current))
+			if err != nil {
+				t.Fatalf("\n%s\nthreeWayPatch(...): unexpected error: %v", tc.reason, err)
+			}
+			if pt != tc.want.pt {
+				t.Errorf("\n%s\nthreeWayPatch(...): want patch type %s, got %s", tc.reason, tc.want.pt, pt)
+			}
+			var patched []byte
+			if pt == types.StrategicMergePatchType {
+				patched, err = strategicpatch.StrategicMergePatch([]byte(tc.args.current), patch, &appsv1.Deployment{})
+			} else {
+				patched, err = jsonpatch.MergePatch([]byte(tc.args.current), patch)
+			}
+			if err != nil {
+				t.Fatalf("\n%s\ncannot apply patch %s: %v", tc.reason, patch, err)
+			}
+			var got, want interface{}
+			_ = json.Unmarshal(patched, &got)
+			_ = json.Unmarshal([]byte(tc.want.patched), &want)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("\n%s\nthreeWayPatch(...): -want patched, +got patched:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestApplyPreservingUnmanagedFields(t *testing.T) {
+	desired := func() *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace("default")
+		u.SetName("settings")
+		return u
+	}
+
+	cases := map[string]struct {
+		reason string
+		client *test.MockClient
+		want   error
+	}{
+		"GetError": {
+			reason: "We should return an error if the live resource cannot be fetched.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(errBoom),
+			},
+			want: errors.Wrap(errBoom, errGetObject),
+		},
+		"Create": {
+			reason: "We should create a resource that does not exist.",
+			client: &test.MockClient{
+				MockGet:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "settings")),
+				MockCreate: test.NewMockCreateFn(nil),
+			},
+		},
+		"Patch": {
+			reason: "We should patch an existing resource with a strategic merge patch.",
+			client: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+				MockPatch: func(_ context.Context, _ client.Object, patch client.Patch, _ ...client.PatchOption) error {
+					if patch.Type() != types.StrategicMergePatchType {
+						t.Errorf("want patch type %s, got %s", types.StrategicMergePatchType, patch.Type())
+					}
+					return nil
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				logger: logging.NewNopLogger(),
+				client: resource.ClientApplicator{Client: tc.client},
+			}
+			err := e.applyPreservingUnmanagedFields(context.Background(), desired())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.applyPreservingUnmanagedFields(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
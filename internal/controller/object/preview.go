@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+)
+
+const (
+	errReadPreviewRequest = "cannot read Object to preview"
+	errDryRunApply        = "cannot dry-run apply the manifest"
+	errDiffPreview        = "cannot compute the diff of the dry-run apply"
+
+	// maxPreviewRequestSize bounds the size of the Object to preview, like
+	// the API server bounds the size of a request.
+	maxPreviewRequestSize = 3 * 1024 * 1024
+)
+
+// PreviewHandler previews the changes applying an Object would make to its
+// resource on the target cluster. POSTing an Object returns a JSON Patch
+// document from the current state of the resource to the state a dry-run
+// apply of its manifest results in. Nothing is persisted, neither on the
+// target cluster nor on the control plane. Previews read the target cluster
+// with the credentials of the provider, so callers must be allowed to update
+// the Object they preview, see Authorizer.
+type PreviewHandler struct {
+	kube                client.Client
+	authorizer          *Authorizer
+	log                 logging.Logger
+	clientForProviderFn func(ctx context.Context, local client.Client, providerConfigName string) (client.Client, *rest.Config, error)
+}
+
+// NewPreviewHandler returns a PreviewHandler that connects to target
+// clusters using the ProviderConfigs of the supplied control plane, for
+// callers authorized by the supplied Authorizer.
+func NewPreviewHandler(c client.Client, a *Authorizer, log logging.Logger) *PreviewHandler {
+	return &PreviewHandler{kube: c, authorizer: a, log: log, clientForProviderFn: kube.ClientForProvider}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *PreviewHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxPreviewRequestSize))
+	if err != nil {
+		http.Error(w, errors.Wrap(err, errReadPreviewRequest).Error(), http.StatusBadRequest)
+		return
+	}
+	cr := &v1alpha2.Object{}
+	if err := json.Unmarshal(data, cr); err != nil {
+		http.Error(w, errors.Wrap(err, errReadPreviewRequest).Error(), http.StatusBadRequest)
+		return
+	}
+	if code, err := h.authorizer.Authorize(r.Context(), r, "update", cr.GetName()); err != nil {
+		h.log.Debug("Rejected preview of Object", "name", cr.GetName(), "error", err)
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	patch, err := h.preview(r.Context(), cr)
+	if err != nil {
+		h.log.Debug("Cannot preview Object", "name", cr.GetName(), "error", err)
+		http.Error(w, err.Error(), previewStatusCode(err))
+		return
+	}
+
+	out, err := json.Marshal(patch)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json-patch+json")
+	_, _ = w.Write(out)
+}
+
+// preview dry-runs the apply of the supplied Object the same way its
+// controller would and returns the resulting changes as JSON Patch.
+func (h *PreviewHandler) preview(ctx context.Context, cr *v1alpha2.Object) ([]jsonpatch.Operation, error) {
+	e := &external{logger: h.log, localClient: h.kube, policies: h.kube}
+	if err := e.resolveReferencies(ctx, cr); err != nil {
+		return nil, errors.Wrap(err, errResolveResourceReferences)
+	}
+	desired, err := getDesired(cr)
+	if err != nil {
+		return nil, err
+	}
+	mutated, err := e.applyMutatingPolicies(ctx, cr, desired)
+	if err != nil {
+		return nil, err
+	}
+	if err := stripIgnoredFields(cr, desired); err != nil {
+		return nil, err
+	}
+	effective, err := effectiveManifest(cr, desired, mutated)
+	if err != nil {
+		return nil, err
+	}
+
+	k, _, err := h.clientForProviderFn(ctx, h.kube, providerConfigName(cr))
+	if err != nil {
+		return nil, errors.Wrap(err, errNewKubernetesClient)
+	}
+
+	current := desired.DeepCopy()
+	err = k.Get(ctx, client.ObjectKeyFromObject(desired), current)
+	exists := err == nil
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, errors.Wrap(err, errGetObject)
+	}
+
+	applied := desired.DeepCopy()
+	meta.AddAnnotations(applied, map[string]string{
+		v1.LastAppliedConfigAnnotation: effective,
+	})
+	if exists {
+		err = k.Patch(ctx, applied, client.Merge, client.DryRunAll)
+	} else {
+		current = &unstructured.Unstructured{Object: map[string]interface{}{}}
+		err = k.Create(ctx, applied, client.DryRunAll)
+	}
+	if err != nil {
+		return nil, errors.Wrap(CleanErr(err), errDryRunApply)
+	}
+
+	return diffResources(current, applied)
+}
+
+// diffResources returns the JSON Patch from one state of a resource to
+// another, ignoring the metadata the API server bumps with every write.
+func diffResources(from, to *unstructured.Unstructured) ([]jsonpatch.Operation, error) {
+	a, err := json.Marshal(withoutVolatileMetadata(from).Object)
+	if err != nil {
+		return nil, errors.Wrap(err, errDiffPreview)
+	}
+	b, err := json.Marshal(withoutVolatileMetadata(to).Object)
+	if err != nil {
+		return nil, errors.Wrap(err, errDiffPreview)
+	}
+	ops, err := jsonpatch.CreatePatch(a, b)
+	if err != nil {
+		return nil, errors.Wrap(err, errDiffPreview)
+	}
+	if ops == nil {
+		ops = []jsonpatch.Operation{}
+	}
+	return ops, nil
+}
+
+func withoutVolatileMetadata(u *unstructured.Unstructured) *unstructured.Unstructured {
+	u = u.DeepCopy()
+	for _, f := range []string{"managedFields", "resourceVersion", "generation", "creationTimestamp", "uid"} {
+		unstructured.RemoveNestedField(u.Object, "metadata", f)
+	}
+	if m, ok := u.Object["metadata"].(map[string]interface{}); ok && len(m) == 0 {
+		delete(u.Object, "metadata")
+	}
+	return u
+}
+
+func previewStatusCode(err error) int {
+	var s kerrors.APIStatus
+	if errors.As(err, &s) && s.Status().Code != 0 {
+		return int(s.Status().Code)
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gomodules.xyz/jsonpatch/v2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestPreviewHandler(t *testing.T) {
+	manifest := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default"},"data":{"key":"new"}}`)
+	body, _ := json.Marshal(kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.Manifest.Raw = manifest
+	}))
+
+	// dryRun merges the manifest into the supplied object like the API
+	// server would, without persisting anything.
+	dryRun := func(obj client.Object) {
+		u := obj.(*unstructured.Unstructured)
+		_ = unstructured.SetNestedField(u.Object, "new", "data", "key")
+		u.SetResourceVersion("2")
+	}
+
+	type args struct {
+		method     string
+		body       []byte
+		authorizer *Authorizer
+		client     *test.MockClient
+	}
+	type want struct {
+		code int
+		ops  []jsonpatch.Operation
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"MethodNotAllowed": {
+			reason: "We should only serve POST requests.",
+			args: args{
+				method: http.MethodGet,
+			},
+			want: want{
+				code: http.StatusMethodNotAllowed,
+			},
+		},
+		"Forbidden": {
+			reason: "We should not preview Objects the caller may not update.",
+			args: args{
+				method:     http.MethodPost,
+				body:       body,
+				authorizer: reviewer(true, false),
+			},
+			want: want{
+				code: http.StatusForbidden,
+			},
+		},
+		"InvalidBody": {
+			reason: "We should reject a body that is not an Object.",
+			args: args{
+				method: http.MethodPost,
+				body:   []byte("{"),
+			},
+			want: want{
+				code: http.StatusBadRequest,
+			},
+		},
+		"ResourceExists": {
+			reason: "We should return the changes a dry-run patch of an existing resource makes, ignoring volatile metadata.",
+			args: args{
+				method: http.MethodPost,
+				body:   body,
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						u := obj.(*unstructured.Unstructured)
+						_ = unstructured.SetNestedField(u.Object, "old", "data", "key")
+						u.SetResourceVersion("1")
+						return nil
+					},
+					MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, opts ...client.PatchOption) error {
+						if !hasDryRun(opts) {
+							t.Errorf("expected a dry-run patch")
+						}
+						dryRun(obj)
+						return nil
+					},
+				},
+			},
+			want: want{
+				code: http.StatusOK,
+				ops: []jsonpatch.Operation{
+					{Operation: "replace", Path: "/data/key", Value: "new"},
+					{Operation: "add", Path: "/metadata/annotations", Value: map[string]interface{}{
+						"kubectl.kubernetes.io/last-applied-configuration": string(manifest),
+					}},
+				},
+			},
+		},
+		"ResourceDoesNotExist": {
+			reason: "We should return the whole resource a dry-run create results in.",
+			args: args{
+				method: http.MethodPost,
+				body:   body,
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "cm")),
+					MockCreate: func(_ context.Context, obj client.Object, opts ...client.CreateOption) error {
+						if len(opts) != 1 || opts[0] != client.DryRunAll {
+							t.Errorf("expected a dry-run create")
+						}
+						return nil
+					},
+				},
+			},
+			want: want{
+				code: http.StatusOK,
+				ops: []jsonpatch.Operation{
+					{Operation: "add", Path: "/apiVersion", Value: "v1"},
+					{Operation: "add", Path: "/data", Value: map[string]interface{}{"key": "new"}},
+					{Operation: "add", Path: "/kind", Value: "ConfigMap"},
+					{Operation: "add", Path: "/metadata", Value: map[string]interface{}{
+						"name":      "cm",
+						"namespace": "default",
+						"annotations": map[string]interface{}{
+							"kubectl.kubernetes.io/last-applied-configuration": string(manifest),
+						},
+					}},
+				},
+			},
+		},
+		"DryRunRejected": {
+			reason: "We should return the status code the target cluster rejected the dry-run with.",
+			args: args{
+				method: http.MethodPost,
+				body:   body,
+				client: &test.MockClient{
+					MockGet:   test.NewMockGetFn(nil),
+					MockPatch: test.NewMockPatchFn(kerrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, "cm", nil)),
+				},
+			},
+			want: want{
+				code: http.StatusForbidden,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := tc.args.authorizer
+			if a == nil {
+				a = reviewer(true, true)
+			}
+			h := &PreviewHandler{
+				authorizer: a,
+				log:        logging.NewNopLogger(),
+				clientForProviderFn: func(_ context.Context, _ client.Client, _ string) (client.Client, *rest.Config, error) {
+					return tc.args.client, nil, nil
+				},
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, authenticated(httptest.NewRequest(tc.args.method, "/preview", bytes.NewReader(tc.args.body))))
+
+			if diff := cmp.Diff(tc.want.code, rec.Code); diff != "" {
+				t.Fatalf("\n%s\nh.ServeHTTP(...): -want code, +got code:\n%s\n%s", tc.reason, diff, rec.Body.String())
+			}
+			if tc.want.code != http.StatusOK {
+				return
+			}
+			var ops []jsonpatch.Operation
+			if err := json.Unmarshal(rec.Body.Bytes(), &ops); err != nil {
+				t.Fatalf("cannot parse response: %v", err)
+			}
+			sort.Slice(ops, func(i, j int) bool { return ops[i].Path < ops[j].Path })
+			if diff := cmp.Diff(tc.want.ops, ops); diff != "" {
+				t.Errorf("\n%s\nh.ServeHTTP(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func hasDryRun(opts []client.PatchOption) bool {
+	po := &client.PatchOptions{}
+	po.ApplyOptions(opts)
+	return len(po.DryRun) > 0
+}
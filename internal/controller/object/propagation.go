@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// maxPropagatedEvents is the number of Events propagated to an Object
+	// per propagationWindow at most.
+	maxPropagatedEvents = 5
+	propagationWindow   = time.Minute
+)
+
+// propagatesEvents returns true if the Warning Events of the resource managed
+// by the supplied Object are re-emitted on it.
+func propagatesEvents(cr *v1alpha2.Object) bool {
+	return cr.Spec.EventPropagation != nil && cr.Spec.EventPropagation.Enabled
+}
+
+// An eventPropagator re-emits the Warning Events of managed resources, as
+// received from the resource informers, on the Objects managing them. Events
+// are matched to Objects by the UID of their involved object, and rate
+// limited per Object so that a crash looping resource cannot flood the
+// cluster the Objects live in.
+type eventPropagator struct {
+	objects client.Reader
+	record  event.Recorder
+	log     logging.Logger
+	now     func() time.Time
+
+	lock sync.Mutex
+	// sent holds when Events were propagated to each Object within the
+	// last propagationWindow.
+	sent map[string][]time.Time
+}
+
+func newEventPropagator(objects client.Reader, record event.Recorder, log logging.Logger) *eventPropagator {
+	return &eventPropagator{
+		objects: objects,
+		record:  record,
+		log:     log,
+		now:     time.Now,
+		sent:    make(map[string][]time.Time),
+	}
+}
+
+// Propagate re-emits the supplied Event of the cluster of the supplied
+// provider config on the Objects managing its involved object, if they
+// propagate Events. Events that are no warnings are ignored, as are Events
+// last seen before the propagation window, e.g. replayed when an informer
+// starts.
+func (p *eventPropagator) Propagate(ctx context.Context, providerConfig string, obj client.Object) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok || u.GroupVersionKind() != eventGVK {
+		return
+	}
+	e := v1.Event{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &e); err != nil {
+		p.log.Debug("cannot convert event for propagation", "error", err)
+		return
+	}
+	if e.Type != v1.EventTypeWarning || e.InvolvedObject.UID == "" {
+		return
+	}
+	now := p.now()
+	if now.Sub(lastSeen(e)) > propagationWindow {
+		return
+	}
+
+	io := e.InvolvedObject
+	key := refKeyProviderNamespacedNameGVK(providerConfig, io.Namespace, io.Name, io.Kind, io.APIVersion)
+	objects := v1alpha2.ObjectList{}
+	if err := p.objects.List(ctx, &objects, client.MatchingFields{resourceRefsIndex: key}); err != nil {
+		p.log.Debug("cannot list objects to propagate an event to", "error", err, "fieldSelector", resourceRefsIndex+"="+key)
+		return
+	}
+
+	for i := range objects.Items {
+		cr := &objects.Items[i]
+		if !propagatesEvents(cr) || observedUID(cr) != string(io.UID) {
+			continue
+		}
+		if !p.allow(cr.GetName(), now) {
+			p.log.Debug("Dropped event of managed resource, propagation rate limit exceeded", "name", cr.GetName(), "reason", e.Reason)
+			continue
+		}
+		p.record.Event(cr, event.Warning(event.Reason(e.Reason), errors.Errorf("%s %s: %s", io.Kind, io.Name, e.Message)))
+	}
+}
+
+// allow returns true if another Event may be propagated to the named Object
+// at the supplied time, accounting for it if so.
+func (p *eventPropagator) allow(name string, now time.Time) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	recent := p.sent[name][:0]
+	for _, t := range p.sent[name] {
+		if now.Sub(t) < propagationWindow {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= maxPropagatedEvents {
+		p.sent[name] = recent
+		return false
+	}
+	p.sent[name] = append(recent, now)
+	return true
+}
+
+// observedUID returns the UID of the resource managed by the supplied Object,
+// as last observed.
+func observedUID(cr *v1alpha2.Object) string {
+	if len(cr.Status.AtProvider.Manifest.Raw) == 0 {
+		return ""
+	}
+	observed := &unstructured.Unstructured{}
+	if err := json.Unmarshal(cr.Status.AtProvider.Manifest.Raw, &observed.Object); err != nil {
+		return ""
+	}
+	return string(observed.GetUID())
+}
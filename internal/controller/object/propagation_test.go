@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
+func TestEventPropagatorPropagate(t *testing.T) {
+	now := time.Now()
+	deployment := corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web", UID: "uid"}
+	warning := func(seen time.Time) corev1.Event {
+		return corev1.Event{Type: corev1.EventTypeWarning, Reason: "ImagePullBackOff", Message: "Back-off pulling image", InvolvedObject: deployment, LastTimestamp: metav1.NewTime(seen)}
+	}
+	pulled := event.Warning("ImagePullBackOff", errors.New("Deployment web: Back-off pulling image"))
+
+	type args struct {
+		event     corev1.Event
+		enabled   bool
+		uid       string
+		propagate int
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []event.Event
+	}{
+		"Propagated": {
+			reason: "We should re-emit a recent Warning event of the managed resource on its Object.",
+			args: args{
+				event:     warning(now),
+				enabled:   true,
+				uid:       "uid",
+				propagate: 1,
+			},
+			want: []event.Event{pulled},
+		},
+		"Disabled": {
+			reason: "We should not re-emit events on Objects that do not propagate them.",
+			args: args{
+				event:     warning(now),
+				uid:       "uid",
+				propagate: 1,
+			},
+		},
+		"Normal": {
+			reason: "We should not re-emit events that are no warnings.",
+			args: args{
+				event:     corev1.Event{Type: corev1.EventTypeNormal, Reason: "ScalingReplicaSet", InvolvedObject: deployment, LastTimestamp: metav1.NewTime(now)},
+				enabled:   true,
+				uid:       "uid",
+				propagate: 1,
+			},
+		},
+		"OtherUID": {
+			reason: "We should not re-emit events of another resource of the same name.",
+			args: args{
+				event:     warning(now),
+				enabled:   true,
+				uid:       "other",
+				propagate: 1,
+			},
+		},
+		"Stale": {
+			reason: "We should not re-emit events last seen before the propagation window.",
+			args: args{
+				event:     warning(now.Add(-2 * propagationWindow)),
+				enabled:   true,
+				uid:       "uid",
+				propagate: 1,
+			},
+		},
+		"RateLimited": {
+			reason: "We should re-emit at most maxPropagatedEvents events per propagation window.",
+			args: args{
+				event:     warning(now),
+				enabled:   true,
+				uid:       "uid",
+				propagate: maxPropagatedEvents + 2,
+			},
+			want: []event.Event{pulled, pulled, pulled, pulled, pulled},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			observed := &unstructured.Unstructured{}
+			observed.SetUID(types.UID(tc.args.uid))
+			raw, _ := observed.MarshalJSON()
+			cr := v1alpha2.Object{
+				ObjectMeta: metav1.ObjectMeta{Name: "web"},
+				Spec: v1alpha2.ObjectSpec{
+					EventPropagation: &v1alpha2.EventPropagation{Enabled: tc.args.enabled},
+				},
+				Status: v1alpha2.ObjectStatus{
+					AtProvider: v1alpha2.ObjectObservation{Manifest: runtime.RawExtension{Raw: raw}},
+				},
+			}
+
+			rec := &eventRecorder{}
+			p := newEventPropagator(&test.MockClient{
+				MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+					lo := &client.ListOptions{}
+					lo.ApplyOptions(opts)
+					want := resourceRefsIndex + "=" + refKeyProviderNamespacedNameGVK("pc", "default", "web", "Deployment", "apps/v1")
+					if got := lo.FieldSelector.String(); got != want {
+						t.Errorf("unexpected field selector %q", got)
+					}
+					list.(*v1alpha2.ObjectList).Items = []v1alpha2.Object{cr}
+					return nil
+				},
+			}, rec, logging.NewNopLogger())
+			p.now = func() time.Time { return now }
+
+			m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&tc.args.event)
+			if err != nil {
+				t.Fatalf("cannot convert event: %v", err)
+			}
+			u := &unstructured.Unstructured{Object: m}
+			u.SetGroupVersionKind(eventGVK)
+			for i := 0; i < tc.args.propagate; i++ {
+				p.Propagate(context.Background(), "pc", u)
+			}
+
+			if diff := cmp.Diff(tc.want, rec.events, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\np.Propagate(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
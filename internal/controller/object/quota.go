@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+const (
+	errGetProviderConfig = "cannot get ProviderConfig"
+	errMaxObjects        = "ProviderConfig %q is limited to %d Objects, %d already use it"
+)
+
+// A QuotaValidator rejects Objects using a ProviderConfig that is already
+// used by its maximum number of Objects. The Objects using a ProviderConfig
+// are counted periodically, so the limit may be exceeded by Objects created
+// in quick succession.
+type QuotaValidator struct {
+	client client.Reader
+}
+
+var _ admission.CustomValidator = &QuotaValidator{}
+
+// NewQuotaValidator returns a QuotaValidator that reads ProviderConfigs
+// using the supplied client.
+func NewQuotaValidator(c client.Reader) *QuotaValidator {
+	return &QuotaValidator{client: c}
+}
+
+// ValidateCreate rejects an Object if its ProviderConfig is used by its
+// maximum number of Objects.
+func (v *QuotaValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr, ok := obj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	return nil, v.validateQuota(ctx, cr)
+}
+
+// ValidateUpdate rejects an Object that changes to a ProviderConfig that is
+// used by its maximum number of Objects.
+func (v *QuotaValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	cr, ok := newObj.(*v1alpha2.Object)
+	if !ok {
+		return nil, errors.New(errNotKubernetesObject)
+	}
+	if providerConfigName(old) == providerConfigName(cr) {
+		return nil, nil
+	}
+	return nil, v.validateQuota(ctx, cr)
+}
+
+// ValidateDelete never rejects an Object.
+func (v *QuotaValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *QuotaValidator) validateQuota(ctx context.Context, cr *v1alpha2.Object) error {
+	name := providerConfigName(cr)
+	if name == "" {
+		return nil
+	}
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := v.client.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			// The Object fails to connect until its ProviderConfig exists.
+			return nil
+		}
+		return errors.Wrap(err, errGetProviderConfig)
+	}
+	if pc.Spec.MaxObjects == nil {
+		return nil
+	}
+	n, ok := apisv1alpha1.ObjectCount(pc)
+	if !ok || n < *pc.Spec.MaxObjects {
+		return nil
+	}
+	return errors.Errorf(errMaxObjects, name, *pc.Spec.MaxObjects, n)
+}
+
+func providerConfigName(cr *v1alpha2.Object) string {
+	if ref := cr.GetProviderConfigReference(); ref != nil {
+		return ref.Name
+	}
+	return ""
+}
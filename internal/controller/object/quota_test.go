@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	kubernetesv1alpha1 "github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+func TestQuotaValidator(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	providerConfig := func(maxObjects *int64, count *int64) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			pc := obj.(*kubernetesv1alpha1.ProviderConfig)
+			pc.Name = key.Name
+			pc.Spec.MaxObjects = maxObjects
+			if count != nil {
+				pc.Status.SetConditions(kubernetesv1alpha1.UsageCount(*count))
+			}
+			return nil
+		}
+	}
+	withProviderConfig := func(name string) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.Spec.ProviderConfigReference = &xpv1.Reference{Name: name}
+		}
+	}
+
+	type args struct {
+		get test.MockGetFn
+		old *v1alpha2.Object
+		obj *v1alpha2.Object
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   error
+	}{
+		"Unlimited": {
+			reason: "We should admit Objects using a ProviderConfig without a limit.",
+			args: args{
+				get: providerConfig(nil, ptr.To[int64](100)),
+				obj: kubernetesObject(),
+			},
+		},
+		"NotCounted": {
+			reason: "We should admit Objects using a ProviderConfig whose Objects were not counted yet.",
+			args: args{
+				get: providerConfig(ptr.To[int64](1), nil),
+				obj: kubernetesObject(),
+			},
+		},
+		"ProviderConfigNotFound": {
+			reason: "We should admit Objects using a ProviderConfig that does not exist.",
+			args: args{
+				get: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, providerName)),
+				obj: kubernetesObject(),
+			},
+		},
+		"GetError": {
+			reason: "We should reject Objects if their ProviderConfig cannot be read.",
+			args: args{
+				get: test.NewMockGetFn(errBoom),
+				obj: kubernetesObject(),
+			},
+			want: errors.Wrap(errBoom, errGetProviderConfig),
+		},
+		"BelowLimit": {
+			reason: "We should admit Objects using a ProviderConfig below its limit.",
+			args: args{
+				get: providerConfig(ptr.To[int64](2), ptr.To[int64](1)),
+				obj: kubernetesObject(),
+			},
+		},
+		"LimitReached": {
+			reason: "We should reject Objects using a ProviderConfig that reached its limit.",
+			args: args{
+				get: providerConfig(ptr.To[int64](2), ptr.To[int64](2)),
+				obj: kubernetesObject(),
+			},
+			want: errors.Errorf(errMaxObjects, providerName, 2, 2),
+		},
+		"UpdateSameProviderConfig": {
+			reason: "We should admit updates of Objects that keep their ProviderConfig.",
+			args: args{
+				get: providerConfig(ptr.To[int64](2), ptr.To[int64](2)),
+				old: kubernetesObject(),
+				obj: kubernetesObject(),
+			},
+		},
+		"UpdateOtherProviderConfig": {
+			reason: "We should reject updates of Objects to a ProviderConfig that reached its limit.",
+			args: args{
+				get: providerConfig(ptr.To[int64](2), ptr.To[int64](2)),
+				old: kubernetesObject(withProviderConfig("other")),
+				obj: kubernetesObject(),
+			},
+			want: errors.Errorf(errMaxObjects, providerName, 2, 2),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := NewQuotaValidator(&test.MockClient{MockGet: tc.args.get})
+			var err error
+			if tc.args.old != nil {
+				_, err = v.ValidateUpdate(context.Background(), tc.args.old, tc.args.obj)
+			} else {
+				_, err = v.ValidateCreate(context.Background(), tc.args.obj)
+			}
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
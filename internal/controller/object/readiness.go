@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/cel/library"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errNewReadinessEnv     = "cannot create CEL environment"
+	errCompileReadiness    = "cannot compile custom readiness expression"
+	errReadinessResultType = "custom readiness expression must return a map with a boolean ready key"
+
+	// readinessCostLimit bounds the cost of evaluating a custom readiness
+	// expression, the same way the API server bounds the cost of a CRD
+	// validation rule.
+	readinessCostLimit = 1000000
+)
+
+var readinessEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("self", cel.DynType),
+		library.Lists(),
+		library.Regex(),
+		library.URLs(),
+		library.Quantity(),
+	)
+})
+
+// readinessPrograms caches the compiled custom readiness expressions of
+// Objects. A program is only compiled again once the expression of its Object
+// changes.
+type readinessPrograms struct {
+	lock     sync.RWMutex
+	programs map[types.UID]compiledReadiness
+}
+
+type compiledReadiness struct {
+	expression string
+	program    cel.Program
+}
+
+func newReadinessPrograms() *readinessPrograms {
+	return &readinessPrograms{programs: make(map[types.UID]compiledReadiness)}
+}
+
+// ProgramFor returns the compiled custom readiness expression of the
+// supplied Object. A nil cache compiles the expression every time.
+func (p *readinessPrograms) ProgramFor(obj *v1alpha2.Object) (cel.Program, error) {
+	expr := obj.Spec.Readiness.CustomExpression
+	if p == nil {
+		return compileReadiness(expr)
+	}
+	p.lock.RLock()
+	cached, ok := p.programs[obj.GetUID()]
+	p.lock.RUnlock()
+	if ok && cached.expression == expr {
+		return cached.program, nil
+	}
+
+	prg, err := compileReadiness(expr)
+	if err != nil {
+		return nil, err
+	}
+	p.lock.Lock()
+	p.programs[obj.GetUID()] = compiledReadiness{expression: expr, program: prg}
+	p.lock.Unlock()
+	return prg, nil
+}
+
+// Forget drops the compiled expression of the supplied Object.
+func (p *readinessPrograms) Forget(uid types.UID) {
+	if p == nil {
+		return
+	}
+	p.lock.Lock()
+	delete(p.programs, uid)
+	p.lock.Unlock()
+}
+
+func compileReadiness(expr string) (cel.Program, error) {
+	env, err := readinessEnv()
+	if err != nil {
+		return nil, errors.Wrap(err, errNewReadinessEnv)
+	}
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, errors.Wrap(iss.Err(), errCompileReadiness)
+	}
+	prg, err := env.Program(ast, cel.CostLimit(readinessCostLimit))
+	return prg, errors.Wrap(err, errCompileReadiness)
+}
+
+// evaluateReadiness returns whether the supplied program considers the
+// observed resource ready, along with the message it returned.
+func evaluateReadiness(prg cel.Program, observed *unstructured.Unstructured) (bool, string, error) {
+	out, _, err := prg.Eval(map[string]interface{}{"self": observed.Object})
+	if err != nil {
+		return false, "", err
+	}
+	v, err := out.ConvertToNative(reflect.TypeOf(map[string]interface{}{}))
+	if err != nil {
+		return false, "", errors.Wrap(err, errReadinessResultType)
+	}
+	res := v.(map[string]interface{})
+	ready, ok := res["ready"].(bool)
+	if !ok {
+		return false, "", errors.New(errReadinessResultType)
+	}
+	msg, _ := res["message"].(string)
+	return ready, msg, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestEvaluateReadiness(t *testing.T) {
+	observed := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase":    "Pending",
+			"replicas": int64(2),
+		},
+	}}
+
+	type want struct {
+		compileErr bool
+		evalErr    bool
+		ready      bool
+		message    string
+	}
+	cases := map[string]struct {
+		reason     string
+		expression string
+		want       want
+	}{
+		"Ready": {
+			reason:     "We should report the resource as ready if the expression says so.",
+			expression: `{"ready": self.status.replicas > 1, "message": "scaled"}`,
+			want: want{
+				ready:   true,
+				message: "scaled",
+			},
+		},
+		"NotReady": {
+			reason:     "We should report the resource as not ready along with the message of the expression.",
+			expression: `{"ready": self.status.phase == "Bound", "message": self.status.phase}`,
+			want: want{
+				message: "Pending",
+			},
+		},
+		"NoMessage": {
+			reason:     "The message of the expression should be optional.",
+			expression: `{"ready": true}`,
+			want: want{
+				ready: true,
+			},
+		},
+		"InvalidExpression": {
+			reason:     "We should return an error if the expression does not compile.",
+			expression: `{"ready": `,
+			want: want{
+				compileErr: true,
+			},
+		},
+		"MissingField": {
+			reason:     "We should return an error if the expression refers to a field the resource does not have.",
+			expression: `{"ready": self.status.conditions.size() > 0}`,
+			want: want{
+				evalErr: true,
+			},
+		},
+		"WrongResultType": {
+			reason:     "We should return an error if the expression does not return a ready boolean.",
+			expression: `{"ready": "yes"}`,
+			want: want{
+				evalErr: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := &v1alpha2.Object{Spec: v1alpha2.ObjectSpec{Readiness: v1alpha2.Readiness{
+				Policy:           v1alpha2.ReadinessPolicyCustom,
+				CustomExpression: tc.expression,
+			}}}
+			prg, err := newReadinessPrograms().ProgramFor(obj)
+			if (err != nil) != tc.want.compileErr {
+				t.Fatalf("\n%s\nProgramFor(...): want error: %t, got: %v", tc.reason, tc.want.compileErr, err)
+			}
+			if err != nil {
+				return
+			}
+			ready, msg, err := evaluateReadiness(prg, observed)
+			if (err != nil) != tc.want.evalErr {
+				t.Fatalf("\n%s\nevaluateReadiness(...): want error: %t, got: %v", tc.reason, tc.want.evalErr, err)
+			}
+			if diff := cmp.Diff(tc.want.ready, ready); diff != "" {
+				t.Errorf("\n%s\nevaluateReadiness(...): -want ready, +got ready:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.message, msg); diff != "" {
+				t.Errorf("\n%s\nevaluateReadiness(...): -want message, +got message:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReadinessProgramsCache(t *testing.T) {
+	p := newReadinessPrograms()
+	obj := &v1alpha2.Object{}
+	obj.SetUID("uid")
+	obj.Spec.Readiness.CustomExpression = `{"ready": true}`
+
+	first, err := p.ProgramFor(obj)
+	if err != nil {
+		t.Fatalf("ProgramFor(...): unexpected error: %v", err)
+	}
+	if again, _ := p.ProgramFor(obj); again != first {
+		t.Errorf("ProgramFor(...): want the cached program while the expression is unchanged")
+	}
+
+	obj.Spec.Readiness.CustomExpression = `{"ready": false}`
+	if changed, _ := p.ProgramFor(obj); changed == first {
+		t.Errorf("ProgramFor(...): want a new program once the expression changed")
+	}
+
+	p.Forget(obj.GetUID())
+	if len(p.programs) != 0 {
+		t.Errorf("Forget(...): want no cached programs, got %d", len(p.programs))
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// resyncBurst is how long the update events of a single resync of a
+// resource cache are expected to take at most.
+const resyncBurst = time.Minute
+
+// A resyncTracker notices resyncs of a resource cache. Reflectors relist all
+// resources once their watch expired, e.g. with 410 Gone after the API
+// server compacted its resource versions, and update events of resources that
+// changed while the watch was down may be lost. Resyncs surface as update
+// events without a changed resource version, which the resourceInformers
+// forward without their old state, so that they are not filtered by watch
+// predicates and every Object referencing the resync's resources is
+// reconciled.
+type resyncTracker struct {
+	log logging.Logger
+	now func() time.Time
+
+	lock        sync.Mutex
+	lastEvent   time.Time
+	expiredAt   time.Time
+	resyncStart time.Time
+}
+
+func newResyncTracker(log logging.Logger) *resyncTracker {
+	return &resyncTracker{log: log, now: time.Now, lastEvent: time.Now()}
+}
+
+// observe records an event of the supplied resource, and returns true if it
+// is part of a resync. old is nil for all but update events.
+func (t *resyncTracker) observe(old, obj client.Object) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	if old == nil || old.GetResourceVersion() != obj.GetResourceVersion() {
+		t.lastEvent = now
+		t.expiredAt = time.Time{}
+		t.resyncStart = time.Time{}
+		return false
+	}
+
+	if now.Sub(t.resyncStart) > resyncBurst {
+		t.resyncStart = now
+		log := t.log.WithValues("gap", now.Sub(t.lastEvent).Round(time.Second).String())
+		if !t.expiredAt.IsZero() {
+			log.Info("Resource watch expired, resyncing all resources", "expiredAt", t.expiredAt)
+		} else {
+			log.Debug("Resyncing all resources")
+		}
+	}
+	return true
+}
+
+// watchExpired records that the watch of the resource cache expired.
+func (t *resyncTracker) watchExpired(err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.expiredAt = t.now()
+	t.log.Info("Resource watch expired, relisting", "error", err, "gap", t.expiredAt.Sub(t.lastEvent).Round(time.Second).String())
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+func Test_resyncTracker_observe(t *testing.T) {
+	withResourceVersion := func(rv string) client.Object {
+		u := &kunstructured.Unstructured{}
+		u.SetResourceVersion(rv)
+		return u
+	}
+
+	type event struct {
+		old    client.Object
+		obj    client.Object
+		after  time.Duration
+		resync bool
+	}
+	cases := map[string]struct {
+		reason  string
+		expired bool
+		events  []event
+	}{
+		"Changes": {
+			reason: "Events of changed resources are not part of a resync.",
+			events: []event{
+				{obj: withResourceVersion("1")},
+				{old: withResourceVersion("1"), obj: withResourceVersion("2")},
+			},
+		},
+		"Resync": {
+			reason: "Updates without a changed resource version are part of a resync.",
+			events: []event{
+				{obj: withResourceVersion("1")},
+				{old: withResourceVersion("1"), obj: withResourceVersion("1"), resync: true},
+				{old: withResourceVersion("3"), obj: withResourceVersion("3"), resync: true},
+				{old: withResourceVersion("3"), obj: withResourceVersion("4")},
+			},
+		},
+		"ResyncAfterExpiredWatch": {
+			reason:  "Updates of the relist after an expired watch are part of a resync.",
+			expired: true,
+			events: []event{
+				{old: withResourceVersion("1"), obj: withResourceVersion("1"), after: time.Hour, resync: true},
+				{old: withResourceVersion("2"), obj: withResourceVersion("2"), after: 2 * resyncBurst, resync: true},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			r := newResyncTracker(logging.NewNopLogger())
+			r.now = func() time.Time { return now }
+			if tc.expired {
+				r.watchExpired(errors.New("too old resource version"))
+			}
+			for n, ev := range tc.events {
+				now = now.Add(ev.after)
+				if got := r.observe(ev.old, ev.obj); got != ev.resync {
+					t.Errorf("\n%s\nobserve(...) of event %d: want %t, got %t", tc.reason, n, ev.resync, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// AnnotationKeyCompositionRevision is the Composition revision of the
+	// composite resource an Object was composed by. It is recorded by the
+	// provider, so that Objects of a specific revision can be found.
+	AnnotationKeyCompositionRevision = "provider-kubernetes.crossplane.io/composition-revision"
+
+	// annotationKeyCompositeCompositionRevision is the revision recorded on
+	// composite resources.
+	annotationKeyCompositeCompositionRevision = "crossplane.io/composition-revision"
+
+	reasonRevisionChanged event.Reason = "RevisionChanged"
+
+	errRecordCompositionRevision = "cannot record composition revision"
+)
+
+// recordCompositionRevision records the Composition revision of the composite
+// resource controlling the supplied Object on it. A changed revision replaces
+// the recorded one, and is reported by a RevisionChanged event.
+// Failing to read the composite resource, e.g. for lack of RBAC, does not
+// fail the reconcile; the annotation is a debugging aid only.
+func (c *external) recordCompositionRevision(ctx context.Context, cr *v1alpha2.Object) error {
+	ref := metav1.GetControllerOf(cr)
+	if ref == nil {
+		return nil
+	}
+
+	xr := &unstructured.Unstructured{}
+	xr.SetAPIVersion(ref.APIVersion)
+	xr.SetKind(ref.Kind)
+	if err := c.localClient.Get(ctx, types.NamespacedName{Name: ref.Name}, xr); err != nil {
+		if !kerrors.IsNotFound(err) {
+			c.logger.Debug("Cannot get composite resource to record its composition revision", "error", err, "kind", ref.Kind, "name", ref.Name)
+		}
+		return nil
+	}
+	rev := compositionRevision(xr)
+	prev, recorded := cr.GetAnnotations()[AnnotationKeyCompositionRevision]
+	if rev == "" || rev == prev {
+		return nil
+	}
+
+	if err := c.patchAnnotations(ctx, cr, map[string]string{AnnotationKeyCompositionRevision: rev}); err != nil {
+		return errors.Wrap(err, errRecordCompositionRevision)
+	}
+
+	if recorded && c.recorder != nil {
+		c.recorder.Event(cr, event.Normal(reasonRevisionChanged, "Composition revision changed from "+prev+" to "+rev))
+	}
+	return nil
+}
+
+// compositionRevision returns the Composition revision of a composite
+// resource. It falls back to the revision the composite resource references,
+// if it is not annotated with one.
+func compositionRevision(xr *unstructured.Unstructured) string {
+	if rev := xr.GetAnnotations()[annotationKeyCompositeCompositionRevision]; rev != "" {
+		return rev
+	}
+	rev, _, _ := unstructured.NestedString(xr.Object, "spec", "compositionRevisionRef", "name")
+	return rev
+}
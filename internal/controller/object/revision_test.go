@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestRecordCompositionRevision(t *testing.T) {
+	errBoom := errors.New("boom")
+	controlled := func(obj *v1alpha2.Object) {
+		obj.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "example.org/v1", Kind: "XApp", Name: "app", Controller: ptr.To(true)}})
+	}
+	withRevision := func(rev string) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.SetAnnotations(map[string]string{AnnotationKeyCompositionRevision: rev})
+		}
+	}
+	composite := func(annotation, ref string) func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		return func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+			u := obj.(*unstructured.Unstructured)
+			if annotation != "" {
+				u.SetAnnotations(map[string]string{annotationKeyCompositeCompositionRevision: annotation})
+			}
+			if ref != "" {
+				_ = unstructured.SetNestedField(u.Object, ref, "spec", "compositionRevisionRef", "name")
+			}
+			return nil
+		}
+	}
+
+	type args struct {
+		cr     *v1alpha2.Object
+		client *test.MockClient
+	}
+	type want struct {
+		err      error
+		revision string
+		events   []event.Event
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotComposed": {
+			reason: "We should not record a revision on Objects without a controller.",
+			args: args{
+				cr:     kubernetesObject(),
+				client: &test.MockClient{},
+			},
+		},
+		"CompositeUnreadable": {
+			reason: "We should not fail if the composite resource cannot be read.",
+			args: args{
+				cr: kubernetesObject(controlled),
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+		},
+		"Annotated": {
+			reason: "We should record the revision annotated on the composite resource.",
+			args: args{
+				cr: kubernetesObject(controlled),
+				client: &test.MockClient{
+					MockGet:   composite("app-abc", "app-def"),
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+			},
+			want: want{
+				revision: "app-abc",
+			},
+		},
+		"Referenced": {
+			reason: "We should record the revision the composite resource references if it is not annotated.",
+			args: args{
+				cr: kubernetesObject(controlled),
+				client: &test.MockClient{
+					MockGet:   composite("", "app-def"),
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+			},
+			want: want{
+				revision: "app-def",
+			},
+		},
+		"Unchanged": {
+			reason: "We should not patch the Object if the revision did not change.",
+			args: args{
+				cr: kubernetesObject(controlled, withRevision("app-abc")),
+				client: &test.MockClient{
+					MockGet: composite("app-abc", ""),
+				},
+			},
+			want: want{
+				revision: "app-abc",
+			},
+		},
+		"Changed": {
+			reason: "We should overwrite a changed revision and emit an event.",
+			args: args{
+				cr: kubernetesObject(controlled, withRevision("app-abc")),
+				client: &test.MockClient{
+					MockGet:   composite("app-xyz", ""),
+					MockPatch: test.NewMockPatchFn(nil),
+				},
+			},
+			want: want{
+				revision: "app-xyz",
+				events:   []event.Event{event.Normal(reasonRevisionChanged, "Composition revision changed from app-abc to app-xyz")},
+			},
+		},
+		"PatchError": {
+			reason: "We should return an error if the revision cannot be recorded.",
+			args: args{
+				cr: kubernetesObject(controlled),
+				client: &test.MockClient{
+					MockGet:   composite("app-abc", ""),
+					MockPatch: test.NewMockPatchFn(errBoom),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errRecordCompositionRevision),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &eventRecorder{}
+			e := &external{logger: logging.NewNopLogger(), localClient: tc.args.client, recorder: rec}
+			err := e.recordCompositionRevision(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.recordCompositionRevision(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.revision, tc.args.cr.GetAnnotations()[AnnotationKeyCompositionRevision]); diff != "" {
+				t.Errorf("\n%s\ne.recordCompositionRevision(...): -want revision, +got revision:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, rec.events); diff != "" {
+				t.Errorf("\n%s\ne.recordCompositionRevision(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// AnnotationKeyRolledBackAt is the time an Object was last rolled back
+	// to the manifest of a previous generation.
+	AnnotationKeyRolledBackAt = "provider-kubernetes.crossplane.io/rolled-back-at"
+
+	errRollbackDeleting   = "cannot roll back an Object that is being deleted"
+	errNoPreviousSnapshot = "no spec snapshot of a previous generation with a different manifest"
+	errParseSnapshot      = "cannot parse spec snapshot"
+	errRollback           = "cannot roll back Object"
+)
+
+// RollbackHandler reverts Objects to the manifest of a previous generation,
+// read from their spec snapshots, see HistoryHandler. POST
+// /rollback?object=<name> restores the manifest of the latest snapshot
+// before the current generation whose manifest differs from the current one.
+// POST /rollback?object=<name>&generation=3 restores the manifest of
+// generation 3. It responds with the snapshot that was restored.
+//
+// Rolled back Objects are annotated with AnnotationKeyRolledBackAt. Objects
+// without a snapshot to restore, and Objects being deleted, are not rolled
+// back. Callers must be allowed to update the Object, see Authorizer.
+type RollbackHandler struct {
+	kube       client.Client
+	reader     client.Reader
+	namespace  string
+	authorizer *Authorizer
+	log        logging.Logger
+}
+
+// NewRollbackHandler returns a RollbackHandler restoring the snapshots stored
+// in the namespace of the supplied options, for callers authorized by the
+// supplied Authorizer.
+func NewRollbackHandler(kube client.Client, r client.Reader, opts HistoryOptions, a *Authorizer, log logging.Logger) *RollbackHandler {
+	return &RollbackHandler{kube: kube, reader: r, namespace: opts.Namespace, authorizer: a, log: log}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *RollbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	q := r.URL.Query()
+	name := q.Get("object")
+	if name == "" {
+		http.Error(w, "the object query parameter is required", http.StatusBadRequest)
+		return
+	}
+	if code, err := h.authorizer.Authorize(r.Context(), r, "update", name); err != nil {
+		h.log.Debug("Rejected roll back of Object", "name", name, "error", err)
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	out, code, err := h.rollback(r.Context(), name, q.Get("generation"))
+	if err != nil {
+		h.log.Debug("Cannot roll back Object", "name", name, "error", err)
+		http.Error(w, err.Error(), code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(out)
+}
+
+func (h *RollbackHandler) rollback(ctx context.Context, name, generation string) ([]byte, int, error) {
+	cr := &v1alpha2.Object{}
+	if err := h.reader.Get(ctx, types.NamespacedName{Name: name}, cr); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, http.StatusNotFound, errors.Wrap(err, errGetHistory)
+		}
+		return nil, http.StatusInternalServerError, errors.Wrap(err, errGetHistory)
+	}
+	if meta.WasDeleted(cr) {
+		return nil, http.StatusConflict, errors.New(errRollbackDeleting)
+	}
+	snapshots, err := listSnapshots(ctx, h.reader, h.namespace, cr.GetUID())
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	s, code, err := rollbackTarget(cr, snapshots, generation)
+	if err != nil {
+		return nil, code, err
+	}
+	prev := v1alpha2.ObjectSpec{}
+	if err := json.Unmarshal([]byte(s.cm.Data[historyKeySpec]), &prev); err != nil {
+		return nil, http.StatusInternalServerError, errors.Wrap(err, errParseSnapshot)
+	}
+
+	// The manifest is restored along with the other fields it may be read
+	// from, since exactly one of them must be set.
+	orig := cr.DeepCopy()
+	cr.Spec.ForProvider.Manifest = prev.ForProvider.Manifest
+	cr.Spec.ForProvider.ManifestYAML = prev.ForProvider.ManifestYAML
+	cr.Spec.ForProvider.ManifestURL = prev.ForProvider.ManifestURL
+	meta.AddAnnotations(cr, map[string]string{AnnotationKeyRolledBackAt: time.Now().UTC().Format(time.RFC3339)})
+	if err := h.kube.Patch(ctx, cr, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{})); err != nil {
+		if kerrors.IsConflict(err) {
+			return nil, http.StatusConflict, errors.Wrap(err, errRollback)
+		}
+		return nil, http.StatusInternalServerError, errors.Wrap(err, errRollback)
+	}
+
+	return marshalHistory(Snapshot{Generation: s.generation, Time: s.cm.GetCreationTimestamp(), Spec: json.RawMessage(s.cm.Data[historyKeySpec])})
+}
+
+// rollbackTarget returns the snapshot the supplied Object is rolled back to,
+// either the one of the supplied generation, or the latest one before the
+// current generation whose manifest differs from the current one. Rolling
+// back to the latest snapshot would not change anything, since it usually is
+// the one of the current generation.
+func rollbackTarget(cr *v1alpha2.Object, snapshots []snapshot, generation string) (snapshot, int, error) {
+	if generation != "" {
+		g, err := strconv.ParseInt(generation, 10, 64)
+		if err != nil {
+			return snapshot{}, http.StatusBadRequest, errors.Wrap(err, errParseGeneration)
+		}
+		for _, s := range snapshots {
+			if s.generation == g {
+				return s, http.StatusOK, nil
+			}
+		}
+		return snapshot{}, http.StatusNotFound, errors.Errorf("%s %d", errNoSnapshot, g)
+	}
+
+	current := manifestOf(cr.Spec.ForProvider)
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		s := snapshots[i]
+		if s.generation >= cr.GetGeneration() {
+			continue
+		}
+		prev := v1alpha2.ObjectSpec{}
+		if err := json.Unmarshal([]byte(s.cm.Data[historyKeySpec]), &prev); err != nil {
+			continue
+		}
+		if !bytes.Equal(manifestOf(prev.ForProvider), current) {
+			return s, http.StatusOK, nil
+		}
+	}
+	return snapshot{}, http.StatusConflict, errors.New(errNoPreviousSnapshot)
+}
+
+// manifestOf returns the fields the manifest of an Object is read from, for
+// comparison.
+func manifestOf(p v1alpha2.ObjectParameters) []byte {
+	b, _ := json.Marshal(struct {
+		Manifest     json.RawMessage `json:"manifest,omitempty"`
+		ManifestYAML string          `json:"manifestYAML,omitempty"`
+		ManifestURL  string          `json:"manifestURL,omitempty"`
+	}{Manifest: compactJSON(p.Manifest.Raw), ManifestYAML: p.ManifestYAML, ManifestURL: p.ManifestURL})
+	return b
+}
+
+// compactJSON returns the supplied JSON without insignificant whitespace, or
+// as is if it is not valid JSON.
+func compactJSON(raw []byte) json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+	b := &bytes.Buffer{}
+	if err := json.Compact(b, raw); err != nil {
+		return raw
+	}
+	return b.Bytes()
+}
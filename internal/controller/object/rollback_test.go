@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestRollbackHandler(t *testing.T) {
+	uid := types.UID("uid")
+	now := metav1.Now()
+	snapshots := listSnapshotsFn(
+		specSnapshot(uid, 1, `{"forProvider": {"manifest": {"data": {"a": "1"}}}}`),
+		specSnapshot(uid, 2, `{"forProvider": {"manifest": {"data": {"a": "2"}}}}`),
+		specSnapshot(uid, 3, `{"forProvider": {"manifest": {"data": {"a": "2"}}}}`),
+		specSnapshot(uid, 4, `{"forProvider": {"manifest": {"data": {"a": "3"}}}}`),
+	)
+	getObject := func(deleted *metav1.Time) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			cr := obj.(*v1alpha2.Object)
+			cr.SetUID(uid)
+			cr.SetGeneration(4)
+			cr.SetDeletionTimestamp(deleted)
+			cr.Spec.ForProvider.Manifest = runtime.RawExtension{Raw: []byte(`{"data": {"a": "3"}}`)}
+			return nil
+		}
+	}
+
+	type args struct {
+		method     string
+		query      string
+		authorizer *Authorizer
+		reader     *test.MockClient
+		patch      error
+	}
+	type want struct {
+		code     int
+		manifest string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"MethodNotAllowed": {
+			reason: "We should only roll back Objects on POST.",
+			args: args{
+				method: http.MethodGet,
+				query:  "object=cool",
+			},
+			want: want{
+				code: http.StatusMethodNotAllowed,
+			},
+		},
+		"Forbidden": {
+			reason: "We should not roll back Objects the caller may not update.",
+			args: args{
+				method:     http.MethodPost,
+				query:      "object=cool",
+				authorizer: reviewer(true, false),
+				reader:     &test.MockClient{MockGet: getObject(nil), MockList: snapshots},
+			},
+			want: want{
+				code: http.StatusForbidden,
+			},
+		},
+		"Unauthenticated": {
+			reason: "We should not roll back Objects for unauthenticated callers.",
+			args: args{
+				method:     http.MethodPost,
+				query:      "object=cool",
+				authorizer: reviewer(false, true),
+				reader:     &test.MockClient{MockGet: getObject(nil), MockList: snapshots},
+			},
+			want: want{
+				code: http.StatusUnauthorized,
+			},
+		},
+		"Deleting": {
+			reason: "We should not roll back Objects that are being deleted.",
+			args: args{
+				method: http.MethodPost,
+				query:  "object=cool",
+				reader: &test.MockClient{MockGet: getObject(&now), MockList: snapshots},
+			},
+			want: want{
+				code: http.StatusConflict,
+			},
+		},
+		"NoHistory": {
+			reason: "We should not roll back Objects without spec snapshots.",
+			args: args{
+				method: http.MethodPost,
+				query:  "object=cool",
+				reader: &test.MockClient{MockGet: getObject(nil), MockList: listSnapshotsFn()},
+			},
+			want: want{
+				code: http.StatusConflict,
+			},
+		},
+		"Previous": {
+			reason: "We should restore the manifest of the latest previous generation that differs from the current one.",
+			args: args{
+				method: http.MethodPost,
+				query:  "object=cool",
+				reader: &test.MockClient{MockGet: getObject(nil), MockList: snapshots},
+			},
+			want: want{
+				code:     http.StatusOK,
+				manifest: `{"data": {"a": "2"}}`,
+			},
+		},
+		"Generation": {
+			reason: "We should restore the manifest of the supplied generation.",
+			args: args{
+				method: http.MethodPost,
+				query:  "object=cool&generation=1",
+				reader: &test.MockClient{MockGet: getObject(nil), MockList: snapshots},
+			},
+			want: want{
+				code:     http.StatusOK,
+				manifest: `{"data": {"a": "1"}}`,
+			},
+		},
+		"NoSnapshotOfGeneration": {
+			reason: "We should return not found for generations without a snapshot.",
+			args: args{
+				method: http.MethodPost,
+				query:  "object=cool&generation=7",
+				reader: &test.MockClient{MockGet: getObject(nil), MockList: snapshots},
+			},
+			want: want{
+				code: http.StatusNotFound,
+			},
+		},
+		"Conflict": {
+			reason: "We should not roll back Objects that changed since they were read.",
+			args: args{
+				method: http.MethodPost,
+				query:  "object=cool",
+				reader: &test.MockClient{MockGet: getObject(nil), MockList: snapshots},
+				patch:  kerrors.NewConflict(schema.GroupResource{}, "cool", nil),
+			},
+			want: want{
+				code: http.StatusConflict,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var patched *v1alpha2.Object
+			kube := &test.MockClient{
+				MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+					patched = obj.(*v1alpha2.Object)
+					return tc.args.patch
+				},
+			}
+			a := tc.args.authorizer
+			if a == nil {
+				a = reviewer(true, true)
+			}
+			h := NewRollbackHandler(kube, tc.args.reader, HistoryOptions{Namespace: "crossplane-system", Size: 10}, a, logging.NewNopLogger())
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, authenticated(httptest.NewRequest(tc.args.method, "/rollback?"+tc.args.query, nil)))
+			if tc.want.code == http.StatusForbidden && patched != nil {
+				t.Errorf("\n%s\nServeHTTP(...): want the Object not to be patched", tc.reason)
+			}
+
+			if rec.Code != tc.want.code {
+				t.Fatalf("\n%s\nServeHTTP(...): want status %d, got %d: %s", tc.reason, tc.want.code, rec.Code, rec.Body.String())
+			}
+			if tc.want.code != http.StatusOK {
+				return
+			}
+			if diff := cmp.Diff(tc.want.manifest, string(patched.Spec.ForProvider.Manifest.Raw)); diff != "" {
+				t.Errorf("\n%s\nServeHTTP(...): -want manifest, +got manifest:\n%s", tc.reason, diff)
+			}
+			if patched.GetAnnotations()[AnnotationKeyRolledBackAt] == "" {
+				t.Errorf("\n%s\nServeHTTP(...): want the Object to be annotated with %s", tc.reason, AnnotationKeyRolledBackAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/kube-openapi/pkg/handler3"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+const (
+	errNewRESTClient     = "cannot create discovery client"
+	errGetOpenAPIPaths   = "cannot get OpenAPI v3 discovery document"
+	errGetOpenAPISchema  = "cannot get OpenAPI v3 schema"
+	errParseOpenAPI      = "cannot parse OpenAPI v3 document"
+	errNoSchemaForGV     = "no OpenAPI v3 schema published for group version"
+	errNoSchemaForGVK    = "no OpenAPI v3 schema published for kind"
+	errUnresolvableRef   = "cannot resolve OpenAPI v3 schema reference"
+	errValidateAgainstGV = "manifest does not conform to the OpenAPI v3 schema"
+
+	extensionGVK = "x-kubernetes-group-version-kind"
+	refPrefix    = "#/components/schemas/"
+)
+
+// schemaValidator fetches the OpenAPI v3 schemas manifests are validated
+// against from the cluster they are applied to.
+// It caches schemas per GVK and cluster. The OpenAPI v3 discovery document
+// contains a hash of every group version's schema, which changes e.g. when a
+// CRD is updated. A cached schema is only used while that hash is unchanged.
+type schemaValidator struct {
+	lock    sync.RWMutex
+	schemas map[gvkWithConfig]cachedSchema
+}
+
+type cachedSchema struct {
+	// url of the group version's OpenAPI document, including its hash.
+	url    string
+	schema *spec.Schema
+}
+
+func newSchemaValidator() *schemaValidator {
+	return &schemaValidator{schemas: make(map[gvkWithConfig]cachedSchema)}
+}
+
+// SchemaFor returns the schema of the supplied GVK as served by the cluster
+// behind rc.
+func (v *schemaValidator) SchemaFor(ctx context.Context, rc *rest.Config, providerConfig string, gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewRESTClient)
+	}
+	c := dc.RESTClient()
+
+	data, err := c.Get().AbsPath("/openapi/v3").Do(ctx).Raw()
+	if err != nil {
+		return nil, errors.Wrap(err, errGetOpenAPIPaths)
+	}
+	disco := &handler3.OpenAPIV3Discovery{}
+	if err := json.Unmarshal(data, disco); err != nil {
+		return nil, errors.Wrap(err, errParseOpenAPI)
+	}
+
+	gv, ok := disco.Paths[openAPIPath(gvk.GroupVersion())]
+	if !ok {
+		return nil, errors.Errorf("%s %q", errNoSchemaForGV, gvk.GroupVersion())
+	}
+
+	key := gvkWithConfig{providerConfig: providerConfig, gvk: gvk}
+	v.lock.RLock()
+	cached, ok := v.schemas[key]
+	v.lock.RUnlock()
+	if ok && cached.url == gv.ServerRelativeURL {
+		return cached.schema, nil
+	}
+
+	loc, err := url.Parse(gv.ServerRelativeURL)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetOpenAPISchema)
+	}
+	req := c.Get().AbsPath(loc.Path).SetHeader("Accept", "application/json")
+	for k, vals := range loc.Query() {
+		for _, val := range vals {
+			req = req.Param(k, val)
+		}
+	}
+	if data, err = req.Do(ctx).Raw(); err != nil {
+		return nil, errors.Wrap(err, errGetOpenAPISchema)
+	}
+	doc := &spec3.OpenAPI{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, errors.Wrap(err, errParseOpenAPI)
+	}
+
+	s, err := schemaForGVK(doc, gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	v.lock.Lock()
+	v.schemas[key] = cachedSchema{url: gv.ServerRelativeURL, schema: s}
+	v.lock.Unlock()
+
+	return s, nil
+}
+
+// openAPIPath returns the key of a group version in the OpenAPI v3 discovery
+// document, e.g. "api/v1" or "apis/apps/v1".
+func openAPIPath(gv schema.GroupVersion) string {
+	if gv.Group == "" {
+		return "api/" + gv.Version
+	}
+	return "apis/" + gv.Group + "/" + gv.Version
+}
+
+// schemaForGVK returns the schema of the supplied GVK from an OpenAPI v3
+// document, with all references resolved.
+func schemaForGVK(doc *spec3.OpenAPI, gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	if doc.Components == nil {
+		return nil, errors.Errorf("%s %q", errNoSchemaForGVK, gvk)
+	}
+	for _, s := range doc.Components.Schemas {
+		if !hasGVK(s, gvk) {
+			continue
+		}
+		r := &refResolver{schemas: doc.Components.Schemas, visiting: map[string]bool{}}
+		out, err := r.resolve(*s)
+		if err != nil {
+			return nil, err
+		}
+		return &out, nil
+	}
+	return nil, errors.Errorf("%s %q", errNoSchemaForGVK, gvk)
+}
+
+func hasGVK(s *spec.Schema, gvk schema.GroupVersionKind) bool {
+	gvks, ok := s.Extensions[extensionGVK].([]interface{})
+	if !ok {
+		return false
+	}
+	for _, g := range gvks {
+		m, ok := g.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if m["group"] == gvk.Group && m["version"] == gvk.Version && m["kind"] == gvk.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// refResolver inlines the references of a schema, which the validator does
+// not support. Recursive references are replaced by a schema that accepts
+// anything.
+type refResolver struct {
+	schemas  map[string]*spec.Schema
+	visiting map[string]bool
+}
+
+func (r *refResolver) resolve(s spec.Schema) (spec.Schema, error) { //nolint:gocyclo // walks every kind of sub schema.
+	if ref := s.Ref.String(); ref != "" {
+		name := strings.TrimPrefix(ref, refPrefix)
+		target, ok := r.schemas[name]
+		if !ok {
+			return spec.Schema{}, errors.Errorf("%s %q", errUnresolvableRef, ref)
+		}
+		if r.visiting[name] {
+			return spec.Schema{}, nil
+		}
+		r.visiting[name] = true
+		defer delete(r.visiting, name)
+		return r.resolve(*target)
+	}
+
+	var err error
+	if s.AllOf, err = r.resolveAll(s.AllOf); err != nil {
+		return s, err
+	}
+	if s.OneOf, err = r.resolveAll(s.OneOf); err != nil {
+		return s, err
+	}
+	if s.AnyOf, err = r.resolveAll(s.AnyOf); err != nil {
+		return s, err
+	}
+	if s.Not != nil {
+		n, err := r.resolve(*s.Not)
+		if err != nil {
+			return s, err
+		}
+		s.Not = &n
+	}
+	if len(s.Properties) > 0 {
+		props := make(map[string]spec.Schema, len(s.Properties))
+		for k, p := range s.Properties {
+			if props[k], err = r.resolve(p); err != nil {
+				return s, err
+			}
+		}
+		s.Properties = props
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		ap, err := r.resolve(*s.AdditionalProperties.Schema)
+		if err != nil {
+			return s, err
+		}
+		s.AdditionalProperties = &spec.SchemaOrBool{Allows: true, Schema: &ap}
+	}
+	if s.Items != nil {
+		items := &spec.SchemaOrArray{}
+		if s.Items.Schema != nil {
+			is, err := r.resolve(*s.Items.Schema)
+			if err != nil {
+				return s, err
+			}
+			items.Schema = &is
+		}
+		if items.Schemas, err = r.resolveAll(s.Items.Schemas); err != nil {
+			return s, err
+		}
+		s.Items = items
+	}
+	return s, nil
+}
+
+// resolveAll resolves a list of schemas into a new list, leaving the
+// original, shared one untouched.
+func (r *refResolver) resolveAll(in []spec.Schema) ([]spec.Schema, error) {
+	if len(in) == 0 {
+		return in, nil
+	}
+	out := make([]spec.Schema, len(in))
+	for i := range in {
+		var err error
+		if out[i], err = r.resolve(in[i]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// A schemaViolation is returned for manifests that do not conform to the
+// schema of their kind.
+type schemaViolation struct {
+	msgs []string
+}
+
+func (e *schemaViolation) Error() string {
+	return errValidateAgainstGV + ": " + strings.Join(e.msgs, "; ")
+}
+
+func validateAgainstSchema(s *spec.Schema, u *unstructured.Unstructured) error {
+	res := validate.NewSchemaValidator(s, nil, "", strfmt.Default).Validate(u.Object)
+	if !res.HasErrors() {
+		return nil
+	}
+	msgs := make([]string, 0, len(res.Errors))
+	for _, e := range res.Errors {
+		msgs = append(msgs, e.Error())
+	}
+	return &schemaViolation{msgs: msgs}
+}
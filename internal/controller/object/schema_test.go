@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/kube-openapi/pkg/spec3"
+)
+
+const testOpenAPIDoc = `{
+  "openapi": "3.0.0",
+  "components": {
+    "schemas": {
+      "io.k8s.api.apps.v1.Deployment": {
+        "type": "object",
+        "x-kubernetes-group-version-kind": [{"group": "apps", "version": "v1", "kind": "Deployment"}],
+        "properties": {
+          "apiVersion": {"type": "string"},
+          "kind": {"type": "string"},
+          "spec": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}]}
+        }
+      },
+      "io.k8s.api.apps.v1.DeploymentSpec": {
+        "type": "object",
+        "required": ["selector"],
+        "properties": {
+          "replicas": {"type": "integer", "format": "int32"},
+          "selector": {"type": "object"},
+          "template": {"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}
+        }
+      }
+    }
+  }
+}`
+
+func TestValidateAgainstSchema(t *testing.T) {
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	type args struct {
+		gvk      schema.GroupVersionKind
+		manifest string
+	}
+	type want struct {
+		schemaErr bool
+		violation string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoSchemaForKind": {
+			reason: "We should return an error if the document does not publish a schema for the kind.",
+			args: args{
+				gvk: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+			},
+			want: want{
+				schemaErr: true,
+			},
+		},
+		"Valid": {
+			reason: "We should not return an error for a manifest that conforms to the schema.",
+			args: args{
+				gvk:      deployment,
+				manifest: `{"apiVersion": "apps/v1", "kind": "Deployment", "spec": {"replicas": 1, "selector": {}}}`,
+			},
+		},
+		"WrongType": {
+			reason: "We should report fields of the wrong type, including those of referenced schemas.",
+			args: args{
+				gvk:      deployment,
+				manifest: `{"apiVersion": "apps/v1", "kind": "Deployment", "spec": {"replicas": "one", "selector": {}}}`,
+			},
+			want: want{
+				violation: "spec.replicas",
+			},
+		},
+		"MissingRequired": {
+			reason: "We should report missing required fields.",
+			args: args{
+				gvk:      deployment,
+				manifest: `{"apiVersion": "apps/v1", "kind": "Deployment", "spec": {"replicas": 1}}`,
+			},
+			want: want{
+				violation: "spec.selector",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			doc := &spec3.OpenAPI{}
+			if err := json.Unmarshal([]byte(testOpenAPIDoc), doc); err != nil {
+				t.Fatalf("cannot parse test document: %v", err)
+			}
+
+			s, err := schemaForGVK(doc, tc.args.gvk)
+			if (err != nil) != tc.want.schemaErr {
+				t.Fatalf("\n%s\nschemaForGVK(...): want error: %t, got error: %v", tc.reason, tc.want.schemaErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			u := &unstructured.Unstructured{}
+			if err := json.Unmarshal([]byte(tc.args.manifest), &u.Object); err != nil {
+				t.Fatalf("cannot parse test manifest: %v", err)
+			}
+			err = validateAgainstSchema(s, u)
+			if tc.want.violation == "" {
+				if err != nil {
+					t.Errorf("\n%s\nvalidateAgainstSchema(...): unexpected error: %v", tc.reason, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want.violation) {
+				t.Errorf("\n%s\nvalidateAgainstSchema(...): want violation of %q, got: %v", tc.reason, tc.want.violation, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"context"
+	"text/template"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errParseSidecarTemplate  = "cannot parse template of sidecar object"
+	errRenderSidecarTemplate = "cannot render template of sidecar object"
+	errUnmarshalSidecar      = "cannot unmarshal rendered manifest of sidecar object"
+	errApplySidecar          = "cannot apply sidecar object"
+	errDeleteSidecar         = "cannot delete sidecar object"
+
+	sidecarFieldOwner = client.FieldOwner("kubernetes.crossplane.io/sidecar-objects")
+	sidecarLabelKey   = "kubernetes.crossplane.io/sidecar-of"
+)
+
+// sidecarTemplateData is what the template of a sidecar object is rendered
+// with.
+type sidecarTemplateData struct {
+	ObjectName  string
+	APIVersion  string
+	Kind        string
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// ensureSidecars applies the sidecar objects of the supplied Object, whose
+// managed resource was observed, and deletes those it no longer declares.
+func (c *external) ensureSidecars(ctx context.Context, cr *v1alpha2.Object, observed *unstructured.Unstructured) error {
+	if len(cr.Spec.SidecarObjects) == 0 && len(cr.Status.SidecarObjects) == 0 {
+		return nil
+	}
+
+	data := sidecarTemplateData{
+		ObjectName:  cr.GetName(),
+		APIVersion:  observed.GetAPIVersion(),
+		Kind:        observed.GetKind(),
+		Name:        observed.GetName(),
+		Namespace:   observed.GetNamespace(),
+		Labels:      observed.GetLabels(),
+		Annotations: observed.GetAnnotations(),
+	}
+
+	names := sets.New[string]()
+	for _, sc := range cr.Spec.SidecarObjects {
+		so, err := sidecarObject(cr, sc, data)
+		if err != nil {
+			return err
+		}
+		if err := c.localClient.Patch(ctx, so, client.Apply, sidecarFieldOwner, client.ForceOwnership); err != nil {
+			return errors.Wrapf(err, "%s %q", errApplySidecar, so.GetName())
+		}
+		names.Insert(so.GetName())
+	}
+
+	for _, n := range cr.Status.SidecarObjects {
+		if names.Has(n) {
+			continue
+		}
+		so := &v1alpha2.Object{}
+		so.SetName(n)
+		if err := c.localClient.Delete(ctx, so); resource.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, "%s %q", errDeleteSidecar, n)
+		}
+	}
+
+	cr.Status.SidecarObjects = nil
+	if names.Len() > 0 {
+		cr.Status.SidecarObjects = sets.List(names)
+	}
+	return nil
+}
+
+// sidecarObject renders the supplied sidecar of an Object into an Object
+// that is controlled by it, and thus garbage collected once it is deleted.
+func sidecarObject(cr *v1alpha2.Object, sc v1alpha2.SidecarObject, data sidecarTemplateData) (*unstructured.Unstructured, error) {
+	tmpl, err := template.New(sc.Name).Option("missingkey=error").Parse(sc.Template)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %q", errParseSidecarTemplate, sc.Name)
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return nil, errors.Wrapf(err, "%s %q", errRenderSidecarTemplate, sc.Name)
+	}
+	manifest, err := yaml.YAMLToJSON(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %q", errUnmarshalSidecar, sc.Name)
+	}
+	// Ensure the template rendered a single object, rather than e.g. a list.
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(manifest, &m); err != nil {
+		return nil, errors.Wrapf(err, "%s %q", errUnmarshalSidecar, sc.Name)
+	}
+
+	o := &v1alpha2.Object{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   cr.GetName() + "-" + sc.Name,
+			Labels: map[string]string{sidecarLabelKey: cr.GetName()},
+		},
+		Spec: v1alpha2.ObjectSpec{
+			ResourceSpec: xpv1.ResourceSpec{
+				ProviderConfigReference: cr.Spec.ProviderConfigReference,
+				DeletionPolicy:          cr.Spec.DeletionPolicy,
+			},
+			ForProvider: v1alpha2.ObjectParameters{
+				Manifest: runtime.RawExtension{Raw: manifest},
+			},
+		},
+	}
+	meta.AddOwnerReference(o, meta.AsController(meta.TypedReferenceTo(cr, v1alpha2.ObjectGroupVersionKind)))
+
+	v, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %q", errApplySidecar, o.GetName())
+	}
+	u := &unstructured.Unstructured{Object: v}
+	u.SetGroupVersionKind(v1alpha2.ObjectGroupVersionKind)
+	return u, nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const testNetworkPolicyTemplate = `apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: default-deny
+  namespace: {{ .Namespace }}
+  labels:
+    app: {{ index .Labels "app" }}
+spec:
+  podSelector: {}
+`
+
+func TestEnsureSidecars(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	observed := &unstructured.Unstructured{}
+	observed.SetAPIVersion("apps/v1")
+	observed.SetKind("Deployment")
+	observed.SetNamespace("team-a")
+	observed.SetName("web")
+	observed.SetLabels(map[string]string{"app": "web"})
+
+	type args struct {
+		client   *test.MockClient
+		sidecars []v1alpha2.SidecarObject
+		existing []string
+	}
+	type want struct {
+		errPrefix string
+		sidecars  []string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoSidecars": {
+			reason: "We should not call the API server if the Object has no sidecars.",
+			args: args{
+				client: &test.MockClient{},
+			},
+		},
+		"ApplySidecar": {
+			reason: "We should apply a sidecar Object rendered from the metadata of the managed resource.",
+			args: args{
+				client: &test.MockClient{
+					MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						u := obj.(*unstructured.Unstructured)
+						if u.GetName() != testObjectName+"-network-policy" {
+							t.Errorf("unexpected sidecar name %q", u.GetName())
+						}
+						if u.GetLabels()[sidecarLabelKey] != testObjectName {
+							t.Errorf("expected sidecar label %q, got %q", testObjectName, u.GetLabels()[sidecarLabelKey])
+						}
+						if refs := u.GetOwnerReferences(); len(refs) != 1 || refs[0].Name != testObjectName {
+							t.Errorf("expected the sidecar to be controlled by %q, got %v", testObjectName, refs)
+						}
+						if p, _, _ := unstructured.NestedString(u.Object, "spec", "deletionPolicy"); p != string(xpv1.DeletionOrphan) {
+							t.Errorf("expected deletion policy %q, got %q", xpv1.DeletionOrphan, p)
+						}
+						raw, _, _ := unstructured.NestedFieldNoCopy(u.Object, "spec", "forProvider", "manifest")
+						b, _ := json.Marshal(raw)
+						m := &unstructured.Unstructured{}
+						_ = m.UnmarshalJSON(b)
+						if m.GetNamespace() != "team-a" || m.GetLabels()["app"] != "web" {
+							t.Errorf("expected the manifest to be rendered from the managed resource, got %s", b)
+						}
+						return nil
+					},
+				},
+				sidecars: []v1alpha2.SidecarObject{{Name: "network-policy", Template: testNetworkPolicyTemplate}},
+			},
+			want: want{
+				sidecars: []string{testObjectName + "-network-policy"},
+			},
+		},
+		"DeleteRemovedSidecar": {
+			reason: "We should delete sidecar Objects the Object no longer declares.",
+			args: args{
+				client: &test.MockClient{
+					MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+						if obj.GetName() != testObjectName+"-stale" {
+							t.Errorf("unexpected deletion of %q", obj.GetName())
+						}
+						return nil
+					},
+				},
+				existing: []string{testObjectName + "-stale"},
+			},
+		},
+		"InvalidTemplate": {
+			reason: "We should return an error if the template of a sidecar refers to unknown data.",
+			args: args{
+				client:   &test.MockClient{},
+				sidecars: []v1alpha2.SidecarObject{{Name: "invalid", Template: "name: {{ .Unknown }}"}},
+			},
+			want: want{
+				errPrefix: errRenderSidecarTemplate,
+			},
+		},
+		"ApplyError": {
+			reason: "We should return an error if a sidecar cannot be applied.",
+			args: args{
+				client: &test.MockClient{
+					MockPatch: test.NewMockPatchFn(errBoom),
+				},
+				sidecars: []v1alpha2.SidecarObject{{Name: "network-policy", Template: testNetworkPolicyTemplate}},
+			},
+			want: want{
+				errPrefix: errApplySidecar,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := kubernetesObject(func(obj *v1alpha2.Object) {
+				obj.Spec.DeletionPolicy = xpv1.DeletionOrphan
+				obj.Spec.SidecarObjects = tc.args.sidecars
+				obj.Status.SidecarObjects = tc.args.existing
+			})
+			e := &external{
+				logger:      logging.NewNopLogger(),
+				localClient: tc.args.client,
+			}
+			err := e.ensureSidecars(context.Background(), cr, observed)
+			if tc.want.errPrefix != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tc.want.errPrefix) {
+					t.Errorf("\n%s\ne.ensureSidecars(...): want error starting with %q, got: %v", tc.reason, tc.want.errPrefix, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("\n%s\ne.ensureSidecars(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.sidecars, cr.Status.SidecarObjects); diff != "" {
+				t.Errorf("\n%s\ne.ensureSidecars(...): -want sidecars, +got sidecars:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
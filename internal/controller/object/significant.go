@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// AnnotationKeySignificantFieldHash is the hash of the significant
+	// fields of the managed resource when the Object was last found to be up
+	// to date. It is only recorded for Objects with significant fields.
+	AnnotationKeySignificantFieldHash = "provider-kubernetes.crossplane.io/significant-field-hash"
+
+	errHashSignificant = "cannot hash significant fields"
+)
+
+// significantFieldHash returns a hash of the significant fields of the
+// supplied observed resource, or an empty string if the supplied Object has
+// none. Fields that are not set are hashed as null, annotations ignored by the
+// drift detection of the Object are not hashed.
+func significantFieldHash(cr *v1alpha2.Object, observed *unstructured.Unstructured) (string, error) {
+	pointers := cr.Spec.DriftDetection.SignificantFields
+	if len(pointers) == 0 {
+		return "", nil
+	}
+	stripped := withoutIgnoredAnnotations(cr, observed)
+	values := make([]interface{}, len(pointers))
+	for i, p := range pointers {
+		segments, err := parseJSONPointer(p)
+		if err != nil {
+			return "", err
+		}
+		values[i] = valueAt(stripped.Object, segments)
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", errors.Wrap(err, errHashSignificant)
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// significantFieldsDrifted returns true if the significant fields of the
+// managed resource of the supplied Object changed since it was last found to
+// be up to date, while its spec did not. Drift is not reported before a hash
+// was recorded, or after the spec changed.
+func significantFieldsDrifted(cr *v1alpha2.Object, hash, sigHash string) bool {
+	a := cr.GetAnnotations()
+	recorded := a[AnnotationKeySignificantFieldHash]
+	return sigHash != "" && recorded != "" && a[AnnotationKeyAppliedSpecHash] == hash && recorded != sigHash
+}
+
+// valueAt returns the value at the supplied path of v, or nil if there is
+// none.
+func valueAt(v interface{}, path []string) interface{} {
+	for _, s := range path {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			v = t[s]
+		case []interface{}:
+			i, err := strconv.Atoi(s)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil
+			}
+			v = t[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errHashSpec       = "cannot hash spec"
+	errRecordObserved = "cannot record up to date spec and resource version"
+
+	// AnnotationKeyAppliedSpecHash is the hash of the spec of an Object that
+	// was last found to be up to date.
+	AnnotationKeyAppliedSpecHash = "provider-kubernetes.crossplane.io/applied-spec-hash"
+
+	// AnnotationKeyLastObservedResourceVersion is the resource version of
+	// the managed resource when the Object was last found to be up to date.
+	AnnotationKeyLastObservedResourceVersion = "provider-kubernetes.crossplane.io/last-observed-rv"
+
+	// annotationKeyObservedResourceVersion was used before
+	// AnnotationKeyLastObservedResourceVersion, and is removed once the
+	// latter is recorded.
+	annotationKeyObservedResourceVersion = "provider-kubernetes.crossplane.io/observed-resource-version"
+)
+
+// specHash returns a hash of the spec of the supplied Object.
+func specHash(cr *v1alpha2.Object) (string, error) {
+	b, err := json.Marshal(cr.Spec)
+	if err != nil {
+		return "", errors.Wrap(err, errHashSpec)
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// withDesiredManifest returns the supplied spec hash combined with a hash of
+// the supplied desired manifest. Objects whose desired manifest does not
+// follow from their spec alone, because it is fetched from their manifest
+// URL or mutated by MutatingPolicies, use it so that they are no longer up to
+// date once the fetched document or a matching policy changed. Objects that
+// stop matching any policy are back to the hash of their spec, which differs
+// from the recorded one, too.
+func withDesiredManifest(hash string, desired *unstructured.Unstructured) (string, error) {
+	b, err := desired.MarshalJSON()
+	if err != nil {
+		return "", errors.Wrap(err, errHashSpec)
+	}
+	h := sha256.New()
+	h.Write([]byte(hash))
+	h.Write([]byte{0})
+	h.Write(b)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// canSkipApply returns true if neither the spec of the supplied Object nor
+// the observed resource changed since the Object was last found to be up
+// to date, in which case it still is. Objects with significant fields only
+// compare the hash of those, rather than the resource version.
+func canSkipApply(cr *v1alpha2.Object, observed *unstructured.Unstructured, hash, sigHash string) bool {
+	if sigHash != "" {
+		a := cr.GetAnnotations()
+		return a[AnnotationKeyAppliedSpecHash] == hash && a[AnnotationKeySignificantFieldHash] == sigHash
+	}
+	return upToDateAt(cr, observed.GetResourceVersion(), hash)
+}
+
+// upToDateAt returns true if the supplied Object was last found to be up to
+// date with the supplied spec hash and resource version of its resource.
+func upToDateAt(cr *v1alpha2.Object, rv, hash string) bool {
+	a := cr.GetAnnotations()
+	return rv != "" && a[AnnotationKeyAppliedSpecHash] == hash && a[AnnotationKeyLastObservedResourceVersion] == rv
+}
+
+// canSkipObserve returns true if the resource version of the managed
+// resource of the supplied Object, as last seen by the watch of its kind,
+// is the one the Object was last found to be up to date at, and its spec did
+// not change since. The resource does not have to be read from its cluster
+// then. Objects waiting for or recording events of their resource are always
+// observed.
+func (c *external) canSkipObserve(ctx context.Context, cr *v1alpha2.Object, desired *unstructured.Unstructured, hash string) bool {
+	if c.resourceVersions == nil || !c.shouldWatch(cr) || watchStopped(cr) || meta.WasDeleted(cr) || waitsForEvent(cr) || recordsEventHistory(cr) {
+		return false
+	}
+	rv, ok := c.resourceVersions.ResourceVersion(ctx, cr.Spec.ProviderConfigReference.Name, desired.GroupVersionKind(), types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()})
+	return ok && upToDateAt(cr, rv, hash)
+}
+
+// watchedUpToDate returns true if the managed resource of the supplied
+// Object is watched and the Object was found to be up to date with its
+// current spec. Changes of the resource trigger a reconcile then, and the
+// Object does not have to be polled for drift. Objects that were recorded
+// with a hash of their desired manifest, see withDesiredManifest, are not
+// found to be up to date here and are always polled.
+func watchedUpToDate(cr *v1alpha2.Object) bool {
+	if !cr.Spec.Watch || watchStopped(cr) || cr.GetAnnotations()[AnnotationKeyLastObservedResourceVersion] == "" {
+		return false
+	}
+	hash, err := specHash(cr)
+	return err == nil && cr.GetAnnotations()[AnnotationKeyAppliedSpecHash] == hash
+}
+
+// recordUpToDate records the supplied spec hash, significant field hash and
+// the resource version of the observed resource on the supplied Object, so
+// that later observations can skip comparing them as long as none changes.
+func (c *external) recordUpToDate(ctx context.Context, cr *v1alpha2.Object, observed *unstructured.Unstructured, hash, sigHash string) error {
+	if observed.GetResourceVersion() == "" {
+		return nil
+	}
+	if upToDateAt(cr, observed.GetResourceVersion(), hash) && cr.GetAnnotations()[AnnotationKeySignificantFieldHash] == sigHash {
+		return nil
+	}
+
+	add := map[string]string{
+		AnnotationKeyAppliedSpecHash:             hash,
+		AnnotationKeyLastObservedResourceVersion: observed.GetResourceVersion(),
+	}
+	remove := []string{annotationKeyObservedResourceVersion}
+	if sigHash != "" {
+		add[AnnotationKeySignificantFieldHash] = sigHash
+	} else {
+		remove = append(remove, AnnotationKeySignificantFieldHash)
+	}
+	return errors.Wrap(c.patchAnnotations(ctx, cr, add, remove...), errRecordObserved)
+}
+
+// patchAnnotations adds and removes the supplied annotations of the supplied
+// Object. A copy is patched, the status of the Object is not persisted yet
+// and must not be overwritten with the one returned by the API server. Only
+// the annotations and the resource version are copied back.
+func (c *external) patchAnnotations(ctx context.Context, cr *v1alpha2.Object, add map[string]string, remove ...string) error {
+	p := cr.DeepCopy()
+	if len(add) > 0 {
+		meta.AddAnnotations(p, add)
+	}
+	if len(remove) > 0 {
+		meta.RemoveAnnotations(p, remove...)
+	}
+	if err := c.localClient.Patch(ctx, p, client.MergeFrom(cr)); err != nil {
+		return err
+	}
+	cr.SetAnnotations(p.GetAnnotations())
+	cr.SetResourceVersion(p.GetResourceVersion())
+	return nil
+}
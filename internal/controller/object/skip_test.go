@@ -0,0 +1,530 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/manifest"
+)
+
+func TestObserveSkipApply(t *testing.T) {
+	type want struct {
+		out     managed.ExternalObservation
+		patched bool
+	}
+	cases := map[string]struct {
+		reason          string
+		resourceVersion string
+		lastApplied     string
+		want            want
+	}{
+		"Unchanged": {
+			reason:          "We should skip comparing the desired and last applied manifests if neither the spec nor the resource changed.",
+			resourceVersion: "42",
+			lastApplied:     `{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"crossplane-system","labels":{"old-label":"gone"}}}`,
+			want: want{
+				out: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"ResourceChanged": {
+			reason:          "We should compare the manifests again if the resource changed.",
+			resourceVersion: "43",
+			lastApplied:     `{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"crossplane-system","labels":{"old-label":"gone"}}}`,
+			want: want{
+				out: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"RecordUpToDate": {
+			reason:          "We should record the spec hash and resource version once the resource is found to be up to date.",
+			resourceVersion: "43",
+			lastApplied:     string(externalResourceRaw),
+			want: want{
+				out:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				patched: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := kubernetesObject()
+			hash, err := specHash(cr)
+			if err != nil {
+				t.Fatalf("specHash(...): %v", err)
+			}
+			cr.SetAnnotations(map[string]string{
+				AnnotationKeyAppliedSpecHash:             hash,
+				AnnotationKeyLastObservedResourceVersion: "42",
+			})
+
+			patched := false
+			c := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					u := externalResourceWithLastAppliedConfigAnnotation(tc.lastApplied)
+					u.SetResourceVersion(tc.resourceVersion)
+					*obj.(*unstructured.Unstructured) = *u
+					return nil
+				}),
+				MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+					patched = true
+					if got := obj.GetAnnotations()[AnnotationKeyLastObservedResourceVersion]; got != tc.resourceVersion {
+						t.Errorf("\n%s\ne.Observe(...): want recorded resource version %q, got %q", tc.reason, tc.resourceVersion, got)
+					}
+					return nil
+				},
+			}
+			e := &external{
+				logger:      logging.NewNopLogger(),
+				client:      resource.ClientApplicator{Client: c},
+				localClient: c,
+			}
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.out, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if patched != tc.want.patched {
+				t.Errorf("\n%s\ne.Observe(...): want patched %t, got %t", tc.reason, tc.want.patched, patched)
+			}
+		})
+	}
+}
+
+func TestObserveSignificantFields(t *testing.T) {
+	labels := func(app string) externalResourceModifier {
+		return func(u *unstructured.Unstructured) {
+			u.SetLabels(map[string]string{"app": app})
+		}
+	}
+	stale := `{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"crossplane-system","labels":{"old-label":"gone"}}}`
+
+	type want struct {
+		out     managed.ExternalObservation
+		patched bool
+	}
+	cases := map[string]struct {
+		reason      string
+		recorded    bool
+		app         string
+		lastApplied string
+		want        want
+	}{
+		"InsignificantChange": {
+			reason:      "We should skip comparing the manifests if only fields other than the significant ones changed.",
+			recorded:    true,
+			app:         "a",
+			lastApplied: stale,
+			want: want{
+				out: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"SignificantChange": {
+			reason:      "We should report drift if the significant fields changed, even if the last applied manifest is up to date.",
+			recorded:    true,
+			app:         "b",
+			lastApplied: string(externalResourceRaw),
+			want: want{
+				out: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"RecordHash": {
+			reason:      "We should record the hash of the significant fields once the resource is found to be up to date.",
+			app:         "b",
+			lastApplied: string(externalResourceRaw),
+			want: want{
+				out:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				patched: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := kubernetesObject()
+			cr.Spec.DriftDetection.SignificantFields = []string{"/metadata/labels"}
+			hash, err := specHash(cr)
+			if err != nil {
+				t.Fatalf("specHash(...): %v", err)
+			}
+			recorded, err := significantFieldHash(cr, externalResource(labels("a")))
+			if err != nil {
+				t.Fatalf("significantFieldHash(...): %v", err)
+			}
+			a := map[string]string{
+				AnnotationKeyAppliedSpecHash:             hash,
+				AnnotationKeyLastObservedResourceVersion: "42",
+			}
+			if tc.recorded {
+				a[AnnotationKeySignificantFieldHash] = recorded
+			}
+			cr.SetAnnotations(a)
+
+			observed := externalResourceWithLastAppliedConfigAnnotation(tc.lastApplied)
+			labels(tc.app)(observed)
+			observed.SetResourceVersion("43")
+			want, err := significantFieldHash(cr, observed)
+			if err != nil {
+				t.Fatalf("significantFieldHash(...): %v", err)
+			}
+
+			patched := false
+			c := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					*obj.(*unstructured.Unstructured) = *observed.DeepCopy()
+					return nil
+				}),
+				MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+					patched = true
+					if got := obj.GetAnnotations()[AnnotationKeySignificantFieldHash]; got != want {
+						t.Errorf("\n%s\ne.Observe(...): want recorded significant field hash %q, got %q", tc.reason, want, got)
+					}
+					return nil
+				},
+			}
+			e := &external{
+				logger:      logging.NewNopLogger(),
+				client:      resource.ClientApplicator{Client: c},
+				localClient: c,
+			}
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.out, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if patched != tc.want.patched {
+				t.Errorf("\n%s\ne.Observe(...): want patched %t, got %t", tc.reason, tc.want.patched, patched)
+			}
+		})
+	}
+}
+
+func TestObserveServerAnnotations(t *testing.T) {
+	cr := kubernetesObject()
+	cr.Spec.DriftDetection.SignificantFields = []string{"/metadata/annotations"}
+	hash, err := specHash(cr)
+	if err != nil {
+		t.Fatalf("specHash(...): %v", err)
+	}
+	recorded, err := significantFieldHash(cr, externalResource())
+	if err != nil {
+		t.Fatalf("significantFieldHash(...): %v", err)
+	}
+	cr.SetAnnotations(map[string]string{
+		AnnotationKeyAppliedSpecHash:             hash,
+		AnnotationKeyLastObservedResourceVersion: "42",
+		AnnotationKeySignificantFieldHash:        recorded,
+	})
+
+	// The API server and controllers annotate the resource after it was
+	// applied.
+	observed := externalResourceWithLastAppliedConfigAnnotation(string(externalResourceRaw))
+	meta.AddAnnotations(observed, map[string]string{"deployment.kubernetes.io/revision": "2"})
+	observed.SetResourceVersion("43")
+
+	c := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			*obj.(*unstructured.Unstructured) = *observed.DeepCopy()
+			return nil
+		}),
+		MockPatch: test.NewMockPatchFn(nil),
+	}
+	e := &external{
+		logger:      logging.NewNopLogger(),
+		client:      resource.ClientApplicator{Client: c},
+		localClient: c,
+	}
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %v", err)
+	}
+	want := managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Observe(...): annotations added by the server must not be drift: -want, +got:\n%s", diff)
+	}
+	if d := cr.Status.Divergence; d == nil || d.FieldCount != 0 {
+		t.Errorf("e.Observe(...): annotations added by the server must not diverge, got %v", d)
+	}
+}
+
+func TestObserveManifestURLChanged(t *testing.T) {
+	doc := func(v string) string {
+		return fmt.Sprintf(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":%q,"labels":{"version":%q}}}`, externalResourceName, v)
+	}
+	body := doc("1")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	cr := kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.Spec.ForProvider.Manifest.Raw = nil
+		obj.Spec.ForProvider.ManifestURL = srv.URL
+	})
+
+	// The resource does not change, only the document behind the URL does.
+	var applied *unstructured.Unstructured
+	c := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			u := externalResourceWithLastAppliedConfigAnnotation(doc("1"))
+			u.SetResourceVersion("42")
+			*obj.(*unstructured.Unstructured) = *u
+			return nil
+		}),
+		MockPatch: test.NewMockPatchFn(nil),
+	}
+	e := &external{
+		logger: logging.NewNopLogger(),
+		client: resource.ClientApplicator{
+			Client: c,
+			Applicator: resource.ApplyFn(func(_ context.Context, obj client.Object, _ ...resource.ApplyOption) error {
+				applied = obj.(*unstructured.Unstructured)
+				return nil
+			}),
+		},
+		localClient: c,
+		manifests:   manifest.NewFetcher(c, true),
+	}
+
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %v", err)
+	}
+	if !got.ResourceUpToDate || cr.GetAnnotations()[AnnotationKeyLastObservedResourceVersion] != "42" {
+		t.Fatalf("e.Observe(...): want the fetched manifest to be recorded as up to date, got %+v", got)
+	}
+
+	body = doc("2")
+	got, err = e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %v", err)
+	}
+	if got.ResourceUpToDate {
+		t.Fatalf("e.Observe(...): the changed document behind the manifest URL should not be skipped as up to date")
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): unexpected error: %v", err)
+	}
+	if applied == nil || applied.GetLabels()["version"] != "2" {
+		t.Errorf("e.Update(...): want the changed document to be applied, got %v", applied)
+	}
+}
+
+type fakeWatches struct {
+	rv string
+	ok bool
+}
+
+func (f *fakeWatches) WatchResources(_ *rest.Config, _ string, _ ...schema.GroupVersionKind) error {
+	return nil
+}
+
+func (f *fakeWatches) ResourceVersion(_ context.Context, _ string, _ schema.GroupVersionKind, _ types.NamespacedName) (string, bool) {
+	return f.rv, f.ok
+}
+
+func TestObserveSkipObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason  string
+		watch   bool
+		watches *fakeWatches
+		want    error
+	}{
+		"Unchanged": {
+			reason:  "We should not read a watched resource whose watch saw the resource version it was last up to date at.",
+			watch:   true,
+			watches: &fakeWatches{rv: "42", ok: true},
+		},
+		"ResourceChanged": {
+			reason:  "We should read the resource if its watch saw another resource version.",
+			watch:   true,
+			watches: &fakeWatches{rv: "43", ok: true},
+			want:    errors.Wrap(errBoom, errGetObject),
+		},
+		"NotSynced": {
+			reason:  "We should read the resource if its watch is not synced yet.",
+			watch:   true,
+			watches: &fakeWatches{},
+			want:    errors.Wrap(errBoom, errGetObject),
+		},
+		"NotWatched": {
+			reason:  "We should read the resource if the Object does not watch it.",
+			watches: &fakeWatches{rv: "42", ok: true},
+			want:    errors.Wrap(errBoom, errGetObject),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := kubernetesObject()
+			cr.Spec.Watch = tc.watch
+			hash, err := specHash(cr)
+			if err != nil {
+				t.Fatalf("specHash(...): %v", err)
+			}
+			cr.SetAnnotations(map[string]string{
+				AnnotationKeyAppliedSpecHash:             hash,
+				AnnotationKeyLastObservedResourceVersion: "42",
+			})
+
+			c := &test.MockClient{MockGet: test.NewMockGetFn(errBoom)}
+			e := &external{
+				logger:           logging.NewNopLogger(),
+				client:           resource.ClientApplicator{Client: c},
+				localClient:      c,
+				kindObserver:     tc.watches,
+				resourceVersions: tc.watches,
+			}
+			_, err = e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWatchedUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		watch       bool
+		annotations func(hash string) map[string]string
+		want        bool
+	}{
+		"UpToDate": {
+			reason: "A watched Object that was up to date with its current spec should not be polled.",
+			watch:  true,
+			annotations: func(hash string) map[string]string {
+				return map[string]string{AnnotationKeyAppliedSpecHash: hash, AnnotationKeyLastObservedResourceVersion: "42"}
+			},
+			want: true,
+		},
+		"SpecChanged": {
+			reason: "A watched Object whose spec changed since it was up to date should be polled.",
+			watch:  true,
+			annotations: func(_ string) map[string]string {
+				return map[string]string{AnnotationKeyAppliedSpecHash: "stale", AnnotationKeyLastObservedResourceVersion: "42"}
+			},
+		},
+		"NotWatched": {
+			reason: "An Object that is not watched should be polled.",
+			annotations: func(hash string) map[string]string {
+				return map[string]string{AnnotationKeyAppliedSpecHash: hash, AnnotationKeyLastObservedResourceVersion: "42"}
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := kubernetesObject()
+			cr.Spec.Watch = tc.watch
+			hash, err := specHash(cr)
+			if err != nil {
+				t.Fatalf("specHash(...): %v", err)
+			}
+			cr.SetAnnotations(tc.annotations(hash))
+			if got := watchedUpToDate(cr); got != tc.want {
+				t.Errorf("\n%s\nwatchedUpToDate(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPatchAnnotations(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		annotations     map[string]string
+		resourceVersion string
+		err             error
+	}
+	cases := map[string]struct {
+		reason   string
+		patchErr error
+		want     want
+	}{
+		"Patched": {
+			reason: "We should copy back the annotations and the resource version of the patched Object, but not its status.",
+			want: want{
+				annotations:     map[string]string{"added": "true", "kept": "true"},
+				resourceVersion: "2",
+			},
+		},
+		"PatchError": {
+			reason:   "We should return the error of the patch and leave the Object unchanged.",
+			patchErr: errBoom,
+			want: want{
+				annotations:     map[string]string{"kept": "true", "removed": "true"},
+				resourceVersion: "1",
+				err:             errBoom,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := kubernetesObject()
+			cr.SetAnnotations(map[string]string{"kept": "true", "removed": "true"})
+			cr.SetResourceVersion("1")
+			cr.Status.AtProvider.Manifest.Raw = []byte(`{"unpersisted":true}`)
+
+			c := &external{localClient: &test.MockClient{
+				MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+					if tc.patchErr != nil {
+						return tc.patchErr
+					}
+					o := obj.(*v1alpha2.Object)
+					o.SetResourceVersion("2")
+					o.Status.AtProvider.Manifest.Raw = nil
+					return nil
+				},
+			}}
+			err := c.patchAnnotations(context.Background(), cr, map[string]string{"added": "true"}, "removed")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\npatchAnnotations(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.annotations, cr.GetAnnotations()); diff != "" {
+				t.Errorf("\n%s\npatchAnnotations(...): -want annotations, +got:\n%s", tc.reason, diff)
+			}
+			if cr.GetResourceVersion() != tc.want.resourceVersion {
+				t.Errorf("\n%s\npatchAnnotations(...): want resource version %q, got %q", tc.reason, tc.want.resourceVersion, cr.GetResourceVersion())
+			}
+			if string(cr.Status.AtProvider.Manifest.Raw) != `{"unpersisted":true}` {
+				t.Errorf("\n%s\npatchAnnotations(...): want the status of the Object kept, got %s", tc.reason, cr.Status.AtProvider.Manifest.Raw)
+			}
+		})
+	}
+}
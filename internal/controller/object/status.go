@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+// statusHoldBack is how long status updates of an Object are held back
+// after its status was written, so that only the final state of rapid
+// successive reconciles is written.
+const statusHoldBack = 100 * time.Millisecond
+
+// statusSyncManager hands out a statusSyncClient instead of the client of
+// the manager it wraps.
+type statusSyncManager struct {
+	ctrl.Manager
+	client client.Client
+}
+
+func (m *statusSyncManager) GetClient() client.Client {
+	return m.client
+}
+
+// statusSyncClient only writes the status of Objects if it differs from the
+// status last read or written, and holds back writes following each other
+// within the hold back duration. Others objects are passed through.
+type statusSyncClient struct {
+	client.Client
+	log      logging.Logger
+	holdBack time.Duration
+	now      func() time.Time
+
+	lock    sync.Mutex
+	objects map[types.NamespacedName]*statusState
+}
+
+type statusState struct {
+	// status is the JSON of the status of the Object as last read or written.
+	status  []byte
+	written time.Time
+	// pending is the latest held back Object, written once timer fires.
+	pending *v1alpha2.Object
+	timer   *time.Timer
+}
+
+func newStatusSyncClient(c client.Client, log logging.Logger) *statusSyncClient {
+	return &statusSyncClient{
+		Client:   c,
+		log:      log,
+		holdBack: statusHoldBack,
+		now:      time.Now,
+		objects:  make(map[types.NamespacedName]*statusState),
+	}
+}
+
+// Get records the status of the Objects it reads.
+func (c *statusSyncClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Client.Get(ctx, key, obj, opts...)
+	cr, ok := obj.(*v1alpha2.Object)
+	if !ok {
+		return err
+	}
+	if kerrors.IsNotFound(err) {
+		c.forget(key)
+	}
+	if err != nil {
+		return err
+	}
+	if s, jerr := json.Marshal(cr.Status); jerr == nil {
+		c.lock.Lock()
+		c.state(key).status = s
+		c.lock.Unlock()
+	}
+	return nil
+}
+
+// Status returns a status writer that skips updates not changing the
+// status of Objects.
+func (c *statusSyncClient) Status() client.SubResourceWriter {
+	return &statusSyncWriter{SubResourceWriter: c.Client.Status(), client: c}
+}
+
+func (c *statusSyncClient) state(key types.NamespacedName) *statusState {
+	s, ok := c.objects[key]
+	if !ok {
+		s = &statusState{}
+		c.objects[key] = s
+	}
+	return s
+}
+
+func (c *statusSyncClient) forget(key types.NamespacedName) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if s, ok := c.objects[key]; ok && s.timer != nil {
+		s.timer.Stop()
+	}
+	delete(c.objects, key)
+}
+
+// flush writes the held back status of the supplied Object onto its latest
+// version, as the resource version of the held back Object may be outdated
+// by now.
+func (c *statusSyncClient) flush(key types.NamespacedName) {
+	c.lock.Lock()
+	s, ok := c.objects[key]
+	if !ok || s.pending == nil {
+		c.lock.Unlock()
+		return
+	}
+	cr := s.pending
+	s.pending, s.timer = nil, nil
+	c.lock.Unlock()
+
+	status, err := json.Marshal(cr.Status)
+	if err != nil {
+		c.log.Info("Cannot write held back status", "name", key.Name, "error", err)
+		return
+	}
+	ctx := context.Background()
+	latest := &v1alpha2.Object{}
+	if err := c.Client.Get(ctx, key, latest); err != nil {
+		c.log.Debug("Cannot write held back status", "name", key.Name, "error", err)
+		return
+	}
+	latest.Status = cr.Status
+	if err := c.Client.Status().Update(ctx, latest); err != nil {
+		// The next reconcile of the Object writes its status again.
+		c.log.Debug("Cannot write held back status", "name", key.Name, "error", err)
+		return
+	}
+	c.lock.Lock()
+	st := c.state(key)
+	st.status, st.written = status, c.now()
+	c.lock.Unlock()
+}
+
+type statusSyncWriter struct {
+	client.SubResourceWriter
+	client *statusSyncClient
+}
+
+// Update writes the status of an Object unless it is unchanged, or holds it
+// back if the status was written within the hold back duration.
+func (w *statusSyncWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	cr, ok := obj.(*v1alpha2.Object)
+	if !ok {
+		return w.SubResourceWriter.Update(ctx, obj, opts...)
+	}
+	status, err := json.Marshal(cr.Status)
+	if err != nil {
+		return w.SubResourceWriter.Update(ctx, obj, opts...)
+	}
+
+	c := w.client
+	key := client.ObjectKeyFromObject(cr)
+	c.lock.Lock()
+	s := c.state(key)
+	if bytes.Equal(s.status, status) {
+		// A held back status is superseded by the unchanged one.
+		if s.timer != nil {
+			s.timer.Stop()
+		}
+		s.pending, s.timer = nil, nil
+		c.lock.Unlock()
+		return nil
+	}
+	if elapsed := c.now().Sub(s.written); elapsed < c.holdBack {
+		s.pending = cr.DeepCopy()
+		if s.timer == nil {
+			s.timer = time.AfterFunc(c.holdBack-elapsed, func() { c.flush(key) })
+		}
+		c.lock.Unlock()
+		return nil
+	}
+	c.lock.Unlock()
+
+	if err := w.SubResourceWriter.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.lock.Lock()
+	s = c.state(key)
+	s.status, s.written = status, c.now()
+	c.lock.Unlock()
+	return nil
+}
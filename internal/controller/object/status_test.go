@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestStatusSyncClient(t *testing.T) {
+	now := time.Now()
+
+	type args struct {
+		// written is how long ago the status was last written, if at all.
+		written *time.Duration
+		status  string
+	}
+	type want struct {
+		updates int64
+		pending bool
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Unchanged": {
+			reason: "We should not write a status that equals the status read.",
+			args: args{
+				status: "read",
+			},
+		},
+		"Changed": {
+			reason: "We should write a status that differs from the status read.",
+			args: args{
+				status: "changed",
+			},
+			want: want{
+				updates: 1,
+			},
+		},
+		"HeldBack": {
+			reason: "We should hold back a status written shortly after the last write.",
+			args: args{
+				written: func() *time.Duration { d := 10 * time.Millisecond; return &d }(),
+				status:  "changed",
+			},
+			want: want{
+				pending: true,
+			},
+		},
+		"HoldBackElapsed": {
+			reason: "We should write a status once the hold back since the last write elapsed.",
+			args: args{
+				written: func() *time.Duration { d := time.Second; return &d }(),
+				status:  "changed",
+			},
+			want: want{
+				updates: 1,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updates := &atomic.Int64{}
+			mc := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					obj.(*v1alpha2.Object).Status.LastErrorCode = "read"
+					return nil
+				},
+				MockStatusUpdate: func(_ context.Context, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+					updates.Add(1)
+					return nil
+				},
+			}
+			c := newStatusSyncClient(mc, logging.NewNopLogger())
+			c.holdBack = time.Hour
+			c.now = func() time.Time { return now }
+
+			cr := kubernetesObject()
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(cr), cr); err != nil {
+				t.Fatalf("Get(...): unexpected error: %v", err)
+			}
+			if tc.args.written != nil {
+				c.state(client.ObjectKeyFromObject(cr)).written = now.Add(-*tc.args.written)
+				c.holdBack = 100 * time.Millisecond
+			}
+
+			cr.Status.LastErrorCode = tc.args.status
+			if err := c.Status().Update(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\nStatus().Update(...): unexpected error: %v", tc.reason, err)
+			}
+			if got := updates.Load(); got != tc.want.updates {
+				t.Errorf("\n%s\nStatus().Update(...): want %d updates, got %d", tc.reason, tc.want.updates, got)
+			}
+			s := c.state(client.ObjectKeyFromObject(cr))
+			if pending := s.pending != nil; pending != tc.want.pending {
+				t.Errorf("\n%s\nStatus().Update(...): want pending: %t, got: %t", tc.reason, tc.want.pending, pending)
+			}
+			if s.timer != nil {
+				s.timer.Stop()
+			}
+		})
+	}
+}
+
+func TestStatusSyncClientFlush(t *testing.T) {
+	written := make(chan string, 1)
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+		MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+			written <- obj.(*v1alpha2.Object).Status.LastErrorCode
+			return nil
+		},
+	}
+	c := newStatusSyncClient(mc, logging.NewNopLogger())
+	c.holdBack = 10 * time.Millisecond
+
+	cr := kubernetesObject()
+	for _, code := range []string{"first", "second", "final"} {
+		cr.Status.LastErrorCode = code
+		if err := c.Status().Update(context.Background(), cr); err != nil {
+			t.Fatalf("Status().Update(...): unexpected error: %v", err)
+		}
+	}
+	if got := <-written; got != "first" {
+		t.Errorf("Status().Update(...): want the first status written right away, got %q", got)
+	}
+	select {
+	case got := <-written:
+		if got != "final" {
+			t.Errorf("flush(): want only the final status written, got %q", got)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("flush(): want the held back status written once the hold back elapsed")
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errGetTemplateSecret   = "cannot get Secret of template value"
+	errNoTemplateSecretKey = "Secret of template value has no key"
+	errParseFieldTemplate  = "cannot parse template of manifest field"
+	errRenderFieldTemplate = "cannot render template of manifest field"
+
+	// maskedValue replaces the values read from Secrets in logs.
+	maskedValue = "**REDACTED**"
+)
+
+var secretGVK = schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+
+// renderTemplateValues renders the templates in the string fields of the
+// supplied desired manifest with the template values of the supplied Object.
+func (c *external) renderTemplateValues(ctx context.Context, cr *v1alpha2.Object, desired *unstructured.Unstructured) error {
+	if len(cr.Spec.ForProvider.TemplateValues) == 0 {
+		return nil
+	}
+	values, err := c.resolveTemplateValues(ctx, cr)
+	if err != nil {
+		return err
+	}
+	rendered, err := renderFields(desired.Object, values)
+	if err != nil {
+		return err
+	}
+	desired.Object = rendered.(map[string]interface{})
+	return nil
+}
+
+// resolveTemplateValues returns the template values of the supplied Object,
+// reading those referencing a Secret from the cluster the Object lives in.
+// They are resolved once per reconcile, and never persisted. Once resolved,
+// the values read from Secrets are masked in the logs of the reconcile.
+func (c *external) resolveTemplateValues(ctx context.Context, cr *v1alpha2.Object) (map[string]string, error) {
+	if c.templateValues != nil {
+		return c.templateValues, nil
+	}
+	values := make(map[string]string, len(cr.Spec.ForProvider.TemplateValues))
+	var secrets []string
+	for name, v := range cr.Spec.ForProvider.TemplateValues {
+		ref := v.SecretKeyRef
+		if ref == nil {
+			if v.Value != nil {
+				values[name] = *v.Value
+			}
+			continue
+		}
+		// Secrets are read like references, uncached.
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(secretGVK)
+		if err := c.localClient.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, u); err != nil {
+			return nil, errors.Wrapf(err, "%s %q", errGetTemplateSecret, name)
+		}
+		s := &corev1.Secret{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, s); err != nil {
+			return nil, errors.Wrapf(err, "%s %q", errGetTemplateSecret, name)
+		}
+		d, ok := s.Data[ref.Key]
+		if !ok {
+			return nil, errors.Errorf("%s %q: %s", errNoTemplateSecretKey, name, ref.Key)
+		}
+		values[name] = string(d)
+		secrets = append(secrets, string(d))
+	}
+	c.templateValues = values
+	c.logger = newMaskingLogger(c.logger, secrets)
+	return values, nil
+}
+
+// renderFields renders the templates of all string fields of the supplied
+// manifest, or part of it. Fields without a template are left as they are.
+func renderFields(v interface{}, values map[string]string) (interface{}, error) {
+	switch v := v.(type) {
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+		tmpl, err := template.New("").Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s %q", errParseFieldTemplate, v)
+		}
+		b := &strings.Builder{}
+		if err := tmpl.Execute(b, values); err != nil {
+			return nil, errors.Wrapf(err, "%s %q", errRenderFieldTemplate, v)
+		}
+		return b.String(), nil
+	case map[string]interface{}:
+		for k, e := range v {
+			r, err := renderFields(e, values)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = r
+		}
+	case []interface{}:
+		for i, e := range v {
+			r, err := renderFields(e, values)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = r
+		}
+	}
+	return v, nil
+}
+
+// withTemplateValues returns the supplied spec hash combined with the
+// supplied resolved template values, so that an Object is no longer up to
+// date once a Secret of its template values changed. Like the checksum
+// annotations commonly used to roll Deployments on Secret changes, this only
+// records a hash of the values.
+func withTemplateValues(hash string, values map[string]string) string {
+	if len(values) == 0 {
+		return hash
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	h.Write([]byte(hash))
+	for _, name := range names {
+		fmt.Fprintf(h, "\x00%s\x00%s", name, values[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// templateSecretRefs returns the Secrets the template values of the supplied
+// Object are read from.
+func templateSecretRefs(cr *v1alpha2.Object) []types.NamespacedName {
+	var refs []types.NamespacedName
+	for _, v := range cr.Spec.ForProvider.TemplateValues {
+		if v.SecretKeyRef != nil {
+			refs = append(refs, types.NamespacedName{Namespace: v.SecretKeyRef.Namespace, Name: v.SecretKeyRef.Name})
+		}
+	}
+	return refs
+}
+
+// A maskingLogger replaces secret values in the messages and values it logs.
+type maskingLogger struct {
+	log     logging.Logger
+	secrets []string
+}
+
+// newMaskingLogger returns a logger masking the supplied secret values, or
+// the supplied logger if there are none.
+func newMaskingLogger(log logging.Logger, secrets []string) logging.Logger {
+	nonEmpty := make([]string, 0, len(secrets))
+	for _, s := range secrets {
+		if s != "" {
+			nonEmpty = append(nonEmpty, s)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return log
+	}
+	return &maskingLogger{log: log, secrets: nonEmpty}
+}
+
+// Info logs a masked message with masked keys and values.
+func (l *maskingLogger) Info(msg string, keysAndValues ...any) {
+	l.log.Info(l.mask(msg), l.maskAll(keysAndValues)...)
+}
+
+// Debug logs a masked message with masked keys and values.
+func (l *maskingLogger) Debug(msg string, keysAndValues ...any) {
+	l.log.Debug(l.mask(msg), l.maskAll(keysAndValues)...)
+}
+
+// WithValues returns a masking logger with the supplied masked keys and
+// values.
+func (l *maskingLogger) WithValues(keysAndValues ...any) logging.Logger {
+	return &maskingLogger{log: l.log.WithValues(l.maskAll(keysAndValues)...), secrets: l.secrets}
+}
+
+func (l *maskingLogger) mask(s string) string {
+	for _, secret := range l.secrets {
+		s = strings.ReplaceAll(s, secret, maskedValue)
+	}
+	return s
+}
+
+// maskAll formats values containing a secret, e.g. a manifest, as masked
+// strings. Other values are logged as they are.
+func (l *maskingLogger) maskAll(keysAndValues []any) []any {
+	out := make([]any, len(keysAndValues))
+	for i, v := range keysAndValues {
+		out[i] = v
+		s := fmt.Sprint(v)
+		if m := l.mask(s); m != s {
+			out[i] = m
+		}
+	}
+	return out
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestRenderTemplateValues(t *testing.T) {
+	errNotFound := kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "db")
+	withValues := func(values map[string]v1alpha2.TemplateValue) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.Spec.ForProvider.TemplateValues = values
+		}
+	}
+	secretRef := func(key string) v1alpha2.TemplateValue {
+		return v1alpha2.TemplateValue{SecretKeyRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Namespace: testNamespace, Name: "db"},
+			Key:             key,
+		}}
+	}
+	getSecret := func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		if key.Name != "db" || key.Namespace != testNamespace {
+			return errNotFound
+		}
+		obj.(*unstructured.Unstructured).Object["data"] = map[string]interface{}{
+			"endpoint": base64.StdEncoding.EncodeToString([]byte("db.example.org:5432")),
+		}
+		return nil
+	}
+	manifest := func(fields ...string) *unstructured.Unstructured {
+		items := make([]interface{}, 0, len(fields))
+		for _, f := range fields {
+			items = append(items, f)
+		}
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"data": map[string]interface{}{
+				"items": items,
+			},
+		}}
+	}
+
+	type args struct {
+		cr      *v1alpha2.Object
+		client  *test.MockClient
+		desired *unstructured.Unstructured
+	}
+	type want struct {
+		err     error
+		desired *unstructured.Unstructured
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoTemplateValues": {
+			reason: "We should leave manifests of Objects without template values as they are.",
+			args: args{
+				cr:      kubernetesObject(),
+				desired: manifest("{{ .endpoint }}"),
+			},
+			want: want{
+				desired: manifest("{{ .endpoint }}"),
+			},
+		},
+		"Rendered": {
+			reason: "We should render literal values and values read from Secrets into string fields.",
+			args: args{
+				cr: kubernetesObject(withValues(map[string]v1alpha2.TemplateValue{
+					"endpoint": secretRef("endpoint"),
+					"user":     {Value: ptr.To("admin")},
+				})),
+				client:  &test.MockClient{MockGet: getSecret},
+				desired: manifest("postgres://{{ .user }}@{{ .endpoint }}", "plain"),
+			},
+			want: want{
+				desired: manifest("postgres://admin@db.example.org:5432", "plain"),
+			},
+		},
+		"SecretNotFound": {
+			reason: "We should return an error if the Secret of a template value cannot be read.",
+			args: args{
+				cr: kubernetesObject(withValues(map[string]v1alpha2.TemplateValue{
+					"endpoint": {SecretKeyRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: testNamespace, Name: "other"}, Key: "endpoint"}},
+				})),
+				client:  &test.MockClient{MockGet: getSecret},
+				desired: manifest("{{ .endpoint }}"),
+			},
+			want: want{
+				err: errors.Wrapf(errNotFound, "%s %q", errGetTemplateSecret, "endpoint"),
+			},
+		},
+		"NoSecretKey": {
+			reason: "We should return an error if the Secret of a template value has no such key.",
+			args: args{
+				cr: kubernetesObject(withValues(map[string]v1alpha2.TemplateValue{
+					"password": secretRef("password"),
+				})),
+				client:  &test.MockClient{MockGet: getSecret},
+				desired: manifest("{{ .password }}"),
+			},
+			want: want{
+				err: errors.Errorf("%s %q: %s", errNoTemplateSecretKey, "password", "password"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger(), localClient: tc.args.client}
+			err := e.renderTemplateValues(context.Background(), tc.args.cr, tc.args.desired)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.renderTemplateValues(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.desired, tc.args.desired); diff != "" {
+				t.Errorf("\n%s\ne.renderTemplateValues(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRenderFieldsMissingValue(t *testing.T) {
+	if _, err := renderFields("{{ .missing }}", map[string]string{}); err == nil {
+		t.Errorf("renderFields(...): want error for a missing template value, got none")
+	}
+}
+
+// capturingLogger records the messages and values it logs.
+type capturingLogger struct {
+	logged *[]any
+}
+
+func (l capturingLogger) Info(msg string, keysAndValues ...any) {
+	*l.logged = append(append(*l.logged, msg), keysAndValues...)
+}
+
+func (l capturingLogger) Debug(msg string, keysAndValues ...any) {
+	*l.logged = append(append(*l.logged, msg), keysAndValues...)
+}
+
+func (l capturingLogger) WithValues(keysAndValues ...any) logging.Logger {
+	*l.logged = append(*l.logged, keysAndValues...)
+	return l
+}
+
+func TestMaskingLogger(t *testing.T) {
+	logged := []any{}
+	l := newMaskingLogger(capturingLogger{logged: &logged}, []string{"s3cr3t", ""})
+
+	l.WithValues("endpoint", "user:s3cr3t@db").Debug("Applying s3cr3t", "error", errors.New("rejected s3cr3t"), "count", 1)
+
+	want := []any{"endpoint", "user:" + maskedValue + "@db", "Applying " + maskedValue, "error", "rejected " + maskedValue, "count", 1}
+	if diff := cmp.Diff(want, logged); diff != "" {
+		t.Errorf("maskingLogger: -want logged, +got logged:\n%s", diff)
+	}
+}
+
+func TestWithTemplateValues(t *testing.T) {
+	if got := withTemplateValues("hash", nil); got != "hash" {
+		t.Errorf("withTemplateValues(...): want the spec hash without template values, got %q", got)
+	}
+	a := withTemplateValues("hash", map[string]string{"endpoint": "a"})
+	b := withTemplateValues("hash", map[string]string{"endpoint": "b"})
+	if a == b {
+		t.Errorf("withTemplateValues(...): want different hashes for different values, got %q", a)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errCompileTerminal    = "cannot compile terminal expression"
+	errTerminalResultType = "terminal expression must return a boolean"
+)
+
+// A WatchPauser stops delivering the events of single resources to their
+// Objects while it keeps watching their kind.
+type WatchPauser interface {
+	// PauseWatch stops delivering events of the resource with the
+	// supplied UID, except for its deletion.
+	PauseWatch(uid types.UID)
+}
+
+// stopsWatchOnTerminal returns whether the supplied Object stops watching
+// its managed resource once it reached a terminal state.
+func stopsWatchOnTerminal(cr *v1alpha2.Object) bool {
+	return cr.Spec.WatchPolicy != nil && cr.Spec.WatchPolicy.StopOnTerminal
+}
+
+// watchStopped returns whether the supplied Object stopped watching its
+// managed resource because it reached a terminal state.
+func watchStopped(cr *v1alpha2.Object) bool {
+	return stopsWatchOnTerminal(cr) && cr.Status.AtProvider.TerminalState != nil
+}
+
+// observeTerminal records whether the observed resource of the supplied
+// Object reached the terminal state of its watch policy, and stops watching
+// it if so.
+func (c *external) observeTerminal(cr *v1alpha2.Object, observed *unstructured.Unstructured) error {
+	if !stopsWatchOnTerminal(cr) {
+		cr.Status.AtProvider.TerminalState = nil
+		return nil
+	}
+	if ts := cr.Status.AtProvider.TerminalState; ts != nil {
+		if ts.UID == observed.GetUID() {
+			return nil
+		}
+		// The resource was recreated and needs to be watched again.
+		cr.Status.AtProvider.TerminalState = nil
+	}
+
+	prg, err := compileTerminal(cr.Spec.WatchPolicy.TerminalExpression)
+	if err != nil {
+		return err
+	}
+	terminal, err := evaluateTerminal(prg, observed)
+	if err != nil {
+		// E.g. the resource has no status yet.
+		c.logger.Debug("Got error while evaluating terminal expression, considering resource not terminal", "error", err, "observed", observed)
+		return nil
+	}
+	if !terminal {
+		return nil
+	}
+	cr.Status.AtProvider.TerminalState = &v1alpha2.TerminalState{UID: observed.GetUID(), Time: metav1.NewTime(time.Now())}
+	if p, ok := c.kindObserver.(WatchPauser); ok {
+		p.PauseWatch(observed.GetUID())
+	}
+	c.logger.Debug("Stopped watching resource in terminal state", "uid", observed.GetUID())
+	return nil
+}
+
+func compileTerminal(expr string) (cel.Program, error) {
+	env, err := readinessEnv()
+	if err != nil {
+		return nil, errors.Wrap(err, errNewReadinessEnv)
+	}
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, errors.Wrap(iss.Err(), errCompileTerminal)
+	}
+	prg, err := env.Program(ast, cel.CostLimit(readinessCostLimit))
+	return prg, errors.Wrap(err, errCompileTerminal)
+}
+
+// evaluateTerminal returns whether the supplied program considers the
+// observed resource to be in a terminal state.
+func evaluateTerminal(prg cel.Program, observed *unstructured.Unstructured) (bool, error) {
+	out, _, err := prg.Eval(map[string]interface{}{"self": observed.Object})
+	if err != nil {
+		return false, err
+	}
+	terminal, ok := out.Value().(bool)
+	if !ok {
+		return false, errors.New(errTerminalResultType)
+	}
+	return terminal, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+type fakePauser struct {
+	paused []types.UID
+}
+
+func (f *fakePauser) WatchResources(_ *rest.Config, _ string, _ ...schema.GroupVersionKind) error {
+	return nil
+}
+
+func (f *fakePauser) PauseWatch(uid types.UID) {
+	f.paused = append(f.paused, uid)
+}
+
+func TestObserveTerminal(t *testing.T) {
+	completedExpr := "has(self.status) && self.status.conditions.exists(c, c.type in ['Complete', 'Failed'] && c.status == 'True')"
+	job := func(uid types.UID, complete bool) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "batch/v1", "kind": "Job"}}
+		u.SetUID(uid)
+		if complete {
+			_ = unstructured.SetNestedSlice(u.Object, []interface{}{
+				map[string]interface{}{"type": "Complete", "status": "True"},
+			}, "status", "conditions")
+		}
+		return u
+	}
+	withPolicy := func(expr string, ts *v1alpha2.TerminalState) *v1alpha2.Object {
+		return kubernetesObject(func(o *v1alpha2.Object) {
+			o.Spec.WatchPolicy = &v1alpha2.WatchPolicy{StopOnTerminal: true, TerminalExpression: expr}
+			o.Status.AtProvider.TerminalState = ts
+		})
+	}
+
+	type want struct {
+		err      bool
+		terminal *v1alpha2.TerminalState
+		paused   []types.UID
+	}
+	cases := map[string]struct {
+		reason   string
+		cr       *v1alpha2.Object
+		observed *unstructured.Unstructured
+		want     want
+	}{
+		"NoPolicy": {
+			reason:   "We should not record terminal states of Objects without a watch policy.",
+			cr:       kubernetesObject(),
+			observed: job("job", true),
+		},
+		"NotTerminal": {
+			reason:   "We should keep watching resources that did not reach a terminal state.",
+			cr:       withPolicy(completedExpr, nil),
+			observed: job("job", false),
+		},
+		"Terminal": {
+			reason:   "We should record the terminal state and pause the watch of resources that reached it.",
+			cr:       withPolicy(completedExpr, nil),
+			observed: job("job", true),
+			want: want{
+				terminal: &v1alpha2.TerminalState{UID: "job"},
+				paused:   []types.UID{"job"},
+			},
+		},
+		"AlreadyTerminal": {
+			reason:   "We should not pause the watch of resources again.",
+			cr:       withPolicy(completedExpr, &v1alpha2.TerminalState{UID: "job"}),
+			observed: job("job", true),
+			want: want{
+				terminal: &v1alpha2.TerminalState{UID: "job"},
+			},
+		},
+		"Recreated": {
+			reason:   "We should watch recreated resources again.",
+			cr:       withPolicy(completedExpr, &v1alpha2.TerminalState{UID: "old-job"}),
+			observed: job("job", false),
+		},
+		"EvaluationError": {
+			reason:   "We should consider resources the expression cannot be evaluated for not terminal.",
+			cr:       withPolicy("self.status.succeeded > 0", nil),
+			observed: job("job", false),
+		},
+		"InvalidExpression": {
+			reason:   "We should return an error if the expression does not compile.",
+			cr:       withPolicy("self.status.", nil),
+			observed: job("job", false),
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &fakePauser{}
+			e := &external{logger: logging.NewNopLogger(), kindObserver: p}
+			err := e.observeTerminal(tc.cr, tc.observed)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\ne.observeTerminal(...): want error: %t, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.terminal, tc.cr.Status.AtProvider.TerminalState, cmpopts.IgnoreTypes(metav1.Time{})); diff != "" {
+				t.Errorf("\n%s\ne.observeTerminal(...): -want terminal state, +got terminal state:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.paused, p.paused); diff != "" {
+				t.Errorf("\n%s\ne.observeTerminal(...): -want paused, +got paused:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
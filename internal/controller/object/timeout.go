@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	// defaultReconcileTimeout limits the reconciles of Objects without a
+	// reconcile timeout.
+	defaultReconcileTimeout = time.Minute
+	// maxReconcileTimeout caps the reconcile timeouts of Objects. It is the
+	// timeout of the managed reconciler, which applies on top.
+	maxReconcileTimeout = 10 * time.Minute
+)
+
+// reconcileTimeout returns the reconcile timeout of the supplied managed
+// resource.
+func reconcileTimeout(mg resource.Managed) time.Duration {
+	cr, ok := mg.(*v1alpha2.Object)
+	if !ok || cr.Spec.ReconcileTimeout == nil || cr.Spec.ReconcileTimeout.Duration <= 0 {
+		return defaultReconcileTimeout
+	}
+	if d := cr.Spec.ReconcileTimeout.Duration; d < maxReconcileTimeout {
+		return d
+	}
+	return maxReconcileTimeout
+}
+
+// A reconcileTimeoutError is returned by reconciles that exceeded the
+// reconcile timeout of their Object.
+type reconcileTimeoutError struct {
+	timeout time.Duration
+	cause   error
+}
+
+func (e *reconcileTimeoutError) Error() string {
+	return "reconcile timed out after " + e.timeout.String() + ": " + e.cause.Error()
+}
+
+func (e *reconcileTimeoutError) Cause() error { return e.cause }
+
+// Unwrap provides compatibility for Go 1.13 error chains.
+func (e *reconcileTimeoutError) Unwrap() error { return e.cause }
+
+// A timeoutConnecter limits the reconcile of a managed resource, from
+// connecting to its cluster to the last call of its external client, to the
+// reconcile timeout of the managed resource.
+type timeoutConnecter struct {
+	managed.ExternalConnecter
+}
+
+func (c *timeoutConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	timeout := reconcileTimeout(mg)
+	deadline := time.Now().Add(timeout)
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, timedOut(ctx, mg, timeout, err)
+	}
+	return &timeoutExternal{ExternalClient: ec, timeout: timeout, deadline: deadline}, nil
+}
+
+type timeoutExternal struct {
+	managed.ExternalClient
+	timeout  time.Duration
+	deadline time.Time
+}
+
+func (e *timeoutExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	ctx, cancel := context.WithDeadline(ctx, e.deadline)
+	defer cancel()
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	return o, timedOut(ctx, mg, e.timeout, err)
+}
+
+func (e *timeoutExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	ctx, cancel := context.WithDeadline(ctx, e.deadline)
+	defer cancel()
+	c, err := e.ExternalClient.Create(ctx, mg)
+	return c, timedOut(ctx, mg, e.timeout, err)
+}
+
+func (e *timeoutExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	ctx, cancel := context.WithDeadline(ctx, e.deadline)
+	defer cancel()
+	u, err := e.ExternalClient.Update(ctx, mg)
+	return u, timedOut(ctx, mg, e.timeout, err)
+}
+
+func (e *timeoutExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	ctx, cancel := context.WithDeadline(ctx, e.deadline)
+	defer cancel()
+	return timedOut(ctx, mg, e.timeout, e.ExternalClient.Delete(ctx, mg))
+}
+
+// timedOut returns a reconcileTimeoutError if the supplied error was caused
+// by the supplied context exceeding its deadline, and records it by the
+// ReconcileTimedOut condition of the supplied Object. Otherwise it returns the
+// supplied error, clearing a previously recorded timeout.
+func timedOut(ctx context.Context, mg resource.Managed, timeout time.Duration, err error) error {
+	cr, ok := mg.(*v1alpha2.Object)
+	if err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if ok && cr.GetCondition(v1alpha2.TypeReconcileTimedOut).Status == corev1.ConditionTrue {
+			cr.SetConditions(v1alpha2.ReconcileCompletedInTime())
+		}
+		return err
+	}
+	reconcileTimeouts.Inc()
+	if ok {
+		cr.SetConditions(v1alpha2.ReconcileTimedOut(timeout))
+	}
+	return &reconcileTimeoutError{timeout: timeout, cause: err}
+}
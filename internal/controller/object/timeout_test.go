@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestReconcileTimeout(t *testing.T) {
+	timeout := func(d time.Duration) kubernetesObjectModifier {
+		return func(obj *v1alpha2.Object) {
+			obj.Spec.ReconcileTimeout = &metav1.Duration{Duration: d}
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   time.Duration
+	}{
+		"NotAnObject": {
+			reason: "Other managed resources should use the default timeout.",
+			mg:     notKubernetesObject{},
+			want:   defaultReconcileTimeout,
+		},
+		"NoTimeout": {
+			reason: "Objects without a timeout should use the default timeout.",
+			mg:     kubernetesObject(),
+			want:   defaultReconcileTimeout,
+		},
+		"Timeout": {
+			reason: "Objects should use their own timeout.",
+			mg:     kubernetesObject(timeout(5 * time.Second)),
+			want:   5 * time.Second,
+		},
+		"Capped": {
+			reason: "Timeouts should be capped at the timeout of the managed reconciler.",
+			mg:     kubernetesObject(timeout(time.Hour)),
+			want:   maxReconcileTimeout,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := reconcileTimeout(tc.mg); got != tc.want {
+				t.Errorf("\n%s\nreconcileTimeout(...): want %s, got %s", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTimeoutExternal(t *testing.T) {
+	cr := kubernetesObject(func(obj *v1alpha2.Object) {
+		obj.Spec.ReconcileTimeout = &metav1.Duration{Duration: 10 * time.Millisecond}
+	})
+	slow := true
+	c := &timeoutConnecter{ExternalConnecter: managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			ObserveFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+				if !slow {
+					return managed.ExternalObservation{}, nil
+				}
+				<-ctx.Done()
+				return managed.ExternalObservation{}, ctx.Err()
+			},
+		}, nil
+	})}
+	before := testutil.ToFloat64(reconcileTimeouts)
+
+	e, err := c.Connect(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Connect(...): unexpected error: %v", err)
+	}
+	_, err = e.Observe(context.Background(), cr)
+	if got := ClassifyError(err); got != ErrorClassTimeout {
+		t.Errorf("Observe(...): want error of class %q, got %q: %v", ErrorClassTimeout, got, err)
+	}
+	if got := cr.GetCondition(v1alpha2.TypeReconcileTimedOut).Status; got != corev1.ConditionTrue {
+		t.Errorf("Observe(...): want ReconcileTimedOut condition to be true, got %q", got)
+	}
+	if got := testutil.ToFloat64(reconcileTimeouts) - before; got != 1 {
+		t.Errorf("Observe(...): want 1 counted timeout, got %v", got)
+	}
+
+	slow = false
+	if e, err = c.Connect(context.Background(), cr); err != nil {
+		t.Fatalf("Connect(...): unexpected error: %v", err)
+	}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Errorf("Observe(...): unexpected error: %v", err)
+	}
+	if got := cr.GetCondition(v1alpha2.TypeReconcileTimedOut).Status; got != corev1.ConditionFalse {
+		t.Errorf("Observe(...): want ReconcileTimedOut condition to be cleared, got %q", got)
+	}
+}
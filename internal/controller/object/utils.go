@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+// serverAnnotations are set by the API server or controllers on the target
+// cluster rather than by the Object's manifest.
+var serverAnnotations = []string{
+	v1.LastAppliedConfigAnnotation,
+	"deployment.kubernetes.io/revision",
+	"autoscaling.alpha.kubernetes.io/conditions",
+}
+
+// stripServerAnnotations returns a copy of the supplied object without the
+// server added annotations and the supplied additional annotation keys.
+func stripServerAnnotations(obj client.Object, ignore ...string) client.Object {
+	out := obj.DeepCopyObject().(client.Object)
+	a := out.GetAnnotations()
+	if len(a) == 0 {
+		return out
+	}
+	for _, k := range serverAnnotations {
+		delete(a, k)
+	}
+	for _, k := range ignore {
+		delete(a, k)
+	}
+	if len(a) == 0 {
+		a = nil
+	}
+	out.SetAnnotations(a)
+	return out
+}
+
+// withoutIgnoredAnnotations returns a copy of the supplied observed resource
+// without the annotations the supplied Object ignores when detecting drift.
+func withoutIgnoredAnnotations(cr *v1alpha2.Object, u *unstructured.Unstructured) *unstructured.Unstructured {
+	if dd := cr.Spec.DriftDetection; dd.StripServerAnnotations != nil && !*dd.StripServerAnnotations {
+		return u
+	}
+	return stripServerAnnotations(u, cr.Spec.DriftDetection.IgnoreAnnotations...).(*unstructured.Unstructured)
+}
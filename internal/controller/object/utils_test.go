@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_stripServerAnnotations(t *testing.T) {
+	withAnnotations := func(a map[string]string) *unstructured.Unstructured {
+		u := externalResource()
+		u.SetAnnotations(a)
+		return u
+	}
+	type args struct {
+		obj    *unstructured.Unstructured
+		ignore []string
+	}
+	cases := map[string]struct {
+		args
+		want map[string]string
+	}{
+		"NoAnnotations": {
+			args: args{
+				obj: externalResource(),
+			},
+		},
+		"ServerAnnotations": {
+			args: args{
+				obj: withAnnotations(map[string]string{
+					"deployment.kubernetes.io/revision": "3",
+					"example.org/keep":                  "true",
+				}),
+			},
+			want: map[string]string{"example.org/keep": "true"},
+		},
+		"IgnoredAnnotations": {
+			args: args{
+				obj: withAnnotations(map[string]string{
+					"example.org/ignore": "true",
+				}),
+				ignore: []string{"example.org/ignore"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			orig := tc.args.obj.DeepCopy()
+			got := stripServerAnnotations(tc.args.obj, tc.args.ignore...)
+			if diff := cmp.Diff(tc.want, got.GetAnnotations()); diff != "" {
+				t.Errorf("stripServerAnnotations(...): -want annotations, +got annotations: %s", diff)
+			}
+			if diff := cmp.Diff(orig, tc.args.obj); diff != "" {
+				t.Errorf("stripServerAnnotations(...): must not modify its input: %s", diff)
+			}
+		})
+	}
+}
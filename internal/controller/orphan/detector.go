@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package orphan reports Objects whose ProviderConfig does not exist.
+package orphan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+const (
+	errListProviderConfigs = "cannot list ProviderConfigs"
+	errListObjects         = "cannot list Objects"
+
+	reasonOrphaned event.Reason = "Orphaned"
+
+	// defaultProviderConfig is the ProviderConfig of Objects that do not
+	// reference one.
+	defaultProviderConfig = "default"
+
+	// maxReportedObjects is the number of orphaned Objects named by the
+	// cluster-wide Event at most.
+	maxReportedObjects = 50
+)
+
+// An OrphanDetector periodically lists all Objects and reports those whose
+// ProviderConfig does not exist, e.g. because it was deleted before them.
+// Orphaned Objects get the Orphaned condition and an Event. Every pass that
+// finds orphaned Objects also emits an Event naming all of them on the
+// namespace the provider runs in.
+type OrphanDetector struct {
+	client    client.Client
+	record    event.Recorder
+	namespace string
+	interval  time.Duration
+	log       logging.Logger
+}
+
+// NewOrphanDetector returns an OrphanDetector that looks for orphaned Objects
+// every interval, and emits its cluster-wide Events on the supplied
+// namespace.
+func NewOrphanDetector(c client.Client, record event.Recorder, namespace string, interval time.Duration, log logging.Logger) *OrphanDetector {
+	return &OrphanDetector{
+		client:    c,
+		record:    record,
+		namespace: namespace,
+		interval:  interval,
+		log:       log.WithValues("controller", "orphandetector"),
+	}
+}
+
+// NeedLeaderElection returns true, only the leader updates Objects.
+func (d *OrphanDetector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start detects orphaned Objects every interval until the supplied context
+// is done.
+func (d *OrphanDetector) Start(ctx context.Context) error {
+	t := time.NewTicker(d.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			if err := d.Detect(ctx); err != nil {
+				d.log.Info("Cannot detect orphaned Objects", "error", err)
+			}
+		}
+	}
+}
+
+// Detect reports the Objects whose ProviderConfig does not exist, and clears
+// the Orphaned condition of those whose ProviderConfig exists again. Objects
+// whose status cannot be updated are retried by the next pass.
+func (d *OrphanDetector) Detect(ctx context.Context) error {
+	pcs := &v1alpha1.ProviderConfigList{}
+	if err := d.client.List(ctx, pcs); err != nil {
+		return errors.Wrap(err, errListProviderConfigs)
+	}
+	existing := sets.New[string]()
+	for _, pc := range pcs.Items {
+		existing.Insert(pc.GetName())
+	}
+
+	l := &v1alpha2.ObjectList{}
+	if err := d.client.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListObjects)
+	}
+
+	var orphaned []string
+	for i := range l.Items {
+		o := &l.Items[i]
+		if meta.WasDeleted(o) {
+			continue
+		}
+		pc := providerConfigName(o)
+		wasOrphaned := o.GetCondition(v1alpha2.TypeOrphaned).Status == corev1.ConditionTrue
+
+		if existing.Has(pc) {
+			if wasOrphaned {
+				o.SetConditions(v1alpha2.NotOrphaned())
+				d.updateStatus(ctx, o)
+			}
+			continue
+		}
+
+		orphaned = append(orphaned, fmt.Sprintf("%s (%s)", o.GetName(), pc))
+		if c := v1alpha2.Orphaned(pc); !o.GetCondition(v1alpha2.TypeOrphaned).Equal(c) {
+			o.SetConditions(c)
+			d.updateStatus(ctx, o)
+		}
+		if !wasOrphaned {
+			d.record.Event(o, event.Warning(reasonOrphaned, errors.Errorf("ProviderConfig %q does not exist", pc)))
+		}
+	}
+
+	if len(orphaned) == 0 {
+		return nil
+	}
+	d.log.Info("Found orphaned Objects", "count", len(orphaned))
+	d.record.Event(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: d.namespace}}, event.Warning(reasonOrphaned, errors.New(orphanedMessage(orphaned))))
+	return nil
+}
+
+func (d *OrphanDetector) updateStatus(ctx context.Context, o *v1alpha2.Object) {
+	if err := d.client.Status().Update(ctx, o); err != nil {
+		d.log.Debug("Cannot update the Orphaned condition of Object", "name", o.GetName(), "error", err)
+	}
+}
+
+// orphanedMessage names the supplied orphaned Objects, sorted, up to
+// maxReportedObjects of them.
+func orphanedMessage(orphaned []string) string {
+	sort.Strings(orphaned)
+	msg := fmt.Sprintf("Objects whose ProviderConfig does not exist (%d): ", len(orphaned))
+	if len(orphaned) > maxReportedObjects {
+		return msg + strings.Join(orphaned[:maxReportedObjects], ", ") + fmt.Sprintf(" and %d more", len(orphaned)-maxReportedObjects)
+	}
+	return msg + strings.Join(orphaned, ", ")
+}
+
+func providerConfigName(o *v1alpha2.Object) string {
+	if ref := o.GetProviderConfigReference(); ref != nil && ref.Name != "" {
+		return ref.Name
+	}
+	return defaultProviderConfig
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphan
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/v1alpha1"
+)
+
+// recordedEvent is an Event recorded on the object of the supplied name.
+type recordedEvent struct {
+	Name  string
+	Event event.Event
+}
+
+type eventRecorder struct {
+	events []recordedEvent
+}
+
+func (r *eventRecorder) Event(obj runtime.Object, e event.Event) {
+	r.events = append(r.events, recordedEvent{Name: obj.(metav1.Object).GetName(), Event: e})
+}
+
+func (r *eventRecorder) WithAnnotations(...string) event.Recorder { return r }
+
+func TestDetect(t *testing.T) {
+	errBoom := errors.New("boom")
+	object := func(name, pc string, cs ...xpv1.Condition) v1alpha2.Object {
+		o := v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if pc != "" {
+			o.Spec.ProviderConfigReference = &xpv1.Reference{Name: pc}
+		}
+		o.SetConditions(cs...)
+		return o
+	}
+	list := func(pcs []string, objs ...v1alpha2.Object) test.MockListFn {
+		return func(ctx context.Context, l client.ObjectList, opts ...client.ListOption) error {
+			switch l := l.(type) {
+			case *v1alpha1.ProviderConfigList:
+				for _, pc := range pcs {
+					l.Items = append(l.Items, v1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: pc}})
+				}
+			case *v1alpha2.ObjectList:
+				l.Items = objs
+			}
+			return nil
+		}
+	}
+
+	type args struct {
+		list test.MockListFn
+	}
+	type want struct {
+		err        error
+		conditions map[string][]xpv1.Condition
+		events     []recordedEvent
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ListProviderConfigsError": {
+			reason: "We should return an error if the ProviderConfigs cannot be listed.",
+			args: args{
+				list: test.NewMockListFn(errBoom),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListProviderConfigs),
+			},
+		},
+		"NoOrphans": {
+			reason: "We should not update Objects whose ProviderConfig exists, including the default one.",
+			args: args{
+				list: list([]string{"default", "remote"}, object("a", "remote"), object("b", "")),
+			},
+		},
+		"Orphaned": {
+			reason: "We should set the Orphaned condition on Objects whose ProviderConfig is missing, and report them all.",
+			args: args{
+				list: list([]string{"default"}, object("a", "default"), object("c", "gone"), object("b", "old")),
+			},
+			want: want{
+				conditions: map[string][]xpv1.Condition{
+					"c": {v1alpha2.Orphaned("gone")},
+					"b": {v1alpha2.Orphaned("old")},
+				},
+				events: []recordedEvent{
+					{Name: "c", Event: event.Warning(reasonOrphaned, errors.New(`ProviderConfig "gone" does not exist`))},
+					{Name: "b", Event: event.Warning(reasonOrphaned, errors.New(`ProviderConfig "old" does not exist`))},
+					{Name: "crossplane-system", Event: event.Warning(reasonOrphaned, errors.New("Objects whose ProviderConfig does not exist (2): b (old), c (gone)"))},
+				},
+			},
+		},
+		"StillOrphaned": {
+			reason: "We should neither update nor emit another Event for Objects that were already reported.",
+			args: args{
+				list: list(nil, object("a", "gone", v1alpha2.Orphaned("gone"))),
+			},
+			want: want{
+				events: []recordedEvent{
+					{Name: "crossplane-system", Event: event.Warning(reasonOrphaned, errors.New("Objects whose ProviderConfig does not exist (1): a (gone)"))},
+				},
+			},
+		},
+		"NoLongerOrphaned": {
+			reason: "We should clear the Orphaned condition once the ProviderConfig exists again.",
+			args: args{
+				list: list([]string{"back"}, object("a", "back", v1alpha2.Orphaned("back"))),
+			},
+			want: want{
+				conditions: map[string][]xpv1.Condition{
+					"a": {v1alpha2.NotOrphaned()},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updated := map[string][]xpv1.Condition{}
+			rec := &eventRecorder{}
+			d := NewOrphanDetector(&test.MockClient{
+				MockList: tc.args.list,
+				MockStatusUpdate: func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					updated[obj.GetName()] = obj.(*v1alpha2.Object).Status.Conditions
+					return nil
+				},
+			}, rec, "crossplane-system", 0, logging.NewNopLogger())
+
+			err := d.Detect(context.Background())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nd.Detect(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, updated, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\nd.Detect(...): -want status updates, +got status updates:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, rec.events, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nd.Detect(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOrphanedMessage(t *testing.T) {
+	orphaned := make([]string, 0, maxReportedObjects+2)
+	for i := 0; i < maxReportedObjects+2; i++ {
+		orphaned = append(orphaned, fmt.Sprintf("o%03d (gone)", i))
+	}
+	got := orphanedMessage(orphaned)
+	want := "and 2 more"
+	if got[len(got)-len(want):] != want {
+		t.Errorf("orphanedMessage(...): want message ending in %q, got %q", want, got)
+	}
+}
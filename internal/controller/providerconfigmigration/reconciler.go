@@ -0,0 +1,244 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providerconfigmigration
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/providerconfigmigration/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/clients/kube"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/config"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/manifest"
+)
+
+const (
+	errListObjects       = "cannot list Objects using the source ProviderConfig"
+	errInvalidSelector   = "invalid objectSelector"
+	errRepointObject     = "cannot point Object to the target ProviderConfig"
+	errUnpauseObject     = "cannot resume reconciling migrated Object"
+	errRollbackObject    = "cannot roll back Object to the source ProviderConfig"
+	errParseManifest     = "cannot parse manifest of Object"
+	errNewTargetClient   = "cannot create client for the target ProviderConfig"
+	errGetThroughTarget  = "cannot get managed resource through the target ProviderConfig"
+	errStatusUpdate      = "cannot update status"
+	errMigrationRollback = "rolled back Object"
+
+	// migrateConcurrency is the maximum number of Objects migrated in
+	// parallel.
+	migrateConcurrency = 10
+)
+
+// Reconciler watches for ProviderConfigMigration resources and points the
+// Objects they select to their target ProviderConfig.
+type Reconciler struct {
+	client              client.Client
+	log                 logging.Logger
+	clientForProviderFn func(ctx context.Context, local client.Client, providerConfigName string) (client.Client, *rest.Config, error)
+}
+
+// Setup adds a controller that reconciles ProviderConfigMigration resources.
+// It requires the Objects to be indexed by their ProviderConfig, see
+// config.Setup.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.ProviderConfigMigrationGroupKind)
+
+	r := &Reconciler{
+		client:              mgr.GetClient(),
+		log:                 o.Logger,
+		clientForProviderFn: kube.ClientForProvider,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.ProviderConfigMigration{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// Reconcile migrates the Objects selected by a ProviderConfigMigration, at
+// most migrateConcurrency at a time, and reports its progress in its status.
+// Objects that cannot be migrated are rolled back to the source
+// ProviderConfig and retried with the next reconcile. The migration is
+// complete once no selected Object uses the source ProviderConfig anymore.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) { //nolint:gocyclo
+	log := r.log.WithValues("request", req)
+
+	defer func() {
+		if retErr == nil {
+			log.Info("Reconciled")
+		} else {
+			log.Info("Retry", "err", retErr)
+		}
+	}()
+
+	pm := &v1alpha1.ProviderConfigMigration{}
+	err := r.client.Get(ctx, req.NamespacedName, pm)
+
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if meta.WasDeleted(pm) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsPaused(pm) {
+		pm.Status.SetConditions(xpv1.ReconcilePaused())
+		return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, pm), errStatusUpdate)
+	}
+
+	if pm.Status.GetCondition(v1alpha1.TypeMigrated).Status == v1.ConditionTrue {
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("Reconciling")
+
+	sel := labels.Everything()
+	if pm.Spec.ObjectSelector != nil {
+		if sel, err = metav1.LabelSelectorAsSelector(pm.Spec.ObjectSelector); err != nil {
+			werr := errors.Wrap(err, errInvalidSelector)
+			pm.Status.SetConditions(xpv1.ReconcileError(werr))
+			return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, pm), errStatusUpdate)
+		}
+	}
+
+	ol := &v1alpha2.ObjectList{}
+	if err := r.client.List(ctx, ol, client.MatchingFields{config.ObjectProviderConfigIndex: pm.Spec.SourceProviderConfig.Name}, client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		werr := errors.Wrap(err, errListObjects)
+		pm.Status.SetConditions(xpv1.ReconcileError(werr))
+		_ = r.client.Status().Update(ctx, pm)
+		return ctrl.Result{}, werr
+	}
+
+	var migrated, failed atomic.Int64
+	g := &errgroup.Group{}
+	g.SetLimit(migrateConcurrency)
+	for i := range ol.Items {
+		o := &ol.Items[i]
+		if meta.WasDeleted(o) {
+			// Deleted Objects are gone soon, there is nothing to migrate.
+			continue
+		}
+		g.Go(func() error {
+			if err := r.migrate(ctx, o, pm.Spec.TargetProviderConfig.Name); err != nil {
+				failed.Add(1)
+				return err
+			}
+			log.Debug("Migrated Object", "name", o.GetName())
+			migrated.Add(1)
+			return nil
+		})
+	}
+	err = g.Wait()
+
+	pm.Status.MigratedCount += migrated.Load()
+	pm.Status.FailedCount = failed.Load()
+	if err != nil {
+		pm.Status.SetConditions(xpv1.ReconcileError(err))
+		_ = r.client.Status().Update(ctx, pm)
+		return ctrl.Result{}, err
+	}
+
+	pm.Status.SetConditions(xpv1.ReconcileSuccess(), v1alpha1.Migrated(), xpv1.Available())
+	return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, pm), errStatusUpdate)
+}
+
+// migrate points the supplied Object to the target ProviderConfig and
+// verifies its managed resource can be read through it. The Object is paused
+// meanwhile, so that its controller does not create its resource through the
+// target ProviderConfig if it is not reachable. Unless the resource could be
+// read, the Object is rolled back to its original ProviderConfig.
+func (r *Reconciler) migrate(ctx context.Context, o *v1alpha2.Object, target string) error {
+	orig := o.DeepCopy()
+	o.Spec.ProviderConfigReference = &xpv1.Reference{Name: target}
+	meta.AddAnnotations(o, map[string]string{meta.AnnotationKeyReconciliationPaused: "true"})
+	// The optimistic lock makes sure the Object is repointed from the
+	// ProviderConfig it was listed with.
+	if err := r.client.Patch(ctx, o, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{})); err != nil {
+		return errors.Wrapf(err, "%s %s", errRepointObject, o.GetName())
+	}
+
+	verr := r.verify(ctx, o, target)
+
+	migrated := o.DeepCopy()
+	if verr != nil {
+		o.Spec.ProviderConfigReference = orig.Spec.ProviderConfigReference
+	}
+	restorePause(o, orig)
+	if err := r.client.Patch(ctx, o, client.MergeFrom(migrated)); err != nil {
+		if verr != nil {
+			return errors.Wrapf(err, "%s %s after failed migration: %v", errRollbackObject, o.GetName(), verr)
+		}
+		return errors.Wrapf(err, "%s %s", errUnpauseObject, o.GetName())
+	}
+	return errors.Wrapf(verr, "%s %s", errMigrationRollback, o.GetName())
+}
+
+// verify returns an error unless the managed resource of the supplied Object
+// can be read through the supplied ProviderConfig.
+func (r *Reconciler) verify(ctx context.Context, o *v1alpha2.Object, providerConfig string) error {
+	raw, err := manifest.ForObject(o)
+	if err != nil {
+		return errors.Wrap(err, errParseManifest)
+	}
+	u := &unstructured.Unstructured{}
+	if err := u.UnmarshalJSON(raw); err != nil {
+		return errors.Wrap(err, errParseManifest)
+	}
+	k, _, err := r.clientForProviderFn(ctx, r.client, providerConfig)
+	if err != nil {
+		return errors.Wrap(err, errNewTargetClient)
+	}
+	return errors.Wrap(k.Get(ctx, types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}, u), errGetThroughTarget)
+}
+
+// restorePause restores the pause annotation of the supplied Object to the
+// one of its original state.
+func restorePause(o, orig *v1alpha2.Object) {
+	if v, ok := orig.GetAnnotations()[meta.AnnotationKeyReconciliationPaused]; ok {
+		meta.AddAnnotations(o, map[string]string{meta.AnnotationKeyReconciliationPaused: v})
+		return
+	}
+	meta.RemoveAnnotations(o, meta.AnnotationKeyReconciliationPaused)
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providerconfigmigration
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/providerconfigmigration/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/controller/config"
+)
+
+func TestReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	object := func(name string, paused bool) v1alpha2.Object {
+		o := v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		o.Spec.ProviderConfigReference = &xpv1.Reference{Name: "dev"}
+		o.Spec.ForProvider.Manifest = runtime.RawExtension{Raw: []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "` + name + `", "namespace": "default"}}`)}
+		if paused {
+			meta.AddAnnotations(&o, map[string]string{meta.AnnotationKeyReconciliationPaused: "true"})
+		}
+		return o
+	}
+
+	// state is the provider config and whether an Object is paused after
+	// the migration.
+	type state struct {
+		ProviderConfig string
+		Paused         bool
+	}
+	type args struct {
+		getErr    error
+		completed bool
+		listErr   error
+		objects   []v1alpha2.Object
+		// unreachable resources cannot be read through the target.
+		unreachable map[string]bool
+	}
+	type want struct {
+		err      error
+		objects  map[string]state
+		status   *v1alpha1.ProviderConfigMigrationStatus
+		migrated corev1.ConditionStatus
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotFound": {
+			reason: "We should not return an error if the ProviderConfigMigration was not found.",
+			args: args{
+				getErr: kerrors.NewNotFound(schema.GroupResource{}, ""),
+			},
+		},
+		"AlreadyMigrated": {
+			reason: "We should not list or migrate any Objects once a ProviderConfigMigration completed.",
+			args: args{
+				completed: true,
+				listErr:   errBoom,
+			},
+		},
+		"ListError": {
+			reason: "We should return an error if the Objects cannot be listed.",
+			args: args{
+				listErr: errBoom,
+			},
+			want: want{
+				err:    errors.Wrap(errBoom, errListObjects),
+				status: &v1alpha1.ProviderConfigMigrationStatus{},
+			},
+		},
+		"Migrate": {
+			reason: "We should point all Objects to the target and keep the pause annotation they had before.",
+			args: args{
+				objects: []v1alpha2.Object{object("a", false), object("b", true)},
+			},
+			want: want{
+				objects: map[string]state{
+					"a": {ProviderConfig: "staging"},
+					"b": {ProviderConfig: "staging", Paused: true},
+				},
+				status:   &v1alpha1.ProviderConfigMigrationStatus{MigratedCount: 2},
+				migrated: corev1.ConditionTrue,
+			},
+		},
+		"MigrateManifestURL": {
+			reason: "We should verify the resources of Objects with a manifest URL by their observed manifest.",
+			args: args{
+				objects: []v1alpha2.Object{func() v1alpha2.Object {
+					o := object("a", false)
+					o.Status.AtProvider.Manifest = o.Spec.ForProvider.Manifest
+					o.Spec.ForProvider.Manifest = runtime.RawExtension{}
+					o.Spec.ForProvider.ManifestURL = "https://example.org/a.yaml"
+					return o
+				}()},
+			},
+			want: want{
+				objects: map[string]state{
+					"a": {ProviderConfig: "staging"},
+				},
+				status:   &v1alpha1.ProviderConfigMigrationStatus{MigratedCount: 1},
+				migrated: corev1.ConditionTrue,
+			},
+		},
+		"RollBack": {
+			reason: "We should roll back Objects whose resource cannot be read through the target and return an error to retry.",
+			args: args{
+				objects:     []v1alpha2.Object{object("a", false), object("b", false)},
+				unreachable: map[string]bool{"b": true},
+			},
+			want: want{
+				err: errors.Wrapf(errors.Wrap(errBoom, errGetThroughTarget), "%s %s", errMigrationRollback, "b"),
+				objects: map[string]state{
+					"a": {ProviderConfig: "staging"},
+					"b": {ProviderConfig: "dev"},
+				},
+				status:   &v1alpha1.ProviderConfigMigrationStatus{MigratedCount: 1, FailedCount: 1},
+				migrated: corev1.ConditionUnknown,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var lock sync.Mutex
+			objects := map[string]state{}
+			var status *v1alpha1.ProviderConfigMigrationStatus
+			var migrated corev1.ConditionStatus
+
+			r := &Reconciler{
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						if tc.args.getErr != nil {
+							return tc.args.getErr
+						}
+						pm := obj.(*v1alpha1.ProviderConfigMigration)
+						pm.Name = key.Name
+						pm.Spec.SourceProviderConfig = xpv1.Reference{Name: "dev"}
+						pm.Spec.TargetProviderConfig = xpv1.Reference{Name: "staging"}
+						if tc.args.completed {
+							pm.Status.SetConditions(v1alpha1.Migrated())
+						}
+						return nil
+					},
+					MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if got := lo.FieldSelector.String(); got != config.ObjectProviderConfigIndex+"=dev" {
+							t.Errorf("unexpected field selector %q", got)
+						}
+						list.(*v1alpha2.ObjectList).Items = tc.args.objects
+						return tc.args.listErr
+					},
+					MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						lock.Lock()
+						defer lock.Unlock()
+						o := obj.(*v1alpha2.Object)
+						objects[o.GetName()] = state{ProviderConfig: o.Spec.ProviderConfigReference.Name, Paused: meta.IsPaused(o)}
+						return nil
+					},
+					MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+						pm := obj.(*v1alpha1.ProviderConfigMigration)
+						status = &v1alpha1.ProviderConfigMigrationStatus{MigratedCount: pm.Status.MigratedCount, FailedCount: pm.Status.FailedCount}
+						migrated = pm.Status.GetCondition(v1alpha1.TypeMigrated).Status
+						return nil
+					},
+				},
+				log: logging.NewNopLogger(),
+				clientForProviderFn: func(_ context.Context, _ client.Client, providerConfigName string) (client.Client, *rest.Config, error) {
+					if providerConfigName != "staging" {
+						t.Errorf("unexpected provider config %q", providerConfigName)
+					}
+					return &test.MockClient{
+						MockGet: func(_ context.Context, key client.ObjectKey, _ client.Object) error {
+							if tc.args.unreachable[key.Name] {
+								return errBoom
+							}
+							return nil
+						},
+					}, nil, nil
+				},
+			}
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "pm"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if tc.want.objects == nil {
+				tc.want.objects = map[string]state{}
+			}
+			if diff := cmp.Diff(tc.want.objects, objects); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want objects, +got objects:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+			if tc.want.migrated != "" && migrated != tc.want.migrated {
+				t.Errorf("\n%s\nr.Reconcile(...): want Migrated condition %q, got %q", tc.reason, tc.want.migrated, migrated)
+			}
+		})
+	}
+}
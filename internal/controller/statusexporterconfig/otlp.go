@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusexporterconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// metricCondition is the gauge the conditions of Objects are exported
+	// as. It is 1 while a condition is True, and 0 otherwise.
+	metricCondition = "crossplane_object_condition"
+	scopeName       = "kubernetes.crossplane.io/status-exporter"
+	serviceName     = "provider-kubernetes"
+
+	// exportTimeout bounds a single export.
+	exportTimeout = 10 * time.Second
+)
+
+// An otlpExporter posts status records as OTLP metrics with JSON encoding,
+// see https://opentelemetry.io/docs/specs/otlp/#otlphttp.
+type otlpExporter struct {
+	client *http.Client
+}
+
+func newOTLPExporter() *otlpExporter {
+	return &otlpExporter{client: &http.Client{Timeout: exportTimeout}}
+}
+
+func (e *otlpExporter) export(ctx context.Context, endpoint string, rec statusRecord) error {
+	body, err := json.Marshal(otlpMetrics(rec))
+	if err != nil {
+		return errors.Wrap(err, errMarshalRecord)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	rsp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close() //nolint:errcheck // Nothing to do about it.
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return errors.Errorf("unexpected status %s", rsp.Status)
+	}
+	return nil
+}
+
+// The OTLP JSON encoding of the subset of ExportMetricsServiceRequest used
+// by the exporter.
+type (
+	exportMetricsRequest struct {
+		ResourceMetrics []resourceMetrics `json:"resourceMetrics"`
+	}
+	resourceMetrics struct {
+		Resource     resource       `json:"resource"`
+		ScopeMetrics []scopeMetrics `json:"scopeMetrics"`
+	}
+	resource struct {
+		Attributes []keyValue `json:"attributes"`
+	}
+	scopeMetrics struct {
+		Scope   scope    `json:"scope"`
+		Metrics []metric `json:"metrics"`
+	}
+	scope struct {
+		Name string `json:"name"`
+	}
+	metric struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		Gauge       gauge  `json:"gauge"`
+	}
+	gauge struct {
+		DataPoints []dataPoint `json:"dataPoints"`
+	}
+	dataPoint struct {
+		Attributes   []keyValue `json:"attributes"`
+		TimeUnixNano string     `json:"timeUnixNano"`
+		AsInt        string     `json:"asInt"`
+	}
+	keyValue struct {
+		Key   string   `json:"key"`
+		Value anyValue `json:"value"`
+	}
+	anyValue struct {
+		StringValue string `json:"stringValue"`
+	}
+)
+
+// otlpMetrics returns the metrics of the supplied record, one data point of
+// the condition gauge per condition of the Object.
+func otlpMetrics(rec statusRecord) exportMetricsRequest {
+	types := make([]string, 0, len(rec.Conditions))
+	for t := range rec.Conditions {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	ts := strconv.FormatInt(rec.Timestamp.UnixNano(), 10)
+	points := make([]dataPoint, 0, len(types))
+	for _, t := range types {
+		v := "0"
+		if rec.Conditions[t] == string(corev1.ConditionTrue) {
+			v = "1"
+		}
+		points = append(points, dataPoint{
+			Attributes: []keyValue{
+				attribute("object", rec.Object),
+				attribute("api_version", rec.APIVersion),
+				attribute("kind", rec.Kind),
+				attribute("condition", t),
+			},
+			TimeUnixNano: ts,
+			AsInt:        v,
+		})
+	}
+	return exportMetricsRequest{ResourceMetrics: []resourceMetrics{{
+		Resource: resource{Attributes: []keyValue{attribute("service.name", serviceName)}},
+		ScopeMetrics: []scopeMetrics{{
+			Scope: scope{Name: scopeName},
+			Metrics: []metric{{
+				Name:        metricCondition,
+				Description: fmt.Sprintf("Whether a condition of an Object is %s.", corev1.ConditionTrue),
+				Gauge:       gauge{DataPoints: points},
+			}},
+		}},
+	}}}
+}
+
+func attribute(k, v string) keyValue {
+	return keyValue{Key: k, Value: anyValue{StringValue: v}}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusexporterconfig
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	rec := statusRecord{
+		Timestamp:  time.Unix(1704164645, 0),
+		Object:     "web",
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Conditions: map[string]string{"Synced": "True", "Ready": "False"},
+	}
+
+	var got exportMetricsRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/metrics" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	if err := newOTLPExporter().export(context.Background(), srv.URL+"/v1/metrics", rec); err != nil {
+		t.Fatalf("export(...): unexpected error: %v", err)
+	}
+	if err := newOTLPExporter().export(context.Background(), srv.URL+"/other", rec); err == nil {
+		t.Errorf("export(...): want error if the endpoint rejects the metrics")
+	}
+
+	point := func(condition, value string) dataPoint {
+		return dataPoint{
+			Attributes: []keyValue{
+				attribute("object", "web"),
+				attribute("api_version", "apps/v1"),
+				attribute("kind", "Deployment"),
+				attribute("condition", condition),
+			},
+			TimeUnixNano: "1704164645000000000",
+			AsInt:        value,
+		}
+	}
+	want := []dataPoint{point("Ready", "0"), point("Synced", "1")}
+	if len(got.ResourceMetrics) != 1 || len(got.ResourceMetrics[0].ScopeMetrics) != 1 || len(got.ResourceMetrics[0].ScopeMetrics[0].Metrics) != 1 {
+		t.Fatalf("export(...): want a single metric, got %+v", got)
+	}
+	m := got.ResourceMetrics[0].ScopeMetrics[0].Metrics[0]
+	if m.Name != metricCondition {
+		t.Errorf("export(...): want metric %q, got %q", metricCondition, m.Name)
+	}
+	if diff := cmp.Diff(want, m.Gauge.DataPoints); diff != "" {
+		t.Errorf("export(...): -want data points, +got data points:\n%s", diff)
+	}
+}
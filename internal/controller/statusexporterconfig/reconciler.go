@@ -0,0 +1,269 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusexporterconfig
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	kerrorsutil "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	runtimeevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/statusexporterconfig/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/internal/manifest"
+)
+
+const (
+	errStatusUpdate     = "cannot update status"
+	errListConfigs      = "cannot list status exporter configs"
+	errParseSelector    = "cannot parse selector"
+	errGetConfigMap     = "cannot get status ConfigMap"
+	errCreateConfigMap  = "cannot create status ConfigMap"
+	errUpdateConfigMap  = "cannot update status ConfigMap"
+	errMarshalRecord    = "cannot marshal status record"
+	errExportOTLP       = "cannot export status to OpenTelemetry endpoint"
+	configMapKeyRecords = "status.jsonl"
+	defaultMaxRecords   = 100
+)
+
+// A statusRecord is the exported status of an Object at a point in time.
+type statusRecord struct {
+	Timestamp  time.Time         `json:"timestamp"`
+	Object     string            `json:"object"`
+	APIVersion string            `json:"apiVersion,omitempty"`
+	Kind       string            `json:"kind,omitempty"`
+	Conditions map[string]string `json:"conditions"`
+}
+
+// Reconciler watches for status changes of Objects and exports their status
+// to the stores of the StatusExporterConfigs selecting them.
+type Reconciler struct {
+	client     client.Client
+	log        logging.Logger
+	now        func() time.Time
+	exportOTLP func(ctx context.Context, endpoint string, rec statusRecord) error
+}
+
+// Setup adds a controller that exports the status of Objects as configured
+// by StatusExporterConfig resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.StatusExporterConfigGroupKind)
+
+	r := &Reconciler{
+		client:     mgr.GetClient(),
+		log:        o.Logger,
+		now:        time.Now,
+		exportOTLP: newOTLPExporter().export,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha2.Object{}, builder.WithPredicates(conditionsChanged)).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// conditionsChanged only lets updates of Objects through that change their
+// conditions. Objects are exported once they are created, but not once they
+// are gone.
+var conditionsChanged = predicate.Funcs{
+	UpdateFunc: func(ev runtimeevent.UpdateEvent) bool {
+		o, ok := ev.ObjectOld.(*v1alpha2.Object)
+		if !ok {
+			return true
+		}
+		n, ok := ev.ObjectNew.(*v1alpha2.Object)
+		if !ok {
+			return true
+		}
+		return !o.Status.ConditionedStatus.Equal(&n.Status.ConditionedStatus)
+	},
+	DeleteFunc: func(runtimeevent.DeleteEvent) bool { return false },
+}
+
+// Reconcile exports the status of an Object to the stores of every
+// StatusExporterConfig selecting it. Failed exports are reported by the
+// Synced condition of the StatusExporterConfig and not retried, the next
+// status change of the Object is exported again.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("request", req)
+
+	o := &v1alpha2.Object{}
+	if err := r.client.Get(ctx, req.NamespacedName, o); err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	l := &v1alpha1.StatusExporterConfigList{}
+	if err := r.client.List(ctx, l); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, errListConfigs)
+	}
+
+	rec := recordOf(o, r.now())
+	for i := range l.Items {
+		sec := &l.Items[i]
+		if meta.WasDeleted(sec) || meta.IsPaused(sec) {
+			continue
+		}
+		s, err := selectorOf(sec)
+		if err != nil {
+			if err := r.setSynced(ctx, sec, xpv1.ReconcileError(errors.Wrap(err, errParseSelector)), false); err != nil {
+				return ctrl.Result{}, err
+			}
+			continue
+		}
+		if !s.Matches(labels.Set(o.GetLabels())) {
+			continue
+		}
+
+		if err := r.export(ctx, sec, rec); err != nil {
+			log.Debug("Cannot export status", "config", sec.Name, "error", err)
+			if err := r.setSynced(ctx, sec, xpv1.ReconcileError(err), false); err != nil {
+				return ctrl.Result{}, err
+			}
+			continue
+		}
+		log.Debug("Exported status", "config", sec.Name)
+		if err := r.setSynced(ctx, sec, xpv1.ReconcileSuccess(), true); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// export writes the supplied record to the stores of the supplied config.
+func (r *Reconciler) export(ctx context.Context, sec *v1alpha1.StatusExporterConfig, rec statusRecord) error {
+	var errs []error
+	if cm := sec.Spec.ConfigMap; cm != nil {
+		errs = append(errs, r.appendToConfigMap(ctx, cm, rec))
+	}
+	if ot := sec.Spec.OpenTelemetry; ot != nil {
+		errs = append(errs, errors.Wrap(r.exportOTLP(ctx, ot.Endpoint, rec), errExportOTLP))
+	}
+	return kerrorsutil.NewAggregate(errs)
+}
+
+// appendToConfigMap appends the supplied record to the ConfigMap, dropping
+// the oldest records beyond its maximum.
+func (r *Reconciler) appendToConfigMap(ctx context.Context, e *v1alpha1.ConfigMapExporter, rec statusRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return errors.Wrap(err, errMarshalRecord)
+	}
+
+	cm := &corev1.ConfigMap{}
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: e.Namespace, Name: e.Name}, cm)
+	if kerrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: e.Namespace, Name: e.Name},
+			Data:       map[string]string{configMapKeyRecords: string(line) + "\n"},
+		}
+		return errors.Wrap(r.client.Create(ctx, cm), errCreateConfigMap)
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetConfigMap)
+	}
+
+	records := strings.Split(strings.TrimSuffix(cm.Data[configMapKeyRecords], "\n"), "\n")
+	if records[0] == "" {
+		records = records[:0]
+	}
+	records = append(records, string(line))
+	if n := maxRecords(e); len(records) > n {
+		records = records[len(records)-n:]
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[configMapKeyRecords] = strings.Join(records, "\n") + "\n"
+	return errors.Wrap(r.client.Update(ctx, cm), errUpdateConfigMap)
+}
+
+// setSynced sets the Synced condition of the supplied config. Its status is
+// only updated if the condition changed, or if exported is true to record
+// the time of the export.
+func (r *Reconciler) setSynced(ctx context.Context, sec *v1alpha1.StatusExporterConfig, c xpv1.Condition, exported bool) error {
+	if !exported && sec.Status.GetCondition(xpv1.TypeSynced).Equal(c) {
+		return nil
+	}
+	sec.Status.SetConditions(c)
+	if exported {
+		t := metav1.NewTime(r.now())
+		sec.Status.LastExportTime = &t
+	}
+	return errors.Wrap(r.client.Status().Update(ctx, sec), errStatusUpdate)
+}
+
+// recordOf returns the status record of the supplied Object. The kind is the
+// one of its manifest, or of the observed resource if the manifest is
+// fetched from a URL.
+func recordOf(o *v1alpha2.Object, now time.Time) statusRecord {
+	rec := statusRecord{
+		Timestamp:  now.UTC(),
+		Object:     o.GetName(),
+		Conditions: make(map[string]string, len(o.Status.Conditions)),
+	}
+	raw, err := manifest.Raw(o.Spec.ForProvider)
+	if err != nil || len(raw) == 0 {
+		raw = o.Status.AtProvider.Manifest.Raw
+	}
+	tm := metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, &tm); err == nil {
+		rec.APIVersion, rec.Kind = tm.APIVersion, tm.Kind
+	}
+	for _, c := range o.Status.Conditions {
+		rec.Conditions[string(c.Type)] = string(c.Status)
+	}
+	return rec
+}
+
+func selectorOf(sec *v1alpha1.StatusExporterConfig) (labels.Selector, error) {
+	if sec.Spec.Selector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(sec.Spec.Selector)
+}
+
+func maxRecords(e *v1alpha1.ConfigMapExporter) int {
+	if e.MaxRecords > 0 {
+		return int(e.MaxRecords)
+	}
+	return defaultMaxRecords
+}
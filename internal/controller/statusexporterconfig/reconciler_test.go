@@ -0,0 +1,274 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusexporterconfig
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/statusexporterconfig/v1alpha1"
+)
+
+const testRecord = `{"timestamp":"2024-01-02T03:04:05Z","object":"web","apiVersion":"apps/v1","kind":"Deployment","conditions":{"Ready":"True"}}`
+
+func TestReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	getObject := func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		switch o := obj.(type) {
+		case *v1alpha2.Object:
+			o.Name = key.Name
+			o.Labels = map[string]string{"team": "a"}
+			o.Spec.ForProvider.Manifest = runtime.RawExtension{Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment"}`)}
+			o.Status.SetConditions(xpv1.Available())
+		case *corev1.ConfigMap:
+			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+		return nil
+	}
+	listConfigs := func(configs ...v1alpha1.StatusExporterConfig) test.MockListFn {
+		return func(_ context.Context, l client.ObjectList, _ ...client.ListOption) error {
+			l.(*v1alpha1.StatusExporterConfigList).Items = configs
+			return nil
+		}
+	}
+	config := func(sel map[string]string, spec v1alpha1.StatusExporterConfigSpec) v1alpha1.StatusExporterConfig {
+		sec := v1alpha1.StatusExporterConfig{ObjectMeta: metav1.ObjectMeta{Name: "history"}, Spec: spec}
+		if sel != nil {
+			sec.Spec.Selector = &metav1.LabelSelector{MatchLabels: sel}
+		}
+		return sec
+	}
+	toConfigMap := v1alpha1.StatusExporterConfigSpec{ConfigMap: &v1alpha1.ConfigMapExporter{Namespace: "crossplane-system", Name: "history"}}
+	toOTLP := v1alpha1.StatusExporterConfigSpec{OpenTelemetry: &v1alpha1.OpenTelemetryExporter{Endpoint: "http://collector:4318/v1/metrics"}}
+
+	type args struct {
+		client     *test.MockClient
+		exportOTLP func(ctx context.Context, endpoint string, rec statusRecord) error
+	}
+	type want struct {
+		err       error
+		records   string
+		exported  []statusRecord
+		condition xpv1.Condition
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ObjectNotFound": {
+			reason: "We should not return an error if the Object was not found.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				},
+			},
+		},
+		"ErrorListConfigs": {
+			reason: "We should return an error if we cannot list the StatusExporterConfigs.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  getObject,
+					MockList: test.NewMockListFn(errBoom),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListConfigs),
+			},
+		},
+		"NotSelected": {
+			reason: "We should not export the status of Objects a StatusExporterConfig does not select.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  getObject,
+					MockList: listConfigs(config(map[string]string{"team": "b"}, toOTLP)),
+				},
+				exportOTLP: func(context.Context, string, statusRecord) error {
+					return errors.New("unexpected export")
+				},
+			},
+		},
+		"CreateConfigMap": {
+			reason: "We should create the ConfigMap with the first record if it does not exist.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:  getObject,
+					MockList: listConfigs(config(map[string]string{"team": "a"}, toConfigMap)),
+					MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+						if got := obj.(*corev1.ConfigMap).Data[configMapKeyRecords]; got != testRecord+"\n" {
+							t.Errorf("Create(...): want records %q, got %q", testRecord+"\n", got)
+						}
+						return nil
+					},
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+			},
+			want: want{
+				condition: xpv1.ReconcileSuccess(),
+			},
+		},
+		"ExportOTLP": {
+			reason: "We should export the status of selected Objects to the OpenTelemetry endpoint.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          getObject,
+					MockList:         listConfigs(config(nil, toOTLP)),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+			},
+			want: want{
+				exported: []statusRecord{{
+					Timestamp:  now,
+					Object:     "web",
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Conditions: map[string]string{"Ready": "True"},
+				}},
+				condition: xpv1.ReconcileSuccess(),
+			},
+		},
+		"ErrorExportOTLP": {
+			reason: "We should report failed exports by the Synced condition of the StatusExporterConfig instead of retrying them.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:          getObject,
+					MockList:         listConfigs(config(nil, toOTLP)),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				exportOTLP: func(context.Context, string, statusRecord) error {
+					return errBoom
+				},
+			},
+			want: want{
+				condition: xpv1.ReconcileError(errors.Wrap(errBoom, errExportOTLP)),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var exported []statusRecord
+			export := tc.args.exportOTLP
+			if export == nil {
+				export = func(_ context.Context, _ string, rec statusRecord) error {
+					exported = append(exported, rec)
+					return nil
+				}
+			}
+			var condition xpv1.Condition
+			if tc.args.client.MockStatusUpdate != nil {
+				tc.args.client.MockStatusUpdate = func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+					condition = obj.(*v1alpha1.StatusExporterConfig).Status.GetCondition(xpv1.TypeSynced)
+					return nil
+				}
+			}
+
+			r := &Reconciler{
+				client:     tc.args.client,
+				log:        logging.NewNopLogger(),
+				now:        func() time.Time { return now },
+				exportOTLP: export,
+			}
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "web"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.exported, exported); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want exported, +got exported:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, condition, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want condition, +got condition:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAppendToConfigMap(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		records    string
+		maxRecords int32
+		want       string
+	}{
+		"Append": {
+			reason:  "We should append the record to the existing ones.",
+			records: "a\nb\n",
+			want:    "a\nb\n" + testRecord + "\n",
+		},
+		"Rotate": {
+			reason:     "We should drop the oldest records beyond the maximum.",
+			records:    "a\nb\nc\n",
+			maxRecords: 2,
+			want:       "c\n" + testRecord + "\n",
+		},
+		"Empty": {
+			reason:  "We should write the record to an empty ConfigMap.",
+			records: "",
+			want:    testRecord + "\n",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			r := &Reconciler{client: &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					if tc.records != "" {
+						obj.(*corev1.ConfigMap).Data = map[string]string{configMapKeyRecords: tc.records}
+					}
+					return nil
+				},
+				MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					got = obj.(*corev1.ConfigMap).Data[configMapKeyRecords]
+					return nil
+				},
+			}}
+			rec := statusRecord{
+				Timestamp:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Object:     "web",
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Conditions: map[string]string{"Ready": "True"},
+			}
+			e := &v1alpha1.ConfigMapExporter{Namespace: "crossplane-system", Name: "history", MaxRecords: tc.maxRecords}
+			if err := r.appendToConfigMap(context.Background(), e, rec); err != nil {
+				t.Fatalf("\n%s\nappendToConfigMap(...): unexpected error: %v", tc.reason, err)
+			}
+			if got != tc.want {
+				t.Errorf("\n%s\nappendToConfigMap(...): want records:\n%s\ngot:\n%s", tc.reason, tc.want, strings.TrimSpace(got))
+			}
+		})
+	}
+}
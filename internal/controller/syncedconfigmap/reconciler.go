@@ -0,0 +1,345 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncedconfigmap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/syncedconfigmap/v1alpha1"
+)
+
+const (
+	errStatusUpdate    = "cannot update status"
+	errApplySource     = "cannot apply source object"
+	errParseSource     = "cannot parse source ConfigMap"
+	errApplyTarget     = "cannot apply target object"
+	errListTargets     = "cannot list target objects"
+	errDeleteTarget    = "cannot delete stale target object"
+	fieldOwner         = client.FieldOwner("kubernetes.crossplane.io/synced-config-map-controller")
+	membershipLabelKey = "kubernetes.crossplane.io/owned-by-synced-config-map"
+
+	// waitForSource is how long to wait for the source Object to observe the
+	// source ConfigMap. Its status changing triggers a reconcile earlier.
+	waitForSource = 10 * time.Second
+)
+
+// Reconciler watches for SyncedConfigMap resources and copies their source
+// ConfigMap to every target.
+type Reconciler struct {
+	client       client.Client
+	log          logging.Logger
+	pollInterval func() time.Duration
+}
+
+// Setup adds a controller that reconciles SyncedConfigMap resources.
+func Setup(mgr ctrl.Manager, o controller.Options, pollJitter time.Duration) error {
+	name := managed.ControllerName(v1alpha1.SyncedConfigMapGroupKind)
+
+	r := &Reconciler{
+		client: mgr.GetClient(),
+		log:    o.Logger,
+		pollInterval: func() time.Duration {
+			return o.PollInterval + time.Duration((rand.Float64()-0.5)*2*float64(pollJitter)) //nolint
+		},
+	}
+
+	// The source ConfigMap is watched through the observe only Object that
+	// reads it. Changes of its status, as well as those of the target
+	// Objects, trigger a reconcile of the owning SyncedConfigMap.
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.SyncedConfigMap{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{}),
+		)).
+		Owns(&v1alpha2.Object{}).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// Reconcile reads the source ConfigMap of a SyncedConfigMap and applies an
+// Object managing a copy of it for each target, removing Objects of targets
+// which are no longer listed.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) { //nolint:gocyclo
+	log := r.log.WithValues("request", req)
+
+	defer func() {
+		if retErr == nil {
+			log.Info("Reconciled")
+		} else {
+			log.Info("Retry", "err", retErr)
+		}
+	}()
+
+	scm := &v1alpha1.SyncedConfigMap{}
+	if err := r.client.Get(ctx, req.NamespacedName, scm); err != nil {
+		if kerrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	// The source and target Objects are owned by the SyncedConfigMap and
+	// garbage collected once it is gone.
+	if meta.WasDeleted(scm) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsPaused(scm) {
+		scm.Status.SetConditions(xpv1.ReconcilePaused())
+		return ctrl.Result{}, errors.Wrap(r.client.Status().Update(ctx, scm), errStatusUpdate)
+	}
+
+	log.Info("Reconciling")
+
+	fail := func(err error) (ctrl.Result, error) {
+		scm.Status.SetConditions(xpv1.ReconcileError(err))
+		_ = r.client.Status().Update(ctx, scm)
+		return ctrl.Result{}, err
+	}
+
+	src, err := objectPatch(scm, sourceObjectName(scm), scm.Spec.SourceRef.ProviderConfigReference, sourceManifest(scm), true)
+	if err != nil {
+		return fail(errors.Wrap(err, errApplySource))
+	}
+	if err := r.client.Patch(ctx, src, client.Apply, fieldOwner, client.ForceOwnership); err != nil {
+		return fail(errors.Wrap(err, errApplySource))
+	}
+	cm, err := observedConfigMap(src)
+	if err != nil {
+		return fail(errors.Wrap(err, errParseSource))
+	}
+	if cm == nil {
+		log.Debug("Waiting for the source ConfigMap to be observed", "name", src.GetName())
+		scm.Status.SetConditions(xpv1.ReconcileSuccess(), xpv1.Unavailable().WithMessage("waiting for the source ConfigMap to be observed"))
+		return ctrl.Result{RequeueAfter: waitForSource}, errors.Wrap(r.client.Status().Update(ctx, scm), errStatusUpdate)
+	}
+
+	// Fetch any existing target Objects of this SyncedConfigMap by label.
+	ml := map[string]string{membershipLabelKey: scm.Name}
+	ol := &v1alpha2.ObjectList{}
+	if err := r.client.List(ctx, ol, client.MatchingLabels(ml)); err != nil {
+		return fail(errors.Wrap(err, errListTargets))
+	}
+
+	names := sets.New[string](src.GetName())
+	targets := make([]v1alpha1.TargetStatus, 0, len(scm.Spec.TargetRefs))
+	unsynced := 0
+	for _, t := range scm.Spec.TargetRefs {
+		name := t.Name
+		if name == "" {
+			name = cm.Name
+		}
+		data, err := transform(scm.Spec.Transform, cm.Data, t, name)
+		if err != nil {
+			return fail(err)
+		}
+		po, err := objectPatch(scm, targetObjectName(scm, t), t.ProviderConfigReference, targetManifest(cm, t.Namespace, name, data), false)
+		if err != nil {
+			return fail(errors.Wrap(err, errApplyTarget))
+		}
+		if err := r.client.Patch(ctx, po, client.Apply, fieldOwner, client.ForceOwnership); err != nil {
+			return fail(errors.Wrapf(err, "%s %s", errApplyTarget, po.GetName()))
+		}
+		log.Debug("applied target object", "name", po.GetName())
+		names.Insert(po.GetName())
+
+		ts := targetStatus(po)
+		ts.ProviderConfig = t.ProviderConfigReference.Name
+		ts.Namespace = t.Namespace
+		ts.Name = name
+		if !ts.Synced {
+			unsynced++
+		}
+		targets = append(targets, ts)
+	}
+
+	// Remove Objects of targets that are no longer listed.
+	for i := range ol.Items {
+		o := ol.Items[i]
+		if names.Has(o.Name) {
+			continue
+		}
+		log.Debug("Removing", "name", o.Name)
+		if err := r.client.Delete(ctx, &ol.Items[i]); resource.IgnoreNotFound(err) != nil {
+			return fail(errors.Wrapf(err, "%s %s", errDeleteTarget, o.Name))
+		}
+	}
+
+	scm.Status.Targets = targets
+	scm.Status.SetConditions(xpv1.ReconcileSuccess(), xpv1.Available())
+	if unsynced > 0 {
+		scm.Status.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("%d of %d targets are not synced", unsynced, len(targets))))
+	}
+
+	return ctrl.Result{RequeueAfter: r.pollInterval()}, r.client.Status().Update(ctx, scm)
+}
+
+func sourceObjectName(scm *v1alpha1.SyncedConfigMap) string {
+	return scm.GetName() + "-source"
+}
+
+// targetObjectName returns a stable name for the Object that manages the copy
+// of the ConfigMap for the supplied target.
+func targetObjectName(scm *v1alpha1.SyncedConfigMap, t v1alpha1.ConfigMapTarget) string {
+	k := fmt.Sprintf("%s/%s/%s", t.ProviderConfigReference.Name, t.Namespace, t.Name)
+	h := sha256.Sum256([]byte(k))
+	return fmt.Sprintf("%s-%s", scm.GetName(), fmt.Sprintf("%x", h)[0:7])
+}
+
+func sourceManifest(scm *v1alpha1.SyncedConfigMap) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetNamespace(scm.Spec.SourceRef.Namespace)
+	u.SetName(scm.Spec.SourceRef.Name)
+	return u
+}
+
+func targetManifest(src *corev1.ConfigMap, namespace, name string, data map[string]string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	m := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		m[k] = v
+	}
+	u.Object["data"] = m
+	if len(src.BinaryData) > 0 {
+		b := make(map[string]interface{}, len(src.BinaryData))
+		for k, v := range src.BinaryData {
+			b[k] = base64.StdEncoding.EncodeToString(v)
+		}
+		u.Object["binaryData"] = b
+	}
+	return u
+}
+
+// observedConfigMap returns the ConfigMap observed by the supplied source
+// Object, or nil if it was not observed yet.
+func observedConfigMap(src *unstructured.Unstructured) (*corev1.ConfigMap, error) {
+	o := &v1alpha2.Object{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(src.Object, o); err != nil {
+		return nil, err
+	}
+	if len(o.Status.AtProvider.Manifest.Raw) == 0 {
+		return nil, nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := json.Unmarshal(o.Status.AtProvider.Manifest.Raw, cm); err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+// targetStatus reports a target as synced once its Object is both synced and
+// ready.
+func targetStatus(po *unstructured.Unstructured) v1alpha1.TargetStatus {
+	ts := v1alpha1.TargetStatus{Object: po.GetName()}
+	o := &v1alpha2.Object{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(po.Object, o); err != nil {
+		ts.Message = err.Error()
+		return ts
+	}
+	for _, ct := range []xpv1.ConditionType{xpv1.TypeSynced, xpv1.TypeReady} {
+		c := o.Status.GetCondition(ct)
+		if c.Status != corev1.ConditionTrue {
+			ts.Message = c.Message
+			if ts.Message == "" {
+				ts.Message = fmt.Sprintf("object is not %s", ct)
+			}
+			return ts
+		}
+	}
+	ts.Synced = true
+	return ts
+}
+
+func objectPatch(scm *v1alpha1.SyncedConfigMap, name string, pc xpv1.Reference, m *unstructured.Unstructured, observe bool) (*unstructured.Unstructured, error) {
+	raw, err := m.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal manifest")
+	}
+	o := &v1alpha2.Object{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				membershipLabelKey: scm.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: v1alpha1.SchemeGroupVersion.String(),
+					Kind:       v1alpha1.SyncedConfigMapKind,
+					Name:       scm.Name,
+					UID:        scm.UID,
+					Controller: ptr.To(true),
+				},
+			},
+		},
+		Spec: v1alpha2.ObjectSpec{
+			ResourceSpec: xpv1.ResourceSpec{
+				ProviderConfigReference: &pc,
+			},
+			ForProvider: v1alpha2.ObjectParameters{
+				Manifest: runtime.RawExtension{Raw: raw},
+			},
+			Watch: observe,
+		},
+	}
+	if observe {
+		o.Spec.ManagementPolicies = xpv1.ManagementPolicies{xpv1.ManagementActionObserve}
+	}
+	v, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot convert to unstructured")
+	}
+	u := &unstructured.Unstructured{Object: v}
+	u.SetGroupVersionKind(v1alpha2.ObjectGroupVersionKind)
+	return u, nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncedconfigmap
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/syncedconfigmap/v1alpha1"
+)
+
+func TestReconciler(t *testing.T) {
+	scmName := types.NamespacedName{Name: "settings"}
+	errBoom := fmt.Errorf("error reading")
+	pollInterval := 10 * time.Second
+
+	target := v1alpha1.ConfigMapTarget{ProviderConfigReference: xpv1.Reference{Name: "spoke"}, Namespace: "apps"}
+	getSyncedConfigMap := func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		scm := obj.(*v1alpha1.SyncedConfigMap)
+		scm.Name = key.Name
+		scm.Spec.SourceRef = v1alpha1.ConfigMapSource{ProviderConfigReference: xpv1.Reference{Name: "hub"}, Namespace: "default", Name: "settings"}
+		scm.Spec.TargetRefs = []v1alpha1.ConfigMapTarget{target}
+		return nil
+	}
+	// observe sets the status the API server would return for an applied
+	// Object.
+	observe := func(obj client.Object) {
+		u := obj.(*unstructured.Unstructured)
+		if u.GetName() == "settings-source" {
+			_ = unstructured.SetNestedField(u.Object, map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"namespace": "default", "name": "settings"},
+				"data":       map[string]interface{}{"endpoint": "https://example.org"},
+			}, "status", "atProvider", "manifest")
+			return
+		}
+		_ = unstructured.SetNestedSlice(u.Object, []interface{}{
+			map[string]interface{}{"type": string(xpv1.TypeSynced), "status": string(corev1.ConditionTrue)},
+			map[string]interface{}{"type": string(xpv1.TypeReady), "status": string(corev1.ConditionTrue)},
+		}, "status", "conditions")
+	}
+
+	type args struct {
+		client *test.MockClient
+	}
+	type want struct {
+		r   reconcile.Result
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ErrorGetSyncedConfigMap": {
+			reason: "We should return error.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"SyncedConfigMapNotFound": {
+			reason: "We should not return an error if the SyncedConfigMap was not found.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				},
+			},
+		},
+		"ErrorApplySource": {
+			reason: "We should return an error and set the Synced condition if the source Object cannot be applied.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:   getSyncedConfigMap,
+					MockPatch: test.NewMockPatchFn(errBoom),
+					MockStatusUpdate: func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						if c := obj.(*v1alpha1.SyncedConfigMap).Status.GetCondition(xpv1.TypeSynced); c.Status != corev1.ConditionFalse {
+							t.Errorf("expected Synced condition to be false, got %v", c)
+						}
+						return nil
+					},
+				},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"WaitForSource": {
+			reason: "We should wait for the source Object to observe the source ConfigMap.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: getSyncedConfigMap,
+					MockPatch: func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if obj.GetName() != "settings-source" {
+							t.Errorf("unexpected patch of %q", obj.GetName())
+						}
+						return nil
+					},
+					MockStatusUpdate: func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						if c := obj.(*v1alpha1.SyncedConfigMap).Status.GetCondition(xpv1.TypeReady); c.Status != corev1.ConditionFalse {
+							t.Errorf("expected Ready condition to be false, got %v", c)
+						}
+						return nil
+					},
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: waitForSource},
+			},
+		},
+		"SyncTargets": {
+			reason: "We should apply an Object for every target and remove those of targets no longer listed.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: getSyncedConfigMap,
+					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						l := list.(*v1alpha2.ObjectList)
+						l.Items = append(l.Items,
+							v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{Name: "settings-source"}},
+							v1alpha2.Object{ObjectMeta: metav1.ObjectMeta{Name: "settings-stale"}},
+						)
+						return nil
+					},
+					MockPatch: func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if l := obj.GetLabels()[membershipLabelKey]; l != scmName.Name {
+							t.Errorf("expected membership label %q, got %q", scmName.Name, l)
+						}
+						observe(obj)
+						return nil
+					},
+					MockDelete: func(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+						if obj.GetName() != "settings-stale" {
+							t.Errorf("unexpected deletion of %q", obj.GetName())
+						}
+						return nil
+					},
+					MockStatusUpdate: func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						scm := obj.(*v1alpha1.SyncedConfigMap)
+						if c := scm.Status.GetCondition(xpv1.TypeReady); c.Status != corev1.ConditionTrue {
+							t.Errorf("expected Ready condition to be true, got %v", c)
+						}
+						want := []v1alpha1.TargetStatus{{
+							ProviderConfig: "spoke",
+							Namespace:      "apps",
+							Name:           "settings",
+							Object:         targetObjectName(scm, target),
+							Synced:         true,
+						}}
+						if diff := cmp.Diff(want, scm.Status.Targets); diff != "" {
+							t.Errorf("status.targets: -want, +got:\n%s", diff)
+						}
+						return nil
+					},
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: pollInterval},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{
+				client: tc.args.client,
+				log:    logging.NewNopLogger(),
+				pollInterval: func() time.Duration {
+					return pollInterval
+				},
+			}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: scmName})
+			if !errors.Is(err, tc.want.err) {
+				t.Errorf("\n%s\nr.Reconcile(...): want error: %v, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncedconfigmap
+
+import (
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/syncedconfigmap/v1alpha1"
+)
+
+const (
+	errNewCELEnv       = "cannot create CEL environment"
+	errCompileCEL      = "cannot compile transform"
+	errEvaluateCEL     = "cannot evaluate transform"
+	errTransformResult = "transform must return a map of strings"
+)
+
+// transform returns the data of the copy of a ConfigMap for the supplied
+// target. The data of the source is returned as is if expr is empty.
+func transform(expr string, data map[string]string, t v1alpha1.ConfigMapTarget, name string) (map[string]string, error) {
+	if expr == "" {
+		return data, nil
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable("data", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("target", cel.MapType(cel.StringType, cel.StringType)),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewCELEnv)
+	}
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, errors.Wrap(iss.Err(), errCompileCEL)
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, errors.Wrap(err, errCompileCEL)
+	}
+
+	if data == nil {
+		data = map[string]string{}
+	}
+	out, _, err := prg.Eval(map[string]interface{}{
+		"data": data,
+		"target": map[string]string{
+			"providerConfig": t.ProviderConfigReference.Name,
+			"namespace":      t.Namespace,
+			"name":           name,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errEvaluateCEL)
+	}
+	v, err := out.ConvertToNative(reflect.TypeOf(map[string]string{}))
+	if err != nil {
+		return nil, errors.Wrap(err, errTransformResult)
+	}
+	return v.(map[string]string), nil
+}
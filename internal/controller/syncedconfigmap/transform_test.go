@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncedconfigmap
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/syncedconfigmap/v1alpha1"
+)
+
+func TestTransform(t *testing.T) {
+	data := map[string]string{"endpoint": "https://example.org", "token": "secret"}
+	target := v1alpha1.ConfigMapTarget{ProviderConfigReference: xpv1.Reference{Name: "spoke"}, Namespace: "apps"}
+
+	type want struct {
+		data map[string]string
+		err  bool
+	}
+	cases := map[string]struct {
+		reason string
+		expr   string
+		want   want
+	}{
+		"NoTransform": {
+			reason: "We should copy the data of the source as is without a transform.",
+			want: want{
+				data: data,
+			},
+		},
+		"Transform": {
+			reason: "We should return the data the expression evaluates to.",
+			expr:   `{"endpoint": data.endpoint, "cluster": target.providerConfig + "/" + target.namespace}`,
+			want: want{
+				data: map[string]string{"endpoint": "https://example.org", "cluster": "spoke/apps"},
+			},
+		},
+		"InvalidExpression": {
+			reason: "We should return an error if the expression does not compile.",
+			expr:   `data.`,
+			want: want{
+				err: true,
+			},
+		},
+		"NotAMapOfStrings": {
+			reason: "We should return an error if the expression does not return a map of strings.",
+			expr:   `size(data)`,
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := transform(tc.expr, data, target, "settings")
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\ntransform(...): want error: %t, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.data, got); diff != "" {
+				t.Errorf("\n%s\ntransform(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
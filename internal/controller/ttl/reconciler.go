@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ttl
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	xperrors "github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errGetObject    = "cannot get Object"
+	errDeleteObject = "cannot delete expired Object"
+	errStatusUpdate = "cannot update status"
+
+	// expiryWarningPeriod is how long before its deadline an Object is
+	// marked as expiring.
+	expiryWarningPeriod = time.Hour
+)
+
+// Reconciler deletes Objects once their deleteAfter TTL has elapsed.
+type Reconciler struct {
+	client client.Client
+	log    logging.Logger
+	now    func() time.Time
+}
+
+// Setup adds a controller that garbage collects expired Objects.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := "ttl/" + strings.ToLower(v1alpha2.ObjectGroupKind)
+
+	r := &Reconciler{
+		client: mgr.GetClient(),
+		log:    o.Logger.WithValues("controller", name),
+		now:    time.Now,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha2.Object{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(ratelimiter.NewReconciler(name, xperrors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// Reconcile deletes the requested Object if its TTL has elapsed, warns about
+// an upcoming deletion, or cancels a pending one if the TTL was removed.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("request", req)
+
+	o := &v1alpha2.Object{}
+	if err := r.client.Get(ctx, req.NamespacedName, o); err != nil {
+		return ctrl.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetObject)
+	}
+
+	if meta.WasDeleted(o) {
+		return ctrl.Result{}, nil
+	}
+
+	if o.Spec.DeleteAfter == nil {
+		return ctrl.Result{}, r.cancelExpiry(ctx, o)
+	}
+
+	deadline := o.GetCreationTimestamp().Add(o.Spec.DeleteAfter.Duration)
+	remaining := deadline.Sub(r.now())
+
+	if remaining <= 0 {
+		log.Info("Deleting expired Object", "deadline", deadline)
+		return ctrl.Result{}, errors.Wrap(resource.IgnoreNotFound(r.client.Delete(ctx, o)), errDeleteObject)
+	}
+
+	if remaining > expiryWarningPeriod {
+		return ctrl.Result{RequeueAfter: remaining - expiryWarningPeriod}, r.cancelExpiry(ctx, o)
+	}
+
+	if c := v1alpha2.Expiring(deadline); !o.GetCondition(c.Type).Equal(c) {
+		o.SetConditions(c)
+		if err := r.client.Status().Update(ctx, o); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, errStatusUpdate)
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: remaining}, nil
+}
+
+// cancelExpiry clears a previously reported upcoming deletion of the Object.
+func (r *Reconciler) cancelExpiry(ctx context.Context, o *v1alpha2.Object) error {
+	if o.GetCondition(v1alpha2.TypeExpiresIn).Status != corev1.ConditionTrue {
+		return nil
+	}
+	o.SetConditions(v1alpha2.ExpiryCancelled())
+	return errors.Wrap(r.client.Status().Update(ctx, o), errStatusUpdate)
+}
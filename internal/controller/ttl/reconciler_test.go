@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ttl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	objectName := types.NamespacedName{Name: "obj"}
+
+	object := func(created time.Time, deleteAfter *metav1.Duration, expiring bool) func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		return func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+			o := obj.(*v1alpha2.Object)
+			o.SetName(key.Name)
+			o.SetCreationTimestamp(metav1.NewTime(created))
+			o.Spec.DeleteAfter = deleteAfter
+			if expiring {
+				o.SetConditions(v1alpha2.Expiring(created.Add(deleteAfter.Duration)))
+			}
+			return nil
+		}
+	}
+
+	type args struct {
+		client *test.MockClient
+	}
+	type want struct {
+		r   ctrl.Result
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ErrorGetObject": {
+			reason: "We should return an error if we cannot get the Object.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"ObjectNotFound": {
+			reason: "We should not return an error if the Object was not found.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				},
+			},
+		},
+		"NoTTL": {
+			reason: "We should do nothing if the Object has no TTL.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: object(now.Add(-48*time.Hour), nil, false),
+				},
+			},
+		},
+		"NotYetExpiring": {
+			reason: "We should requeue when the expiry warning period starts.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: object(now.Add(-time.Hour), &metav1.Duration{Duration: 24 * time.Hour}, false),
+				},
+			},
+			want: want{
+				r: ctrl.Result{RequeueAfter: 22 * time.Hour},
+			},
+		},
+		"Expiring": {
+			reason: "We should warn about the upcoming deletion and requeue at the deadline.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: object(now.Add(-23*time.Hour-30*time.Minute), &metav1.Duration{Duration: 24 * time.Hour}, false),
+					MockStatusUpdate: func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						if c := obj.(*v1alpha2.Object).GetCondition(v1alpha2.TypeExpiresIn); c.Status != corev1.ConditionTrue {
+							t.Errorf("expected %s condition to be true, got %v", v1alpha2.TypeExpiresIn, c)
+						}
+						return nil
+					},
+				},
+			},
+			want: want{
+				r: ctrl.Result{RequeueAfter: 30 * time.Minute},
+			},
+		},
+		"Expired": {
+			reason: "We should delete the Object once its TTL has elapsed.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:    object(now.Add(-25*time.Hour), &metav1.Duration{Duration: 24 * time.Hour}, true),
+					MockDelete: test.NewMockDeleteFn(nil),
+				},
+			},
+		},
+		"ErrorDelete": {
+			reason: "We should return an error if we cannot delete an expired Object.",
+			args: args{
+				client: &test.MockClient{
+					MockGet:    object(now.Add(-25*time.Hour), &metav1.Duration{Duration: 24 * time.Hour}, true),
+					MockDelete: test.NewMockDeleteFn(errBoom),
+				},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"TTLRemoved": {
+			reason: "We should cancel a pending expiry if the TTL was removed.",
+			args: args{
+				client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						o := obj.(*v1alpha2.Object)
+						o.SetConditions(v1alpha2.Expiring(now))
+						return nil
+					},
+					MockStatusUpdate: func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						if c := obj.(*v1alpha2.Object).GetCondition(v1alpha2.TypeExpiresIn); c.Status != corev1.ConditionFalse {
+							t.Errorf("expected %s condition to be false, got %v", v1alpha2.TypeExpiresIn, c)
+						}
+						return nil
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Reconciler{
+				client: tc.args.client,
+				log:    logging.NewNopLogger(),
+				now:    func() time.Time { return now },
+			}
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: objectName})
+			if !errors.Is(err, tc.want.err) {
+				t.Errorf("\n%s\nr.Reconcile(...): want error: %v, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drain waits for in-flight reconciles before the provider shuts
+// down.
+package drain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+const (
+	// AnnotationKeyDrainInProgress is set on the pod of the provider while
+	// it waits for in-flight reconciles to complete before shutting down.
+	AnnotationKeyDrainInProgress = "provider-kubernetes.crossplane.io/drain-in-progress"
+
+	errAnnotatePod = "cannot annotate pod of the provider"
+
+	annotateTimeout = 5 * time.Second
+)
+
+// A Drainer tracks in-flight reconciles of the reconcilers it wraps. Once its
+// context is done, it stops accepting new reconciles and waits up to its
+// timeout for those in flight to complete.
+//
+// The controller manager stops runnables that do not need leader election
+// before controllers, so reconciles keep running with a live context while
+// the Drainer waits for them. Once it returns, the controllers are stopped,
+// closing their workqueues and cancelling reconciles still in flight.
+type Drainer struct {
+	client  client.Client
+	pod     types.NamespacedName
+	timeout time.Duration
+	log     logging.Logger
+
+	lock     sync.RWMutex
+	draining bool
+	inFlight sync.WaitGroup
+}
+
+// New returns a Drainer that waits up to the supplied timeout for in-flight
+// reconciles. The supplied pod is annotated while draining, unless its name
+// is empty.
+func New(c client.Client, pod types.NamespacedName, timeout time.Duration, log logging.Logger) *Drainer {
+	return &Drainer{
+		client:  c,
+		pod:     pod,
+		timeout: timeout,
+		log:     log.WithValues("drainTimeout", timeout.String()),
+	}
+}
+
+// Wrap returns a reconciler whose reconciles are tracked by the Drainer.
+// Reconciles are requeued without running once draining started. A nil
+// Drainer returns the supplied reconciler.
+func (d *Drainer) Wrap(r reconcile.Reconciler) reconcile.Reconciler {
+	if d == nil {
+		return r
+	}
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		d.lock.RLock()
+		if d.draining {
+			d.lock.RUnlock()
+			return reconcile.Result{RequeueAfter: d.timeout}, nil
+		}
+		d.inFlight.Add(1)
+		d.lock.RUnlock()
+		defer d.inFlight.Done()
+		return r.Reconcile(ctx, req)
+	})
+}
+
+// NeedLeaderElection returns false, every replica drains its own reconciles.
+func (d *Drainer) NeedLeaderElection() bool {
+	return false
+}
+
+// Start drains once the supplied context is done.
+func (d *Drainer) Start(ctx context.Context) error {
+	<-ctx.Done()
+	d.Drain()
+	return nil
+}
+
+// Drain stops accepting new reconciles and waits for those in flight to
+// complete, or the timeout of the Drainer to pass.
+func (d *Drainer) Drain() {
+	d.lock.Lock()
+	d.draining = true
+	d.lock.Unlock()
+
+	d.log.Info("Draining in-flight reconciles")
+	if err := d.annotate(); err != nil {
+		d.log.Info("Cannot mark pod as draining", "error", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		d.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		d.log.Info("Drained in-flight reconciles")
+	case <-time.After(d.timeout):
+		d.log.Info("Drain timeout exceeded, cancelling in-flight reconciles")
+	}
+}
+
+// annotate marks the pod of the provider as draining. The context of the
+// Drainer is done by now, so the pod is patched with a fresh one.
+func (d *Drainer) annotate() error {
+	if d.pod.Name == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), annotateTimeout)
+	defer cancel()
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: d.pod.Namespace, Name: d.pod.Name}}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:"true"}}}`, AnnotationKeyDrainInProgress)
+	return errors.Wrap(d.client.Patch(ctx, pod, client.RawPatch(types.MergePatchType, []byte(patch))), errAnnotatePod)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestDrainer(t *testing.T) {
+	pod := types.NamespacedName{Namespace: "crossplane-system", Name: "provider-kubernetes-abc"}
+
+	type want struct {
+		completed bool
+		annotated string
+	}
+	cases := map[string]struct {
+		reason  string
+		pod     types.NamespacedName
+		timeout time.Duration
+		want    want
+	}{
+		"Drained": {
+			reason:  "We should wait for in-flight reconciles and annotate the pod while draining.",
+			pod:     pod,
+			timeout: time.Minute,
+			want: want{
+				completed: true,
+				annotated: pod.Name,
+			},
+		},
+		"TimeoutExceeded": {
+			reason:  "We should stop waiting for in-flight reconciles once the timeout passed.",
+			pod:     pod,
+			timeout: 10 * time.Millisecond,
+			want: want{
+				annotated: pod.Name,
+			},
+		},
+		"NoPod": {
+			reason:  "We should not annotate a pod if its name is unknown.",
+			timeout: time.Minute,
+			want: want{
+				completed: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var annotated string
+			c := &test.MockClient{
+				MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+					annotated = obj.GetName()
+					return nil
+				},
+			}
+			d := New(c, tc.pod, tc.timeout, logging.NewNopLogger())
+
+			started, release := make(chan struct{}), make(chan struct{})
+			completed := make(chan struct{})
+			r := d.Wrap(reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				close(started)
+				<-release
+				close(completed)
+				return reconcile.Result{}, nil
+			}))
+			go func() { _, _ = r.Reconcile(context.Background(), reconcile.Request{}) }()
+			<-started
+
+			if tc.want.completed {
+				time.AfterFunc(50*time.Millisecond, func() { close(release) })
+			} else {
+				defer close(release)
+			}
+			d.Drain()
+
+			select {
+			case <-completed:
+				if !tc.want.completed {
+					t.Errorf("\n%s\nd.Drain(...): want in-flight reconcile not to complete", tc.reason)
+				}
+			default:
+				if tc.want.completed {
+					t.Errorf("\n%s\nd.Drain(...): want in-flight reconcile to complete", tc.reason)
+				}
+			}
+			if diff := cmp.Diff(tc.want.annotated, annotated); diff != "" {
+				t.Errorf("\n%s\nd.Drain(...): -want annotated pod, +got annotated pod:\n%s", tc.reason, diff)
+			}
+
+			got, err := r.Reconcile(context.Background(), reconcile.Request{})
+			if err != nil {
+				t.Errorf("\n%s\nr.Reconcile(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(reconcile.Result{RequeueAfter: tc.timeout}, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): want reconciles to be requeued while draining, -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events publishes the outcome of reconciles as CloudEvents.
+package events
+
+import (
+	"context"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+const (
+	errNewClient = "cannot create CloudEvents client"
+	errSetData   = "cannot set CloudEvent data"
+	errSend      = "cannot send CloudEvent"
+	errQueueFull = "CloudEvents queue is full, dropping event"
+
+	// Source of the CloudEvents published by the provider.
+	Source = "kubernetes.crossplane.io/provider-kubernetes"
+
+	// sendTimeout bounds the delivery of a single event.
+	sendTimeout = 10 * time.Second
+)
+
+// An EventType of a CloudEvent.
+type EventType string
+
+// Types of the CloudEvents published for Objects.
+const (
+	TypeObjectCreated EventType = "com.crossplane.object.created"
+	TypeObjectUpdated EventType = "com.crossplane.object.updated"
+	TypeObjectDeleted EventType = "com.crossplane.object.deleted"
+	TypeObjectFailed  EventType = "com.crossplane.object.failed"
+)
+
+// A CloudEventsEmitter publishes CloudEvents to HTTP endpoints.
+type CloudEventsEmitter struct {
+	client cloudevents.Client
+}
+
+// NewCloudEventsEmitter returns a CloudEventsEmitter publishing in binary
+// content mode over HTTP.
+func NewCloudEventsEmitter() (*CloudEventsEmitter, error) {
+	c, err := cloudevents.NewClientHTTP()
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &CloudEventsEmitter{client: c}, nil
+}
+
+// Emit publishes an event of the supplied type about the supplied object to
+// the supplied endpoint. The data of the event is the metadata of the object,
+// without its managed fields.
+func (e *CloudEventsEmitter) Emit(ctx context.Context, endpoint string, t EventType, obj client.Object) error {
+	m := meta.AsPartialObjectMetadata(obj)
+	m.ManagedFields = nil
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	m.TypeMeta = metav1.TypeMeta{APIVersion: gvk.GroupVersion().String(), Kind: gvk.Kind}
+
+	ev := cloudevents.NewEvent()
+	ev.SetID(uuid.NewString())
+	ev.SetSource(Source)
+	ev.SetType(string(t))
+	ev.SetSubject(obj.GetName())
+	ev.SetTime(time.Now())
+	if err := ev.SetData(cloudevents.ApplicationJSON, m); err != nil {
+		return errors.Wrap(err, errSetData)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	if res := e.client.Send(cloudevents.ContextWithTarget(ctx, endpoint), ev); !cloudevents.IsACK(res) {
+		return errors.Wrap(res, errSend)
+	}
+	return nil
+}
+
+// An Emitter publishes events about objects.
+type Emitter interface {
+	Emit(ctx context.Context, endpoint string, t EventType, obj client.Object) error
+}
+
+type queuedEvent struct {
+	endpoint string
+	t        EventType
+	obj      client.Object
+}
+
+// A Queue publishes events through an Emitter in the background, so that
+// slow or unreachable endpoints do not hold up the callers. Events are
+// dropped while the queue is full.
+type Queue struct {
+	emitter Emitter
+	events  chan queuedEvent
+	log     logging.Logger
+}
+
+// NewQueue returns a Queue holding up to the supplied number of events to
+// be published through the supplied Emitter.
+func NewQueue(e Emitter, size int, log logging.Logger) *Queue {
+	return &Queue{emitter: e, events: make(chan queuedEvent, size), log: log}
+}
+
+// Emit queues an event of the supplied type about the supplied object. It
+// returns an error if the queue is full.
+func (q *Queue) Emit(_ context.Context, endpoint string, t EventType, obj client.Object) error {
+	select {
+	case q.events <- queuedEvent{endpoint: endpoint, t: t, obj: obj.DeepCopyObject().(client.Object)}:
+		return nil
+	default:
+		return errors.New(errQueueFull)
+	}
+}
+
+// Start publishes the queued events until the supplied context is done.
+func (q *Queue) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev := <-q.events:
+			if err := q.emitter.Emit(ctx, ev.endpoint, ev.t, ev.obj); err != nil {
+				q.log.Debug("Cannot publish CloudEvent", "type", ev.t, "endpoint", ev.endpoint, "error", err)
+			}
+		}
+	}
+}
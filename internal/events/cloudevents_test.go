@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+func TestEmit(t *testing.T) {
+	type want struct {
+		err     bool
+		headers map[string]string
+		data    metav1.PartialObjectMetadata
+	}
+	cases := map[string]struct {
+		reason string
+		status int
+		want   want
+	}{
+		"Delivered": {
+			reason: "We should publish the metadata of the object without its managed fields.",
+			status: http.StatusAccepted,
+			want: want{
+				headers: map[string]string{
+					"Ce-Type":    string(TypeObjectCreated),
+					"Ce-Source":  Source,
+					"Ce-Subject": "cm",
+				},
+				data: metav1.PartialObjectMetadata{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "kubernetes.crossplane.io/v1alpha2", Kind: "Object"},
+					ObjectMeta: metav1.ObjectMeta{Name: "cm", Labels: map[string]string{"app": "example"}},
+				},
+			},
+		},
+		"Rejected": {
+			reason: "We should return an error if the endpoint does not accept the event.",
+			status: http.StatusBadRequest,
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			headers := map[string]string{}
+			var data metav1.PartialObjectMetadata
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k := range tc.want.headers {
+					headers[k] = r.Header.Get(k)
+				}
+				body, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(body, &data)
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			obj := &metav1.PartialObjectMetadata{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:          "cm",
+					Labels:        map[string]string{"app": "example"},
+					ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+				},
+			}
+			obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "kubernetes.crossplane.io", Version: "v1alpha2", Kind: "Object"})
+
+			e, err := NewCloudEventsEmitter()
+			if err != nil {
+				t.Fatalf("NewCloudEventsEmitter(): %v", err)
+			}
+			err = e.Emit(context.Background(), srv.URL, TypeObjectCreated, obj)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\ne.Emit(...): want error: %t, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.headers, headers); diff != "" {
+				t.Errorf("\n%s\ne.Emit(...): -want headers, +got headers:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, data); diff != "" {
+				t.Errorf("\n%s\ne.Emit(...): -want data, +got data:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type emitFn func(ctx context.Context, endpoint string, t EventType, obj client.Object) error
+
+func (fn emitFn) Emit(ctx context.Context, endpoint string, t EventType, obj client.Object) error {
+	return fn(ctx, endpoint, t, obj)
+}
+
+func TestQueue(t *testing.T) {
+	release := make(chan struct{})
+	published := make(chan string, 2)
+	q := NewQueue(emitFn(func(_ context.Context, _ string, _ EventType, obj client.Object) error {
+		<-release
+		published <- obj.GetName()
+		return nil
+	}), 1, logging.NewNopLogger())
+
+	obj := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "first"}}
+	if err := q.Emit(context.Background(), "https://example.org", TypeObjectCreated, obj); err != nil {
+		t.Fatalf("q.Emit(...): want the event queued, got error: %v", err)
+	}
+	// The queued event is a copy, later changes of the object are not
+	// published.
+	obj.SetName("changed")
+	if err := q.Emit(context.Background(), "https://example.org", TypeObjectCreated, obj); err == nil {
+		t.Errorf("q.Emit(...): want an error while the queue is full")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = q.Start(ctx) }()
+	close(release)
+	if got := <-published; got != "first" {
+		t.Errorf("q.Start(...): want the queued event about %q published, got %q", "first", got)
+	}
+}
@@ -0,0 +1,363 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package informers implements informers for resource kinds with too many
+// resources to cache them in full.
+package informers
+
+import (
+	"context"
+	"hash/fnv"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
+	kcache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	errNewHTTPClient     = "cannot create HTTP client"
+	errNewRESTMapper     = "cannot create REST mapper"
+	errMapGVK            = "cannot map kind to resource"
+	errNewMetadataClient = "cannot create metadata client"
+	errNewDynamicClient  = "cannot create dynamic client"
+	errList              = "cannot list resources"
+	errWatch             = "cannot watch resources"
+
+	defaultPageSize = 500
+
+	// retryInterval is how long a ListWatcher waits before listing again
+	// after a list or watch failed.
+	retryInterval = 5 * time.Second
+)
+
+// Options configure a ListWatcher.
+type Options struct {
+	// MaxCacheSize is the number of resources whose changes are tracked at
+	// most. Changes of resources beyond it are not detected until tracked
+	// resources are deleted. Zero or less means no limit.
+	MaxCacheSize int
+	// PageSize is the number of resources listed per request. Defaults to
+	// 500.
+	PageSize int64
+	// WatchErrorHandler is called when listing or watching resources
+	// failed. The ListWatcher lists the resources again afterwards.
+	WatchErrorHandler func(err error)
+}
+
+// A ListWatcher detects changes of all resources of a kind, like an informer
+// does, without caching them. It lists and watches the metadata of the
+// resources only, and remembers a hash of the resource version of every
+// resource. Added or changed resources are fetched in full with a GET and
+// passed to the OnAdd method of the event handler, deleted ones are passed to
+// its OnDelete method with their metadata only.
+//
+// Resources that exist when the ListWatcher starts are not passed to the
+// event handler, which would fetch every one of them. Neither are previous
+// states of changed resources, since they are not cached.
+type ListWatcher struct {
+	metadata metadata.ResourceInterface
+	full     dynamic.NamespaceableResourceInterface
+	gvk      schema.GroupVersionKind
+	handler  kcache.ResourceEventHandler
+	opts     Options
+
+	synced atomic.Bool
+
+	lock     sync.RWMutex
+	versions map[types.NamespacedName]uint64
+}
+
+// NewListWatcher returns a ListWatcher of the supplied kind in the cluster of
+// the supplied rest.Config.
+func NewListWatcher(rc *rest.Config, gvk schema.GroupVersionKind, h kcache.ResourceEventHandler, o Options) (*ListWatcher, error) {
+	hc, err := rest.HTTPClientFor(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewHTTPClient)
+	}
+	rm, err := apiutil.NewDynamicRESTMapper(rc, hc)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewRESTMapper)
+	}
+	m, err := rm.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrap(err, errMapGVK)
+	}
+	return newListWatcherForConfig(rc, hc, m.Resource, gvk, h, o)
+}
+
+func newListWatcherForConfig(rc *rest.Config, hc *http.Client, gvr schema.GroupVersionResource, gvk schema.GroupVersionKind, h kcache.ResourceEventHandler, o Options) (*ListWatcher, error) {
+	mc, err := metadata.NewForConfigAndClient(rc, hc)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewMetadataClient)
+	}
+	dc, err := dynamic.NewForConfigAndClient(rc, hc)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewDynamicClient)
+	}
+	return newListWatcher(mc.Resource(gvr), dc.Resource(gvr), gvk, h, o), nil
+}
+
+func newListWatcher(m metadata.ResourceInterface, full dynamic.NamespaceableResourceInterface, gvk schema.GroupVersionKind, h kcache.ResourceEventHandler, o Options) *ListWatcher {
+	if o.PageSize <= 0 {
+		o.PageSize = defaultPageSize
+	}
+	if o.WatchErrorHandler == nil {
+		o.WatchErrorHandler = func(error) {}
+	}
+	return &ListWatcher{
+		metadata: m,
+		full:     full,
+		gvk:      gvk,
+		handler:  h,
+		opts:     o,
+		versions: make(map[types.NamespacedName]uint64),
+	}
+}
+
+// Start lists and watches the resources until ctx is done.
+func (w *ListWatcher) Start(ctx context.Context) error {
+	rv := ""
+	for ctx.Err() == nil {
+		if rv == "" {
+			var err error
+			if rv, err = w.list(ctx); err != nil {
+				w.opts.WatchErrorHandler(err)
+				sleep(ctx, retryInterval)
+				continue
+			}
+			w.synced.Store(true)
+		}
+
+		var err error
+		rv, err = w.watch(ctx, rv)
+		switch {
+		case ctx.Err() != nil:
+		case kerrors.IsResourceExpired(err) || kerrors.IsGone(err):
+			// The watch fell too far behind, list the resources again
+			// to catch up on the changes it missed.
+			w.opts.WatchErrorHandler(err)
+			rv = ""
+		case err != nil:
+			w.opts.WatchErrorHandler(err)
+			rv = ""
+			sleep(ctx, retryInterval)
+		}
+	}
+	return nil
+}
+
+// HasSynced returns true once the resources were listed.
+func (w *ListWatcher) HasSynced() bool {
+	return w.synced.Load()
+}
+
+// WaitForSync waits until the resources were listed. It returns false if
+// ctx is done before.
+func (w *ListWatcher) WaitForSync(ctx context.Context) bool {
+	t := time.NewTicker(100 * time.Millisecond)
+	defer t.Stop()
+	for !w.HasSynced() {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-t.C:
+		}
+	}
+	return true
+}
+
+// Len returns the number of resources whose changes are tracked.
+func (w *ListWatcher) Len() int {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return len(w.versions)
+}
+
+// list lists the metadata of all resources page by page and returns the
+// resource version to watch them from. When listing again, e.g. after a
+// watch expired, the resources changed or deleted in the meantime are passed
+// to the event handler.
+func (w *ListWatcher) list(ctx context.Context) (string, error) {
+	versions := make(map[types.NamespacedName]uint64)
+	rv := ""
+	opts := metav1.ListOptions{Limit: w.opts.PageSize}
+	for {
+		l, err := w.metadata.List(ctx, opts)
+		if err != nil {
+			return "", errors.Wrap(err, errList)
+		}
+		if rv == "" {
+			// All pages are served from the resource version of the
+			// first.
+			rv = l.GetResourceVersion()
+		}
+		for i := range l.Items {
+			if w.opts.MaxCacheSize > 0 && len(versions) >= w.opts.MaxCacheSize {
+				break
+			}
+			versions[keyOf(&l.Items[i])] = hash(l.Items[i].GetResourceVersion())
+		}
+		if opts.Continue = l.GetContinue(); opts.Continue == "" {
+			break
+		}
+	}
+
+	w.lock.Lock()
+	previous := w.versions
+	w.versions = versions
+	w.lock.Unlock()
+
+	if !w.HasSynced() {
+		return rv, nil
+	}
+	for k, h := range versions {
+		if p, ok := previous[k]; !ok || p != h {
+			w.changed(ctx, k)
+		}
+	}
+	for k := range previous {
+		if _, ok := versions[k]; !ok {
+			w.deleted(&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: k.Namespace, Name: k.Name}})
+		}
+	}
+	return rv, nil
+}
+
+// watch watches the metadata of all resources from the supplied resource
+// version until the watch is closed, and returns the last resource version
+// it saw.
+func (w *ListWatcher) watch(ctx context.Context, rv string) (string, error) {
+	wi, err := w.metadata.Watch(ctx, metav1.ListOptions{ResourceVersion: rv, AllowWatchBookmarks: true})
+	if err != nil {
+		return rv, errors.Wrap(err, errWatch)
+	}
+	defer wi.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return rv, nil
+		case ev, ok := <-wi.ResultChan():
+			if !ok {
+				return rv, nil
+			}
+			if ev.Type == watch.Error {
+				return rv, kerrors.FromObject(ev.Object)
+			}
+			m, ok := ev.Object.(*metav1.PartialObjectMetadata)
+			if !ok {
+				continue
+			}
+			rv = m.GetResourceVersion()
+			w.handle(ctx, ev.Type, m)
+		}
+	}
+}
+
+// handle passes the resource of the supplied watch event to the event
+// handler if it was added, changed or deleted.
+func (w *ListWatcher) handle(ctx context.Context, t watch.EventType, m *metav1.PartialObjectMetadata) {
+	k := keyOf(m)
+	h := hash(m.GetResourceVersion())
+
+	w.lock.Lock()
+	p, tracked := w.versions[k]
+	switch t { //nolint:exhaustive // bookmarks and errors carry no change.
+	case watch.Added, watch.Modified:
+		if tracked && p == h {
+			w.lock.Unlock()
+			return
+		}
+		if !tracked && w.opts.MaxCacheSize > 0 && len(w.versions) >= w.opts.MaxCacheSize {
+			w.lock.Unlock()
+			return
+		}
+		w.versions[k] = h
+		w.lock.Unlock()
+		w.changed(ctx, k)
+	case watch.Deleted:
+		delete(w.versions, k)
+		w.lock.Unlock()
+		if tracked {
+			w.deleted(m)
+		}
+	default:
+		w.lock.Unlock()
+	}
+}
+
+// changed fetches the resource of the supplied key and passes it to the
+// event handler.
+func (w *ListWatcher) changed(ctx context.Context, k types.NamespacedName) {
+	u, err := w.full.Namespace(k.Namespace).Get(ctx, k.Name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		// The resource is deleted, which the watch reports next.
+		return
+	}
+	if err != nil {
+		w.opts.WatchErrorHandler(errors.Wrapf(err, "cannot get %s %s", w.gvk.Kind, k))
+		return
+	}
+	u.SetGroupVersionKind(w.gvk)
+	w.handler.OnAdd(u, false)
+}
+
+// deleted passes the supplied metadata of a deleted resource to the event
+// handler.
+func (w *ListWatcher) deleted(m *metav1.PartialObjectMetadata) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&metav1.PartialObjectMetadata{ObjectMeta: m.ObjectMeta})
+	if err != nil {
+		return
+	}
+	u := &unstructured.Unstructured{Object: obj}
+	u.SetGroupVersionKind(w.gvk)
+	w.handler.OnDelete(u)
+}
+
+func keyOf(m metav1.Object) types.NamespacedName {
+	return types.NamespacedName{Namespace: m.GetNamespace(), Name: m.GetName()}
+}
+
+// hash returns the hash of a resource version, which takes less memory than
+// the resource version itself.
+func hash(rv string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(rv))
+	return h.Sum64()
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
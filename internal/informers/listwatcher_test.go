@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	kcache "k8s.io/client-go/tools/cache"
+)
+
+var (
+	podGVK = schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	podGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+)
+
+func podMetadata(name, rv string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, ResourceVersion: rv},
+	}
+}
+
+func pod(name, rv string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(podGVK)
+	u.SetNamespace("default")
+	u.SetName(name)
+	u.SetResourceVersion(rv)
+	return u
+}
+
+// recordingHandler records the names of the resources it is called with,
+// prefixed by the kind of event.
+type recordingHandler struct {
+	events []string
+}
+
+func (h *recordingHandler) handler() kcache.ResourceEventHandler {
+	return kcache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			u := obj.(*unstructured.Unstructured)
+			h.events = append(h.events, "add "+u.GetName()+"@"+u.GetResourceVersion())
+		},
+		DeleteFunc: func(obj interface{}) {
+			h.events = append(h.events, "delete "+obj.(*unstructured.Unstructured).GetName())
+		},
+	}
+}
+
+func newMetadataClient(objs ...runtime.Object) *metadatafake.FakeMetadataClient {
+	s := metadatafake.NewTestScheme()
+	s.AddKnownTypeWithName(podGVK, &metav1.PartialObjectMetadata{})
+	s.AddKnownTypeWithName(podGVK.GroupVersion().WithKind("PodList"), &metav1.PartialObjectMetadataList{})
+	return metadatafake.NewSimpleMetadataClient(s, objs...)
+}
+
+func newTestListWatcher(h kcache.ResourceEventHandler, maxSize int, metadata []runtime.Object, full []runtime.Object) *ListWatcher {
+	mc := newMetadataClient(metadata...)
+	dc := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{podGVR: "PodList"}, full...)
+	return newListWatcher(mc.Resource(podGVR), dc.Resource(podGVR), podGVK, h, Options{MaxCacheSize: maxSize})
+}
+
+func TestListWatcherHandle(t *testing.T) {
+	type args struct {
+		maxSize  int
+		existing []runtime.Object
+		event    watch.EventType
+		resource *metav1.PartialObjectMetadata
+	}
+	type want struct {
+		events []string
+		len    int
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Added": {
+			reason: "We should fetch and pass on added resources.",
+			args: args{
+				existing: []runtime.Object{podMetadata("a", "1")},
+				event:    watch.Added,
+				resource: podMetadata("b", "2"),
+			},
+			want: want{
+				events: []string{"add b@2"},
+				len:    2,
+			},
+		},
+		"Unchanged": {
+			reason: "We should not pass on resources of an unchanged resource version.",
+			args: args{
+				existing: []runtime.Object{podMetadata("a", "1")},
+				event:    watch.Modified,
+				resource: podMetadata("a", "1"),
+			},
+			want: want{
+				len: 1,
+			},
+		},
+		"Modified": {
+			reason: "We should fetch and pass on resources whose resource version changed.",
+			args: args{
+				existing: []runtime.Object{podMetadata("a", "1")},
+				event:    watch.Modified,
+				resource: podMetadata("a", "2"),
+			},
+			want: want{
+				events: []string{"add a@2"},
+				len:    1,
+			},
+		},
+		"Deleted": {
+			reason: "We should pass on deleted resources and stop tracking them.",
+			args: args{
+				existing: []runtime.Object{podMetadata("a", "1")},
+				event:    watch.Deleted,
+				resource: podMetadata("a", "2"),
+			},
+			want: want{
+				events: []string{"delete a"},
+			},
+		},
+		"CacheFull": {
+			reason: "We should not track resources beyond the cache size limit.",
+			args: args{
+				maxSize:  1,
+				existing: []runtime.Object{podMetadata("a", "1")},
+				event:    watch.Added,
+				resource: podMetadata("b", "2"),
+			},
+			want: want{
+				len: 1,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := &recordingHandler{}
+			w := newTestListWatcher(h.handler(), tc.args.maxSize, tc.args.existing, []runtime.Object{pod(tc.args.resource.GetName(), tc.args.resource.GetResourceVersion())})
+
+			if _, err := w.list(context.Background()); err != nil {
+				t.Fatalf("w.list(...): unexpected error: %v", err)
+			}
+			w.synced.Store(true)
+			w.handle(context.Background(), tc.args.event, tc.args.resource)
+
+			if diff := cmp.Diff(tc.want.events, h.events); diff != "" {
+				t.Errorf("\n%s\nw.handle(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.len, w.Len()); diff != "" {
+				t.Errorf("\n%s\nw.Len(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestListWatcherRelist(t *testing.T) {
+	h := &recordingHandler{}
+	w := newTestListWatcher(h.handler(), 0,
+		[]runtime.Object{podMetadata("a", "1"), podMetadata("b", "1")},
+		[]runtime.Object{pod("a", "2")},
+	)
+
+	if _, err := w.list(context.Background()); err != nil {
+		t.Fatalf("w.list(...): unexpected error: %v", err)
+	}
+	w.synced.Store(true)
+	if len(h.events) != 0 {
+		t.Errorf("w.list(...): resources of the initial list should not be passed on, got %v", h.events)
+	}
+
+	// While the watch was down, a was changed and b deleted.
+	w.metadata = newMetadataClient(podMetadata("a", "2")).Resource(podGVR)
+	if _, err := w.list(context.Background()); err != nil {
+		t.Fatalf("w.list(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"add a@2", "delete b"}, h.events); diff != "" {
+		t.Errorf("w.list(...): -want events, +got events:\n%s", diff)
+	}
+}
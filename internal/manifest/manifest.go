@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manifest reads the manifests of Objects.
+package manifest
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errManifests        = "exactly one of manifest, manifestYAML or manifestURL is required"
+	errFetchedFromURL   = "manifest is fetched from manifestURL"
+	errParseYAML        = "cannot parse manifestYAML"
+	errMultipleDocs     = "manifestYAML must contain a single YAML document"
+	errNotMapping       = "manifestYAML must be a YAML mapping"
+	errDirective        = "manifestYAML must not contain directives"
+	errAnchor           = "manifestYAML must not contain anchors or aliases"
+	errTag              = "manifestYAML must not contain explicit tags"
+	errConvertYAMLToRaw = "cannot convert manifestYAML to JSON"
+)
+
+// Raw returns the raw JSON manifest of the supplied Object parameters. A
+// ManifestYAML is converted to JSON, stripping its comments. Manifests of a
+// ManifestURL are only known once fetched, an error is returned for them.
+func Raw(p v1alpha2.ObjectParameters) ([]byte, error) {
+	switch {
+	case p.ManifestURL != "":
+		return nil, errors.New(errFetchedFromURL)
+	case p.ManifestYAML != "":
+		return FromYAML(p.ManifestYAML)
+	}
+	return p.Manifest.Raw, nil
+}
+
+// ForObject returns the raw JSON manifest of the supplied Object like Raw.
+// The manifest of a ManifestURL is the one last observed, recorded in the
+// status of the Object, an error is returned until it is observed.
+func ForObject(o *v1alpha2.Object) ([]byte, error) {
+	raw, err := Raw(o.Spec.ForProvider)
+	if err != nil && o.Spec.ForProvider.ManifestURL != "" && len(o.Status.AtProvider.Manifest.Raw) > 0 {
+		return o.Status.AtProvider.Manifest.Raw, nil
+	}
+	return raw, err
+}
+
+// Validate returns an error unless exactly one of the manifest, the manifest
+// YAML or the manifest URL of the supplied Object parameters is set, and the
+// manifest YAML or URL is valid. Plain HTTP URLs are only accepted if
+// allowHTTP is true.
+func Validate(p v1alpha2.ObjectParameters, allowHTTP bool) error {
+	set := 0
+	for _, ok := range []bool{len(p.Manifest.Raw) > 0, p.ManifestYAML != "", p.ManifestURL != ""} {
+		if ok {
+			set++
+		}
+	}
+	switch {
+	case set != 1:
+		return errors.New(errManifests)
+	case p.ManifestYAML != "":
+		_, err := FromYAML(p.ManifestYAML)
+		return err
+	case p.ManifestURL != "":
+		return ValidateURL(p.ManifestURL, allowHTTP)
+	}
+	return nil
+}
+
+// FromYAML converts the supplied YAML manifest to JSON. Comments are
+// stripped. Any other YAML feature without a JSON equivalent, i.e.
+// directives, anchors, aliases and explicit tags, is rejected rather than
+// silently resolved, as are multiple documents.
+func FromYAML(s string) ([]byte, error) {
+	if hasDirective(s) {
+		return nil, errors.New(errDirective)
+	}
+
+	d := yamlv3.NewDecoder(strings.NewReader(s))
+	doc := &yamlv3.Node{}
+	if err := d.Decode(doc); err != nil {
+		return nil, errors.Wrap(err, errParseYAML)
+	}
+	if err := d.Decode(&yamlv3.Node{}); !errors.Is(err, io.EOF) {
+		return nil, errors.New(errMultipleDocs)
+	}
+	if len(doc.Content) != 1 || doc.Content[0].Kind != yamlv3.MappingNode {
+		return nil, errors.New(errNotMapping)
+	}
+	if err := checkNode(doc.Content[0]); err != nil {
+		return nil, err
+	}
+
+	b, err := yaml.YAMLToJSON([]byte(s))
+	return b, errors.Wrap(err, errConvertYAMLToRaw)
+}
+
+// hasDirective returns true if the supplied YAML contains a directive, like
+// %YAML or %TAG. Directives start at the beginning of a line, where a
+// manifest, being a mapping, never has content starting with %.
+func hasDirective(s string) bool {
+	sc := bufio.NewScanner(strings.NewReader(s))
+	for sc.Scan() {
+		if strings.HasPrefix(sc.Text(), "%") {
+			return true
+		}
+	}
+	return false
+}
+
+func checkNode(n *yamlv3.Node) error {
+	if n.Kind == yamlv3.AliasNode || n.Anchor != "" {
+		return errors.New(errAnchor)
+	}
+	if n.Style&yamlv3.TaggedStyle != 0 {
+		return errors.New(errTag)
+	}
+	for _, c := range n.Content {
+		if err := checkNode(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
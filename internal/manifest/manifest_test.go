@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestFromYAML(t *testing.T) {
+	type want struct {
+		json string
+		err  bool
+	}
+	cases := map[string]struct {
+		reason string
+		yaml   string
+		want   want
+	}{
+		"Comments": {
+			reason: "We should strip comments.",
+			yaml: `# The namespace of team a.
+apiVersion: v1
+kind: Namespace # cluster scoped
+metadata:
+  name: team-a
+  labels:
+    # Billed monthly.
+    cost-center: "42" # a string, not a number
+`,
+			want: want{
+				json: `{"apiVersion":"v1","kind":"Namespace","metadata":{"labels":{"cost-center":"42"},"name":"team-a"}}`,
+			},
+		},
+		"BlockScalar": {
+			reason: "We should keep the content of block scalars, even if it looks like a comment.",
+			yaml: `apiVersion: v1
+kind: ConfigMap
+data:
+  script: |
+    # not a comment
+    %not a directive
+`,
+			want: want{
+				json: `{"apiVersion":"v1","data":{"script":"# not a comment\n%not a directive\n"},"kind":"ConfigMap"}`,
+			},
+		},
+		"Directive": {
+			reason: "We should reject directives.",
+			yaml:   "%YAML 1.2\n---\napiVersion: v1\nkind: Namespace\n",
+			want: want{
+				err: true,
+			},
+		},
+		"Anchor": {
+			reason: "We should reject anchors and aliases.",
+			yaml:   "apiVersion: v1\nkind: ConfigMap\ndata: &data\n  a: b\nbinaryData: *data\n",
+			want: want{
+				err: true,
+			},
+		},
+		"Tag": {
+			reason: "We should reject explicit tags.",
+			yaml:   "apiVersion: v1\nkind: ConfigMap\ndata:\n  a: !!str 1\n",
+			want: want{
+				err: true,
+			},
+		},
+		"MultipleDocuments": {
+			reason: "We should reject multiple documents.",
+			yaml:   "apiVersion: v1\nkind: Namespace\n---\napiVersion: v1\nkind: Namespace\n",
+			want: want{
+				err: true,
+			},
+		},
+		"NotAMapping": {
+			reason: "We should reject documents that are not a mapping.",
+			yaml:   "- apiVersion: v1\n",
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := FromYAML(tc.yaml)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nFromYAML(...): want error: %t, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.json, string(got)); diff != "" {
+				t.Errorf("\n%s\nFromYAML(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	raw := runtime.RawExtension{Raw: []byte(`{"apiVersion": "v1", "kind": "Namespace"}`)}
+
+	cases := map[string]struct {
+		reason string
+		params v1alpha2.ObjectParameters
+		err    bool
+	}{
+		"Manifest": {
+			reason: "We should accept a manifest.",
+			params: v1alpha2.ObjectParameters{Manifest: raw},
+		},
+		"ManifestYAML": {
+			reason: "We should accept a manifest YAML.",
+			params: v1alpha2.ObjectParameters{ManifestYAML: "apiVersion: v1\nkind: Namespace\n"},
+		},
+		"Both": {
+			reason: "We should reject setting both manifest and manifest YAML.",
+			params: v1alpha2.ObjectParameters{Manifest: raw, ManifestYAML: "apiVersion: v1\nkind: Namespace\n"},
+			err:    true,
+		},
+		"Neither": {
+			reason: "We should reject setting neither manifest nor manifest YAML.",
+			err:    true,
+		},
+		"ManifestURL": {
+			reason: "We should accept a manifest URL.",
+			params: v1alpha2.ObjectParameters{ManifestURL: "https://example.org/namespace.yaml"},
+		},
+		"ManifestAndURL": {
+			reason: "We should reject setting both manifest and manifest URL.",
+			params: v1alpha2.ObjectParameters{Manifest: raw, ManifestURL: "https://example.org/namespace.yaml"},
+			err:    true,
+		},
+		"HTTPURL": {
+			reason: "We should reject plain HTTP manifest URLs.",
+			params: v1alpha2.ObjectParameters{ManifestURL: "http://example.org/namespace.yaml"},
+			err:    true,
+		},
+		"InvalidYAML": {
+			reason: "We should reject an invalid manifest YAML.",
+			params: v1alpha2.ObjectParameters{ManifestYAML: "apiVersion: v1\nkind: [Namespace\n"},
+			err:    true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(tc.params, false)
+			if (err != nil) != tc.err {
+				t.Errorf("\n%s\nValidate(...): want error: %t, got error: %v", tc.reason, tc.err, err)
+			}
+		})
+	}
+}
+
+func TestForObject(t *testing.T) {
+	raw := runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Namespace"}`)}
+	observed := runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"observed"}}`)}
+
+	type want struct {
+		raw string
+		err bool
+	}
+	cases := map[string]struct {
+		reason string
+		o      *v1alpha2.Object
+		want   want
+	}{
+		"Manifest": {
+			reason: "We should return the manifest of the Object.",
+			o: &v1alpha2.Object{
+				Spec:   v1alpha2.ObjectSpec{ForProvider: v1alpha2.ObjectParameters{Manifest: raw}},
+				Status: v1alpha2.ObjectStatus{AtProvider: v1alpha2.ObjectObservation{Manifest: observed}},
+			},
+			want: want{raw: string(raw.Raw)},
+		},
+		"ManifestURL": {
+			reason: "We should return the observed manifest of an Object with a manifest URL.",
+			o: &v1alpha2.Object{
+				Spec:   v1alpha2.ObjectSpec{ForProvider: v1alpha2.ObjectParameters{ManifestURL: "https://example.org/namespace.yaml"}},
+				Status: v1alpha2.ObjectStatus{AtProvider: v1alpha2.ObjectObservation{Manifest: observed}},
+			},
+			want: want{raw: string(observed.Raw)},
+		},
+		"ManifestURLNotObserved": {
+			reason: "We should return an error for an Object with a manifest URL that was not observed yet.",
+			o: &v1alpha2.Object{
+				Spec: v1alpha2.ObjectSpec{ForProvider: v1alpha2.ObjectParameters{ManifestURL: "https://example.org/namespace.yaml"}},
+			},
+			want: want{err: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ForObject(tc.o)
+			if (err != nil) != tc.want.err {
+				t.Errorf("\n%s\nForObject(...): want error: %t, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.raw, string(got)); diff != "" {
+				t.Errorf("\n%s\nForObject(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errParseURL        = "cannot parse manifestURL"
+	errURLScheme       = "manifestURL must be an https URL"
+	errURLHost         = "manifestURL must have a host"
+	errGetAuthSecret   = "cannot get basic auth Secret of manifestURL"
+	errAuthSecretKeys  = "basic auth Secret of manifestURL must have username and password keys"
+	errGetCASecret     = "cannot get CA Secret of manifestURL"
+	errInvalidCABundle = "CA Secret of manifestURL does not contain a PEM encoded CA bundle"
+	errNewRequest      = "cannot create request for manifestURL"
+	errFetch           = "cannot fetch manifestURL"
+	errFetchStatus     = "cannot fetch manifestURL: unexpected status %s"
+	errReadBody        = "cannot read manifest fetched from manifestURL"
+	errTooLarge        = "manifest fetched from manifestURL exceeds %d bytes"
+
+	keyUsername = "username"
+	keyPassword = "password"
+
+	// DefaultFetchTimeout is how long fetching a manifest takes at most,
+	// unless its Object configures another timeout.
+	DefaultFetchTimeout = 10 * time.Second
+
+	// maxManifestSize is the size of the largest manifest fetched. It is
+	// about the size etcd accepts for a single resource.
+	maxManifestSize = 3 << 20
+)
+
+// ValidateURL returns an error unless the supplied manifest URL is an
+// absolute HTTPS URL. Plain HTTP URLs are accepted if allowHTTP is true.
+func ValidateURL(s string, allowHTTP bool) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return errors.Wrap(err, errParseURL)
+	}
+	if u.Scheme != "https" && (!allowHTTP || u.Scheme != "http") {
+		return errors.New(errURLScheme)
+	}
+	if u.Host == "" {
+		return errors.New(errURLHost)
+	}
+	return nil
+}
+
+// A Fetcher fetches the manifests of Objects from their manifest URL. It
+// caches the manifests by URL and credentials, and revalidates the cached
+// manifest using the ETag and Last-Modified headers it was served with.
+type Fetcher struct {
+	kube      client.Reader
+	allowHTTP bool
+	transport http.RoundTripper
+
+	lock  sync.Mutex
+	cache map[fetchKey]fetched
+}
+
+type fetchKey struct {
+	url       string
+	basicAuth xpv1.SecretReference
+}
+
+type fetched struct {
+	etag         string
+	lastModified string
+	raw          []byte
+}
+
+// NewFetcher returns a Fetcher reading the Secrets referenced by Objects
+// using the supplied client. Plain HTTP URLs are only fetched if allowHTTP
+// is true.
+func NewFetcher(kube client.Reader, allowHTTP bool) *Fetcher {
+	return &Fetcher{
+		kube:      kube,
+		allowHTTP: allowHTTP,
+		transport: http.DefaultTransport,
+		cache:     make(map[fetchKey]fetched),
+	}
+}
+
+// Fetch returns the raw JSON manifest served at the manifest URL of the
+// supplied Object parameters.
+func (f *Fetcher) Fetch(ctx context.Context, p v1alpha2.ObjectParameters) ([]byte, error) {
+	if err := ValidateURL(p.ManifestURL, f.allowHTTP); err != nil {
+		return nil, err
+	}
+	cfg := p.ManifestURLConfig
+	if cfg == nil {
+		cfg = &v1alpha2.ManifestURLConfig{}
+	}
+
+	timeout := DefaultFetchTimeout
+	if cfg.Timeout != nil {
+		timeout = cfg.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.ManifestURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewRequest)
+	}
+	req.Header.Set("Accept", "application/yaml, application/json;q=0.9, */*;q=0.8")
+
+	key := fetchKey{url: p.ManifestURL}
+	if ref := cfg.BasicAuthSecretRef; ref != nil {
+		key.basicAuth = *ref
+		user, pass, err := f.basicAuth(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(user, pass)
+	}
+
+	f.lock.Lock()
+	cached, ok := f.cache[key]
+	f.lock.Unlock()
+	if ok {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	t := f.transport
+	if cfg.CASecretRef != nil {
+		ht, err := f.transportWithCA(ctx, cfg.CASecretRef)
+		if err != nil {
+			return nil, err
+		}
+		defer ht.CloseIdleConnections()
+		t = ht
+	}
+
+	resp, err := (&http.Client{Transport: t}).Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, errFetch)
+	}
+	defer resp.Body.Close() //nolint:errcheck // Nothing to do about it.
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		return cached.raw, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf(errFetchStatus, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, errReadBody)
+	}
+	if len(body) > maxManifestSize {
+		return nil, errors.Errorf(errTooLarge, maxManifestSize)
+	}
+	raw, err := FromYAML(string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	f.lock.Lock()
+	if etag != "" || lastModified != "" {
+		f.cache[key] = fetched{etag: etag, lastModified: lastModified, raw: raw}
+	} else {
+		delete(f.cache, key)
+	}
+	f.lock.Unlock()
+	return raw, nil
+}
+
+func (f *Fetcher) basicAuth(ctx context.Context, ref *xpv1.SecretReference) (string, string, error) {
+	s := &corev1.Secret{}
+	if err := f.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return "", "", errors.Wrap(err, errGetAuthSecret)
+	}
+	user, pass := s.Data[keyUsername], s.Data[keyPassword]
+	if len(user) == 0 || len(pass) == 0 {
+		return "", "", errors.New(errAuthSecretKeys)
+	}
+	return string(user), string(pass), nil
+}
+
+// transportWithCA returns a transport verifying servers against the CA
+// bundle of the supplied Secret key only.
+func (f *Fetcher) transportWithCA(ctx context.Context, ref *xpv1.SecretKeySelector) (*http.Transport, error) {
+	s := &corev1.Secret{}
+	if err := f.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetCASecret)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(s.Data[ref.Key]) {
+		return nil, errors.New(errInvalidCABundle)
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return t, nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestFetcherFetch(t *testing.T) {
+	const etag = `"v1"`
+	manifestYAML := "# The namespace of team a.\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: team-a\n"
+	manifestJSON := `{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"team-a"}}`
+
+	served := 0
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/private.yaml":
+			if u, p, ok := r.BasicAuth(); !ok || u != "admin" || p != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		case "/missing.yaml":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		served++
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(manifestYAML))
+	}))
+	defer srv.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			s := obj.(*corev1.Secret)
+			switch key.Name {
+			case "ca":
+				s.Data = map[string][]byte{"ca.crt": ca}
+			case "auth":
+				s.Data = map[string][]byte{keyUsername: []byte("admin"), keyPassword: []byte("secret")}
+			}
+			return nil
+		},
+	}
+	withCA := func(cfg v1alpha2.ManifestURLConfig) *v1alpha2.ManifestURLConfig {
+		cfg.CASecretRef = &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "ca"}, Key: "ca.crt"}
+		return &cfg
+	}
+
+	type want struct {
+		raw    string
+		served int
+		err    bool
+	}
+	cases := map[string]struct {
+		reason string
+		params v1alpha2.ObjectParameters
+		want   want
+	}{
+		"Fetch": {
+			reason: "We should fetch and convert the manifest served at the URL.",
+			params: v1alpha2.ObjectParameters{ManifestURL: srv.URL + "/namespace.yaml", ManifestURLConfig: withCA(v1alpha2.ManifestURLConfig{})},
+			want: want{
+				raw:    manifestJSON,
+				served: 1,
+			},
+		},
+		"NotModified": {
+			reason: "We should return the cached manifest if the server did not modify it.",
+			params: v1alpha2.ObjectParameters{ManifestURL: srv.URL + "/namespace.yaml", ManifestURLConfig: withCA(v1alpha2.ManifestURLConfig{})},
+			want: want{
+				raw:    manifestJSON,
+				served: 1,
+			},
+		},
+		"BasicAuth": {
+			reason: "We should authenticate with the credentials of the basic auth Secret.",
+			params: v1alpha2.ObjectParameters{ManifestURL: srv.URL + "/private.yaml", ManifestURLConfig: withCA(v1alpha2.ManifestURLConfig{
+				BasicAuthSecretRef: &xpv1.SecretReference{Namespace: "crossplane-system", Name: "auth"},
+			})},
+			want: want{
+				raw:    manifestJSON,
+				served: 2,
+			},
+		},
+		"NotFound": {
+			reason: "We should return an error if the server does not serve the manifest.",
+			params: v1alpha2.ObjectParameters{ManifestURL: srv.URL + "/missing.yaml", ManifestURLConfig: withCA(v1alpha2.ManifestURLConfig{})},
+			want: want{
+				served: 2,
+				err:    true,
+			},
+		},
+		"UntrustedCertificate": {
+			reason: "We should not trust the certificate of the server without a CA override.",
+			params: v1alpha2.ObjectParameters{ManifestURL: srv.URL + "/namespace.yaml"},
+			want: want{
+				served: 2,
+				err:    true,
+			},
+		},
+		"HTTP": {
+			reason: "We should refuse to fetch plain HTTP URLs.",
+			params: v1alpha2.ObjectParameters{ManifestURL: "http://example.org/namespace.yaml"},
+			want: want{
+				served: 2,
+				err:    true,
+			},
+		},
+	}
+
+	// The cases share the cache of the Fetcher, and run in order.
+	f := NewFetcher(kube, false)
+	for _, name := range []string{"Fetch", "NotModified", "BasicAuth", "NotFound", "UntrustedCertificate", "HTTP"} {
+		tc := cases[name]
+		t.Run(name, func(t *testing.T) {
+			raw, err := f.Fetch(context.Background(), tc.params)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nf.Fetch(...): want error: %t, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.raw, string(raw)); diff != "" {
+				t.Errorf("\n%s\nf.Fetch(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if served != tc.want.served {
+				t.Errorf("\n%s\nf.Fetch(...): want manifest served %d times, got %d", tc.reason, tc.want.served, served)
+			}
+		})
+	}
+}
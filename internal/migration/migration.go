@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migration migrates Objects stored in an older API version to the
+// storage version.
+package migration
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+const (
+	errListObjects      = "cannot list Objects"
+	errConvert          = "cannot convert Object"
+	errGetStored        = "cannot get Object"
+	errUpdate           = "cannot update Object"
+	errUnsupportedPath  = "unsupported migration"
+	errUnmarshalPayload = "cannot unmarshal manifest"
+)
+
+// Options of a migration.
+type Options struct {
+	// From is the API version Objects are migrated from.
+	From string
+	// To is the API version Objects are migrated to.
+	To string
+	// DryRun prints the changes of every Object instead of applying them.
+	DryRun bool
+	// Namespace only migrates Objects whose manifest is in this namespace,
+	// if set.
+	Namespace string
+	// LabelSelector only migrates Objects matching it, if set.
+	LabelSelector labels.Selector
+}
+
+// A Result reports how many Objects a migration migrated, failed to migrate
+// and skipped because they did not match its filters.
+type Result struct {
+	Migrated int
+	Skipped  int
+	Failed   int
+}
+
+// Migrate converts all Objects using the logic of the conversion webhook and
+// updates them in place, so the API server stores them in the new version.
+// Failures to migrate an Object are written to out and do not stop the
+// migration.
+func Migrate(ctx context.Context, c client.Client, out io.Writer, o Options) (Result, error) {
+	if o.From != v1alpha1.SchemeGroupVersion.Version || o.To != v1alpha2.SchemeGroupVersion.Version {
+		return Result{}, errors.Errorf("%s from %q to %q, only %s to %s is supported", errUnsupportedPath, o.From, o.To,
+			v1alpha1.SchemeGroupVersion.Version, v1alpha2.SchemeGroupVersion.Version)
+	}
+
+	var opts []client.ListOption
+	if o.LabelSelector != nil {
+		opts = append(opts, client.MatchingLabelsSelector{Selector: o.LabelSelector})
+	}
+	l := &v1alpha1.ObjectList{}
+	if err := c.List(ctx, l, opts...); err != nil {
+		return Result{}, errors.Wrap(err, errListObjects)
+	}
+
+	res := Result{}
+	for i := range l.Items {
+		src := &l.Items[i]
+		if o.Namespace != "" {
+			ns, err := manifestNamespace(src)
+			if err != nil {
+				fmt.Fprintf(out, "Object %s: %v\n", src.Name, err)
+				res.Failed++
+				continue
+			}
+			if ns != o.Namespace {
+				res.Skipped++
+				continue
+			}
+		}
+
+		if err := migrate(ctx, c, out, src, o.DryRun); err != nil {
+			fmt.Fprintf(out, "Object %s: %v\n", src.Name, err)
+			res.Failed++
+			continue
+		}
+		res.Migrated++
+	}
+	return res, nil
+}
+
+func migrate(ctx context.Context, c client.Client, out io.Writer, src *v1alpha1.Object, dryRun bool) error {
+	converted := &v1alpha2.Object{}
+	if err := src.ConvertTo(converted); err != nil {
+		return errors.Wrap(err, errConvert)
+	}
+
+	stored := &v1alpha2.Object{}
+	if err := c.Get(ctx, types.NamespacedName{Name: src.Name}, stored); err != nil {
+		return errors.Wrap(err, errGetStored)
+	}
+	dst := withConverted(stored, converted)
+
+	if dryRun {
+		d, err := diff(src, dst)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Object %s:\n%s\n", src.Name, d)
+		return nil
+	}
+
+	// The API server stores an updated Object in its storage version, even
+	// if the update does not change it.
+	if err := c.Update(ctx, dst); err != nil {
+		return errors.Wrap(err, errUpdate)
+	}
+	fmt.Fprintf(out, "Object %s: migrated to %s\n", src.Name, v1alpha2.SchemeGroupVersion)
+	return nil
+}
+
+// withConverted returns the stored Object with the fields the old version
+// represents set to their converted values. Fields only the new version has
+// are kept, so migrating an Object that was written in the new version does
+// not lose them.
+func withConverted(stored, converted *v1alpha2.Object) *v1alpha2.Object {
+	o := stored.DeepCopy()
+	o.SetGroupVersionKind(v1alpha2.ObjectGroupVersionKind)
+	o.SetLabels(converted.GetLabels())
+	o.SetAnnotations(converted.GetAnnotations())
+	o.Spec.ResourceSpec = converted.Spec.ResourceSpec
+	o.Spec.ConnectionDetails = converted.Spec.ConnectionDetails
+	o.Spec.ForProvider.Manifest = converted.Spec.ForProvider.Manifest
+	o.Spec.References = converted.Spec.References
+	o.Spec.Readiness.Policy = converted.Spec.Readiness.Policy
+	return o
+}
+
+// diff returns the difference of the supplied Objects, ignoring fields
+// managed by the API server.
+func diff(src *v1alpha1.Object, dst *v1alpha2.Object) (string, error) {
+	from, err := runtime.DefaultUnstructuredConverter.ToUnstructured(src)
+	if err != nil {
+		return "", errors.Wrap(err, errConvert)
+	}
+	to, err := runtime.DefaultUnstructuredConverter.ToUnstructured(dst)
+	if err != nil {
+		return "", errors.Wrap(err, errConvert)
+	}
+	for _, u := range []map[string]interface{}{from, to} {
+		unstructured.RemoveNestedField(u, "metadata", "managedFields")
+		unstructured.RemoveNestedField(u, "status")
+	}
+	from["apiVersion"] = v1alpha1.SchemeGroupVersion.String()
+	to["apiVersion"] = v1alpha2.SchemeGroupVersion.String()
+	return cmp.Diff(from, to), nil
+}
+
+func manifestNamespace(o *v1alpha1.Object) (string, error) {
+	u := &unstructured.Unstructured{}
+	if err := u.UnmarshalJSON(o.Spec.ForProvider.Manifest.Raw); err != nil {
+		return "", errors.Wrap(err, errUnmarshalPayload)
+	}
+	return u.GetNamespace(), nil
+}
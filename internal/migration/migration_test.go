@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha1"
+	"github.com/crossplane-contrib/provider-kubernetes/apis/object/v1alpha2"
+)
+
+func TestMigrate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	object := func(name, namespace string) v1alpha1.Object {
+		o := v1alpha1.Object{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		o.Spec.ForProvider.Manifest = runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"` + namespace + `","name":"` + name + `"}}`)}
+		o.Spec.ManagementPolicy = v1alpha1.Observe
+		return o
+	}
+	list := func(_ context.Context, l client.ObjectList, _ ...client.ListOption) error {
+		l.(*v1alpha1.ObjectList).Items = []v1alpha1.Object{object("a", "default"), object("b", "other")}
+		return nil
+	}
+	getStored := func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		o := obj.(*v1alpha2.Object)
+		o.Name = key.Name
+		// A field only v1alpha2 has, which must survive the migration.
+		o.Spec.Watch = true
+		return nil
+	}
+
+	type args struct {
+		client *test.MockClient
+		o      Options
+	}
+	type want struct {
+		res     Result
+		err     error
+		updated []string
+		out     string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"UnsupportedVersions": {
+			reason: "We should return an error for versions we cannot migrate between.",
+			args: args{
+				client: &test.MockClient{},
+				o:      Options{From: "v1alpha2", To: "v1alpha1"},
+			},
+			want: want{
+				err: errors.Errorf("%s from %q to %q, only v1alpha1 to v1alpha2 is supported", errUnsupportedPath, "v1alpha2", "v1alpha1"),
+			},
+		},
+		"ErrorList": {
+			reason: "We should return an error if Objects cannot be listed.",
+			args: args{
+				client: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+				o:      Options{From: "v1alpha1", To: "v1alpha2"},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListObjects),
+			},
+		},
+		"Migrate": {
+			reason: "We should update every Object in the new version.",
+			args: args{
+				client: &test.MockClient{MockList: list, MockGet: getStored},
+				o:      Options{From: "v1alpha1", To: "v1alpha2"},
+			},
+			want: want{
+				res:     Result{Migrated: 2},
+				updated: []string{"a", "b"},
+				out:     "Object a: migrated to kubernetes.crossplane.io/v1alpha2",
+			},
+		},
+		"NamespaceFilter": {
+			reason: "We should only migrate Objects whose manifest is in the supplied namespace.",
+			args: args{
+				client: &test.MockClient{MockList: list, MockGet: getStored},
+				o:      Options{From: "v1alpha1", To: "v1alpha2", Namespace: "other"},
+			},
+			want: want{
+				res:     Result{Migrated: 1, Skipped: 1},
+				updated: []string{"b"},
+			},
+		},
+		"DryRun": {
+			reason: "We should print the changes instead of updating Objects in dry-run mode.",
+			args: args{
+				client: &test.MockClient{MockList: list, MockGet: getStored},
+				o:      Options{From: "v1alpha1", To: "v1alpha2", DryRun: true},
+			},
+			want: want{
+				res: Result{Migrated: 2},
+				out: "managementPolicies",
+			},
+		},
+		"ErrorUpdate": {
+			reason: "We should count Objects that cannot be updated as failed and go on.",
+			args: args{
+				client: &test.MockClient{MockList: list, MockGet: test.NewMockGetFn(errBoom)},
+				o:      Options{From: "v1alpha1", To: "v1alpha2"},
+			},
+			want: want{
+				res: Result{Failed: 2},
+				out: "Object a: cannot get Object: boom",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var updated []string
+			tc.args.client.MockUpdate = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				o := obj.(*v1alpha2.Object)
+				if !o.Spec.Watch {
+					t.Errorf("\n%s\nMigrate(...): migrated Object lost fields of the new version", tc.reason)
+				}
+				if diff := cmp.Diff(xpv1.ManagementPolicies{xpv1.ManagementActionObserve}, o.Spec.ManagementPolicies); diff != "" {
+					t.Errorf("\n%s\nMigrate(...): -want management policies, +got:\n%s", tc.reason, diff)
+				}
+				updated = append(updated, o.Name)
+				return nil
+			}
+			out := &bytes.Buffer{}
+			res, err := Migrate(context.Background(), tc.args.client, out, tc.args.o)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nMigrate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.res, res); diff != "" {
+				t.Errorf("\n%s\nMigrate(...): -want result, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("\n%s\nMigrate(...): -want updated, +got:\n%s", tc.reason, diff)
+			}
+			if !strings.Contains(out.String(), tc.want.out) {
+				t.Errorf("\n%s\nMigrate(...): want output to contain %q, got:\n%s", tc.reason, tc.want.out, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reload applies provider configuration from a ConfigMap at runtime.
+package reload
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	kcache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+const (
+	errNewCache        = "cannot create ConfigMap cache"
+	errGetInformer     = "cannot get ConfigMap informer"
+	errAddEventHandler = "cannot add ConfigMap event handler"
+)
+
+// A Handler applies the value of a configuration key.
+type Handler func(value string) error
+
+// A Reloader watches a ConfigMap and applies the configuration keys it has a
+// Handler for whenever their value changes. Keys that can only be applied by
+// restarting the provider are ignored.
+type Reloader struct {
+	log       logging.Logger
+	config    *rest.Config
+	namespace string
+	name      string
+
+	handlers map[string]Handler
+	restart  map[string]bool
+
+	lock    sync.Mutex
+	applied map[string]string
+}
+
+// New returns a Reloader for the named ConfigMap, watched using the supplied
+// config.
+func New(cfg *rest.Config, namespace, name string, log logging.Logger) *Reloader {
+	return &Reloader{
+		log:       log.WithValues("configMap", namespace+"/"+name),
+		config:    cfg,
+		namespace: namespace,
+		name:      name,
+		handlers:  make(map[string]Handler),
+		restart:   make(map[string]bool),
+		applied:   make(map[string]string),
+	}
+}
+
+// Handle applies changes of the supplied key using the supplied Handler.
+func (r *Reloader) Handle(key string, h Handler) {
+	r.handlers[key] = h
+}
+
+// RequiresRestart marks the supplied keys as known, but only applied when
+// the provider restarts.
+func (r *Reloader) RequiresRestart(keys ...string) {
+	for _, k := range keys {
+		r.restart[k] = true
+	}
+}
+
+// NeedLeaderElection returns false, every replica applies the configuration.
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start watches the ConfigMap until the supplied context is done.
+func (r *Reloader) Start(ctx context.Context) error {
+	ca, err := cache.New(r.config, cache.Options{
+		Scheme:            scheme.Scheme,
+		DefaultNamespaces: map[string]cache.Config{r.namespace: {}},
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {Field: fields.OneTermEqualSelector("metadata.name", r.name)},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, errNewCache)
+	}
+	inf, err := ca.GetInformer(ctx, &corev1.ConfigMap{}, cache.BlockUntilSynced(false))
+	if err != nil {
+		return errors.Wrap(err, errGetInformer)
+	}
+	if _, err := inf.AddEventHandler(kcache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			r.apply(obj.(*corev1.ConfigMap).Data)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			r.apply(obj.(*corev1.ConfigMap).Data)
+		},
+	}); err != nil {
+		return errors.Wrap(err, errAddEventHandler)
+	}
+	r.log.Info("Watching ConfigMap for configuration changes")
+	return ca.Start(ctx)
+}
+
+// apply calls the Handlers of all keys whose value changed since they were
+// last applied.
+func (r *Reloader) apply(data map[string]string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := data[k]
+		if prev, ok := r.applied[k]; ok && prev == v {
+			continue
+		}
+		log := r.log.WithValues("key", k, "value", v)
+		h, ok := r.handlers[k]
+		switch {
+		case ok:
+			if err := h(v); err != nil {
+				log.Info("Cannot apply configuration", "error", err)
+				continue
+			}
+			log.Info("Applied configuration")
+		case r.restart[k]:
+			log.Info("Ignoring configuration that is only applied on restart")
+		default:
+			log.Info("Ignoring unknown configuration")
+		}
+		r.applied[k] = v
+	}
+}
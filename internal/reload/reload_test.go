@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reload
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+func TestApply(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		applied map[string]string
+		data    map[string]string
+		err     error
+	}
+	type want struct {
+		calls   []string
+		applied map[string]string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NewValues": {
+			reason: "We should apply all keys we have a handler for.",
+			args: args{
+				data: map[string]string{"a": "1", "b": "2"},
+			},
+			want: want{
+				calls:   []string{"a=1", "b=2"},
+				applied: map[string]string{"a": "1", "b": "2"},
+			},
+		},
+		"UnchangedValues": {
+			reason: "We should only apply values that changed since they were last applied.",
+			args: args{
+				applied: map[string]string{"a": "1", "b": "2"},
+				data:    map[string]string{"a": "1", "b": "3"},
+			},
+			want: want{
+				calls:   []string{"b=3"},
+				applied: map[string]string{"a": "1", "b": "3"},
+			},
+		},
+		"RestartAndUnknownKeys": {
+			reason: "We should not call any handler for keys that require a restart or are unknown.",
+			args: args{
+				data: map[string]string{"restart": "1", "unknown": "2"},
+			},
+			want: want{
+				applied: map[string]string{"restart": "1", "unknown": "2"},
+			},
+		},
+		"HandlerError": {
+			reason: "We should retry to apply values whose handler returned an error.",
+			args: args{
+				data: map[string]string{"a": "1"},
+				err:  errBoom,
+			},
+			want: want{
+				calls:   []string{"a=1"},
+				applied: map[string]string{},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			h := func(k string) Handler {
+				return func(v string) error {
+					calls = append(calls, k+"="+v)
+					return tc.args.err
+				}
+			}
+
+			r := New(nil, "crossplane-system", "config", logging.NewNopLogger())
+			r.Handle("a", h("a"))
+			r.Handle("b", h("b"))
+			r.RequiresRestart("restart")
+			for k, v := range tc.args.applied {
+				r.applied[k] = v
+			}
+
+			r.apply(tc.args.data)
+
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nr.apply(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.applied, r.applied); diff != "" {
+				t.Errorf("\n%s\nr.apply(...): -want applied, +got applied:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}